@@ -1,5 +1,7 @@
 package crosschain
 
+import "fmt"
+
 // Address is an address on the blockchain, either sender or recipient
 type Address string
 
@@ -10,6 +12,7 @@ type ContractAddress Address
 type AddressBuilder interface {
 	GetAddressFromPublicKey(publicKeyBytes []byte) (Address, error)
 	GetAllPossibleAddressesFromPublicKey(publicKeyBytes []byte) ([]PossibleAddress, error)
+	ValidateAddress(address Address) *AddressValidation
 }
 
 // AddressType represents the type of an address, for discovery purposes
@@ -22,7 +25,9 @@ const (
 	AddressTypeP2PKH     AddressType = AddressType("P2PKH")
 	AddressTypeP2WPKH    AddressType = AddressType("P2WPKH")
 	AddressTypeP2TR      AddressType = AddressType("P2TR")
+	AddressTypeP2WSH     AddressType = AddressType("P2WSH")
 	AddressTypeETHKeccak AddressType = AddressType("ETHKeccak")
+	AddressTypeBech32    AddressType = AddressType("Bech32")
 	AddressTypeDefault   AddressType = AddressType("Default")
 )
 
@@ -31,3 +36,35 @@ type PossibleAddress struct {
 	Address Address
 	Type    AddressType
 }
+
+// AddressValidation is the result of validating an address for a chain
+type AddressValidation struct {
+	Valid bool `json:"valid"`
+	// Why the address is not valid
+	Reason string `json:"reason,omitempty"`
+	// The address in the canonical format for the chain
+	Normalized Address     `json:"normalized,omitempty"`
+	Type       AddressType `json:"type,omitempty"`
+	// Set when the format of the address shows it belongs to a contract or program rather
+	// than a key.  This is not looked up on chain, so a false value does not rule it out.
+	Contract bool `json:"contract,omitempty"`
+	// Destination tag embedded in the address, e.g. for XRP X-addresses
+	Tag *uint64 `json:"tag,omitempty"`
+}
+
+// ValidAddress returns a validation result for a valid address
+func ValidAddress(normalized Address, addressType AddressType) *AddressValidation {
+	return &AddressValidation{
+		Valid:      true,
+		Normalized: normalized,
+		Type:       addressType,
+	}
+}
+
+// InvalidAddress returns a validation result for an invalid address
+func InvalidAddress(format string, args ...interface{}) *AddressValidation {
+	return &AddressValidation{
+		Valid:  false,
+		Reason: fmt.Sprintf(format, args...),
+	}
+}
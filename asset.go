@@ -300,6 +300,29 @@ func (staking *StakingConfig) Enabled() bool {
 	return len(staking.Providers) > 0
 }
 
+// TransportConfig is the policy for outbound http requests to a chain's nodes and indexers
+type TransportConfig struct {
+	// maximum requests per second, unlimited if not set
+	RateLimit float64 `yaml:"rate_limit,omitempty"`
+	// number of requests that may be made at once before the rate limit applies, defaults to 1
+	RateLimitBurst int `yaml:"rate_limit_burst,omitempty"`
+	// number of times to retry requests that are rate limited (429), fail to connect, or fail on the server (5xx),
+	// -1 to disable.  Server errors are not retried when submitting transactions, as they may have been processed.
+	MaxRetries int `yaml:"max_retries,omitempty"`
+	// timeout for each request, e.g. "30s"
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// IbcChannelConfig is the IBC channel used to transfer from a cosmos chain to another chain
+type IbcChannelConfig struct {
+	// the chain on the other end of the channel
+	Chain NativeAsset `yaml:"chain,omitempty"`
+	// the channel on this chain, e.g. "channel-0"
+	Channel string `yaml:"channel,omitempty"`
+	// the port on this chain, defaults to "transfer"
+	Port string `yaml:"port,omitempty"`
+}
+
 // AssetConfig is the model used to represent an asset read from config file or db
 type ChainConfig struct {
 	Chain                NativeAsset     `yaml:"chain,omitempty"`
@@ -341,6 +364,12 @@ type ChainConfig struct {
 
 	Staking StakingConfig `yaml:"staking,omitempty"`
 
+	// IBC channels to other chains, if relevant
+	IbcChannels []*IbcChannelConfig `yaml:"ibc_channels,omitempty"`
+
+	// Rate limits, retries and timeouts for requests to the chain
+	Transport TransportConfig `yaml:"transport,omitempty"`
+
 	// Internal
 	// dereferenced api token if used
 	AuthSecret string `yaml:"-"`
@@ -392,6 +421,16 @@ func (native *ChainConfig) GetContract() string {
 	return ""
 }
 
+// Lookup the IBC channel used to transfer to the destination chain
+func (native *ChainConfig) GetIbcChannel(destination NativeAsset) (*IbcChannelConfig, bool) {
+	for _, channel := range native.IbcChannels {
+		if channel.Chain == destination {
+			return channel, true
+		}
+	}
+	return nil, false
+}
+
 // TODO we should delete these extra fields that are indicative of chain
 // func (asset *NativeAssetConfig) GetChainIdentifier() string {
 // 	return asset.Asset
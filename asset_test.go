@@ -32,7 +32,35 @@ func (s *CrosschainTestSuite) TestAssetDriver() {
 		drivers[driver] = true
 		// test driver is valid
 		require.NotEmpty(driver.SignatureAlgorithm(), "driver is not valid")
+
+		metadata := driver.SighashMetadata()
+		require.NotEmpty(metadata.Hash, "driver %s has no sighash metadata", driver)
+		require.NotEmpty(metadata.SignatureEncoding, "driver %s has no sighash metadata", driver)
+		require.NotEmpty(metadata.PublicKeyFormat, "driver %s has no sighash metadata", driver)
+		// only ed25519 signs the full message
+		require.Equal(driver.SignatureAlgorithm() != Ed255, metadata.PreHashed)
 	}
+	require.Equal(SighashMetadata{
+		PreHashed:         true,
+		Hash:              Keccak256,
+		Algorithm:         K256Keccak,
+		SignatureEncoding: SignatureEncodingRsv,
+		PublicKeyFormat:   Uncompressed,
+	}, DriverEVM.SighashMetadata())
+	require.Equal(SighashMetadata{
+		PreHashed:         true,
+		Hash:              DoubleSha256,
+		Algorithm:         K256Sha256,
+		SignatureEncoding: SignatureEncodingRs,
+		PublicKeyFormat:   Compressed,
+	}, DriverBitcoin.SighashMetadata())
+	require.Equal(SighashMetadata{
+		PreHashed:         false,
+		Hash:              Sha512,
+		Algorithm:         Ed255,
+		SignatureEncoding: SignatureEncodingEd25519,
+		PublicKeyFormat:   Raw,
+	}, DriverSolana.SighashMetadata())
 }
 
 func (s *CrosschainTestSuite) TestChainType() {
@@ -16,6 +16,13 @@ type builderOptions struct {
 	validator    *string
 	stakeOwner   *xc.Address
 	stakeAccount *string
+	subnet       *uint16
+
+	feeGranter        *xc.Address
+	feePayer          *xc.Address
+	feePayerPublicKey *[]byte
+
+	destinationTag *uint32
 }
 
 // All ArgumentBuilders should provide base arguments for transactions
@@ -28,6 +35,15 @@ type TransactionOptions interface {
 
 var _ TransactionOptions = &builderOptions{}
 
+// Options for having another account pay for the transaction fees
+type FeeOptions interface {
+	GetFeeGranter() (xc.Address, bool)
+	GetFeePayer() (xc.Address, bool)
+	GetFeePayerPublicKey() ([]byte, bool)
+}
+
+var _ FeeOptions = &builderOptions{}
+
 func get[T any](arg *T) (T, bool) {
 	if arg == nil {
 		var zero T
@@ -46,6 +62,13 @@ func (opts *builderOptions) GetPublicKey() ([]byte, bool)           { return get
 func (opts *builderOptions) GetValidator() (string, bool)      { return get(opts.validator) }
 func (opts *builderOptions) GetStakeOwner() (xc.Address, bool) { return get(opts.stakeOwner) }
 func (opts *builderOptions) GetStakeAccount() (string, bool)   { return get(opts.stakeAccount) }
+func (opts *builderOptions) GetSubnet() (uint16, bool)         { return get(opts.subnet) }
+func (opts *builderOptions) GetDestinationTag() (uint32, bool) { return get(opts.destinationTag) }
+
+// Fee options
+func (opts *builderOptions) GetFeeGranter() (xc.Address, bool)    { return get(opts.feeGranter) }
+func (opts *builderOptions) GetFeePayer() (xc.Address, bool)      { return get(opts.feePayer) }
+func (opts *builderOptions) GetFeePayerPublicKey() ([]byte, bool) { return get(opts.feePayerPublicKey) }
 
 type BuilderOption func(opts *builderOptions) error
 
@@ -94,6 +117,40 @@ func OptionStakeAccount(account string) BuilderOption {
 	}
 }
 
+// Set the subnet to stake in, used by bittensor (the netuid).  Defaults to the root network.
+func OptionSubnet(netuid uint16) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.subnet = &netuid
+		return nil
+	}
+}
+
+// Set an account that has granted an allowance to pay the fees (e.g. cosmos x/feegrant).
+// The granter does not need to sign the transaction.
+func OptionFeeGranter(granter xc.Address) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.feeGranter = &granter
+		return nil
+	}
+}
+
+// Set an account that pays the fees directly.  The fee payer must also sign the transaction.
+func OptionFeePayer(payer xc.Address, publicKey []byte) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.feePayer = &payer
+		opts.feePayerPublicKey = &publicKey
+		return nil
+	}
+}
+
+// Set a destination tag, used by chains like XRP to identify the recipient of a shared address (e.g. an exchange).
+func OptionDestinationTag(tag uint32) BuilderOption {
+	return func(opts *builderOptions) error {
+		opts.destinationTag = &tag
+		return nil
+	}
+}
+
 // Previously the crosschain abstraction would require callers to set options
 // directly on the transaction input, if the interface was implemented on the input type.
 // However, this is very clear or easy to use.  This function bridges the gap, to allow
@@ -14,6 +14,7 @@ type StakeArgs struct {
 }
 
 var _ TransactionOptions = &StakeArgs{}
+var _ FeeOptions = &StakeArgs{}
 
 // Staking arguments
 func (args *StakeArgs) GetFrom() xc.Address            { return args.from }
@@ -25,10 +26,18 @@ func (args *StakeArgs) GetTimestamp() (int64, bool)            { return args.opt
 func (args *StakeArgs) GetPriority() (xc.GasFeePriority, bool) { return args.options.GetPriority() }
 func (args *StakeArgs) GetPublicKey() ([]byte, bool)           { return args.options.GetPublicKey() }
 
+// Fee options
+func (args *StakeArgs) GetFeeGranter() (xc.Address, bool) { return args.options.GetFeeGranter() }
+func (args *StakeArgs) GetFeePayer() (xc.Address, bool)   { return args.options.GetFeePayer() }
+func (args *StakeArgs) GetFeePayerPublicKey() ([]byte, bool) {
+	return args.options.GetFeePayerPublicKey()
+}
+
 // Staking options
 func (args *StakeArgs) GetValidator() (string, bool)      { return args.options.GetValidator() }
 func (args *StakeArgs) GetStakeOwner() (xc.Address, bool) { return args.options.GetStakeOwner() }
 func (args *StakeArgs) GetStakeAccount() (string, bool)   { return args.options.GetStakeAccount() }
+func (args *StakeArgs) GetSubnet() (uint16, bool)         { return args.options.GetSubnet() }
 
 func NewStakeArgs(chain xc.NativeAsset, from xc.Address, amount xc.AmountBlockchain, options ...BuilderOption) (StakeArgs, error) {
 	builderOptions := builderOptions{}
@@ -52,7 +61,7 @@ func NewStakeArgs(chain xc.NativeAsset, from xc.Address, amount xc.AmountBlockch
 		if err != nil {
 			return args, err
 		}
-	case xc.DriverCosmos, xc.DriverSolana:
+	case xc.DriverCosmos, xc.DriverSolana, xc.DriverTon:
 		if _, ok := args.GetValidator(); !ok {
 			return args, fmt.Errorf("validator to be delegated to is required for %s chain", chain)
 		}
@@ -13,6 +13,7 @@ type TransferArgs struct {
 }
 
 var _ TransactionOptions = &TransferArgs{}
+var _ FeeOptions = &TransferArgs{}
 
 // Transfer relevant arguments
 func (args *TransferArgs) GetFrom() xc.Address            { return args.from }
@@ -24,6 +25,14 @@ func (args *TransferArgs) GetMemo() (string, bool)                { return args.
 func (args *TransferArgs) GetTimestamp() (int64, bool)            { return args.options.GetTimestamp() }
 func (args *TransferArgs) GetPriority() (xc.GasFeePriority, bool) { return args.options.GetPriority() }
 func (args *TransferArgs) GetPublicKey() ([]byte, bool)           { return args.options.GetPublicKey() }
+func (args *TransferArgs) GetDestinationTag() (uint32, bool)      { return args.options.GetDestinationTag() }
+
+// Fee options
+func (args *TransferArgs) GetFeeGranter() (xc.Address, bool) { return args.options.GetFeeGranter() }
+func (args *TransferArgs) GetFeePayer() (xc.Address, bool)   { return args.options.GetFeePayer() }
+func (args *TransferArgs) GetFeePayerPublicKey() ([]byte, bool) {
+	return args.options.GetFeePayerPublicKey()
+}
 
 func NewTransferArgs(from xc.Address, to xc.Address, amount xc.AmountBlockchain, options ...BuilderOption) (TransferArgs, error) {
 	builderOptions := builderOptions{}
@@ -3,6 +3,8 @@ package aptos
 import (
 	"encoding/hex"
 	"errors"
+	"fmt"
+	"strings"
 
 	xc "github.com/cordialsys/crosschain"
 	"golang.org/x/crypto/sha3"
@@ -46,3 +48,16 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress accepts 32 byte hex addresses, which may be shortened by dropping leading zeros (e.g. 0x1).
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	hexS := strings.TrimPrefix(strings.ToLower(string(address)), "0x")
+	if len(hexS) == 0 || len(hexS) > 64 {
+		return xc.InvalidAddress("expected 1 to 64 hex characters, got %d", len(hexS))
+	}
+	hexS = fmt.Sprintf("%064s", hexS)
+	if _, err := hex.DecodeString(hexS); err != nil {
+		return xc.InvalidAddress("invalid hex: %v", err)
+	}
+	return xc.ValidAddress(xc.Address("0x"+hexS), xc.AddressTypeDefault)
+}
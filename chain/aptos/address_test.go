@@ -55,3 +55,24 @@ func (s *AptosTestSuite) TestGetAllPossibleAddressesFromPublicKey() {
 	require.Equal(xc.Address("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab85"), addresses[0].Address)
 	require.Equal(xc.AddressTypeDefault, addresses[0].Type)
 }
+
+func (s *AptosTestSuite) TestValidateAddress() {
+	require := s.Require()
+	builder, _ := NewAddressBuilder(&xc.ChainConfig{})
+
+	result := builder.ValidateAddress("0xA589A80D61EC380C24A5FDDA109C3848C082584E6CB725E5AB19B18354B2AB85")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab85"), result.Normalized)
+
+	result = builder.ValidateAddress("0x1")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("0x0000000000000000000000000000000000000000000000000000000000000001"), result.Normalized)
+
+	result = builder.ValidateAddress("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab8500")
+	require.False(result.Valid)
+	require.Equal("expected 1 to 64 hex characters, got 66", result.Reason)
+
+	result = builder.ValidateAddress("0xg")
+	require.False(result.Valid)
+	require.NotEmpty(result.Reason)
+}
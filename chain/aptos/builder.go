@@ -2,6 +2,8 @@ package aptos
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	transactionbuilder "github.com/coming-chat/go-aptos/transaction_builder"
 	xc "github.com/cordialsys/crosschain"
@@ -25,17 +27,39 @@ func NewTxBuilder(asset xc.ITask) (TxBuilder, error) {
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
-	return txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	tf, err := txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	if err != nil {
+		return tf, err
+	}
+	if feePayer, ok := args.GetFeePayer(); ok {
+		feePayerPublicKey, _ := args.GetFeePayerPublicKey()
+		if len(feePayerPublicKey) == 0 {
+			return nil, fmt.Errorf("associated public key for fee payer %s is required", feePayer)
+		}
+		tx := tf.(*Tx)
+		copy(tx.FeePayer[:], mustDecodeHex(string(feePayer)))
+		tx.FeePayerPublicKey = feePayerPublicKey
+	}
+	return tf, nil
 }
 
 // Old transfer interface
 func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
-	if _, ok := txBuilder.Asset.(*xc.TokenAssetConfig); ok {
+	if token, ok := txBuilder.Asset.(*xc.TokenAssetConfig); ok {
+		if IsFungibleAsset(token.Contract) {
+			return txBuilder.NewFungibleAssetTransfer(from, to, amount, input)
+		}
 		return txBuilder.NewTokenTransfer(from, to, amount, input)
 	}
 	return txBuilder.NewNativeTransfer(from, to, amount, input)
 }
 
+// IsFungibleAsset returns true if the contract is the address of a fungible asset (FA) metadata object,
+// rather than the struct tag of a legacy coin (e.g. "0x1::aptos_coin::AptosCoin").
+func IsFungibleAsset(contract string) bool {
+	return contract != "" && !strings.Contains(contract, "::")
+}
+
 // NewNativeTransfer creates a new transfer for a native asset
 func (txBuilder TxBuilder) NewNativeTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
 	var local_input *tx_input.TxInput
@@ -125,3 +149,55 @@ func (txb *TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amount xc
 		Input: local_input,
 	}, nil
 }
+
+// NewFungibleAssetTransfer creates a new transfer for a token using the fungible asset (FA) standard,
+// where the contract is the address of the asset's metadata object.
+func (txb TxBuilder) NewFungibleAssetTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	var local_input *tx_input.TxInput
+	var ok bool
+	if local_input, ok = input.(*tx_input.TxInput); !ok {
+		return &Tx{}, errors.New("xc.TxInput is not from an aptos chain")
+	}
+	contract := txb.Asset.GetContract()
+	metadataBz, err := decodeHexAddress(contract)
+	if err != nil {
+		return &Tx{}, fmt.Errorf("invalid fungible asset metadata address '%s': %v", contract, err)
+	}
+	metadata_addr := [transactionbuilder.ADDRESS_LENGTH]byte{}
+	to_addr := [transactionbuilder.ADDRESS_LENGTH]byte{}
+	from_addr := [transactionbuilder.ADDRESS_LENGTH]byte{}
+	copy(metadata_addr[transactionbuilder.ADDRESS_LENGTH-len(metadataBz):], metadataBz)
+	copy(from_addr[:], mustDecodeHex(string(from)))
+	copy(to_addr[:], mustDecodeHex(string(to)))
+	toAmountBytes := transactionbuilder.BCSSerializeBasicValue(amount.Int().Uint64())
+
+	typeTag, err := transactionbuilder.NewTypeTagStructFromString(FungibleAssetMetadataType)
+	if err != nil {
+		return &Tx{}, err
+	}
+	moduleName, err := transactionbuilder.NewModuleIdFromString("0x1::primary_fungible_store")
+	if err != nil {
+		return &Tx{}, err
+	}
+	payload := transactionbuilder.TransactionPayloadEntryFunction{
+		ModuleName:   *moduleName,
+		FunctionName: "transfer",
+		TyArgs:       []transactionbuilder.TypeTag{*typeTag},
+		Args: [][]byte{
+			metadata_addr[:], to_addr[:], toAmountBytes,
+		},
+	}
+	return &Tx{
+		tx: transactionbuilder.RawTransaction{
+			Sender:         from_addr,
+			SequenceNumber: local_input.SequenceNumber,
+			Payload:        payload,
+			MaxGasAmount:   local_input.GasLimit,
+			GasUnitPrice:   local_input.GasPrice,
+			// ~1 hour expiration
+			ExpirationTimestampSecs: local_input.Timestamp + 60*60,
+			ChainId:                 uint8(local_input.ChainId),
+		},
+		Input: local_input,
+	}, nil
+}
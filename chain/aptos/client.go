@@ -1,10 +1,13 @@
 package aptos
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 
 	"github.com/coming-chat/go-aptos/aptosclient"
@@ -13,6 +16,7 @@ import (
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/aptos/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -23,11 +27,12 @@ type Client struct {
 }
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 
 // NewClient returns a new Aptos Client
 func NewClient(cfgI xc.ITask) (*Client, error) {
 	cfg := cfgI.GetChain()
-	client, err := aptosclient.Dial(context.Background(), cfg.URL)
+	client, err := aptosclient.DialWithClient(context.Background(), cfg.URL, utils.NewHttpClient(cfg))
 	return &Client{
 		Asset:       cfgI,
 		AptosClient: client,
@@ -43,6 +48,15 @@ func NewClientFrom(asset xc.ITask, client *aptosclient.RestClient) *Client {
 	}
 }
 
+// FetchLatestHeight returns the latest block height of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	ledger, err := client.AptosClient.LedgerInfo()
+	if err != nil {
+		return 0, err
+	}
+	return ledger.BlockHeight, nil
+}
+
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	ledger, err := client.AptosClient.LedgerInfo()
 	if err != nil {
@@ -52,6 +66,13 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	if err != nil {
 		return &tx_input.TxInput{}, err
 	}
+	if feePayer, ok := args.GetFeePayer(); ok {
+		// The fee payer does not need a sequence number, but the account must exist to pay for gas.
+		_, err := client.AptosClient.GetAccount(string(feePayer))
+		if err != nil {
+			return &tx_input.TxInput{}, fmt.Errorf("could not fetch fee payer account %s: %v", feePayer, err)
+		}
+	}
 	gas_price, err := client.EstimateGas(ctx, ledger)
 	if err != nil {
 		return &tx_input.TxInput{}, err
@@ -82,9 +103,9 @@ func (client *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
 	if err != nil {
 		return err
 	}
-	newTxn, err := client.AptosClient.SubmitSignedBCSTransaction(tx_bz)
-	_ = newTxn
-	return err
+	// posted directly, as the go-aptos client doesn't take a context to mark the request as non-idempotent
+	newTxn := &aptostypes.Transaction{}
+	return client.post(utils.NonIdempotent(ctx), "/transactions", "application/x.aptos.signed_transaction+bcs", tx_bz, newTxn)
 }
 
 type ChangeAndEvents struct {
@@ -121,6 +142,72 @@ type CoinDepositEvent struct {
 }
 type CoinWithdrawEvent = CoinDepositEvent // same structure
 
+// Events emitted for fungible asset (FA) transfers reference the store, not the owner.
+type FungibleAssetEvent struct {
+	Store  string `json:"store"`
+	Amount string `json:"amount"`
+}
+type FungibleStoreResource struct {
+	Metadata struct {
+		Inner string `json:"inner"`
+	} `json:"metadata"`
+}
+type ObjectCoreResource struct {
+	Owner string `json:"owner"`
+}
+
+// A fungible store is an object owned by an account, holding the balance of a single asset.
+type fungibleStore struct {
+	Owner    string
+	Metadata string
+}
+
+// Index the fungible stores written by the transaction, so FA events can be mapped back to the owner and asset.
+func parseFungibleStores(changes []aptostypes.Change) (map[string]*fungibleStore, error) {
+	stores := map[string]*fungibleStore{}
+	getStore := func(address string) *fungibleStore {
+		address = normalizeAddress(address)
+		if _, ok := stores[address]; !ok {
+			stores[address] = &fungibleStore{}
+		}
+		return stores[address]
+	}
+	for _, ch := range changes {
+		if ch.Type != "write_resource" {
+			continue
+		}
+		changeInner := AptosChangeInner{}
+		err := reserializeJson(ch.Data, &changeInner)
+		if err != nil {
+			return nil, fmt.Errorf("could not deserialize aptos change")
+		}
+		switch changeInner.Type {
+		case "0x1::fungible_asset::FungibleStore":
+			store := FungibleStoreResource{}
+			if err := json.Unmarshal(changeInner.Data, &store); err != nil {
+				return nil, fmt.Errorf("could not deserialize aptos fungible store")
+			}
+			getStore(ch.Address).Metadata = store.Metadata.Inner
+		case "0x1::object::ObjectCore":
+			object := ObjectCoreResource{}
+			if err := json.Unmarshal(changeInner.Data, &object); err != nil {
+				return nil, fmt.Errorf("could not deserialize aptos object")
+			}
+			getStore(ch.Address).Owner = object.Owner
+		}
+	}
+	return stores, nil
+}
+
+// The contract of a fungible asset is the metadata address, except for APT which we continue to identify
+// using the coin type.
+func fungibleAssetContract(metadata string) string {
+	if normalizeAddress(metadata) == AptosCoinMetadataAddress {
+		return AptosCoinType
+	}
+	return metadata
+}
+
 func reserializeJson(obj any, target any) error {
 	bz, err := json.Marshal(obj)
 	if err != nil {
@@ -194,6 +281,44 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 	sources := []*xc.LegacyTxInfoEndpoint{}
 	destinations := []*xc.LegacyTxInfoEndpoint{}
 
+	fungibleStores, err := parseFungibleStores(tx.Changes)
+	if err != nil {
+		return xc.LegacyTxInfo{}, err
+	}
+	// FA events are module events, which are not associated with a CoinStore change.
+	for _, ev := range tx.Events {
+		if ev.Type != "0x1::fungible_asset::Withdraw" && ev.Type != "0x1::fungible_asset::Deposit" {
+			continue
+		}
+		faEvent := &FungibleAssetEvent{}
+		err := reserializeJson(ev.Data, faEvent)
+		if err != nil {
+			logrus.WithField("txhash", txHash).WithError(err).Error("could not deserialize aptos fungible asset event")
+			continue
+		}
+		store, ok := fungibleStores[normalizeAddress(faEvent.Store)]
+		if !ok || store.Owner == "" || store.Metadata == "" {
+			logrus.WithFields(logrus.Fields{
+				"txhash": txHash,
+				"store":  faEvent.Store,
+			}).Warn("could not find fungible store for aptos event")
+			continue
+		}
+		contract := xc.ContractAddress(fungibleAssetContract(store.Metadata))
+		endpoint := &xc.LegacyTxInfoEndpoint{
+			ContractAddress:            contract,
+			LegacyAptosContractAddress: string(contract),
+			NativeAsset:                client.Asset.GetChain().Chain,
+			Address:                    xc.Address(store.Owner),
+			Amount:                     xc.NewAmountBlockchainFromStr(faEvent.Amount),
+		}
+		if ev.Type == "0x1::fungible_asset::Withdraw" {
+			sources = append(sources, endpoint)
+		} else {
+			destinations = append(destinations, endpoint)
+		}
+	}
+
 	for _, coinChange := range coinChanges {
 		for _, ev := range coinChange.Events {
 			switch ev.Type {
@@ -312,6 +437,9 @@ func (client *Client) FetchTxInfo(ctx context.Context, txHashStr xc.TxHash) (xcl
 // FetchBalance fetches balance for an Aptos address
 func (client *Client) FetchBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
 	if token, ok := client.Asset.(*xc.TokenAssetConfig); ok {
+		if IsFungibleAsset(token.Contract) {
+			return client.FetchFungibleAssetBalance(ctx, address, token.Contract)
+		}
 		balance, err := client.AptosClient.BalanceOf(string(address), token.Contract)
 		if err != nil {
 			return xc.NewAmountBlockchainFromUint64(0), err
@@ -330,6 +458,63 @@ func (client *Client) FetchNativeBalance(ctx context.Context, address xc.Address
 	return xc.AmountBlockchain(*balance), nil
 }
 
+// FetchFungibleAssetBalance fetches the balance of a fungible asset (FA) in the primary store of an address
+func (client *Client) FetchFungibleAssetBalance(ctx context.Context, address xc.Address, metadata string) (xc.AmountBlockchain, error) {
+	zero := xc.NewAmountBlockchainFromUint64(0)
+	result := []string{}
+	err := client.View(ctx, "0x1::primary_fungible_store::balance", []string{FungibleAssetMetadataType}, []any{string(address), metadata}, &result)
+	if err != nil {
+		return zero, err
+	}
+	if len(result) != 1 {
+		return zero, fmt.Errorf("unexpected aptos balance response: %v", result)
+	}
+	return xc.NewAmountBlockchainFromStr(result[0]), nil
+}
+
+type ViewRequest struct {
+	Function      string   `json:"function"`
+	TypeArguments []string `json:"type_arguments"`
+	Arguments     []any    `json:"arguments"`
+}
+
+// View calls a view function, which the go-aptos client does not support.
+func (client *Client) View(ctx context.Context, function string, typeArguments []string, arguments []any, result any) error {
+	body, err := json.Marshal(&ViewRequest{
+		Function:      function,
+		TypeArguments: typeArguments,
+		Arguments:     arguments,
+	})
+	if err != nil {
+		return err
+	}
+	return client.post(ctx, "/view", "application/json", body, result)
+}
+
+func (client *Client) post(ctx context.Context, path string, contentType string, body []byte, result any) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", client.AptosClient.GetVersionedRpcUrl()+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := utils.NewHttpClient(client.Asset.GetChain()).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		restError := &aptostypes.RestError{}
+		_ = json.Unmarshal(respBody, restError)
+		restError.Code = resp.StatusCode
+		return restError
+	}
+	return json.Unmarshal(respBody, result)
+}
+
 func (client *Client) EstimateGas(ctx context.Context, ledgerInfo *aptostypes.LedgerInfo) (xc.AmountBlockchain, error) {
 
 	// estimate using last 1 blocks
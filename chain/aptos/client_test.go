@@ -1,11 +1,14 @@
 package aptos
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/aptos/tx_input"
+	xclient "github.com/cordialsys/crosschain/client"
 	testtypes "github.com/cordialsys/crosschain/testutil/types"
 )
 
@@ -94,6 +97,45 @@ func (s *AptosTestSuite) TestFetchTxInput() {
 	}
 }
 
+func (s *AptosTestSuite) TestFetchTxInputWithFeePayer() {
+	require := s.Require()
+	ledger := `{"chain_id":58,"epoch":"61","ledger_version":"3524910","oldest_ledger_version":"0","ledger_timestamp":"1683057860656414","node_role":"full_node","oldest_block_height":"0","block_height":"1317171","git_hash":"57f8b499aead5adf38276acb585cd2c0de398568"}`
+	from := xc.Address("0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682")
+	feePayer := xc.Address("0xcc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11")
+	args, _ := xcbuilder.NewTransferArgs(from, "", xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionFeePayer(feePayer, make([]byte, 32)))
+
+	for _, feePayerExists := range []bool{true, false} {
+		server, close := testtypes.MockHTTP(s.T(), ledger, 200)
+		client, _ := NewClient(&xc.ChainConfig{URL: server.URL})
+		resp := []string{
+			ledger,
+			ledger,
+			`{"sequence_number":"2","authentication_key":"0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682"}`,
+		}
+		if feePayerExists {
+			resp = append(resp, `{"sequence_number":"7","authentication_key":"0xcc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11"}`)
+		} else {
+			resp = append(resp, `{"message":"Account not found by Address(0xcc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11)","error_code":"account_not_found","vm_error_code":null}`)
+			server.StatusCodes = []int{200, 200, 200, 404}
+		}
+		// satisfy the gas estimate to go with default value
+		for i := 0; i < 20; i++ {
+			resp = append(resp, `{"message":"block not found","error_code":"block_not_found","vm_error_code":null}`)
+		}
+		server.Response = resp
+
+		input, err := client.FetchTransferInput(s.Ctx, args)
+		if feePayerExists {
+			require.NoError(err)
+			// sequence is for the sender
+			require.EqualValues(2, input.(*tx_input.TxInput).SequenceNumber)
+		} else {
+			require.ErrorContains(err, "could not fetch fee payer account")
+		}
+		close()
+	}
+}
+
 func (s *AptosTestSuite) TestSubmitTx() {
 	require := s.Require()
 	server, close := testtypes.MockHTTP(s.T(), []string{
@@ -237,6 +279,49 @@ func (s *AptosTestSuite) TestFetchTxInfo() {
 			},
 			"",
 		},
+		// fungible asset transfer
+		{
+			"0x7d3b13c1e3bcbd2e8e5e1f4b5ae9e3c7e4ab0ea0c6bde3c8de3e5f0d1b7a1a2b",
+			[]string{
+				`{"chain_id":1,"epoch":"9000","ledger_version":"2119854600","oldest_ledger_version":"0","ledger_timestamp":"1727000000000000","node_role":"full_node","oldest_block_height":"0","block_height":"250000010","git_hash":"f43ce082abbdaa3a8b38ac07d928feed4248eb73"}`,
+				`{"version":"2119854577","hash":"0x7d3b13c1e3bcbd2e8e5e1f4b5ae9e3c7e4ab0ea0c6bde3c8de3e5f0d1b7a1a2b","state_change_hash":"0x8f9c","event_root_hash":"0x3846","state_checkpoint_hash":null,"gas_used":"11","success":true,"vm_status":"Executed successfully","accumulator_root_hash":"0x30c4","changes":[{"address":"0x1111111111111111111111111111111111111111111111111111111111111111","state_key_hash":"0x01","data":{"type":"0x1::fungible_asset::FungibleStore","data":{"balance":"95000","frozen":false,"metadata":{"inner":"0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b"}}},"type":"write_resource"},{"address":"0x1111111111111111111111111111111111111111111111111111111111111111","state_key_hash":"0x01","data":{"type":"0x1::object::ObjectCore","data":{"allow_ungated_transfer":false,"guid_creation_num":"1125899906842625","owner":"0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682","transfer_events":{"counter":"0","guid":{"id":{"addr":"0x1111111111111111111111111111111111111111111111111111111111111111","creation_num":"1125899906842624"}}}}},"type":"write_resource"},{"address":"0x2222222222222222222222222222222222222222222222222222222222222222","state_key_hash":"0x02","data":{"type":"0x1::fungible_asset::FungibleStore","data":{"balance":"5000","frozen":false,"metadata":{"inner":"0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b"}}},"type":"write_resource"},{"address":"0x2222222222222222222222222222222222222222222222222222222222222222","state_key_hash":"0x02","data":{"type":"0x1::object::ObjectCore","data":{"allow_ungated_transfer":false,"guid_creation_num":"1125899906842625","owner":"0x2a5ddd8e5ac5e30f61e42e4dc54a2d6a904412810767fa2e1674b08ca3b04365","transfer_events":{"counter":"0","guid":{"id":{"addr":"0x2222222222222222222222222222222222222222222222222222222222222222","creation_num":"1125899906842624"}}}}},"type":"write_resource"},{"address":"0x333","state_key_hash":"0x03","data":{"type":"0x1::fungible_asset::FungibleStore","data":{"balance":"800000","frozen":false,"metadata":{"inner":"0xa"}}},"type":"write_resource"},{"address":"0x333","state_key_hash":"0x03","data":{"type":"0x1::object::ObjectCore","data":{"allow_ungated_transfer":false,"guid_creation_num":"1125899906842625","owner":"0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682","transfer_events":{"counter":"0","guid":{"id":{"addr":"0x333","creation_num":"1125899906842624"}}}}},"type":"write_resource"},{"state_key_hash":"0x6e4b","handle":"0x1b85","key":"0x0619","value":"0xa053","data":null,"type":"write_table_item"}],"sender":"0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682","sequence_number":"4","max_gas_amount":"2000","gas_unit_price":"100","expiration_timestamp_secs":"1727000000","payload":{"function":"0x1::primary_fungible_store::transfer","type_arguments":["0x1::fungible_asset::Metadata"],"arguments":[{"inner":"0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b"},"0x2a5ddd8e5ac5e30f61e42e4dc54a2d6a904412810767fa2e1674b08ca3b04365","5000"],"type":"entry_function_payload"},"signature":{"public_key":"0xa09bb3957ad788bfcfd3f7c5eda9ab2876ff0de8db38dafdf439cfe3f96673b6","signature":"0xd488","type":"ed25519_signature"},"events":[{"guid":{"creation_number":"0","account_address":"0x0"},"sequence_number":"0","type":"0x1::fungible_asset::Withdraw","data":{"amount":"5000","store":"0x1111111111111111111111111111111111111111111111111111111111111111"}},{"guid":{"creation_number":"0","account_address":"0x0"},"sequence_number":"0","type":"0x1::fungible_asset::Deposit","data":{"amount":"5000","store":"0x2222222222222222222222222222222222222222222222222222222222222222"}},{"guid":{"creation_number":"0","account_address":"0x0"},"sequence_number":"0","type":"0x1::fungible_asset::Withdraw","data":{"amount":"1","store":"0x0000000000000000000000000000000000000000000000000000000000000333"}},{"guid":{"creation_number":"0","account_address":"0x0"},"sequence_number":"0","type":"0x1::transaction_fee::FeeStatement","data":{"execution_gas_units":"5","io_gas_units":"6","storage_fee_octas":"0","storage_fee_refund_octas":"0","total_charge_gas_units":"11"}}],"timestamp":"1726999000123456","type":"user_transaction"}`,
+				`{"block_height":"250000000","block_hash":"0x65a9","block_timestamp":"1726999000123456","first_version":"2119854570","last_version":"2119854580","transactions":null}`,
+				`{"chain_id":1,"epoch":"9000","ledger_version":"2119854600","oldest_ledger_version":"0","ledger_timestamp":"1727000000000000","node_role":"full_node","oldest_block_height":"0","block_height":"250000010","git_hash":"f43ce082abbdaa3a8b38ac07d928feed4248eb73"}`,
+			},
+			xc.LegacyTxInfo{
+				TxID:          "0x7d3b13c1e3bcbd2e8e5e1f4b5ae9e3c7e4ab0ea0c6bde3c8de3e5f0d1b7a1a2b",
+				BlockHash:     "2119854577",
+				ExplorerURL:   "/txn/2119854577?network=devnet",
+				From:          "0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682",
+				To:            "0x2a5ddd8e5ac5e30f61e42e4dc54a2d6a904412810767fa2e1674b08ca3b04365",
+				Amount:        xc.NewAmountBlockchainFromUint64(5000),
+				Fee:           xc.NewAmountBlockchainFromUint64(1100),
+				BlockIndex:    2119854577,
+				BlockTime:     1726999000,
+				Confirmations: 10,
+				Sources: []*xc.LegacyTxInfoEndpoint{
+					{
+						Address:                    "0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682",
+						Amount:                     xc.NewAmountBlockchainFromUint64(5000),
+						ContractAddress:            "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b",
+						LegacyAptosContractAddress: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b",
+					},
+					{
+						// APT held as a fungible asset
+						Address:                    "0xf08819a2ca002c1da8c6242040607617093f519eb2525201efaba47b0841f682",
+						Amount:                     xc.NewAmountBlockchainFromUint64(1),
+						LegacyAptosContractAddress: "0x1::aptos_coin::AptosCoin",
+					},
+				},
+				Destinations: []*xc.LegacyTxInfoEndpoint{{
+					Address:                    "0x2a5ddd8e5ac5e30f61e42e4dc54a2d6a904412810767fa2e1674b08ca3b04365",
+					Amount:                     xc.NewAmountBlockchainFromUint64(5000),
+					ContractAddress:            "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b",
+					LegacyAptosContractAddress: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b",
+				}},
+			},
+			"",
+		},
 	}
 
 	for _, v := range vectors {
@@ -296,6 +381,25 @@ func (s *AptosTestSuite) TestFetchBalance() {
 			"0",
 			"",
 		},
+		{
+			// fungible asset
+			&xc.TokenAssetConfig{Contract: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b", ChainConfig: &xc.ChainConfig{Chain: xc.APTOS}},
+			[]string{
+				`{}`,
+				`["1500000"]`,
+			},
+			"1500000",
+			"",
+		},
+		{
+			&xc.TokenAssetConfig{Contract: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b", ChainConfig: &xc.ChainConfig{Chain: xc.APTOS}},
+			[]string{
+				`{}`,
+				`{"message":"Invalid input: function not found","error_code":"invalid_input","vm_error_code":null}`,
+			},
+			"0",
+			"function not found",
+		},
 	}
 
 	for _, v := range vectors {
@@ -408,3 +512,144 @@ func (s *AptosTestSuite) TestNewTokenTransfer() {
 	_, err = builder.NewTokenTransfer(from, to, amount, input)
 	require.ErrorContains(err, "Invalid struct tag string literal")
 }
+
+func (s *AptosTestSuite) TestNewFungibleAssetTransfer() {
+	require := s.Require()
+
+	native_asset := &xc.ChainConfig{Chain: xc.APTOS, Net: "devnet"}
+	asset := &xc.TokenAssetConfig{Asset: "USDC", Contract: "0xbae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b", ChainConfig: native_asset}
+	builder, _ := NewTxBuilder(asset)
+	from := xc.Address("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab85")
+	to := xc.Address("0xbb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00")
+	amount := xc.NewAmountBlockchainFromUint64(1)
+	pubkey := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	input := &tx_input.TxInput{
+		TxInputEnvelope: *xc.NewTxInputEnvelope(xc.DriverAptos),
+		SequenceNumber:  3,
+		GasLimit:        2000,
+		GasPrice:        10,
+		Timestamp:       12345,
+		ChainId:         1,
+		Pubkey:          pubkey,
+	}
+	tf, err := builder.NewTransfer(from, to, amount, input)
+	require.NoError(err)
+
+	sig := []byte{}
+	for i := 0; i < 64; i++ {
+		sig = append(sig, byte(i))
+	}
+	err = tf.AddSignatures(xc.TxSignature(sig))
+	require.NoError(err)
+
+	ser, err := tf.Serialize()
+	require.NoError(err)
+	require.Equal("a589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab850300000000000000020000000000000000000000000000000000000000000000000000000000000001167072696d6172795f66756e6769626c655f73746f7265087472616e73666572010700000000000000000000000000000000000000000000000000000000000000010e66756e6769626c655f6173736574084d65746164617461000320bae207659db88bea0cbead6da0ed00aac12edcdda169e591cd41c94180b46f3b20bb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00080100000000000000d0070000000000000a00000000000000493e000000000000010020010203040506070801020304050607080102030405060708010203040506070840000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f", hex.EncodeToString(ser))
+
+	// coin contracts still use the coin module
+	bad_asset := &xc.TokenAssetConfig{Asset: "USDC", Contract: "0x1::bad", ChainConfig: native_asset}
+	builder, _ = NewTxBuilder(bad_asset)
+	_, err = builder.NewTransfer(from, to, amount, input)
+	require.ErrorContains(err, "Invalid struct tag string literal")
+
+	// invalid metadata address
+	bad_asset = &xc.TokenAssetConfig{Asset: "USDC", Contract: "0xzz", ChainConfig: native_asset}
+	builder, _ = NewTxBuilder(bad_asset)
+	_, err = builder.NewTransfer(from, to, amount, input)
+	require.ErrorContains(err, "invalid fungible asset metadata address")
+}
+
+func (s *AptosTestSuite) TestFeePayerTransfer() {
+	require := s.Require()
+
+	asset := &xc.ChainConfig{Chain: xc.APTOS, Net: "devnet"}
+	builder, _ := NewTxBuilder(asset)
+	from := xc.Address("0xa589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab85")
+	to := xc.Address("0xbb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00")
+	feePayer := xc.Address("0xcc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11")
+	amount := xc.NewAmountBlockchainFromUint64(1)
+	pubkey := []byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6, 7, 8}
+	feePayerPubkey := []byte{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9}
+	input := &tx_input.TxInput{
+		TxInputEnvelope: *xc.NewTxInputEnvelope(xc.DriverAptos),
+		SequenceNumber:  3,
+		GasLimit:        2000,
+		GasPrice:        10,
+		Timestamp:       12345,
+		ChainId:         1,
+		Pubkey:          pubkey,
+	}
+
+	// fee payer requires a public key
+	args, err := xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionFeePayer(feePayer, nil))
+	require.NoError(err)
+	_, err = builder.Transfer(args, input)
+	require.ErrorContains(err, "public key for fee payer")
+
+	args, err = xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionFeePayer(feePayer, feePayerPubkey))
+	require.NoError(err)
+	tf, err := builder.Transfer(args, input)
+	require.NoError(err)
+
+	sighashes, err := tf.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 2)
+	require.Equal(sighashes[0], sighashes[1])
+	// signing message is over the raw transaction + fee payer address
+	require.Equal("5efa3c4f02f83a0f4b2d69fc95c607cc02825cc4e7be536ef0992df050d9e67c01a589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab8503000000000000000200000000000000000000000000000000000000000000000000000000000000010d6170746f735f6163636f756e74087472616e73666572000220bb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00080100000000000000d0070000000000000a00000000000000493e0000000000000100cc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab11", hex.EncodeToString(sighashes[0]))
+
+	sig := []byte{}
+	feePayerSig := []byte{}
+	for i := 0; i < 64; i++ {
+		sig = append(sig, byte(i))
+		feePayerSig = append(feePayerSig, byte(64+i))
+	}
+	err = tf.AddSignatures(xc.TxSignature(sig))
+	require.ErrorContains(err, "expecting 2 signatures")
+	err = tf.AddSignatures(xc.TxSignature(sig), xc.TxSignature(feePayerSig))
+	require.NoError(err)
+	require.Len(tf.GetSignatures(), 2)
+	require.Len(tf.Hash(), 64)
+
+	ser, err := tf.Serialize()
+	require.NoError(err)
+	require.Equal("a589a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab8503000000000000000200000000000000000000000000000000000000000000000000000000000000010d6170746f735f6163636f756e74087472616e73666572000220bb89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab00080100000000000000d0070000000000000a00000000000000493e00000000000001030020010203040506070801020304050607080102030405060708010203040506070840000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f202122232425262728292a2b2c2d2e2f303132333435363738393a3b3c3d3e3f0000cc89a80d61ec380c24a5fdda109c3848c082584e6cb725e5ab19b18354b2ab110020090909090909090909090909090909090909090909090909090909090909090940404142434445464748494a4b4c4d4e4f505152535455565758595a5b5c5d5e5f606162636465666768696a6b6c6d6e6f707172737475767778797a7b7c7d7e7f", hex.EncodeToString(ser))
+}
+
+func (s *AptosTestSuite) TestFetchTokenMetadata() {
+	require := s.Require()
+	ledger := `{"chain_id":1,"epoch":"8000","ledger_version":"1500000000","oldest_ledger_version":"0","ledger_timestamp":"1720000000000000","node_role":"full_node","oldest_block_height":"0","block_height":"200000000","git_hash":"1"}`
+
+	vectors := []struct {
+		name     string
+		contract xc.ContractAddress
+		resp     string
+		expected *xclient.TokenMetadata
+	}{
+		{
+			name:     "coin",
+			contract: "0xf22bede237a07e121b56d91a491eb7bcdfd1f5907926a9e58338f964a01b17fa::asset::USDC",
+			resp:     `{"type":"0x1::coin::CoinInfo<0xf22bede237a07e121b56d91a491eb7bcdfd1f5907926a9e58338f964a01b17fa::asset::USDC>","data":{"decimals":6,"name":"USD Coin","supply":{"vec":[]},"symbol":"USDC"}}`,
+			expected: &xclient.TokenMetadata{Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		},
+		{
+			name:     "fungible_asset",
+			contract: "0x357b0b74bc833e95a115ad22604854d6b0fca151cecd94111770e5d6ffc9dc2b",
+			resp:     `{"type":"0x1::fungible_asset::Metadata","data":{"decimals":6,"icon_uri":"","name":"Tether USD","project_uri":"","symbol":"USDt"}}`,
+			expected: &xclient.TokenMetadata{Name: "Tether USD", Symbol: "USDt", Decimals: 6},
+		},
+	}
+	for _, v := range vectors {
+		s.Run(v.name, func() {
+			server, close := testtypes.MockHTTP(s.T(), []string{ledger, v.resp}, 200)
+			defer close()
+			client, err := NewClient(&xc.ChainConfig{URL: server.URL})
+			require.NoError(err)
+
+			metadata, err := client.FetchTokenMetadata(context.Background(), v.contract)
+			require.NoError(err)
+			v.expected.Contract = v.contract
+			require.Equal(v.expected, metadata)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package aptos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+// Both `0x1::coin::CoinInfo` and `0x1::fungible_asset::Metadata` have these fields
+type coinInfo struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals int32  `json:"decimals"`
+}
+
+// FetchTokenMetadata reads the CoinInfo resource of a legacy coin, or the metadata object of a fungible asset.
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	var owner, resourceType string
+	if IsFungibleAsset(string(contract)) {
+		owner = string(contract)
+		resourceType = "0x1::fungible_asset::Metadata"
+	} else {
+		owner, _, _ = strings.Cut(string(contract), "::")
+		resourceType = fmt.Sprintf("0x1::coin::CoinInfo<%s>", contract)
+	}
+	resource, err := client.AptosClient.GetAccountResource(owner, resourceType, 0)
+	if err != nil {
+		return nil, fmt.Errorf("could not get %s: %v", resourceType, err)
+	}
+	bz, _ := json.Marshal(resource.Data)
+	info := coinInfo{}
+	if err := json.Unmarshal(bz, &info); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %v", resourceType, err)
+	}
+	return &xclient.TokenMetadata{
+		Contract: contract,
+		Name:     info.Name,
+		Symbol:   info.Symbol,
+		Decimals: info.Decimals,
+	}, nil
+}
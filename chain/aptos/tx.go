@@ -12,13 +12,26 @@ import (
 )
 
 type Tx struct {
-	Input              *tx_input.TxInput
-	tx                 transactionbuilder.RawTransaction
-	tx_serialized      []byte
-	tx_signing_message []byte
-	tx_signature       []byte
+	Input *tx_input.TxInput
+	// Optional sponsor that pays for gas, which must also sign the transaction
+	FeePayer            transactionbuilder.AccountAddress
+	FeePayerPublicKey   []byte
+	tx                  transactionbuilder.RawTransaction
+	tx_serialized       []byte
+	tx_signing_message  []byte
+	tx_signature        []byte
+	fee_payer_signature []byte
 }
 
+// Variant of RawTransactionWithData that is signed by both the sender and fee payer
+const rawTransactionWithFeePayerVariant = 1
+
+// Variant of TransactionAuthenticator for fee payer transactions
+const transactionAuthenticatorFeePayerVariant = 3
+
+// Variant of AccountAuthenticator for single ed25519 keys
+const accountAuthenticatorEd25519Variant = 0
+
 var _ xc.Tx = &Tx{}
 
 // Hash returns the tx hash or id
@@ -34,19 +47,55 @@ func (tx Tx) Hash() xc.TxHash {
 	return xc.TxHash(hex.EncodeToString(hash[:]))
 }
 
-// Sighashes returns the tx payload to sign, aka sighash
+func (tx Tx) HasFeePayer() bool {
+	return len(tx.FeePayerPublicKey) > 0
+}
+
+func (tx Tx) signingMessage() ([]byte, error) {
+	if !tx.HasFeePayer() {
+		return tx.tx.GetSigningMessage()
+	}
+	// Both the sender and fee payer sign over the raw transaction with the fee payer address
+	// (RawTransactionWithData::MultiAgentWithFeePayer).
+	prefix := sha3.Sum256([]byte(transactionbuilder.RAW_TRANSACTION_WITH_DATA_SALT))
+	rawTx, err := lcs.Marshal(&tx.tx)
+	if err != nil {
+		return nil, err
+	}
+	msg := append([]byte{}, prefix[:]...)
+	msg = append(msg, rawTransactionWithFeePayerVariant)
+	msg = append(msg, rawTx...)
+	// no secondary signers
+	msg = append(msg, 0)
+	msg = append(msg, tx.FeePayer[:]...)
+	return msg, nil
+}
+
+// Sighashes returns the tx payload to sign, aka sighash.  If there is a fee payer,
+// the second sighash is for the fee payer.
 func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
-	msg, err := tx.tx.GetSigningMessage()
-	return []xc.TxDataToSign{[]byte(msg[:])}, err
+	msg, err := tx.signingMessage()
+	if err != nil {
+		return []xc.TxDataToSign{}, err
+	}
+	if tx.HasFeePayer() {
+		return []xc.TxDataToSign{msg, msg}, nil
+	}
+	return []xc.TxDataToSign{msg}, nil
 }
 
 // AddSignatures adds a signature to Tx
 func (tx *Tx) AddSignatures(signatures ...xc.TxSignature) error {
-	msg, err := tx.tx.GetSigningMessage()
+	msg, err := tx.signingMessage()
 	if err != nil {
 		return err
 	}
-	if len(signatures) != 1 {
+	if tx.HasFeePayer() {
+		if len(signatures) != 2 {
+			return errors.New("expecting 2 signatures (sender and fee payer)")
+		}
+		tx.fee_payer_signature = signatures[1]
+	} else if len(signatures) != 1 {
 		return errors.New("expecting 1 signature")
 	}
 	tx.tx_signing_message = msg
@@ -65,6 +114,9 @@ func (tx *Tx) GetSignatures() []xc.TxSignature {
 	if len(tx.tx_signature) > 0 {
 		sigs = append(sigs, tx.tx_signature)
 	}
+	if len(tx.fee_payer_signature) > 0 {
+		sigs = append(sigs, tx.fee_payer_signature)
+	}
 	return sigs
 }
 
@@ -76,6 +128,10 @@ func (tx Tx) Serialize() ([]byte, error) {
 		return []byte{}, errors.New("unable to serialize without setting public key")
 	}
 
+	if tx.HasFeePayer() {
+		return tx.serializeWithFeePayer()
+	}
+
 	publickey, err := transactionbuilder.NewEd25519PublicKey(tx.Input.Pubkey)
 	if err != nil {
 		return []byte{}, err
@@ -96,3 +152,51 @@ func (tx Tx) Serialize() ([]byte, error) {
 	data, err := lcs.Marshal(signedTxn)
 	return data, err
 }
+
+func newAccountAuthenticatorEd25519(pubkey []byte, sig []byte) ([]byte, error) {
+	publickey, err := transactionbuilder.NewEd25519PublicKey(pubkey)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := transactionbuilder.NewEd25519Signature(sig)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := lcs.Marshal(transactionbuilder.AccountAuthenticatorEd25519{
+		PublicKey: *publickey,
+		Signature: *signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{accountAuthenticatorEd25519Variant}, bz...), nil
+}
+
+// The go-aptos library predates the fee payer authenticator, so we serialize it manually:
+// SignedTransaction { raw_txn, TransactionAuthenticator::FeePayer { sender, secondary_signer_addresses,
+// secondary_signers, fee_payer_address, fee_payer_signer } }
+func (tx Tx) serializeWithFeePayer() ([]byte, error) {
+	if len(tx.fee_payer_signature) == 0 {
+		return []byte{}, errors.New("unable to serialize without the fee payer signature")
+	}
+	rawTx, err := lcs.Marshal(&tx.tx)
+	if err != nil {
+		return []byte{}, err
+	}
+	sender, err := newAccountAuthenticatorEd25519(tx.Input.Pubkey, tx.tx_signature)
+	if err != nil {
+		return []byte{}, err
+	}
+	feePayer, err := newAccountAuthenticatorEd25519(tx.FeePayerPublicKey, tx.fee_payer_signature)
+	if err != nil {
+		return []byte{}, err
+	}
+	data := append([]byte{}, rawTx...)
+	data = append(data, transactionAuthenticatorFeePayerVariant)
+	data = append(data, sender...)
+	// no secondary signer addresses or signers
+	data = append(data, 0, 0)
+	data = append(data, tx.FeePayer[:]...)
+	data = append(data, feePayer...)
+	return data, nil
+}
@@ -2,9 +2,16 @@ package aptos
 
 import (
 	"encoding/hex"
+	"fmt"
 	"strings"
 )
 
+const FungibleAssetMetadataType = "0x1::fungible_asset::Metadata"
+
+// The native APT coin is also tracked as a fungible asset at this metadata address.
+const AptosCoinMetadataAddress = "0x000000000000000000000000000000000000000000000000000000000000000a"
+const AptosCoinType = "0x1::aptos_coin::AptosCoin"
+
 func mustDecodeHex(h string) []byte {
 	h = strings.Replace(h, "0x", "", 1)
 	bz, err := hex.DecodeString(h)
@@ -13,3 +20,26 @@ func mustDecodeHex(h string) []byte {
 	}
 	return bz
 }
+
+// Decode an address that may be in short form (e.g. "0xa")
+func decodeHexAddress(h string) ([]byte, error) {
+	h = strings.TrimPrefix(h, "0x")
+	if len(h) > 64 {
+		return nil, fmt.Errorf("address is too long")
+	}
+	if len(h)%2 == 1 {
+		h = "0" + h
+	}
+	return hex.DecodeString(h)
+}
+
+// Normalize an address to the long 32-byte form, so short addresses like "0xa" can be compared.
+func normalizeAddress(address string) string {
+	bz, err := decodeHexAddress(address)
+	if err != nil {
+		return address
+	}
+	padded := make([]byte, 32)
+	copy(padded[32-len(bz):], bz)
+	return "0x" + hex.EncodeToString(padded)
+}
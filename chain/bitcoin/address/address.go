@@ -94,6 +94,36 @@ func (ab AddressBuilder) GetAddressFromPublicKey(publicKeyBytes []byte) (xc.Addr
 	}
 }
 
+// ValidateAddress decodes the address for the configured network, which checks its checksum.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	addr, err := NewAddressDecoder().Decode(address, ab.params)
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	if !addr.IsForNet(ab.params) {
+		return xc.InvalidAddress("address is not for %s", ab.params.Name)
+	}
+	return xc.ValidAddress(xc.Address(addr.EncodeAddress()), AddressType(addr))
+}
+
+// AddressType returns the type of script the address pays to
+func AddressType(addr btcutil.Address) xc.AddressType {
+	switch addr.(type) {
+	case *btcutil.AddressPubKeyHash:
+		return xc.AddressTypeP2PKH
+	case *btcutil.AddressScriptHash:
+		return xc.AddressTypeP2SH
+	case *btcutil.AddressWitnessPubKeyHash:
+		return xc.AddressTypeP2WPKH
+	case *btcutil.AddressWitnessScriptHash:
+		return xc.AddressTypeP2WSH
+	case *btcutil.AddressTaproot:
+		return xc.AddressTypeP2TR
+	default:
+		return xc.AddressTypeDefault
+	}
+}
+
 // GetAllPossibleAddressesFromPublicKey returns all PossubleAddress(es) given a public key
 func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []byte) ([]xc.PossibleAddress, error) {
 
@@ -126,6 +126,43 @@ func (s *CrosschainTestSuite) TestGetAllPossibleAddressesFromPublicKey() {
 	require.True(validated_p2wkh)
 }
 
+func (s *CrosschainTestSuite) TestValidateAddress() {
+	require := s.Require()
+	builder, err := address.NewAddressBuilder(&xc.ChainConfig{
+		Net:    "mainnet",
+		Chain:  xc.BTC,
+		Driver: xc.DriverBitcoin,
+	})
+	require.NoError(err)
+	vectors := []struct {
+		address     string
+		normalized  string
+		addressType xc.AddressType
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", xc.AddressTypeP2PKH},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", xc.AddressTypeP2SH},
+		{"bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", xc.AddressTypeP2WPKH},
+		{"BC1QAR0SRRR7XFKVY5L643LYDNW9RE59GTZZWF5MDQ", "bc1qar0srrr7xfkvy5l643lydnw9re59gtzzwf5mdq", xc.AddressTypeP2WPKH},
+		{"bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3", "bc1qrp33g0q5c5txsp9arysrx4k6zdkfs4nce4xj0gdcccefvpysxf3qccfmv3", xc.AddressTypeP2WSH},
+		{"bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", "bc1p5d7rjq7g6rdk2yhzks9smlaqtedr4dekq08ge8ztwac72sfr9rusxg3297", xc.AddressTypeP2TR},
+	}
+	for _, v := range vectors {
+		result := builder.ValidateAddress(xc.Address(v.address))
+		require.True(result.Valid, result.Reason)
+		require.EqualValues(v.normalized, result.Normalized)
+		require.Equal(v.addressType, result.Type)
+	}
+
+	// bad checksum
+	result := builder.ValidateAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb")
+	require.False(result.Valid)
+	require.Equal("checksum mismatch", result.Reason)
+	// testnet address
+	result = builder.ValidateAddress("tb1q6y6kkfsrzhlex4u8eel436cyh26qmlmjxgwrel")
+	require.False(result.Valid)
+	require.NotEmpty(result.Reason)
+}
+
 // TxBuilder
 
 func (s *CrosschainTestSuite) TestNewTxBuilder() {
@@ -283,6 +320,48 @@ func (s *CrosschainTestSuite) TestTxSighashes() {
 	require.NoError(err)
 }
 
+func (s *CrosschainTestSuite) TestTxDisplayPayloads() {
+	require := s.Require()
+	_, err := (&tx.Tx{}).DisplayPayloads()
+	require.ErrorContains(err, "transaction not initialized")
+
+	asset := &xc.ChainConfig{Chain: xc.BTC, Net: "testnet"}
+	builder, _ := NewTxBuilder(asset)
+	from := xc.Address("tb1qhymp5maj7x2rqxsj02exqn26v5jcqm0q3x3pz4")
+	to := xc.Address("mxVFsFW5N4mu1HPkxPttorvocvzeZ7KZyk")
+	segwitScript, _ := hex.DecodeString("0014b9361a6fb2f194301a127ab2604d5a652580ede0")
+	legacyScript, _ := hex.DecodeString("76a914ba27f99e007c7f605a8305e318c1abde3cd220ac88ac")
+	input := &tx_input.TxInput{
+		UnspentOutputs: []tx_input.Output{
+			{Value: xc.NewAmountBlockchainFromUint64(10000), PubKeyScript: segwitScript},
+			{Value: xc.NewAmountBlockchainFromUint64(10000), PubKeyScript: legacyScript},
+		},
+		GasPricePerByte: xc.NewAmountBlockchainFromUint64(1),
+	}
+	tf, err := builder.NewNativeTransfer(from, to, xc.NewAmountBlockchainFromUint64(15000), input)
+	require.NoError(err)
+	sighashes, err := tf.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 2)
+
+	payloads, err := tf.(*tx.Tx).DisplayPayloads()
+	require.NoError(err)
+	require.Len(payloads, 2)
+	for i, payload := range payloads {
+		parsed, err := tx.ParseDisplayPayload(payload)
+		require.NoError(err)
+		require.Equal(i, parsed.Index)
+		require.EqualValues(10000, parsed.Value)
+		require.Equal(tf.(*tx.Tx).MsgTx.TxHash(), parsed.MsgTx.TxHash())
+		sighash, err := parsed.Sighash()
+		require.NoError(err)
+		require.EqualValues(sighashes[i], sighash)
+	}
+
+	_, err = tx.ParseDisplayPayload(payloads[0][:20])
+	require.ErrorContains(err, "invalid display payload")
+}
+
 func (s *CrosschainTestSuite) TestTxAddSignature() {
 	require := s.Require()
 	asset := &xc.ChainConfig{Chain: xc.BTC, Net: "testnet"}
@@ -23,6 +23,7 @@ import (
 
 	// "github.com/cordialsys/crosschain/chain/bitcoin_cash"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
@@ -36,12 +37,13 @@ type BlockbookClient struct {
 }
 
 var _ xclient.FullClient = &BlockbookClient{}
+var _ xclient.LatestHeightClient = &BlockbookClient{}
 var _ address.WithAddressDecoder = &BlockbookClient{}
 
 func NewClient(cfgI xc.ITask) (*BlockbookClient, error) {
 	asset := cfgI
 	cfg := cfgI.GetChain()
-	httpClient := http.Client{}
+	httpClient := *utils.NewHttpClient(cfg)
 	chaincfg, err := params.GetParams(cfg)
 	if err != nil {
 		return &BlockbookClient{}, err
@@ -70,7 +72,13 @@ func (client *BlockbookClient) LatestBlock(ctx context.Context) (uint64, error)
 	return uint64(stats.Backend.Blocks), nil
 }
 
+// FetchLatestHeight returns the latest block height of the node
+func (client *BlockbookClient) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return client.LatestBlock(ctx)
+}
+
 func (client *BlockbookClient) SubmitTx(ctx context.Context, tx xc.Tx) error {
+	ctx = utils.NonIdempotent(ctx)
 	serial, err := tx.Serialize()
 	if err != nil {
 		return fmt.Errorf("bad tx: %v", err)
@@ -358,7 +366,12 @@ func (client *BlockbookClient) post(ctx context.Context, path string, contentTyp
 		"url":  url,
 		"body": string(input),
 	}).Debug("post")
-	res, err := client.httpClient.Post(url, contentType, bytes.NewReader(input))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(input))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	res, err := client.httpClient.Do(req)
 	if err != nil {
 		return fmt.Errorf("blockbook post failed: %v", err)
 	}
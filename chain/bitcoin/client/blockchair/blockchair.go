@@ -21,6 +21,7 @@ import (
 	"github.com/cordialsys/crosschain/chain/bitcoin/tx"
 	"github.com/cordialsys/crosschain/chain/bitcoin/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -36,13 +37,14 @@ type BlockchairClient struct {
 }
 
 var _ xclient.FullClient = &BlockchairClient{}
+var _ xclient.LatestHeightClient = &BlockchairClient{}
 var _ address.WithAddressDecoder = &BlockchairClient{}
 
 // NewClient returns a new Bitcoin Client
 func NewBlockchairClient(cfgI xc.ITask) (*BlockchairClient, error) {
 	asset := cfgI
 	cfg := cfgI.GetChain()
-	httpClient := http.Client{}
+	httpClient := *utils.NewHttpClient(cfg)
 	params, err := params.GetParams(cfg)
 	if err != nil {
 		return &BlockchairClient{}, err
@@ -77,6 +79,11 @@ func (client *BlockchairClient) LatestBlock(ctx context.Context) (uint64, error)
 	return stats.Data.Blocks, nil
 }
 
+// FetchLatestHeight returns the latest block height reported by blockchair
+func (client *BlockchairClient) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return client.LatestBlock(ctx)
+}
+
 func (client *BlockchairClient) SubmitTx(ctx context.Context, tx xc.Tx) error {
 	serial, err := tx.Serialize()
 	if err != nil {
@@ -86,7 +93,12 @@ func (client *BlockchairClient) SubmitTx(ctx context.Context, tx xc.Tx) error {
 	postUrl := fmt.Sprintf("%s/push/transaction?key=%s", client.Url, client.ApiKey)
 	postData := fmt.Sprintf("data=%s", hex.EncodeToString(serial))
 	log.Debug(postData)
-	res, err := client.httpClient.Post(postUrl, "application/x-www-form-urlencoded", bytes.NewBuffer([]byte(postData)))
+	req, err := http.NewRequestWithContext(utils.NonIdempotent(ctx), "POST", postUrl, bytes.NewBuffer([]byte(postData)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	res, err := client.httpClient.Do(req)
 	if err != nil {
 		log.Warn(err)
 		return err
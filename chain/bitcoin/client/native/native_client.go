@@ -25,14 +25,13 @@ import (
 	"github.com/cordialsys/crosschain/chain/bitcoin/tx"
 	"github.com/cordialsys/crosschain/chain/bitcoin/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/shopspring/decimal"
 )
 
 var (
 	// default timeout for client
 	DefaultClientTimeout = time.Minute
-	// default retry period for failed connections
-	DefaultClientTimeoutRetry = 10 * time.Second
 	// default host to connect to rpc node
 	DefaultClientHost = "http://0.0.0.0:18443"
 	// default user for rpc connection
@@ -45,19 +44,17 @@ var (
 
 func DefaultClientOptions() ClientOptions {
 	return ClientOptions{
-		Timeout:      DefaultClientTimeout,
-		TimeoutRetry: DefaultClientTimeoutRetry,
-		Host:         DefaultClientHost,
-		User:         DefaultClientUser,
-		Password:     DefaultClientPassword,
-		AuthHeader:   DefaultClientAuthHeader,
+		Timeout:    DefaultClientTimeout,
+		Host:       DefaultClientHost,
+		User:       DefaultClientUser,
+		Password:   DefaultClientPassword,
+		AuthHeader: DefaultClientAuthHeader,
 	}
 }
 
 // ClientOptions are used to parameterise the behaviour of the Client.
 type ClientOptions struct {
 	Timeout         time.Duration
-	TimeoutRetry    time.Duration
 	Host            string
 	User            string
 	Password        string
@@ -75,13 +72,14 @@ type NativeClient struct {
 }
 
 var _ xclient.Client = &NativeClient{}
+var _ xclient.LatestHeightClient = &NativeClient{}
 var _ address.WithAddressDecoder = &NativeClient{}
 
 // NewClient returns a new Bitcoin Client
 func NewNativeClient(cfgI xc.ITask) (*NativeClient, error) {
 	native := cfgI.GetChain()
 	opts := DefaultClientOptions()
-	httpClient := http.Client{}
+	httpClient := *utils.NewHttpClient(native)
 	httpClient.Timeout = opts.Timeout
 	opts.Host = native.URL
 	params, err := params.GetParams(native)
@@ -120,6 +118,7 @@ func (client *NativeClient) FetchTransferInput(ctx context.Context, args xcbuild
 
 // SubmitTx submits a Bitcoin tx
 func (client *NativeClient) SubmitTx(ctx context.Context, txInput xc.Tx) error {
+	ctx = utils.NonIdempotent(ctx)
 	serial, err := txInput.Serialize()
 	if err != nil {
 		return fmt.Errorf("bad tx: %v", err)
@@ -277,34 +276,30 @@ func (client *NativeClient) send(ctx context.Context, resp interface{}, method s
 		return err
 	}
 
-	return retry(ctx, client.opts.TimeoutRetry, func() error {
-		// Create request and add basic authentication headers. The context is
-		// not attached to the request, and instead we all each attempt to run
-		// for the timeout duration, and we keep attempting until success, or
-		// the context is done.
-		req, err := http.NewRequest("POST", client.opts.Host, bytes.NewBuffer(data))
-		if err != nil {
-			return fmt.Errorf("building http request: %v", err)
-		}
-		req.SetBasicAuth(client.opts.User, client.opts.Password)
-		if client.opts.AuthHeader != "" {
-			req.Header.Set(client.opts.AuthHeader, client.opts.AuthHeaderValue)
-		}
+	// Create request and add basic authentication headers. Rate limited and
+	// failed requests are retried by the transport.
+	req, err := http.NewRequestWithContext(ctx, "POST", client.opts.Host, bytes.NewBuffer(data))
+	if err != nil {
+		return fmt.Errorf("building http request: %v", err)
+	}
+	req.SetBasicAuth(client.opts.User, client.opts.Password)
+	if client.opts.AuthHeader != "" {
+		req.Header.Set(client.opts.AuthHeader, client.opts.AuthHeaderValue)
+	}
 
-		// Send the request and decode the response.
-		res, err := client.httpClient.Do(req)
-		if err != nil {
-			return fmt.Errorf("sending http request: %v", err)
-		}
-		defer res.Body.Close()
-		if res.StatusCode == 401 {
-			return fmt.Errorf("http response: %v", res.Status)
-		}
-		if err := decodeResponse(resp, res.Body); err != nil {
-			return fmt.Errorf("decoding http response: %v", err)
-		}
-		return nil
-	})
+	// Send the request and decode the response.
+	res, err := client.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sending http request: %v", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == 401 {
+		return fmt.Errorf("http response: %v", res.Status)
+	}
+	if err := decodeResponse(resp, res.Body); err != nil {
+		return fmt.Errorf("decoding http response: %v", err)
+	}
+	return nil
 }
 
 // UnspentOutputs spendable by the given address.
@@ -427,6 +422,11 @@ func (client *NativeClient) LatestBlock(ctx context.Context) (uint64, error) {
 	return uint64(resp), nil
 }
 
+// FetchLatestHeight returns the latest block height of the node
+func (client *NativeClient) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return client.LatestBlock(ctx)
+}
+
 // Output associated with an outpoint, and its number of confirmations.
 func (client *NativeClient) Output(ctx context.Context, outpoint tx_input.Outpoint) (tx_input.Output, uint64, error) {
 	resp := btcjson.TxRawResult{}
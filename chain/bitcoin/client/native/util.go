@@ -1,31 +1,12 @@
 package native
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
-	"time"
-
-	log "github.com/sirupsen/logrus"
 )
 
-func retry(ctx context.Context, dur time.Duration, f func() error) error {
-	ticker := time.NewTicker(dur)
-	err := f()
-	for err != nil {
-		log.Printf("retrying: %v", err)
-		select {
-		case <-ctx.Done():
-			return fmt.Errorf("%v: %v", ctx.Err(), err)
-		case <-ticker.C:
-			err = f()
-		}
-	}
-	return nil
-}
-
 func encodeRequest(method string, params []interface{}) ([]byte, error) {
 	rawParams, err := json.Marshal(params)
 	if err != nil {
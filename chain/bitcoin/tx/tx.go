@@ -3,6 +3,7 @@ package tx
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -73,34 +74,96 @@ func (tx *Tx) Sighashes() ([]xc.TxDataToSign, error) {
 	sighashes := make([]xc.TxDataToSign, len(tx.Input.UnspentOutputs))
 
 	for i, utxo := range tx.Input.UnspentOutputs {
-		pubKeyScript := utxo.PubKeyScript
-		value := utxo.Value.Uint64()
-		fetcher := txscript.NewCannedPrevOutputFetcher(
-			pubKeyScript, int64(value),
-		)
-
-		var hash []byte
-		var err error
-
-		log.Debugf("Sighashes params: IsPayToWitnessPubKeyHash(pubKeyScript)=%t", txscript.IsPayToWitnessPubKeyHash(pubKeyScript))
-		if txscript.IsPayToWitnessPubKeyHash(pubKeyScript) {
-			log.Debugf("CalcWitnessSigHash with pubKeyScript: %s", base64.RawURLEncoding.EncodeToString(pubKeyScript))
-			hash, err = txscript.CalcWitnessSigHash(pubKeyScript, txscript.NewTxSigHashes(tx.MsgTx, fetcher), txscript.SigHashAll, tx.MsgTx, i, int64(value))
-		} else {
-			log.Debugf("CalcSignatureHash with pubKeyScript: %s", base64.RawURLEncoding.EncodeToString(pubKeyScript))
-			hash, err = txscript.CalcSignatureHash(pubKeyScript, txscript.SigHashAll, tx.MsgTx, i)
-		}
-
+		hash, err := sighash(tx.MsgTx, i, utxo.PubKeyScript, int64(utxo.Value.Uint64()))
 		if err != nil {
 			return []xc.TxDataToSign{}, err
 		}
-
 		sighashes[i] = hash
 	}
 
 	return sighashes, nil
 }
 
+func sighash(msgTx *wire.MsgTx, index int, pubKeyScript []byte, value int64) ([]byte, error) {
+	fetcher := txscript.NewCannedPrevOutputFetcher(pubKeyScript, value)
+
+	log.Debugf("Sighashes params: IsPayToWitnessPubKeyHash(pubKeyScript)=%t", txscript.IsPayToWitnessPubKeyHash(pubKeyScript))
+	if txscript.IsPayToWitnessPubKeyHash(pubKeyScript) {
+		log.Debugf("CalcWitnessSigHash with pubKeyScript: %s", base64.RawURLEncoding.EncodeToString(pubKeyScript))
+		return txscript.CalcWitnessSigHash(pubKeyScript, txscript.NewTxSigHashes(msgTx, fetcher), txscript.SigHashAll, msgTx, index, value)
+	} else {
+		log.Debugf("CalcSignatureHash with pubKeyScript: %s", base64.RawURLEncoding.EncodeToString(pubKeyScript))
+		return txscript.CalcSignatureHash(pubKeyScript, txscript.SigHashAll, msgTx, index)
+	}
+}
+
+// DisplayPayloads returns a payload for each input, with everything needed to display the tx and compute the
+// input's sighash: the input index, the value and script of the output being spent, and the unsigned tx.
+func (tx *Tx) DisplayPayloads() ([]xc.TxDataToDisplay, error) {
+	if tx.MsgTx == nil || tx.Input == nil {
+		return nil, errors.New("transaction not initialized")
+	}
+	unsignedTx := tx.MsgTx.Copy()
+	for _, txIn := range unsignedTx.TxIn {
+		txIn.SignatureScript = nil
+		txIn.Witness = nil
+	}
+	unsigned := new(bytes.Buffer)
+	if err := unsignedTx.SerializeNoWitness(unsigned); err != nil {
+		return nil, err
+	}
+	payloads := make([]xc.TxDataToDisplay, len(tx.Input.UnspentOutputs))
+	for i, utxo := range tx.Input.UnspentOutputs {
+		buf := new(bytes.Buffer)
+		_ = binary.Write(buf, binary.LittleEndian, uint32(i))
+		_ = binary.Write(buf, binary.LittleEndian, utxo.Value.Uint64())
+		if err := wire.WriteVarBytes(buf, 0, utxo.PubKeyScript); err != nil {
+			return nil, err
+		}
+		buf.Write(unsigned.Bytes())
+		payloads[i] = buf.Bytes()
+	}
+	return payloads, nil
+}
+
+// DisplayPayload is a decoded display payload for an input
+type DisplayPayload struct {
+	Index        int
+	Value        int64
+	PubKeyScript []byte
+	MsgTx        *wire.MsgTx
+}
+
+// ParseDisplayPayload decodes a payload from DisplayPayloads
+func ParseDisplayPayload(payload []byte) (*DisplayPayload, error) {
+	reader := bytes.NewReader(payload)
+	var index uint32
+	var value uint64
+	if err := binary.Read(reader, binary.LittleEndian, &index); err != nil {
+		return nil, fmt.Errorf("invalid display payload: %v", err)
+	}
+	if err := binary.Read(reader, binary.LittleEndian, &value); err != nil {
+		return nil, fmt.Errorf("invalid display payload: %v", err)
+	}
+	pubKeyScript, err := wire.ReadVarBytes(reader, 0, txscript.MaxScriptSize, "pubKeyScript")
+	if err != nil {
+		return nil, fmt.Errorf("invalid display payload: %v", err)
+	}
+	msgTx := wire.NewMsgTx(wire.TxVersion)
+	if err := msgTx.DeserializeNoWitness(reader); err != nil {
+		return nil, fmt.Errorf("invalid display payload: %v", err)
+	}
+	if int(index) >= len(msgTx.TxIn) {
+		return nil, fmt.Errorf("invalid display payload: input %d is out of range", index)
+	}
+	return &DisplayPayload{int(index), int64(value), pubKeyScript, msgTx}, nil
+}
+
+// Sighash computes the sighash of the input
+func (payload *DisplayPayload) Sighash() ([]byte, error) {
+	return sighash(payload.MsgTx, payload.Index, payload.PubKeyScript, payload.Value)
+}
+
 // returns (r, s, err)
 func DecodeEcdsaSignature(signature xc.TxSignature) (btcec.ModNScalar, btcec.ModNScalar, error) {
 	var err error
@@ -122,6 +122,32 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 	}, nil
 }
 
+// ValidateAddress accepts cashaddr and legacy addresses, and normalizes them to the cashaddr format.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	addr, err := NewAddressDecoder().Decode(address, ab.params)
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	if !addr.IsForNet(ab.params) {
+		return xc.InvalidAddress("address is not for %s", ab.params.Name)
+	}
+	var version byte
+	switch addr.(type) {
+	case *btcutil.AddressPubKeyHash:
+		version = 0x00
+	case *btcutil.AddressScriptHash:
+		version = 0x08
+	default:
+		return xc.InvalidAddress("unsupported bitcoin cash address type %T", addr)
+	}
+	encoded, err := encodeBchAddress(version, addr.ScriptAddress(), ab.params)
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	normalized := xc.Address(AddressPrefix(ab.params) + ":" + encoded)
+	return xc.ValidAddress(normalized, bitcoinaddress.AddressType(addr))
+}
+
 func BchAddressFromBytes(addrBytes []byte, params *chaincfg.Params) (btcutil.Address, error) {
 	switch len(addrBytes) - 1 {
 	case ripemd160.Size: // P2PKH or P2SH
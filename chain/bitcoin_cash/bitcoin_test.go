@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
@@ -65,6 +66,35 @@ func (s *CrosschainTestSuite) TestGetAddressFromPublicKey() {
 	}
 }
 
+func (s *CrosschainTestSuite) TestValidateAddress() {
+	require := s.Require()
+	builderI, err := NewAddressBuilder(&xc.ChainConfig{
+		Net:   "testnet",
+		Chain: xc.BCH,
+	})
+	require.NoError(err)
+	builder := builderI.(AddressBuilder)
+	pubkey, err := base64.RawStdEncoding.DecodeString("A3bpQsIiW5ipniaDtYXQjeU2LwtRDkfWQNlAcY3u2pu7")
+	require.NoError(err)
+	legacy, err := builder.GetLegacyAddressFromPublicKey(pubkey)
+	require.NoError(err)
+
+	for _, addr := range []xc.Address{
+		"bchtest:qpkxhv02hftvxe0gx654nzx3292cvfu4tqdkf49c09",
+		"qpkxhv02hftvxe0gx654nzx3292cvfu4tqdkf49c09",
+		xc.Address(strings.TrimPrefix(string(legacy), "bchtest:")),
+	} {
+		result := builder.ValidateAddress(addr)
+		require.True(result.Valid, result.Reason)
+		require.Equal(xc.Address("bchtest:qpkxhv02hftvxe0gx654nzx3292cvfu4tqdkf49c09"), result.Normalized)
+		require.Equal(xc.AddressTypeP2PKH, result.Type)
+	}
+
+	result := builder.ValidateAddress("bchtest:qpkxhv02hftvxe0gx654nzx3292cvfu4tqdkf49c08")
+	require.False(result.Valid)
+	require.NotEmpty(result.Reason)
+}
+
 // TxBuilder
 
 func (s *CrosschainTestSuite) TestNewTxBuilder() {
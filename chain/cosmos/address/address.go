@@ -3,8 +3,12 @@ package address
 import (
 	xc "github.com/cordialsys/crosschain"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 )
 
+// Cosmwasm contracts, and other module derived accounts, have 32 byte addresses instead of 20
+const ContractAddressLength = 32
+
 // AddressBuilder for Cosmos
 type AddressBuilder struct {
 	Asset xc.ITask
@@ -40,3 +44,25 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress checks the bech32 checksum and that the prefix matches the chain.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	prefix := ab.Asset.GetChain().ChainPrefix
+	hrp, bz, err := bech32.DecodeAndConvert(string(address))
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	if hrp != prefix {
+		return xc.InvalidAddress("expected prefix %s, got %s", prefix, hrp)
+	}
+	if err := sdk.VerifyAddressFormat(bz); err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	normalized, err := sdk.Bech32ifyAddressBytes(prefix, bz)
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	result := xc.ValidAddress(xc.Address(normalized), xc.AddressTypeBech32)
+	result.Contract = len(bz) == ContractAddressLength
+	return result
+}
@@ -209,3 +209,29 @@ func TestGetPublicKey(t *testing.T) {
 	pubKey = address.GetPublicKey(&xc.ChainConfig{Driver: xc.DriverCosmosEvmos}, []byte{})
 	require.Exactly(t, &ethsecp256k1.PubKey{Key: []byte{}}, pubKey)
 }
+
+func TestValidateAddress(t *testing.T) {
+	builder, _ := address.NewAddressBuilder(&xc.ChainConfig{Chain: "LUNA", ChainPrefix: "terra"})
+
+	result := builder.ValidateAddress("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg")
+	require.True(t, result.Valid, result.Reason)
+	require.Equal(t, xc.Address("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg"), result.Normalized)
+	require.Equal(t, xc.AddressTypeBech32, result.Type)
+	require.False(t, result.Contract)
+
+	result = builder.ValidateAddress("TERRA1DP3Q305HGTTT8N34RT8RG9XPANC42Z4YE7UPFG")
+	require.True(t, result.Valid, result.Reason)
+	require.Equal(t, xc.Address("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg"), result.Normalized)
+
+	result = builder.ValidateAddress("terra1ejpjr43ht3y56pplm5pxpusmcrk9rkkvna4tklusnnwdxpqm0zlsz74tve")
+	require.True(t, result.Valid, result.Reason)
+	require.True(t, result.Contract)
+
+	result = builder.ValidateAddress("osmo1ejpjr43ht3y56pplm5pxpusmcrk9rkkvp60las")
+	require.False(t, result.Valid)
+	require.Equal(t, "expected prefix terra, got osmo", result.Reason)
+
+	result = builder.ValidateAddress("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfh")
+	require.False(t, result.Valid)
+	require.Contains(t, result.Reason, "invalid checksum")
+}
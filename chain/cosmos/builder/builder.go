@@ -16,6 +16,7 @@ import (
 	localcodectypes "github.com/cordialsys/crosschain/chain/cosmos/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
 	"github.com/cosmos/cosmos-sdk/x/auth/signing"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
@@ -54,16 +55,17 @@ func DefaultMaxGasPrice(nativeAsset *xc.ChainConfig) float64 {
 
 // Old transfer interface
 func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.newTransfer(from, to, amount, input, nil)
+}
+
+// NewTransfer creates a new transfer for an Asset, either native or token
+func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.newTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input, &args)
+}
+
+func (txBuilder TxBuilder) newTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput, feeOptions xcbuilder.FeeOptions) (xc.Tx, error) {
 	txInput := input.(*tx_input.TxInput)
-	native := txBuilder.Asset.GetChain()
-	max := native.ChainMaxGasPrice
-	if max <= 0 {
-		max = DefaultMaxGasPrice(native)
-	}
-	// enforce a maximum gas price
-	if txInput.GasPrice > max {
-		txInput.GasPrice = max
-	}
+	txBuilder.enforceMaxGasPrice(txInput)
 
 	// cosmos is unique in that:
 	// - the native asset is in one of the native modules, x/bank
@@ -78,19 +80,14 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 	// to determine which cosmos module we should
 	switch txInput.AssetType {
 	case tx_input.BANK:
-		return txBuilder.NewBankTransfer(from, to, amount, input)
+		return txBuilder.newBankTransfer(from, to, amount, input, feeOptions)
 	case tx_input.CW20:
-		return txBuilder.NewCW20Transfer(from, to, amount, input)
+		return txBuilder.newCW20Transfer(from, to, amount, input, feeOptions)
 	default:
 		return nil, errors.New("unknown cosmos asset type: " + string(txInput.AssetType))
 	}
 }
 
-// NewTransfer creates a new transfer for an Asset, either native or token
-func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
-	return txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
-}
-
 // See NewTransfer
 func (txBuilder TxBuilder) NewNativeTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
 	return txBuilder.NewTransfer(from, to, amount, input)
@@ -103,6 +100,10 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 
 // x/bank MsgSend transfer
 func (txBuilder TxBuilder) NewBankTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.newBankTransfer(from, to, amount, input, nil)
+}
+
+func (txBuilder TxBuilder) newBankTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput, feeOptions xcbuilder.FeeOptions) (xc.Tx, error) {
 	txInput := input.(*tx_input.TxInput)
 	amountInt := big.Int(amount)
 
@@ -126,10 +127,14 @@ func (txBuilder TxBuilder) NewBankTransfer(from xc.Address, to xc.Address, amoun
 	return txBuilder.createTxWithMsg(txInput, msgSend, txArgs{
 		Memo:          txInput.LegacyMemo,
 		FromPublicKey: txInput.LegacyFromPublicKey,
-	}, fees)
+	}.withFeeOptions(from, feeOptions), fees)
 }
 
 func (txBuilder TxBuilder) NewCW20Transfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.newCW20Transfer(from, to, amount, input, nil)
+}
+
+func (txBuilder TxBuilder) newCW20Transfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput, feeOptions xcbuilder.FeeOptions) (xc.Tx, error) {
 	txInput := input.(*tx_input.TxInput)
 	asset := txBuilder.Asset
 
@@ -149,7 +154,7 @@ func (txBuilder TxBuilder) NewCW20Transfer(from xc.Address, to xc.Address, amoun
 	return txBuilder.createTxWithMsg(txInput, msgSend, txArgs{
 		Memo:          txInput.LegacyMemo,
 		FromPublicKey: txInput.LegacyFromPublicKey,
-	}, fees)
+	}.withFeeOptions(from, feeOptions), fees)
 }
 
 func (txBuilder TxBuilder) GetDenom() string {
@@ -164,7 +169,7 @@ func (txBuilder TxBuilder) GetDenom() string {
 		}
 	}
 
-	return denom
+	return NormalizeDenom(denom)
 }
 
 // Returns the amount in blockchain that is percentage of amount.
@@ -225,15 +230,49 @@ func (txBuilder TxBuilder) calculateFees(amount xc.AmountBlockchain, input *tx_i
 type txArgs struct {
 	Memo          string
 	FromPublicKey []byte
+	// Account with a x/feegrant allowance for the signer, that will pay the fees
+	FeeGranter xc.Address
+	// Account that pays the fees directly, and must also sign
+	FeePayer          xc.Address
+	FeePayerPublicKey []byte
+}
+
+// withFeeOptions sets the fee granter and payer of the options.  A fee payer that is the sender is ignored, as the
+// sender already pays the fees and signs once.
+func (args txArgs) withFeeOptions(from xc.Address, options xcbuilder.FeeOptions) txArgs {
+	if options == nil {
+		return args
+	}
+	if granter, ok := options.GetFeeGranter(); ok {
+		args.FeeGranter = granter
+	}
+	if payer, ok := options.GetFeePayer(); ok && payer != from {
+		args.FeePayer = payer
+		args.FeePayerPublicKey, _ = options.GetFeePayerPublicKey()
+	}
+	return args
+}
+
+// Implemented by the cosmos-sdk tx builder
+type protoTxProvider interface {
+	GetProtoTx() *txtypes.Tx
 }
 
 // createTxWithMsg creates a new Tx given Cosmos Msg
 func (txBuilder TxBuilder) createTxWithMsg(input *tx_input.TxInput, msg types.Msg, args txArgs, fees types.Coins) (xc.Tx, error) {
+	return txBuilder.createTxWithMsgs(input, []types.Msg{msg}, args, fees)
+}
+
+// createTxWithMsgs creates a new Tx bundling one or more Cosmos Msg's, all signed by the same account
+func (txBuilder TxBuilder) createTxWithMsgs(input *tx_input.TxInput, msgs []types.Msg, args txArgs, fees types.Coins) (xc.Tx, error) {
 	asset := txBuilder.Asset
 	cosmosTxConfig := txBuilder.CosmosTxConfig
 	cosmosBuilder := txBuilder.CosmosTxBuilder
 
-	err := cosmosBuilder.SetMsgs(msg)
+	if len(msgs) == 0 {
+		return nil, errors.New("at least one cosmos message is required")
+	}
+	err := cosmosBuilder.SetMsgs(msgs...)
 	if err != nil {
 		return nil, err
 	}
@@ -241,6 +280,18 @@ func (txBuilder TxBuilder) createTxWithMsg(input *tx_input.TxInput, msg types.Ms
 	cosmosBuilder.SetMemo(args.Memo)
 	cosmosBuilder.SetGasLimit(input.GasLimit)
 
+	if args.FeePayer != "" && len(args.FeePayerPublicKey) == 0 {
+		return nil, fmt.Errorf("associated public key for fee payer %s is required", args.FeePayer)
+	}
+	// Set the fee payer and granter directly, as the cosmos-sdk setters depend on the global bech32 prefix.
+	// This is always set, as the cosmos builder is reused between transactions.
+	protoTx, ok := cosmosBuilder.(protoTxProvider)
+	if !ok {
+		return nil, fmt.Errorf("unsupported cosmos tx builder %T", cosmosBuilder)
+	}
+	protoTx.GetProtoTx().AuthInfo.Fee.Payer = string(args.FeePayer)
+	protoTx.GetProtoTx().AuthInfo.Fee.Granter = string(args.FeeGranter)
+	// Must be set after the payer and granter, so the cached auth info is reset
 	cosmosBuilder.SetFeeAmount(fees)
 
 	sigMode := signingtypes.SignMode_SIGN_MODE_DIRECT
@@ -254,6 +305,17 @@ func (txBuilder TxBuilder) createTxWithMsg(input *tx_input.TxInput, msg types.Ms
 			Sequence: input.Sequence,
 		},
 	}
+	if args.FeePayer != "" {
+		// the fee payer is always the last signer
+		sigsV2 = append(sigsV2, signingtypes.SignatureV2{
+			PubKey: address.GetPublicKey(asset.GetChain(), args.FeePayerPublicKey),
+			Data: &signingtypes.SingleSignatureData{
+				SignMode:  sigMode,
+				Signature: nil,
+			},
+			Sequence: input.FeePayerSequence,
+		})
+	}
 	err = cosmosBuilder.SetSignatures(sigsV2...)
 	if err != nil {
 		return nil, err
@@ -274,12 +336,30 @@ func (txBuilder TxBuilder) createTxWithMsg(input *tx_input.TxInput, msg types.Ms
 		return nil, err
 	}
 	sighash := tx.GetSighash(asset.GetChain(), sighashData)
+
+	var feePayerSighash []byte
+	var feePayerSighashData []byte
+	if args.FeePayer != "" {
+		feePayerSignerData := signing.SignerData{
+			AccountNumber: input.FeePayerAccountNumber,
+			ChainID:       chainId,
+			Sequence:      input.FeePayerSequence,
+		}
+		feePayerSighashData, err = cosmosTxConfig.SignModeHandler().GetSignBytes(sigMode, feePayerSignerData, cosmosBuilder.GetTx())
+		if err != nil {
+			return nil, err
+		}
+		feePayerSighash = tx.GetSighash(asset.GetChain(), feePayerSighashData)
+	}
 	return &tx.Tx{
-		CosmosTx:        cosmosBuilder.GetTx(),
-		ParsedTransfers: []types.Msg{msg},
-		CosmosTxBuilder: cosmosBuilder,
-		CosmosTxEncoder: cosmosTxConfig.TxEncoder(),
-		SigsV2:          sigsV2,
-		TxDataToSign:    sighash,
+		CosmosTx:           cosmosBuilder.GetTx(),
+		ParsedTransfers:    msgs,
+		CosmosTxBuilder:    cosmosBuilder,
+		CosmosTxEncoder:    cosmosTxConfig.TxEncoder(),
+		SigsV2:             sigsV2,
+		TxDataToSign:       sighash,
+		FeePayerDataToSign: feePayerSighash,
+		SignDoc:            sighashData,
+		FeePayerSignDoc:    feePayerSighashData,
 	}, nil
 }
@@ -0,0 +1,92 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// NewGrant creates a x/authz MsgGrant transaction, allowing the grantee to execute messages on
+// behalf of the granter (the signer), as permitted by the authorization.
+// An optional expiration may be set, after which the grant is no longer valid.
+func (txBuilder TxBuilder) NewGrant(granter xc.Address, grantee xc.Address, authorization authz.Authorization, expiration *time.Time, input xc.TxInput) (xc.Tx, error) {
+	msg, err := NewMsgGrant(granter, grantee, authorization, expiration)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewGenericGrant is a shorthand for a grant with authz.GenericAuthorization, permitting the grantee
+// to execute any message of the given type url (e.g. "/cosmos.staking.v1beta1.MsgDelegate").
+func (txBuilder TxBuilder) NewGenericGrant(granter xc.Address, grantee xc.Address, msgTypeUrl string, expiration *time.Time, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.NewGrant(granter, grantee, authz.NewGenericAuthorization(msgTypeUrl), expiration, input)
+}
+
+// NewRevoke creates a x/authz MsgRevoke transaction, removing any grant for the given message type.
+func (txBuilder TxBuilder) NewRevoke(granter xc.Address, grantee xc.Address, msgTypeUrl string, input xc.TxInput) (xc.Tx, error) {
+	if msgTypeUrl == "" {
+		return nil, errors.New("message type url is required to revoke a grant")
+	}
+	msg := &authz.MsgRevoke{
+		Granter:    string(granter),
+		Grantee:    string(grantee),
+		MsgTypeUrl: msgTypeUrl,
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewExec creates a x/authz MsgExec transaction, signed by the grantee (e.g. a hot key), which
+// executes the messages on behalf of the granter (e.g. a cold key).  The messages should be built
+// as if the granter were sending them.
+func (txBuilder TxBuilder) NewExec(grantee xc.Address, input xc.TxInput, msgs ...types.Msg) (xc.Tx, error) {
+	msg, err := NewMsgExec(grantee, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewMsgGrant constructs a MsgGrant without relying on the global bech32 prefix config,
+// which is not compatible with using multiple cosmos chains.
+func NewMsgGrant(granter xc.Address, grantee xc.Address, authorization authz.Authorization, expiration *time.Time) (*authz.MsgGrant, error) {
+	if authorization == nil {
+		return nil, errors.New("authorization is required to grant")
+	}
+	authorizationAny, err := codectypes.NewAnyWithValue(authorization)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode authorization: %v", err)
+	}
+	return &authz.MsgGrant{
+		Granter: string(granter),
+		Grantee: string(grantee),
+		Grant: authz.Grant{
+			Authorization: authorizationAny,
+			Expiration:    expiration,
+		},
+	}, nil
+}
+
+// NewMsgExec constructs a MsgExec without relying on the global bech32 prefix config.
+func NewMsgExec(grantee xc.Address, msgs ...types.Msg) (*authz.MsgExec, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("at least one message is required to execute")
+	}
+	msgsAny := make([]*codectypes.Any, len(msgs))
+	for i, msg := range msgs {
+		msgAny, err := codectypes.NewAnyWithValue(msg)
+		if err != nil {
+			return nil, fmt.Errorf("could not encode message %d: %v", i, err)
+		}
+		msgsAny[i] = msgAny
+	}
+	return &authz.MsgExec{
+		Grantee: string(grantee),
+		Msgs:    msgsAny,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package builder
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	wasmtypes "github.com/cordialsys/crosschain/chain/cosmos/types/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// ExecuteContract creates a CosmWasm MsgExecuteContract transaction, calling the contract with the
+// json message.  Any funds are sent along to the contract.
+func (txBuilder TxBuilder) ExecuteContract(sender xc.Address, contract xc.ContractAddress, jsonMsg []byte, funds types.Coins, input xc.TxInput) (xc.Tx, error) {
+	msg, err := NewMsgExecuteContract(sender, contract, jsonMsg, funds)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// InstantiateContract creates a CosmWasm MsgInstantiateContract transaction, creating a new contract from
+// the stored code.  The admin is optional, and is the only account permitted to migrate the contract.
+func (txBuilder TxBuilder) InstantiateContract(sender xc.Address, codeId uint64, jsonMsg []byte, label string, admin xc.Address, funds types.Coins, input xc.TxInput) (xc.Tx, error) {
+	msg, err := NewMsgInstantiateContract(sender, codeId, jsonMsg, label, admin, funds)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+func NewMsgExecuteContract(sender xc.Address, contract xc.ContractAddress, jsonMsg []byte, funds types.Coins) (*wasmtypes.MsgExecuteContract, error) {
+	if contract == "" {
+		return nil, errors.New("contract address is required")
+	}
+	if !json.Valid(jsonMsg) {
+		return nil, errors.New("contract message must be valid json")
+	}
+	funds, err := validateFunds(funds)
+	if err != nil {
+		return nil, err
+	}
+	return &wasmtypes.MsgExecuteContract{
+		Sender:   string(sender),
+		Contract: string(contract),
+		Msg:      wasmtypes.RawContractMessage(jsonMsg),
+		Funds:    funds,
+	}, nil
+}
+
+func NewMsgInstantiateContract(sender xc.Address, codeId uint64, jsonMsg []byte, label string, admin xc.Address, funds types.Coins) (*wasmtypes.MsgInstantiateContract, error) {
+	if codeId == 0 {
+		return nil, errors.New("code id is required")
+	}
+	if label == "" {
+		return nil, errors.New("label is required")
+	}
+	if !json.Valid(jsonMsg) {
+		return nil, errors.New("instantiate message must be valid json")
+	}
+	funds, err := validateFunds(funds)
+	if err != nil {
+		return nil, err
+	}
+	return &wasmtypes.MsgInstantiateContract{
+		Sender: string(sender),
+		Admin:  string(admin),
+		CodeID: codeId,
+		Label:  label,
+		Msg:    wasmtypes.RawContractMessage(jsonMsg),
+		Funds:  funds,
+	}, nil
+}
+
+// funds must be sorted or the transaction is rejected
+func validateFunds(funds types.Coins) (types.Coins, error) {
+	funds = append(types.Coins{}, funds...).Sort()
+	if err := funds.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid funds: %v", err)
+	}
+	return funds, nil
+}
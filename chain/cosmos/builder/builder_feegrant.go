@@ -0,0 +1,65 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+// NewGrantAllowance creates a x/feegrant MsgGrantAllowance transaction, allowing the grantee to have
+// its fees paid by the granter (the signer), as permitted by the allowance.
+func (txBuilder TxBuilder) NewGrantAllowance(granter xc.Address, grantee xc.Address, allowance feegrant.FeeAllowanceI, input xc.TxInput) (xc.Tx, error) {
+	msg, err := NewMsgGrantAllowance(granter, grantee, allowance)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewBasicGrantAllowance is a shorthand for a grant with feegrant.BasicAllowance.  An empty spend limit
+// means there is no limit on the fees paid.  An optional expiration may be set, after which the allowance is no longer valid.
+func (txBuilder TxBuilder) NewBasicGrantAllowance(granter xc.Address, grantee xc.Address, spendLimit types.Coins, expiration *time.Time, input xc.TxInput) (xc.Tx, error) {
+	allowance := &feegrant.BasicAllowance{
+		SpendLimit: spendLimit,
+		Expiration: expiration,
+	}
+	return txBuilder.NewGrantAllowance(granter, grantee, allowance, input)
+}
+
+// NewRevokeAllowance creates a x/feegrant MsgRevokeAllowance transaction, removing any allowance for the grantee.
+func (txBuilder TxBuilder) NewRevokeAllowance(granter xc.Address, grantee xc.Address, input xc.TxInput) (xc.Tx, error) {
+	msg := &feegrant.MsgRevokeAllowance{
+		Granter: string(granter),
+		Grantee: string(grantee),
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewMsgGrantAllowance constructs a MsgGrantAllowance without relying on the global bech32 prefix config.
+func NewMsgGrantAllowance(granter xc.Address, grantee xc.Address, allowance feegrant.FeeAllowanceI) (*feegrant.MsgGrantAllowance, error) {
+	if allowance == nil {
+		return nil, errors.New("allowance is required to grant")
+	}
+	if err := allowance.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid allowance: %v", err)
+	}
+	allowanceMsg, ok := allowance.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot encode allowance %T", allowance)
+	}
+	allowanceAny, err := codectypes.NewAnyWithValue(allowanceMsg)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode allowance: %v", err)
+	}
+	return &feegrant.MsgGrantAllowance{
+		Granter:   string(granter),
+		Grantee:   string(grantee),
+		Allowance: allowanceAny,
+	}, nil
+}
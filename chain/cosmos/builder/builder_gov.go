@@ -0,0 +1,122 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cosmos/cosmos-sdk/types"
+	govutils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+)
+
+// GovVersion is the version of the x/gov messages to vote with.  Chains on older cosmos-sdk versions only have v1beta1.
+type GovVersion string
+
+const (
+	GovV1      GovVersion = "v1"
+	GovV1Beta1 GovVersion = "v1beta1"
+)
+
+// NewVote creates a x/gov MsgVote transaction, voting on the proposal with the voter's (the signer) delegated stake.
+func (txBuilder TxBuilder) NewVote(voter xc.Address, proposalId uint64, option govv1.VoteOption, version GovVersion, input xc.TxInput) (xc.Tx, error) {
+	msg, err := NewMsgVote(voter, proposalId, option, version)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewWeightedVote creates a x/gov MsgVoteWeighted transaction, splitting the voting power between
+// multiple options.  The weights must add up to 1.
+func (txBuilder TxBuilder) NewWeightedVote(voter xc.Address, proposalId uint64, options govv1.WeightedVoteOptions, version GovVersion, input xc.TxInput) (xc.Tx, error) {
+	msg, err := NewMsgVoteWeighted(voter, proposalId, options, version)
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.NewMsgsTx(input, msg)
+}
+
+// NewMsgVote creates a gov v1 or v1beta1 MsgVote
+func NewMsgVote(voter xc.Address, proposalId uint64, option govv1.VoteOption, version GovVersion) (types.Msg, error) {
+	if !govv1.ValidVoteOption(option) {
+		return nil, fmt.Errorf("invalid vote option: %s", option)
+	}
+	switch version {
+	case GovV1:
+		return &govv1.MsgVote{
+			ProposalId: proposalId,
+			Voter:      string(voter),
+			Option:     option,
+		}, nil
+	case GovV1Beta1:
+		return &govv1beta1.MsgVote{
+			ProposalId: proposalId,
+			Voter:      string(voter),
+			Option:     govv1beta1.VoteOption(option),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported gov version: %s", version)
+	}
+}
+
+// NewMsgVoteWeighted creates a gov v1 or v1beta1 MsgVoteWeighted
+func NewMsgVoteWeighted(voter xc.Address, proposalId uint64, options govv1.WeightedVoteOptions, version GovVersion) (types.Msg, error) {
+	if len(options) == 0 {
+		return nil, errors.New("at least one vote option is required")
+	}
+	total := types.ZeroDec()
+	seen := map[govv1.VoteOption]bool{}
+	weights := []types.Dec{}
+	for _, option := range options {
+		if option == nil || !govv1.ValidWeightedVoteOption(*option) {
+			return nil, fmt.Errorf("invalid weighted vote option: %v", option)
+		}
+		if seen[option.Option] {
+			return nil, fmt.Errorf("duplicated vote option: %s", option.Option)
+		}
+		seen[option.Option] = true
+		weight, _ := types.NewDecFromStr(option.Weight)
+		weights = append(weights, weight)
+		total = total.Add(weight)
+	}
+	if !total.Equal(types.OneDec()) {
+		return nil, fmt.Errorf("total weight of vote options must be 1, not %s", total)
+	}
+	switch version {
+	case GovV1:
+		return &govv1.MsgVoteWeighted{
+			ProposalId: proposalId,
+			Voter:      string(voter),
+			Options:    options,
+		}, nil
+	case GovV1Beta1:
+		optionsV1Beta1 := make([]govv1beta1.WeightedVoteOption, len(options))
+		for i, option := range options {
+			optionsV1Beta1[i] = govv1beta1.WeightedVoteOption{
+				Option: govv1beta1.VoteOption(option.Option),
+				Weight: weights[i],
+			}
+		}
+		return &govv1beta1.MsgVoteWeighted{
+			ProposalId: proposalId,
+			Voter:      string(voter),
+			Options:    optionsV1Beta1,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported gov version: %s", version)
+	}
+}
+
+// ParseVoteOption parses a vote option, e.g. "yes", "no", "abstain", "no_with_veto", or "VOTE_OPTION_YES"
+func ParseVoteOption(option string) (govv1.VoteOption, error) {
+	return govv1.VoteOptionFromString(govutils.NormalizeVoteOption(strings.TrimSpace(option)))
+}
+
+// ParseWeightedVoteOptions parses weighted vote options, e.g. "yes=0.6,no=0.4".
+// An option without a weight has a weight of 1.
+func ParseWeightedVoteOptions(options string) (govv1.WeightedVoteOptions, error) {
+	return govv1.WeightedVoteOptionsFromString(govutils.NormalizeWeightedVoteOptions(strings.ReplaceAll(options, " ", "")))
+}
@@ -0,0 +1,78 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	ibctransfer "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	ibcclient "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/core/02-client/types"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewIbcTransfer creates an IBC MsgTransfer to the destination chain, using the channel configured on the chain.
+// Any memo is passed through to the IBC packet (e.g. for exchange deposits or packet forwarding), not the tx.
+func (txBuilder TxBuilder) NewIbcTransfer(args xcbuilder.TransferArgs, destination xc.NativeAsset, input xc.TxInput) (xc.Tx, error) {
+	txInput, ok := input.(*tx_input.TxInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input %T, expected %T", input, txInput)
+	}
+	if txInput.AssetType == tx_input.CW20 {
+		return nil, errors.New("cw20 tokens cannot be transferred using IBC MsgTransfer")
+	}
+	channel, ok := txBuilder.Asset.GetChain().GetIbcChannel(destination)
+	if !ok {
+		return nil, fmt.Errorf("no IBC channel configured from %s to %s", txBuilder.Asset.GetChain().Chain, destination)
+	}
+	if txInput.IbcTimeoutTimestamp == 0 && txInput.IbcTimeoutRevisionHeight == 0 {
+		return nil, errors.New("IBC timeout timestamp or height must be set on the input")
+	}
+	txBuilder.enforceMaxGasPrice(txInput)
+	if txInput.GasLimit == 0 {
+		txInput.GasLimit = gas.NativeTransferGasLimit
+	}
+
+	port := channel.Port
+	if port == "" {
+		port = ibctransfer.PortID
+	}
+	memo, ok := args.GetMemo()
+	if !ok {
+		memo = txInput.LegacyMemo
+	}
+	pubkey, ok := args.GetPublicKey()
+	if !ok {
+		pubkey = txInput.LegacyFromPublicKey
+	}
+	amount := args.GetAmount()
+
+	msg := &ibctransfer.MsgTransfer{
+		SourcePort:       port,
+		SourceChannel:    channel.Channel,
+		Token:            types.NewCoin(txBuilder.GetDenom(), types.NewIntFromBigInt(amount.Int())),
+		Sender:           string(args.GetFrom()),
+		Receiver:         string(args.GetTo()),
+		TimeoutHeight:    ibcclient.NewHeight(txInput.IbcTimeoutRevisionNumber, txInput.IbcTimeoutRevisionHeight),
+		TimeoutTimestamp: txInput.IbcTimeoutTimestamp,
+		Memo:             memo,
+	}
+
+	fees := txBuilder.calculateFees(amount, txInput, true)
+	return txBuilder.createTxWithMsg(txInput, msg, txArgs{
+		FromPublicKey: pubkey,
+	}, fees)
+}
+
+// NormalizeDenom converts IBC denoms given by their full trace path (e.g. "transfer/channel-0/uatom")
+// into the "ibc/{hash}" denom used by x/bank.  Other denoms are returned as is.
+func NormalizeDenom(denom string) string {
+	if ibctransfer.IsIBCDenom(denom) {
+		// the hash is always upper case hex
+		return ibctransfer.DenomPrefix + "/" + strings.ToUpper(strings.TrimPrefix(denom, ibctransfer.DenomPrefix+"/"))
+	}
+	return ibctransfer.ParseDenomTrace(denom).IBCDenom()
+}
@@ -0,0 +1,68 @@
+package builder
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewMsgsTx creates a transaction bundling any number of cosmos messages (e.g. claiming rewards
+// from many validators and re-delegating), signed by the account described in the input.
+// The public key of the signer must be set on the input.  If no gas limit is set on the input,
+// a conservative default is used per message; callers should prefer to simulate the bundle first.
+func (txBuilder TxBuilder) NewMsgsTx(input xc.TxInput, msgs ...types.Msg) (xc.Tx, error) {
+	txInput, err := getBaseTxInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, errors.New("at least one cosmos message is required")
+	}
+	if len(txInput.LegacyFromPublicKey) == 0 {
+		return nil, errors.New("public key of the signer must be set on the input")
+	}
+	txBuilder.enforceMaxGasPrice(txInput)
+
+	if txInput.GasLimit == 0 {
+		txInput.GasLimit = gas.NativeTransferGasLimit * uint64(len(msgs))
+	}
+
+	// There is no single "amount" for a bundle of messages, so any transfer tax is not included.
+	fees := txBuilder.calculateFees(xc.NewAmountBlockchainFromUint64(0), txInput, false)
+	return txBuilder.createTxWithMsgs(txInput, msgs, txArgs{
+		Memo:          txInput.LegacyMemo,
+		FromPublicKey: txInput.LegacyFromPublicKey,
+	}, fees)
+}
+
+// enforce a maximum gas price on the input
+func (txBuilder TxBuilder) enforceMaxGasPrice(txInput *tx_input.TxInput) {
+	native := txBuilder.Asset.GetChain()
+	max := native.ChainMaxGasPrice
+	if max <= 0 {
+		max = DefaultMaxGasPrice(native)
+	}
+	if txInput.GasPrice > max {
+		txInput.GasPrice = max
+	}
+}
+
+// All of the cosmos inputs (transfer, staking, etc) share the same base input
+func getBaseTxInput(input xc.TxInput) (*tx_input.TxInput, error) {
+	switch input := input.(type) {
+	case *tx_input.TxInput:
+		return input, nil
+	case *tx_input.StakingInput:
+		return &input.TxInput, nil
+	case *tx_input.UnstakingInput:
+		return &input.TxInput, nil
+	case *tx_input.WithdrawInput:
+		return &input.TxInput, nil
+	default:
+		return nil, fmt.Errorf("invalid input %T, expected %T", input, &tx_input.TxInput{})
+	}
+}
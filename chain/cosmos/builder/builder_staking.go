@@ -42,7 +42,7 @@ func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput
 	return txBuilder.createTxWithMsg(&stakeInput.TxInput, msg, txArgs{
 		Memo:          memo,
 		FromPublicKey: pubkey,
-	}, fees)
+	}.withFeeOptions(from, &args), fees)
 }
 
 func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
@@ -76,7 +76,7 @@ func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxI
 	return txBuilder.createTxWithMsg(&stakeInput.TxInput, msg, txArgs{
 		Memo:          memo,
 		FromPublicKey: pubkey,
-	}, fees)
+	}.withFeeOptions(from, &args), fees)
 }
 
 func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
@@ -109,5 +109,5 @@ func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawT
 	return txBuilder.createTxWithMsg(&withdrawInput.TxInput, msg, txArgs{
 		Memo:          memo,
 		FromPublicKey: pubkey,
-	}, fees)
+	}.withFeeOptions(from, &args), fees)
 }
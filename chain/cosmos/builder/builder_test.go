@@ -4,17 +4,32 @@ import (
 
 	// "github.com/cosmos/cosmos-sdk/types/tx"
 
+	"encoding/base64"
+	"strings"
 	"testing"
+	"time"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/cosmos/builder"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	wasmtypes "github.com/cordialsys/crosschain/chain/cosmos/types/CosmWasm/wasmd/x/wasm/types"
+	ibctransfer "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/apps/transfer/types"
 	"github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	disttypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	"github.com/stretchr/testify/require"
 )
 
+var pubkey, _ = base64.StdEncoding.DecodeString("Avz3JMl9/6wgIe+hgYwv7zvLt1PKIpE6jbXnnsSj3uDR")
+
 func TestTaxRate(t *testing.T) {
 	amount := xc.NewAmountBlockchainFromUint64(100)
 	tax := 0.05
@@ -146,3 +161,459 @@ func TestTransferWithMaxGasPrice(t *testing.T) {
 
 	}
 }
+
+func TestNewMsgsTx(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "LUNA",
+		ChainCoin:   "uluna",
+		ChainPrefix: "terra",
+		Decimals:    6,
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+
+	delegator := "terra18pptupzy59ulkvn0eyrawuuxspc93w6a9ctp9j"
+	validators := []string{
+		"terravaloper1zdpgj8am5nqqvht927k3etljyl6a52kwqup0je",
+		"terravaloper1c4r9cjc4zenmz7z2unfj2q5u7ulqcdcm7m0twf",
+	}
+	msgs := []types.Msg{}
+	for _, validator := range validators {
+		msgs = append(msgs, &disttypes.MsgWithdrawDelegatorReward{
+			DelegatorAddress: delegator,
+			ValidatorAddress: validator,
+		})
+	}
+	msgs = append(msgs, &stakingtypes.MsgDelegate{
+		DelegatorAddress: delegator,
+		ValidatorAddress: validators[0],
+		Amount:           types.NewCoin("uluna", types.NewInt(100)),
+	})
+
+	input := tx_input.NewTxInput()
+	input.GasPrice = 0.015
+	// missing public key
+	_, err = txBuilder.NewMsgsTx(input, msgs...)
+	require.ErrorContains(t, err, "public key")
+
+	input.LegacyFromPublicKey = pubkey
+	_, err = txBuilder.NewMsgsTx(input)
+	require.ErrorContains(t, err, "at least one")
+
+	xcTx, err := txBuilder.NewMsgsTx(input, msgs...)
+	require.NoError(t, err)
+	cosmosTx := xcTx.(*tx.Tx)
+	require.Len(t, cosmosTx.CosmosTx.GetMsgs(), 3)
+	require.Len(t, cosmosTx.ParsedTransfers, 3)
+	// default gas limit scales with the number of messages
+	require.EqualValues(t, 3*gas.NativeTransferGasLimit, cosmosTx.CosmosTx.(types.FeeTx).GetGas())
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	// simulated gas limit is respected
+	input.GasLimit = 123_000
+	xcTx, err = txBuilder.NewMsgsTx(input, msgs...)
+	require.NoError(t, err)
+	require.EqualValues(t, 123_000, xcTx.(*tx.Tx).CosmosTx.(types.FeeTx).GetGas())
+}
+
+func TestNewAuthzTxs(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "LUNA",
+		ChainCoin:   "uluna",
+		ChainPrefix: "terra",
+		Decimals:    6,
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+	granter := xc.Address("terra18pptupzy59ulkvn0eyrawuuxspc93w6a9ctp9j")
+	grantee := xc.Address("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg")
+	delegateUrl := types.MsgTypeURL(&stakingtypes.MsgDelegate{})
+
+	input := tx_input.NewTxInput()
+	input.LegacyFromPublicKey = pubkey
+
+	// grant
+	expiration := time.Unix(1900000000, 0).UTC()
+	xcTx, err := txBuilder.NewGenericGrant(granter, grantee, delegateUrl, &expiration, input)
+	require.NoError(t, err)
+	msgs := xcTx.(*tx.Tx).CosmosTx.GetMsgs()
+	require.Len(t, msgs, 1)
+	grant := msgs[0].(*authz.MsgGrant)
+	require.Equal(t, string(granter), grant.Granter)
+	require.Equal(t, string(grantee), grant.Grantee)
+	require.Equal(t, &expiration, grant.Grant.Expiration)
+	authorization := grant.Grant.Authorization.GetCachedValue().(*authz.GenericAuthorization)
+	require.Equal(t, delegateUrl, authorization.Msg)
+
+	// revoke
+	xcTx, err = txBuilder.NewRevoke(granter, grantee, delegateUrl, input)
+	require.NoError(t, err)
+	revoke := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*authz.MsgRevoke)
+	require.Equal(t, delegateUrl, revoke.MsgTypeUrl)
+	_, err = txBuilder.NewRevoke(granter, grantee, "", input)
+	require.Error(t, err)
+
+	// exec, signed by the grantee on behalf of the granter
+	delegate := &stakingtypes.MsgDelegate{
+		DelegatorAddress: string(granter),
+		ValidatorAddress: "terravaloper1zdpgj8am5nqqvht927k3etljyl6a52kwqup0je",
+		Amount:           types.NewCoin("uluna", types.NewInt(100)),
+	}
+	xcTx, err = txBuilder.NewExec(grantee, input, delegate, delegate)
+	require.NoError(t, err)
+	exec := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*authz.MsgExec)
+	require.Equal(t, string(grantee), exec.Grantee)
+	require.Len(t, exec.Msgs, 2)
+	require.Equal(t, delegateUrl, exec.Msgs[0].TypeUrl)
+
+	_, err = txBuilder.NewExec(grantee, input)
+	require.Error(t, err)
+}
+
+func TestNewIbcTransfer(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "ATOM",
+		ChainCoin:   "uatom",
+		ChainPrefix: "cosmos",
+		Decimals:    6,
+		IbcChannels: []*xc.IbcChannelConfig{
+			{Chain: "TIA", Channel: "channel-617"},
+		},
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+
+	from := xc.Address("cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0wgcdq5")
+	to := xc.Address("celestia1hdvf6vv5amc7wp84js0ls27apekwxpr0w3fxaz")
+	args, err := xcbuilder.NewTransferArgs(from, to, xc.NewAmountBlockchainFromUint64(100), xcbuilder.OptionMemo("deposit-123"), xcbuilder.OptionPublicKey(pubkey))
+	require.NoError(t, err)
+
+	input := tx_input.NewTxInput()
+	input.AssetType = tx_input.BANK
+
+	// no timeout
+	_, err = txBuilder.NewIbcTransfer(args, "TIA", input)
+	require.ErrorContains(t, err, "timeout")
+
+	input.IbcTimeoutTimestamp = 1700000000000000000
+	// no channel
+	_, err = txBuilder.NewIbcTransfer(args, "INJ", input)
+	require.ErrorContains(t, err, "no IBC channel")
+
+	xcTx, err := txBuilder.NewIbcTransfer(args, "TIA", input)
+	require.NoError(t, err)
+	msg := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*ibctransfer.MsgTransfer)
+	require.Equal(t, "transfer", msg.SourcePort)
+	require.Equal(t, "channel-617", msg.SourceChannel)
+	require.Equal(t, "100uatom", msg.Token.String())
+	require.Equal(t, string(from), msg.Sender)
+	require.Equal(t, string(to), msg.Receiver)
+	require.EqualValues(t, 1700000000000000000, msg.TimeoutTimestamp)
+	require.True(t, msg.TimeoutHeight.IsZero())
+	require.Equal(t, "deposit-123", msg.Memo)
+	require.NoError(t, msg.ValidateBasic())
+
+	// timeout height only
+	input.IbcTimeoutTimestamp = 0
+	input.IbcTimeoutRevisionNumber = 1
+	input.IbcTimeoutRevisionHeight = 3000
+	xcTx, err = txBuilder.NewIbcTransfer(args, "TIA", input)
+	require.NoError(t, err)
+	msg = xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*ibctransfer.MsgTransfer)
+	require.EqualValues(t, 1, msg.TimeoutHeight.RevisionNumber)
+	require.EqualValues(t, 3000, msg.TimeoutHeight.RevisionHeight)
+	require.EqualValues(t, 0, msg.TimeoutTimestamp)
+	require.NoError(t, msg.ValidateBasic())
+
+	// cw20 is not supported
+	input.AssetType = tx_input.CW20
+	_, err = txBuilder.NewIbcTransfer(args, "TIA", input)
+	require.Error(t, err)
+}
+
+func TestNormalizeDenom(t *testing.T) {
+	hash := "27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+	require.Equal(t, "uatom", builder.NormalizeDenom("uatom"))
+	require.Equal(t, "ibc/"+hash, builder.NormalizeDenom("ibc/"+hash))
+	require.Equal(t, "ibc/"+hash, builder.NormalizeDenom("ibc/"+strings.ToLower(hash)))
+	require.Equal(t, "ibc/"+hash, builder.NormalizeDenom("transfer/channel-0/uatom"))
+	require.Equal(t, "factory/inj1abc/token", builder.NormalizeDenom("factory/inj1abc/token"))
+}
+
+func TestNewFeegrantTxs(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "LUNA",
+		ChainCoin:   "uluna",
+		ChainPrefix: "terra",
+		Decimals:    6,
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+	granter := xc.Address("terra18pptupzy59ulkvn0eyrawuuxspc93w6a9ctp9j")
+	grantee := xc.Address("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg")
+
+	input := tx_input.NewTxInput()
+	input.LegacyFromPublicKey = pubkey
+
+	// grant
+	expiration := time.Unix(1900000000, 0).UTC()
+	spendLimit := types.NewCoins(types.NewCoin("uluna", types.NewInt(1_000_000)))
+	xcTx, err := txBuilder.NewBasicGrantAllowance(granter, grantee, spendLimit, &expiration, input)
+	require.NoError(t, err)
+	grant := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*feegrant.MsgGrantAllowance)
+	require.Equal(t, string(granter), grant.Granter)
+	require.Equal(t, string(grantee), grant.Grantee)
+	allowance := grant.Allowance.GetCachedValue().(*feegrant.BasicAllowance)
+	require.Equal(t, spendLimit, allowance.SpendLimit)
+	require.Equal(t, &expiration, allowance.Expiration)
+
+	// invalid allowance
+	_, err = txBuilder.NewGrantAllowance(granter, grantee, &feegrant.BasicAllowance{
+		SpendLimit: types.Coins{{Denom: "uluna", Amount: types.NewInt(0)}},
+	}, input)
+	require.ErrorContains(t, err, "invalid allowance")
+
+	// revoke
+	xcTx, err = txBuilder.NewRevokeAllowance(granter, grantee, input)
+	require.NoError(t, err)
+	revoke := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*feegrant.MsgRevokeAllowance)
+	require.Equal(t, string(granter), revoke.Granter)
+	require.Equal(t, string(grantee), revoke.Grantee)
+}
+
+func TestTransferWithFeeOptions(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "LUNA",
+		ChainCoin:   "uluna",
+		ChainPrefix: "terra",
+		Decimals:    6,
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+	from := xc.Address("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg")
+	to := xc.Address("terra1h8ljdmae7lx05kjj79c9ekscwsyjd3yr8wyvdn")
+	gasStation := xc.Address("terra18pptupzy59ulkvn0eyrawuuxspc93w6a9ctp9j")
+	amount := xc.NewAmountBlockchainFromUint64(100)
+
+	newInput := func() *tx_input.TxInput {
+		input := tx_input.NewTxInput()
+		input.AssetType = tx_input.BANK
+		input.LegacyFromPublicKey = pubkey
+		input.AccountNumber = 1
+		input.Sequence = 5
+		input.FeePayerAccountNumber = 2
+		input.FeePayerSequence = 7
+		return input
+	}
+	getFee := func(xcTx xc.Tx) *txtypes.Fee {
+		return xcTx.(*tx.Tx).CosmosTxBuilder.(interface{ GetProtoTx() *txtypes.Tx }).GetProtoTx().AuthInfo.Fee
+	}
+
+	// fee granter does not need to sign
+	args, err := xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionFeeGranter(gasStation))
+	require.NoError(t, err)
+	xcTx, err := txBuilder.Transfer(args, newInput())
+	require.NoError(t, err)
+	require.Equal(t, string(gasStation), getFee(xcTx).Granter)
+	require.Equal(t, "", getFee(xcTx).Payer)
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	// fee payer must also sign
+	args, err = xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionFeePayer(gasStation, pubkey))
+	require.NoError(t, err)
+	xcTx, err = txBuilder.Transfer(args, newInput())
+	require.NoError(t, err)
+	require.Equal(t, "", getFee(xcTx).Granter)
+	require.Equal(t, string(gasStation), getFee(xcTx).Payer)
+	sighashes, err = xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 2)
+	require.NotEqual(t, sighashes[0], sighashes[1])
+	// each sign doc hashes to its sighash
+	signDocs, err := xcTx.(xc.TxWithDisplayPayloads).DisplayPayloads()
+	require.NoError(t, err)
+	require.Len(t, signDocs, 2)
+	for i := range signDocs {
+		require.EqualValues(t, sighashes[i], tx.GetSighash(asset, signDocs[i]))
+	}
+	signerInfos := xcTx.(*tx.Tx).CosmosTxBuilder.(interface{ GetProtoTx() *txtypes.Tx }).GetProtoTx().AuthInfo.SignerInfos
+	require.Len(t, signerInfos, 2)
+	require.EqualValues(t, 5, signerInfos[0].Sequence)
+	require.EqualValues(t, 7, signerInfos[1].Sequence)
+
+	require.Error(t, xcTx.AddSignatures(make([]byte, 64)))
+	require.NoError(t, xcTx.AddSignatures(make([]byte, 64), make([]byte, 64)))
+	_, err = xcTx.Serialize()
+	require.NoError(t, err)
+
+	// fee payer public key is required
+	args, err = xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionFeePayer(gasStation, nil))
+	require.NoError(t, err)
+	_, err = txBuilder.Transfer(args, newInput())
+	require.ErrorContains(t, err, "fee payer")
+
+	// the sender paying its own fees signs once
+	args, err = xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionFeePayer(from, pubkey))
+	require.NoError(t, err)
+	xcTx, err = txBuilder.Transfer(args, newInput())
+	require.NoError(t, err)
+	require.Equal(t, "", getFee(xcTx).Payer)
+	sighashes, err = xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+	signerInfos = xcTx.(*tx.Tx).CosmosTxBuilder.(interface{ GetProtoTx() *txtypes.Tx }).GetProtoTx().AuthInfo.SignerInfos
+	require.Len(t, signerInfos, 1)
+	require.NoError(t, xcTx.AddSignatures(make([]byte, 64)))
+
+	// fee options are not carried over to the next transaction
+	xcTx, err = txBuilder.NewTransfer(from, to, amount, newInput())
+	require.NoError(t, err)
+	require.Equal(t, "", getFee(xcTx).Granter)
+	require.Equal(t, "", getFee(xcTx).Payer)
+	sighashes, err = xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	// staking
+	stakeArgs, err := xcbuilder.NewStakeArgs("LUNA", from, amount,
+		xcbuilder.OptionValidator("terravaloper1zdpgj8am5nqqvht927k3etljyl6a52kwqup0je"),
+		xcbuilder.OptionPublicKey(pubkey),
+		xcbuilder.OptionFeePayer(gasStation, pubkey),
+	)
+	require.NoError(t, err)
+	xcTx, err = txBuilder.Stake(stakeArgs, &tx_input.StakingInput{TxInput: *newInput()})
+	require.NoError(t, err)
+	require.Equal(t, string(gasStation), getFee(xcTx).Payer)
+	sighashes, err = xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 2)
+}
+
+func TestNewVoteTxs(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "ATOM",
+		ChainCoin:   "uatom",
+		ChainPrefix: "cosmos",
+		Decimals:    6,
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+	voter := xc.Address("cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0wgcdq5")
+
+	input := tx_input.NewTxInput()
+	input.LegacyFromPublicKey = pubkey
+
+	option, err := builder.ParseVoteOption("no_with_veto")
+	require.NoError(t, err)
+	require.Equal(t, govv1.OptionNoWithVeto, option)
+	_, err = builder.ParseVoteOption("maybe")
+	require.Error(t, err)
+
+	xcTx, err := txBuilder.NewVote(voter, 42, govv1.OptionYes, builder.GovV1, input)
+	require.NoError(t, err)
+	vote := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*govv1.MsgVote)
+	require.EqualValues(t, 42, vote.ProposalId)
+	require.Equal(t, string(voter), vote.Voter)
+	require.Equal(t, govv1.OptionYes, vote.Option)
+
+	_, err = txBuilder.NewVote(voter, 42, govv1.OptionEmpty, builder.GovV1, input)
+	require.Error(t, err)
+
+	// weighted
+	options, err := builder.ParseWeightedVoteOptions("yes=0.6, no=0.4")
+	require.NoError(t, err)
+	xcTx, err = txBuilder.NewWeightedVote(voter, 42, options, builder.GovV1, input)
+	require.NoError(t, err)
+	weighted := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*govv1.MsgVoteWeighted)
+	require.Len(t, weighted.Options, 2)
+	require.Equal(t, govv1.OptionYes, weighted.Options[0].Option)
+	require.Equal(t, "0.600000000000000000", weighted.Options[0].Weight)
+
+	for _, invalid := range []string{"yes=0.6,no=0.6", "yes=0.5,yes=0.5", "yes=0.6"} {
+		options, err := builder.ParseWeightedVoteOptions(invalid)
+		require.NoError(t, err)
+		_, err = txBuilder.NewWeightedVote(voter, 42, options, builder.GovV1, input)
+		require.Error(t, err, invalid)
+	}
+
+	// chains that only have gov v1beta1
+	xcTx, err = txBuilder.NewVote(voter, 42, govv1.OptionNoWithVeto, builder.GovV1Beta1, input)
+	require.NoError(t, err)
+	voteV1Beta1 := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*govv1beta1.MsgVote)
+	require.EqualValues(t, 42, voteV1Beta1.ProposalId)
+	require.Equal(t, string(voter), voteV1Beta1.Voter)
+	require.Equal(t, govv1beta1.OptionNoWithVeto, voteV1Beta1.Option)
+
+	xcTx, err = txBuilder.NewWeightedVote(voter, 42, options, builder.GovV1Beta1, input)
+	require.NoError(t, err)
+	weightedV1Beta1 := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*govv1beta1.MsgVoteWeighted)
+	require.Equal(t, []govv1beta1.WeightedVoteOption{
+		{Option: govv1beta1.OptionYes, Weight: types.MustNewDecFromStr("0.6")},
+		{Option: govv1beta1.OptionNo, Weight: types.MustNewDecFromStr("0.4")},
+	}, weightedV1Beta1.Options)
+
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+	require.NoError(t, xcTx.AddSignatures(make([]byte, 64)))
+	_, err = xcTx.Serialize()
+	require.NoError(t, err)
+
+	_, err = txBuilder.NewVote(voter, 42, govv1.OptionYes, "v2", input)
+	require.ErrorContains(t, err, "unsupported gov version")
+}
+
+func TestNewCosmWasmTxs(t *testing.T) {
+	asset := &xc.ChainConfig{
+		Chain:       "LUNA",
+		ChainCoin:   "uluna",
+		ChainPrefix: "terra",
+		Decimals:    6,
+	}
+	txBuilder, err := builder.NewTxBuilder(asset)
+	require.NoError(t, err)
+	sender := xc.Address("terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg")
+	contract := xc.ContractAddress("terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr")
+
+	input := tx_input.NewTxInput()
+	input.LegacyFromPublicKey = pubkey
+
+	// execute, funds are sorted
+	swapMsg := []byte(`{"swap":{"offer_asset":{"info":{"native_token":{"denom":"uluna"}},"amount":"100"}}}`)
+	funds := types.Coins{types.NewCoin("uusd", types.NewInt(5)), types.NewCoin("uluna", types.NewInt(100))}
+	xcTx, err := txBuilder.ExecuteContract(sender, contract, swapMsg, funds, input)
+	require.NoError(t, err)
+	execute := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*wasmtypes.MsgExecuteContract)
+	require.Equal(t, string(sender), execute.Sender)
+	require.Equal(t, string(contract), execute.Contract)
+	require.JSONEq(t, string(swapMsg), string(execute.Msg))
+	require.Equal(t, "100uluna,5uusd", execute.Funds.String())
+	// caller's funds are not modified
+	require.Equal(t, "uusd", funds[0].Denom)
+
+	_, err = txBuilder.ExecuteContract(sender, contract, []byte(`{"swap":`), nil, input)
+	require.ErrorContains(t, err, "json")
+	_, err = txBuilder.ExecuteContract(sender, "", swapMsg, nil, input)
+	require.Error(t, err)
+	_, err = txBuilder.ExecuteContract(sender, contract, swapMsg, types.Coins{{Denom: "uluna", Amount: types.NewInt(-1)}}, input)
+	require.ErrorContains(t, err, "invalid funds")
+
+	// instantiate
+	initMsg := []byte(`{"name":"Token","symbol":"TKN","decimals":6,"initial_balances":[]}`)
+	xcTx, err = txBuilder.InstantiateContract(sender, 7, initMsg, "my token", sender, nil, input)
+	require.NoError(t, err)
+	instantiate := xcTx.(*tx.Tx).CosmosTx.GetMsgs()[0].(*wasmtypes.MsgInstantiateContract)
+	require.EqualValues(t, 7, instantiate.CodeID)
+	require.Equal(t, "my token", instantiate.Label)
+	require.Equal(t, string(sender), instantiate.Admin)
+	require.JSONEq(t, string(initMsg), string(instantiate.Msg))
+
+	_, err = txBuilder.InstantiateContract(sender, 0, initMsg, "my token", "", nil, input)
+	require.ErrorContains(t, err, "code id")
+	_, err = txBuilder.InstantiateContract(sender, 7, initMsg, "", "", nil, input)
+	require.ErrorContains(t, err, "label")
+}
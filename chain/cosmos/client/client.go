@@ -11,6 +11,7 @@ import (
 	comettypes "github.com/cometbft/cometbft/rpc/core/types"
 	jsonrpcclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
@@ -22,8 +23,6 @@ import (
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 
-	wasmtypes "github.com/cordialsys/crosschain/chain/cosmos/types/CosmWasm/wasmd/x/wasm/types"
-
 	xc "github.com/cordialsys/crosschain"
 	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/utils"
@@ -42,6 +41,7 @@ type Client struct {
 }
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 var _ xclient.StakingClient = &Client{}
 
 func ReplaceIncompatiableCosmosResponses(body []byte) []byte {
@@ -77,7 +77,7 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 	asset := cfgI
 	cfg := cfgI.GetChain()
 	host := cfg.URL
-	interceptor := utils.NewHttpInterceptor(ReplaceIncompatiableCosmosResponses)
+	interceptor := utils.NewChainHttpInterceptor(cfg, ReplaceIncompatiableCosmosResponses)
 	interceptor.Enable()
 
 	rawHttpClient := &http.Client{
@@ -121,14 +121,42 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 	}, nil
 }
 
+// FetchLatestHeight returns the latest block height of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	abciInfo, err := client.Ctx.Client.ABCIInfo(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(abciInfo.Response.LastBlockHeight), nil
+}
+
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	baseTxInput, err := client.FetchBaseTxInput(ctx, args.GetFrom())
 	if err != nil {
 		return nil, err
 	}
+	err = client.setFeePayerInput(ctx, baseTxInput, args.GetFrom(), &args)
+	if err != nil {
+		return nil, err
+	}
 	return baseTxInput, nil
 }
 
+// setFeePayerInput looks up the account of the fee payer, if set and not the sender, as it must also sign the transaction.
+func (client *Client) setFeePayerInput(ctx context.Context, txInput *tx_input.TxInput, from xc.Address, options xcbuilder.FeeOptions) error {
+	feePayer, ok := options.GetFeePayer()
+	if !ok || feePayer == from {
+		return nil
+	}
+	account, err := client.GetAccount(ctx, feePayer)
+	if err != nil || account == nil {
+		return fmt.Errorf("failed to get account data for fee payer %v: %v", feePayer, err)
+	}
+	txInput.FeePayerAccountNumber = account.GetAccountNumber()
+	txInput.FeePayerSequence = account.GetSequence()
+	return nil
+}
+
 func (client *Client) FetchBaseTxInput(ctx context.Context, from xc.Address) (*tx_input.TxInput, error) {
 	txInput := tx_input.NewTxInput()
 
@@ -184,7 +212,7 @@ func (client *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, t
 func (client *Client) SubmitTx(ctx context.Context, tx1 xc.Tx) error {
 	txBytes, _ := tx1.Serialize()
 
-	res, err := client.Ctx.BroadcastTx(txBytes)
+	res, err := broadcastTxSync(utils.NonIdempotent(ctx), client.Ctx, txBytes)
 	if err != nil {
 		return fmt.Errorf("failed to broadcast tx %v", err)
 	}
@@ -197,8 +225,27 @@ func (client *Client) SubmitTx(ctx context.Context, tx1 xc.Tx) error {
 	return nil
 }
 
+// Same as client.Context.BroadcastTxSync, which doesn't take a context.
+func broadcastTxSync(ctx context.Context, clientCtx client.Context, txBytes []byte) (*types.TxResponse, error) {
+	node, err := clientCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+	res, err := node.BroadcastTxSync(ctx, txBytes)
+	if errRes := client.CheckTendermintError(err, txBytes); errRes != nil {
+		return errRes, nil
+	}
+	return types.NewResponseFormatBroadcastTx(res), err
+}
+
 // FetchLegacyTxInfo returns tx info for a Cosmos tx
 func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (xc.LegacyTxInfo, error) {
+	result, _, err := client.fetchLegacyTxInfo(ctx, txHash)
+	return result, err
+}
+
+func (client *Client) fetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (xc.LegacyTxInfo, ParsedEvents, error) {
+	events := ParsedEvents{}
 	result := xc.LegacyTxInfo{
 		Fee:           xc.AmountBlockchain{},
 		BlockIndex:    0,
@@ -209,42 +256,25 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		txHash = txHash[2:]
 	}
 
-	hash, err := hex.DecodeString(string(txHash))
+	resultRaw, err := client.fetchTxResult(ctx, txHash)
 	if err != nil {
-		return result, err
-	}
-
-	resultRaw := new(comettypes.ResultTx)
-
-	var hashFormatted interface{} = hash
-	switch client.Asset.GetChain().Chain {
-	case xc.SEI:
-		// Frustratingly, SEI expects the hash as a hex encoded string
-		hashFormatted = hex.EncodeToString(hash)
-	}
-
-	_, err = client.rpcClient.Call(ctx, "tx", map[string]interface{}{
-		"hash":  hashFormatted,
-		"prove": false,
-	}, resultRaw)
-	if err != nil {
-		return result, fmt.Errorf("could not download tx: %v", err)
+		return result, events, err
 	}
 
 	blockResultRaw, err := client.Ctx.Client.Block(ctx, &resultRaw.Height)
 	if err != nil {
-		return result, err
+		return result, events, err
 	}
 
 	abciInfo, err := client.Ctx.Client.ABCIInfo(ctx)
 	if err != nil {
-		return result, err
+		return result, events, err
 	}
 
 	decoder := client.Ctx.TxConfig.TxDecoder()
 	decodedTx, err := decoder(resultRaw.Tx)
 	if err != nil {
-		return result, err
+		return result, events, err
 	}
 
 	tx := &tx.Tx{
@@ -256,7 +286,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 	result.ExplorerURL = client.Asset.GetChain().ExplorerURL + "/tx/" + result.TxID
 	result.Fee = tx.Fee()
 
-	events := ParseEvents(resultRaw.TxResult.Events)
+	events = ParseEvents(resultRaw.TxResult.Events)
 	for _, ev := range events.Transfers {
 		result.Sources = append(result.Sources, &xc.LegacyTxInfoEndpoint{
 			Address:         xc.Address(ev.Sender),
@@ -269,6 +299,11 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 			Amount:          ev.Amount,
 		})
 	}
+	// IBC transfers move funds to an escrow or module account, so report the receiver on the other chain instead
+	for i, packet := range events.IbcTransfers() {
+		result.Destinations[i].Address = xc.Address(packet.Receiver)
+		result.Destinations[i].Memo = packet.Memo
+	}
 	for _, ev := range events.Delegates {
 		result.AddStakeEvent(&xclient.Stake{
 			Balance:   ev.Amount,
@@ -301,7 +336,10 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 	if withMemo, ok := decodedTx.(types.TxWithMemo); ok {
 		memo := withMemo.GetMemo()
 		for _, dst := range result.Destinations {
-			dst.Memo = memo
+			// the memo of an IBC packet takes precedence
+			if dst.Memo == "" {
+				dst.Memo = memo
+			}
 		}
 	}
 
@@ -313,18 +351,58 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		result.Status = xc.TxStatusFailure
 	}
 
-	return result, nil
+	return result, events, nil
+}
+
+func (client *Client) fetchTxResult(ctx context.Context, txHash xc.TxHash) (*comettypes.ResultTx, error) {
+	hash, err := hex.DecodeString(strings.TrimPrefix(string(txHash), "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	resultRaw := new(comettypes.ResultTx)
+
+	var hashFormatted interface{} = hash
+	switch client.Asset.GetChain().Chain {
+	case xc.SEI:
+		// Frustratingly, SEI expects the hash as a hex encoded string
+		hashFormatted = hex.EncodeToString(hash)
+	}
+
+	_, err = client.rpcClient.Call(ctx, "tx", map[string]interface{}{
+		"hash":  hashFormatted,
+		"prove": false,
+	}, resultRaw)
+	if err != nil {
+		return nil, fmt.Errorf("could not download tx: %v", err)
+	}
+	return resultRaw, nil
 }
 
 func (client *Client) FetchTxInfo(ctx context.Context, txHashStr xc.TxHash) (xclient.TxInfo, error) {
-	legacyTx, err := client.FetchLegacyTxInfo(ctx, txHashStr)
+	legacyTx, events, err := client.fetchLegacyTxInfo(ctx, txHashStr)
 	if err != nil {
 		return xclient.TxInfo{}, err
 	}
 	chain := client.Asset.GetChain().Chain
 
 	// remap to new tx
-	return xclient.TxInfoFromLegacy(chain, legacyTx, xclient.Account), nil
+	txInfo := xclient.TxInfoFromLegacy(chain, legacyTx, xclient.Account)
+	for _, ev := range events.Votes {
+		vote := &xclient.Vote{
+			Proposal: ev.ProposalId,
+			Address:  ev.Voter,
+			Options:  []*xclient.VoteOption{},
+		}
+		for _, option := range ev.Options {
+			vote.Options = append(vote.Options, &xclient.VoteOption{
+				Option: option.Option,
+				Weight: option.Weight,
+			})
+		}
+		txInfo.Votes = append(txInfo.Votes, vote)
+	}
+	return txInfo, nil
 }
 
 // GetAccount returns a Cosmos account
@@ -392,14 +470,11 @@ func (client *Client) FetchCw20Balance(ctx context.Context, address xc.Address,
 	}
 	var balResult TokenBalance
 
-	balResp, err := wasmtypes.NewQueryClient(client.Ctx).SmartContractState(ctx, &wasmtypes.QuerySmartContractStateRequest{
-		QueryData: wasmtypes.RawContractMessage(input),
-		Address:   contractAddress,
-	})
+	balResp, err := client.QuerySmart(ctx, xc.ContractAddress(contractAddress), input)
 	if err != nil {
 		return zero, fmt.Errorf("failed to get token balance: '%v': %v", address, err)
 	}
-	err = json.Unmarshal(balResp.Data.Bytes(), &balResult)
+	err = json.Unmarshal(balResp, &balResult)
 	if err != nil {
 		return zero, fmt.Errorf("failed to parse token balance: '%v': %v", address, err)
 	}
@@ -433,6 +508,7 @@ func (client *Client) fetchBankModuleBalance(ctx context.Context, address xc.Add
 	if denom == "" {
 		return zero, fmt.Errorf("failed to account balance: no denom on asset %s", asset.ID())
 	}
+	denom = builder.NormalizeDenom(denom)
 
 	queryClient := banktypes.NewQueryClient(client.Ctx)
 	balResp, err := queryClient.Balance(ctx, &banktypes.QueryBalanceRequest{
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	xc "github.com/cordialsys/crosschain"
+	wasmtypes "github.com/cordialsys/crosschain/chain/cosmos/types/CosmWasm/wasmd/x/wasm/types"
+)
+
+// QuerySmart runs a read-only smart query on a CosmWasm contract, returning the json response of the contract.
+func (client *Client) QuerySmart(ctx context.Context, contract xc.ContractAddress, jsonMsg []byte) (json.RawMessage, error) {
+	if !json.Valid(jsonMsg) {
+		return nil, errors.New("contract query must be valid json")
+	}
+	res, err := wasmtypes.NewQueryClient(client.Ctx).SmartContractState(ctx, &wasmtypes.QuerySmartContractStateRequest{
+		Address:   string(contract),
+		QueryData: wasmtypes.RawContractMessage(jsonMsg),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(res.Data.Bytes()), nil
+}
@@ -0,0 +1,234 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	"github.com/cosmos/gogoproto/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ErrUnknownQuery is returned for queries the chain doesn't have, e.g. gov v1 on chains that only have v1beta1
+var ErrUnknownQuery = errors.New("unknown query")
+
+type ProposalTally struct {
+	Yes        xc.AmountBlockchain `json:"yes"`
+	Abstain    xc.AmountBlockchain `json:"abstain"`
+	No         xc.AmountBlockchain `json:"no"`
+	NoWithVeto xc.AmountBlockchain `json:"no_with_veto"`
+}
+
+type Proposal struct {
+	Id      uint64 `json:"id"`
+	Title   string `json:"title"`
+	Summary string `json:"summary,omitempty"`
+	// e.g. "voting_period", "passed", "rejected"
+	Status          string         `json:"status"`
+	SubmitTime      *time.Time     `json:"submit_time,omitempty"`
+	VotingStartTime *time.Time     `json:"voting_start_time,omitempty"`
+	VotingEndTime   *time.Time     `json:"voting_end_time,omitempty"`
+	Tally           *ProposalTally `json:"tally"`
+}
+
+// FetchActiveProposals lists the x/gov proposals that are currently in their voting period, with the current tally.
+// Chains that only have gov v1beta1 are queried using v1beta1.
+func (client *Client) FetchActiveProposals(ctx context.Context) ([]*Proposal, error) {
+	proposals, err := client.fetchActiveProposalsV1(ctx)
+	if errors.Is(err, ErrUnknownQuery) {
+		proposals, err = client.fetchActiveProposalsV1Beta1(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not list proposals: %w", err)
+	}
+	return proposals, nil
+}
+
+// FetchGovVersion checks if the chain has gov v1, or only gov v1beta1 (older cosmos-sdk versions).
+func (client *Client) FetchGovVersion(ctx context.Context) (builder.GovVersion, error) {
+	err := client.queryRaw(ctx, "/cosmos.gov.v1.Query/Params", &govv1.QueryParamsRequest{
+		ParamsType: govv1.ParamVoting,
+	}, &govv1.QueryParamsResponse{})
+	if errors.Is(err, ErrUnknownQuery) {
+		return builder.GovV1Beta1, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not lookup gov params: %w", err)
+	}
+	return builder.GovV1, nil
+}
+
+func (client *Client) fetchActiveProposalsV1(ctx context.Context) ([]*Proposal, error) {
+	proposals := []*Proposal{}
+	var nextKey []byte
+	for {
+		res := &govv1.QueryProposalsResponse{}
+		err := client.queryRaw(ctx, "/cosmos.gov.v1.Query/Proposals", &govv1.QueryProposalsRequest{
+			ProposalStatus: govv1.StatusVotingPeriod,
+			Pagination: &query.PageRequest{
+				Key: nextKey,
+			},
+		}, res)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range res.Proposals {
+			tally, err := client.FetchProposalTally(ctx, p.Id)
+			if err != nil {
+				return nil, err
+			}
+			proposals = append(proposals, &Proposal{
+				Id:              p.Id,
+				Title:           p.Title,
+				Summary:         p.Summary,
+				Status:          normalizeProposalStatus(p.Status),
+				SubmitTime:      p.SubmitTime,
+				VotingStartTime: p.VotingStartTime,
+				VotingEndTime:   p.VotingEndTime,
+				Tally:           tally,
+			})
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = res.Pagination.NextKey
+	}
+	return proposals, nil
+}
+
+func (client *Client) fetchActiveProposalsV1Beta1(ctx context.Context) ([]*Proposal, error) {
+	proposals := []*Proposal{}
+	var nextKey []byte
+	for {
+		res := &govv1beta1.QueryProposalsResponse{}
+		err := client.queryRaw(ctx, "/cosmos.gov.v1beta1.Query/Proposals", &govv1beta1.QueryProposalsRequest{
+			ProposalStatus: govv1beta1.StatusVotingPeriod,
+			Pagination: &query.PageRequest{
+				Key: nextKey,
+			},
+		}, res)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range res.Proposals {
+			tally, err := client.FetchProposalTally(ctx, p.ProposalId)
+			if err != nil {
+				return nil, err
+			}
+			proposal := &Proposal{
+				Id:              p.ProposalId,
+				Status:          normalizeProposalStatus(govv1.ProposalStatus(p.Status)),
+				SubmitTime:      &p.SubmitTime,
+				VotingStartTime: &p.VotingStartTime,
+				VotingEndTime:   &p.VotingEndTime,
+				Tally:           tally,
+			}
+			if p.Content != nil {
+				// the content may be any proposal type, but they all start with a title and description
+				proposal.Title = string(protoBytesField(p.Content.Value, 1))
+				proposal.Summary = string(protoBytesField(p.Content.Value, 2))
+			}
+			proposals = append(proposals, proposal)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		nextKey = res.Pagination.NextKey
+	}
+	return proposals, nil
+}
+
+// FetchProposalTally fetches the current tally of the votes on a proposal.
+func (client *Client) FetchProposalTally(ctx context.Context, proposalId uint64) (*ProposalTally, error) {
+	tally := &ProposalTally{}
+	res := &govv1.QueryTallyResultResponse{}
+	err := client.queryRaw(ctx, "/cosmos.gov.v1.Query/TallyResult", &govv1.QueryTallyResultRequest{
+		ProposalId: proposalId,
+	}, res)
+	if errors.Is(err, ErrUnknownQuery) {
+		resV1Beta1 := &govv1beta1.QueryTallyResultResponse{}
+		err = client.queryRaw(ctx, "/cosmos.gov.v1beta1.Query/TallyResult", &govv1beta1.QueryTallyResultRequest{
+			ProposalId: proposalId,
+		}, resV1Beta1)
+		if err == nil {
+			tally.Yes = xc.NewAmountBlockchainFromStr(resV1Beta1.Tally.Yes.String())
+			tally.Abstain = xc.NewAmountBlockchainFromStr(resV1Beta1.Tally.Abstain.String())
+			tally.No = xc.NewAmountBlockchainFromStr(resV1Beta1.Tally.No.String())
+			tally.NoWithVeto = xc.NewAmountBlockchainFromStr(resV1Beta1.Tally.NoWithVeto.String())
+			return tally, nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup tally of proposal %d: %w", proposalId, err)
+	}
+	if res.Tally != nil {
+		tally.Yes = xc.NewAmountBlockchainFromStr(res.Tally.YesCount)
+		tally.Abstain = xc.NewAmountBlockchainFromStr(res.Tally.AbstainCount)
+		tally.No = xc.NewAmountBlockchainFromStr(res.Tally.NoCount)
+		tally.NoWithVeto = xc.NewAmountBlockchainFromStr(res.Tally.NoWithVetoCount)
+	}
+	return tally, nil
+}
+
+// queryRaw runs a grpc query over abci, without unpacking any Any's in the response.  Proposals may contain
+// chain specific messages that are not registered, which would fail to unpack.
+func (client *Client) queryRaw(ctx context.Context, path string, req proto.Message, res proto.Message) error {
+	reqBz, err := proto.Marshal(req)
+	if err != nil {
+		return err
+	}
+	resBz, err := client.queryRawBytes(ctx, path, reqBz)
+	if err != nil {
+		return err
+	}
+	return proto.Unmarshal(resBz, res)
+}
+
+// queryRawBytes runs a grpc query over abci with an encoded request, returning the encoded response
+func (client *Client) queryRawBytes(ctx context.Context, path string, req []byte) ([]byte, error) {
+	result, err := client.Ctx.Client.ABCIQuery(ctx, path, req)
+	if err != nil {
+		return nil, err
+	}
+	if !result.Response.IsOK() {
+		if result.Response.Codespace == sdkerrors.RootCodespace && result.Response.Code == sdkerrors.ErrUnknownRequest.ABCICode() {
+			return nil, fmt.Errorf("%w %s: %s", ErrUnknownQuery, path, result.Response.Log)
+		}
+		return nil, fmt.Errorf("query failed with code %d: %s", result.Response.Code, result.Response.Log)
+	}
+	return result.Response.Value, nil
+}
+
+// protoBytesField returns the first length-delimited field with the number in an encoded message, if any
+func protoBytesField(msg []byte, number protowire.Number) []byte {
+	for len(msg) > 0 {
+		num, typ, n := protowire.ConsumeTag(msg)
+		if n < 0 {
+			return nil
+		}
+		msg = msg[n:]
+		if typ == protowire.BytesType && num == number {
+			value, _ := protowire.ConsumeBytes(msg)
+			return value
+		}
+		n = protowire.ConsumeFieldValue(num, typ, msg)
+		if n < 0 {
+			return nil
+		}
+		msg = msg[n:]
+	}
+	return nil
+}
+
+// e.g. "PROPOSAL_STATUS_VOTING_PERIOD" -> "voting_period"
+func normalizeProposalStatus(status govv1.ProposalStatus) string {
+	return strings.ToLower(strings.TrimPrefix(status.String(), "PROPOSAL_STATUS_"))
+}
@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	comettypes "github.com/cometbft/cometbft/abci/types"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	ibctransfer "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/apps/transfer/types"
+	ibcclient "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/core/02-client/types"
+	ibcchannel "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	ibctm "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+)
+
+// How long an IBC transfer can take to be relayed before it times out
+const DefaultIbcTimeout = 10 * time.Minute
+
+// How many blocks of the destination chain an IBC transfer can take to be relayed before it times out
+const DefaultIbcTimeoutHeightOffset = 1000
+
+type IbcTransferState string
+
+const (
+	// The packet has not been acknowledged or timed out yet
+	IbcTransferPending IbcTransferState = "pending"
+	// The packet was received and successfully processed on the destination chain
+	IbcTransferAcknowledged IbcTransferState = "acknowledged"
+	// The packet was received on the destination chain, but failed (funds are refunded)
+	IbcTransferFailed IbcTransferState = "failed"
+	// The packet was not relayed in time (funds are refunded)
+	IbcTransferTimedOut IbcTransferState = "timed_out"
+)
+
+type IbcTransferStatus struct {
+	State      IbcTransferState `json:"state"`
+	Sequence   string           `json:"sequence"`
+	SrcPort    string           `json:"src_port"`
+	SrcChannel string           `json:"src_channel"`
+	DstChannel string           `json:"dst_channel"`
+	// The transaction on this chain that acknowledged or timed out the packet, if any
+	ResolvedBy string `json:"resolved_by,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// FetchIbcTransferInput fetches the input for an IBC transfer to the destination chain.  The timeout timestamp is
+// derived from the latest block, and the timeout height from the latest height of the destination chain known
+// to the light client of the channel.
+func (client *Client) FetchIbcTransferInput(ctx context.Context, args xcbuilder.TransferArgs, destination xc.NativeAsset) (*tx_input.TxInput, error) {
+	chain := client.Asset.GetChain()
+	channel, ok := chain.GetIbcChannel(destination)
+	if !ok {
+		return nil, fmt.Errorf("no IBC channel configured from %s to %s", chain.Chain, destination)
+	}
+	txInput, err := client.FetchBaseTxInput(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	status, err := client.Ctx.Client.Status(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not lookup latest block: %v", err)
+	}
+	latest := status.SyncInfo.LatestBlockTime
+	if latest.IsZero() {
+		return nil, errors.New("could not determine latest block time")
+	}
+	txInput.IbcTimeoutTimestamp = uint64(latest.Add(DefaultIbcTimeout).UnixNano())
+
+	port := channel.Port
+	if port == "" {
+		port = ibctransfer.PortID
+	}
+	height, err := client.FetchIbcCounterpartyHeight(ctx, port, channel.Channel)
+	if err != nil {
+		return nil, err
+	}
+	if !height.IsZero() {
+		txInput.IbcTimeoutRevisionNumber = height.RevisionNumber
+		txInput.IbcTimeoutRevisionHeight = height.RevisionHeight + DefaultIbcTimeoutHeightOffset
+	}
+	return txInput, nil
+}
+
+const tendermintClientStateTypeUrl = "/ibc.lightclients.tendermint.v1.ClientState"
+
+// FetchIbcCounterpartyHeight looks up the latest height of the chain on the other end of the channel, as known by
+// its light client on this chain.  The revision is taken from the counterparty chain id when it's not set.  A zero
+// height is returned for light clients other than tendermint.
+func (client *Client) FetchIbcCounterpartyHeight(ctx context.Context, port string, channel string) (ibcclient.Height, error) {
+	res, err := ibcchannel.NewQueryClient(client.Ctx).ChannelClientState(ctx, &ibcchannel.QueryChannelClientStateRequest{
+		PortId:    port,
+		ChannelId: channel,
+	})
+	if err != nil {
+		return ibcclient.Height{}, fmt.Errorf("could not lookup client state of %s/%s: %v", port, channel, err)
+	}
+	if res.IdentifiedClientState == nil || res.IdentifiedClientState.ClientState == nil {
+		return ibcclient.Height{}, fmt.Errorf("no client state found for %s/%s", port, channel)
+	}
+	if res.IdentifiedClientState.ClientState.TypeUrl != tendermintClientStateTypeUrl {
+		return ibcclient.Height{}, nil
+	}
+	clientState := &ibctm.ClientState{}
+	if err := clientState.Unmarshal(res.IdentifiedClientState.ClientState.Value); err != nil {
+		return ibcclient.Height{}, fmt.Errorf("invalid client state of %s/%s: %v", port, channel, err)
+	}
+	height := clientState.LatestHeight
+	if height.RevisionNumber == 0 {
+		height.RevisionNumber = ibcclient.ParseChainID(clientState.ChainId)
+	}
+	return height, nil
+}
+
+// FetchDenomTrace looks up the path and base denom of an "ibc/{hash}" denom.
+func (client *Client) FetchDenomTrace(ctx context.Context, denom string) (ibctransfer.DenomTrace, error) {
+	if !ibctransfer.IsIBCDenom(denom) {
+		return ibctransfer.DenomTrace{}, fmt.Errorf("not an IBC denom: %s", denom)
+	}
+	hash := strings.TrimPrefix(denom, ibctransfer.DenomPrefix+"/")
+	res, err := ibctransfer.NewQueryClient(client.Ctx).DenomTrace(ctx, &ibctransfer.QueryDenomTraceRequest{
+		Hash: hash,
+	})
+	if err != nil {
+		return ibctransfer.DenomTrace{}, fmt.Errorf("could not lookup denom trace for %s: %v", denom, err)
+	}
+	if res.DenomTrace == nil {
+		return ibctransfer.DenomTrace{}, fmt.Errorf("no denom trace found for %s", denom)
+	}
+	return *res.DenomTrace, nil
+}
+
+// FetchIbcTransferStatus tracks an IBC transfer sent in the given transaction, by searching for the
+// acknowledgement or timeout of the packet on this chain.
+func (client *Client) FetchIbcTransferStatus(ctx context.Context, txHash xc.TxHash) (*IbcTransferStatus, error) {
+	resultRaw, err := client.fetchTxResult(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	events := ParseEvents(resultRaw.TxResult.Events)
+	if len(events.IbcPackets) == 0 {
+		return nil, fmt.Errorf("no IBC packet was sent in transaction %s", txHash)
+	}
+	packet := events.IbcPackets[0]
+	status := &IbcTransferStatus{
+		State:      IbcTransferPending,
+		Sequence:   packet.Sequence,
+		SrcPort:    packet.SrcPort,
+		SrcChannel: packet.SrcChannel,
+		DstChannel: packet.DstChannel,
+	}
+
+	for _, eventType := range []string{"acknowledge_packet", "timeout_packet"} {
+		query := fmt.Sprintf("%s.packet_sequence='%s' AND %s.packet_src_channel='%s'", eventType, packet.Sequence, eventType, packet.SrcChannel)
+		res, err := client.Ctx.Client.TxSearch(ctx, query, false, nil, nil, "")
+		if err != nil {
+			return nil, fmt.Errorf("could not search for %s: %v", eventType, err)
+		}
+		if len(res.Txs) == 0 {
+			continue
+		}
+		resolvedBy := res.Txs[0]
+		status.ResolvedBy = resolvedBy.Hash.String()
+		if eventType == "timeout_packet" {
+			status.State = IbcTransferTimedOut
+		} else {
+			status.State = IbcTransferAcknowledged
+			if errMsg, failed := parseIbcAcknowledgementError(resolvedBy.TxResult.Events); failed {
+				status.State = IbcTransferFailed
+				status.Error = errMsg
+			}
+		}
+		break
+	}
+	return status, nil
+}
+
+// The transfer module emits the result of the acknowledgement on the source chain
+func parseIbcAcknowledgementError(events []comettypes.Event) (string, bool) {
+	for _, event := range events {
+		DecodeEventAttributes(event.Attributes)
+		if event.Type != "fungible_token_packet" {
+			continue
+		}
+		for _, attr := range event.Attributes {
+			if attr.Key == "error" {
+				return attr.Value, true
+			}
+			if attr.Key == "success" && attr.Value == "false" {
+				return "acknowledgement failed", true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
+	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	"github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// FetchMsgsInput fetches the input for a transaction bundling the given messages (see builder.NewMsgsTx),
+// and sets the gas limit by simulating the whole bundle.
+func (client *Client) FetchMsgsInput(ctx context.Context, from xc.Address, publicKey []byte, msgs ...types.Msg) (*tx_input.TxInput, error) {
+	txInput, err := client.FetchBaseTxInput(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	txInput.LegacyFromPublicKey = publicKey
+
+	gasLimit, err := client.SimulateMsgsGasLimit(ctx, txInput, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	txInput.GasLimit = gasLimit
+	return txInput, nil
+}
+
+// SimulateMsgsGasLimit builds an unsigned transaction with the messages and simulates it
+// to determine the gas limit needed.
+func (client *Client) SimulateMsgsGasLimit(ctx context.Context, input *tx_input.TxInput, msgs ...types.Msg) (uint64, error) {
+	txBuilder, err := builder.NewTxBuilder(client.Asset)
+	if err != nil {
+		return 0, err
+	}
+	// copy so the input gas limit is not modified by the builder
+	inputCopy := *input
+	tx, err := txBuilder.NewMsgsTx(&inputCopy, msgs...)
+	if err != nil {
+		return 0, err
+	}
+	return client.SimulateGasLimit(ctx, tx)
+}
+
+// SimulateGasLimit simulates a transaction on the node and returns the gas limit to use for it.
+// The transaction does not need to be signed.
+func (client *Client) SimulateGasLimit(ctx context.Context, tx xc.Tx) (uint64, error) {
+	txBytes, err := tx.Serialize()
+	if err != nil {
+		return 0, err
+	}
+	res, err := txtypes.NewServiceClient(client.Ctx).Simulate(ctx, &txtypes.SimulateRequest{
+		TxBytes: txBytes,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not simulate transaction: %v", err)
+	}
+	if res.GasInfo == nil || res.GasInfo.GasUsed == 0 {
+		return 0, errors.New("simulation did not report any gas used")
+	}
+	return gas.SimulatedGasLimit(res.GasInfo.GasUsed), nil
+}
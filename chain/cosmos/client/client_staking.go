@@ -66,6 +66,10 @@ func (client *Client) FetchStakingInput(ctx context.Context, args xcbuilder.Stak
 	if err != nil {
 		return nil, err
 	}
+	err = client.setFeePayerInput(ctx, baseTxInput, args.GetFrom(), &args)
+	if err != nil {
+		return nil, err
+	}
 	return &tx_input.StakingInput{
 		TxInput: *baseTxInput,
 	}, nil
@@ -76,6 +80,10 @@ func (client *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.St
 	if err != nil {
 		return nil, err
 	}
+	err = client.setFeePayerInput(ctx, baseTxInput, args.GetFrom(), &args)
+	if err != nil {
+		return nil, err
+	}
 	return &tx_input.UnstakingInput{
 		TxInput: *baseTxInput,
 	}, nil
@@ -86,6 +94,10 @@ func (client *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.Sta
 	if err != nil {
 		return nil, err
 	}
+	err = client.setFeePayerInput(ctx, baseTxInput, args.GetFrom(), &args)
+	if err != nil {
+		return nil, err
+	}
 	return &tx_input.WithdrawInput{
 		TxInput: *baseTxInput,
 	}, nil
@@ -6,13 +6,27 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	comettypes "github.com/cometbft/cometbft/abci/types"
 	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/cosmos/builder"
 	"github.com/cordialsys/crosschain/chain/cosmos/client"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input"
 	"github.com/cordialsys/crosschain/chain/cosmos/tx_input/gas"
+	wasmtypes "github.com/cordialsys/crosschain/chain/cosmos/types/CosmWasm/wasmd/x/wasm/types"
+	ibcclient "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/core/02-client/types"
+	ibcchannel "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/core/04-channel/types"
+	ibctm "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/light-clients/07-tendermint"
+	xcclient "github.com/cordialsys/crosschain/client"
 	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	"github.com/stretchr/testify/require"
 )
 
@@ -591,3 +605,458 @@ func TestFetchBalance(t *testing.T) {
 		}
 	}
 }
+
+func TestSimulateMsgsGasLimit(t *testing.T) {
+	simulateResponse := &txtypes.SimulateResponse{
+		GasInfo: &types.GasInfo{GasWanted: 0, GasUsed: 100_000},
+	}
+	simulateBz, err := simulateResponse.Marshal()
+	require.NoError(t, err)
+	server, close := testtypes.MockJSONRPC(t, []string{
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"%s","proofOps":null,"height":"2803726","codespace":""}}}`, base64.StdEncoding.EncodeToString(simulateBz)),
+		`{"jsonrpc":"2.0","id":1,"result":{"response":{"code":1,"log":"out of gas","info":"","index":"0","key":null,"value":"","proofOps":null,"height":"2803726","codespace":"sdk"}}}`,
+	})
+	defer close()
+
+	asset := &xc.ChainConfig{Chain: "LUNA", ChainCoin: "uluna", ChainPrefix: "terra", URL: server.URL}
+	client, _ := client.NewClient(asset)
+
+	input := tx_input.NewTxInput()
+	input.LegacyFromPublicKey = ignoreError(base64.StdEncoding.DecodeString("Avz3JMl9/6wgIe+hgYwv7zvLt1PKIpE6jbXnnsSj3uDR"))
+	msg := &banktypes.MsgSend{
+		FromAddress: "terra1dp3q305hgttt8n34rt8rg9xpanc42z4ye7upfg",
+		ToAddress:   "terra1h8ljdmae7lx05kjj79c9ekscwsyjd3yr8wyvdn",
+		Amount:      types.NewCoins(types.NewCoin("uluna", types.NewInt(1))),
+	}
+	gasLimit, err := client.SimulateMsgsGasLimit(context.Background(), input, msg, msg)
+	require.NoError(t, err)
+	require.EqualValues(t, gas.SimulatedGasLimit(100_000), gasLimit)
+	// input is not modified
+	require.EqualValues(t, 0, input.GasLimit)
+
+	_, err = client.SimulateMsgsGasLimit(context.Background(), input, msg)
+	require.ErrorContains(t, err, "could not simulate")
+}
+
+func TestFetchIbcTransferStatus(t *testing.T) {
+	sendTx := `{"jsonrpc":"2.0","id":0,"result":{"hash":"E9C24C2E23CDCA56C8CE87A583149F8F88E75923F0CD958C003A84F631948978","height":"100","index":0,"tx_result":{"code":0,"events":[` +
+		`{"type":"message","attributes":[{"key":"sender","value":"cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0wgcdq5","index":true}]},` +
+		`{"type":"send_packet","attributes":[{"key":"packet_sequence","value":"42","index":true},{"key":"packet_src_port","value":"transfer","index":true},{"key":"packet_src_channel","value":"channel-617","index":true},{"key":"packet_dst_port","value":"transfer","index":true},{"key":"packet_dst_channel","value":"channel-0","index":true},{"key":"packet_timeout_timestamp","value":"1700000000000000000","index":true}]}` +
+		`]},"tx":""}}`
+	// the raw rpc client and the cometbft client count request ids separately
+	noTxs := `{"jsonrpc":"2.0","id":%d,"result":{"txs":[],"total_count":"0"}}`
+	resolvedTx := func(id int, eventType string, events string) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"txs":[{"hash":"7A13CB946589D07834119E3D9F3BF27E38DA9990894E24850323582A404DE46B","height":"105","index":0,"tx_result":{"code":0,"events":[`+
+			`{"type":"%s","attributes":[{"key":"packet_sequence","value":"42","index":true}]}%s]},"tx":""}],"total_count":"1"}}`, id, eventType, events)
+	}
+
+	vectors := []struct {
+		name     string
+		resp     []string
+		state    client.IbcTransferState
+		errorMsg string
+	}{
+		{
+			name:  "pending",
+			resp:  []string{sendTx, fmt.Sprintf(noTxs, 0), fmt.Sprintf(noTxs, 1)},
+			state: client.IbcTransferPending,
+		},
+		{
+			name: "acknowledged",
+			resp: []string{sendTx, resolvedTx(0, "acknowledge_packet",
+				`,{"type":"fungible_token_packet","attributes":[{"key":"success","value":"\u0001","index":true}]}`)},
+			state: client.IbcTransferAcknowledged,
+		},
+		{
+			name: "failed",
+			resp: []string{sendTx, resolvedTx(0, "acknowledge_packet",
+				`,{"type":"fungible_token_packet","attributes":[{"key":"error","value":"insufficient funds","index":true}]}`)},
+			state:    client.IbcTransferFailed,
+			errorMsg: "insufficient funds",
+		},
+		{
+			name:  "timed out",
+			resp:  []string{sendTx, fmt.Sprintf(noTxs, 0), resolvedTx(1, "timeout_packet", "")},
+			state: client.IbcTransferTimedOut,
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, close := testtypes.MockJSONRPC(t, v.resp)
+			defer close()
+			asset := &xc.ChainConfig{Chain: "ATOM", ChainCoin: "uatom", ChainPrefix: "cosmos", URL: server.URL}
+			cl, _ := client.NewClient(asset)
+
+			status, err := cl.FetchIbcTransferStatus(context.Background(), "E9C24C2E23CDCA56C8CE87A583149F8F88E75923F0CD958C003A84F631948978")
+			require.NoError(t, err)
+			require.Equal(t, v.state, status.State)
+			require.Equal(t, "42", status.Sequence)
+			require.Equal(t, "channel-617", status.SrcChannel)
+			require.Equal(t, "channel-0", status.DstChannel)
+			require.Equal(t, v.errorMsg, status.Error)
+			if v.state != client.IbcTransferPending {
+				require.Equal(t, "7A13CB946589D07834119E3D9F3BF27E38DA9990894E24850323582A404DE46B", status.ResolvedBy)
+			}
+		})
+	}
+}
+
+func TestFetchIbcCounterpartyHeight(t *testing.T) {
+	clientStateResponse := func(typeUrl string, chainId string, revisionNumber uint64, revisionHeight uint64) string {
+		clientState := &ibctm.ClientState{
+			ChainId:      chainId,
+			LatestHeight: ibcclient.NewHeight(revisionNumber, revisionHeight),
+		}
+		clientStateBz, err := clientState.Marshal()
+		require.NoError(t, err)
+		res := &ibcchannel.QueryChannelClientStateResponse{
+			IdentifiedClientState: &ibcclient.IdentifiedClientState{
+				ClientId:    "07-tendermint-1",
+				ClientState: &codectypes.Any{TypeUrl: typeUrl, Value: clientStateBz},
+			},
+		}
+		resBz, err := res.Marshal()
+		require.NoError(t, err)
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"%s","proofOps":null,"height":"2803726","codespace":""}}}`, base64.StdEncoding.EncodeToString(resBz))
+	}
+	tendermint := "/ibc.lightclients.tendermint.v1.ClientState"
+
+	vectors := []struct {
+		name   string
+		resp   string
+		height ibcclient.Height
+	}{
+		{"latest height", clientStateResponse(tendermint, "celestia-1", 1, 2000), ibcclient.NewHeight(1, 2000)},
+		{"revision from chain id", clientStateResponse(tendermint, "mocha-4", 0, 2000), ibcclient.NewHeight(4, 2000)},
+		{"other light client", clientStateResponse("/ibc.lightclients.wasm.v1.ClientState", "", 0, 0), ibcclient.Height{}},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, close := testtypes.MockJSONRPC(t, v.resp)
+			defer close()
+			asset := &xc.ChainConfig{Chain: "ATOM", ChainCoin: "uatom", ChainPrefix: "cosmos", URL: server.URL}
+			cl, _ := client.NewClient(asset)
+
+			height, err := cl.FetchIbcCounterpartyHeight(context.Background(), "transfer", "channel-617")
+			require.NoError(t, err)
+			require.Equal(t, v.height, height)
+		})
+	}
+}
+
+func TestParseIbcPacketEvents(t *testing.T) {
+	sender := "cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0wgcdq5"
+	// escrow account of transfer/channel-141
+	escrow := "cosmos1x54ltnyg88k0ejmk8ytwrhd3ltm84xehrnlslf"
+	// transfer module account, vouchers are sent to it to be burned
+	module := "cosmos1yl6hdjhmkf37639730gffanpzndzdpmhwlkfhr"
+	osmo := "ibc/14F9BC3E44B8A9C1BE1FB08980FAB87034C9905EF17CF2F5008FC085218811CC"
+	events := client.ParseEvents([]comettypes.Event{
+		{Type: "message", Attributes: []comettypes.EventAttribute{{Key: "sender", Value: sender}}},
+		// a transfer of the same amount, that is not sent over IBC
+		{Type: "transfer", Attributes: []comettypes.EventAttribute{
+			{Key: "recipient", Value: "cosmos1a53udazy8ayufvy0s434pfwjcedzqv34kvz9tw"},
+			{Key: "sender", Value: sender},
+			{Key: "amount", Value: "100uatom"},
+		}},
+		{Type: "transfer", Attributes: []comettypes.EventAttribute{
+			{Key: "recipient", Value: module},
+			{Key: "sender", Value: sender},
+			{Key: "amount", Value: "100" + osmo},
+		}},
+		{Type: "transfer", Attributes: []comettypes.EventAttribute{
+			{Key: "recipient", Value: escrow},
+			{Key: "sender", Value: sender},
+			{Key: "amount", Value: "100uatom"},
+		}},
+		{Type: "send_packet", Attributes: []comettypes.EventAttribute{
+			{Key: "packet_data", Value: `{"amount":"100","denom":"uatom","memo":"deposit-123","receiver":"celestia1hdvf6vv5amc7wp84js0ls27apekwxpr0w3fxaz","sender":"` + sender + `"}`},
+			{Key: "packet_sequence", Value: "42"},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: "channel-141"},
+		}},
+		{Type: "send_packet", Attributes: []comettypes.EventAttribute{
+			{Key: "packet_data", Value: `{"amount":"100","denom":"transfer/channel-141/uosmo","receiver":"osmo1hdvf6vv5amc7wp84js0ls27apekwxpr0jq3pmd","sender":"` + sender + `"}`},
+			{Key: "packet_sequence", Value: "43"},
+			{Key: "packet_src_port", Value: "transfer"},
+			{Key: "packet_src_channel", Value: "channel-141"},
+		}},
+	})
+	require.Len(t, events.IbcPackets, 2)
+	packet := events.IbcPackets[0]
+	require.Equal(t, "42", packet.Sequence)
+	require.Equal(t, "celestia1hdvf6vv5amc7wp84js0ls27apekwxpr0w3fxaz", packet.Receiver)
+	require.Equal(t, "deposit-123", packet.Memo)
+	require.Equal(t, "100", packet.Amount.String())
+
+	// the packets are matched to the transfers to the escrow and module accounts, with the same denom
+	require.Equal(t, map[int]client.IbcPacketEvent{
+		1: events.IbcPackets[1],
+		2: events.IbcPackets[0],
+	}, events.IbcTransfers())
+}
+
+func TestFetchActiveProposals(t *testing.T) {
+	abciResponse := func(id int, msg interface{ Marshal() ([]byte, error) }) string {
+		bz, err := msg.Marshal()
+		require.NoError(t, err)
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"%s","proofOps":null,"height":"2803726","codespace":""}}}`, id, base64.StdEncoding.EncodeToString(bz))
+	}
+	endTime := time.Unix(1700000000, 0).UTC()
+	proposals := &govv1.QueryProposalsResponse{
+		Proposals: []*govv1.Proposal{
+			{
+				Id:     42,
+				Title:  "Upgrade",
+				Status: govv1.StatusVotingPeriod,
+				// chain specific messages that are not registered should not be a problem
+				Messages:      []*codectypes.Any{{TypeUrl: "/unknown.v1.MsgUnknown", Value: []byte{1, 2, 3}}},
+				VotingEndTime: &endTime,
+			},
+		},
+	}
+	tally := &govv1.QueryTallyResultResponse{
+		Tally: &govv1.TallyResult{YesCount: "1000", AbstainCount: "10", NoCount: "200", NoWithVetoCount: "0"},
+	}
+	server, close := testtypes.MockJSONRPC(t, []string{
+		abciResponse(0, proposals),
+		abciResponse(1, tally),
+	})
+	defer close()
+	asset := &xc.ChainConfig{Chain: "ATOM", ChainCoin: "uatom", ChainPrefix: "cosmos", URL: server.URL}
+	cl, _ := client.NewClient(asset)
+
+	result, err := cl.FetchActiveProposals(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.EqualValues(t, 42, result[0].Id)
+	require.Equal(t, "Upgrade", result[0].Title)
+	require.Equal(t, "voting_period", result[0].Status)
+	require.Equal(t, &endTime, result[0].VotingEndTime)
+	require.Equal(t, "1000", result[0].Tally.Yes.String())
+	require.Equal(t, "10", result[0].Tally.Abstain.String())
+	require.Equal(t, "200", result[0].Tally.No.String())
+	require.Equal(t, "0", result[0].Tally.NoWithVeto.String())
+}
+
+func TestFetchActiveProposalsV1Beta1(t *testing.T) {
+	abciResponse := func(id int, msg interface{ Marshal() ([]byte, error) }) string {
+		bz, err := msg.Marshal()
+		require.NoError(t, err)
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"%s","proofOps":null,"height":"2803726","codespace":""}}}`, id, base64.StdEncoding.EncodeToString(bz))
+	}
+	unknownQuery := func(id int) string {
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":{"response":{"code":6,"log":"unknown query path","info":"","index":"0","key":null,"value":null,"proofOps":null,"height":"2803726","codespace":"sdk"}}}`, id)
+	}
+	content, err := (&govv1beta1.TextProposal{Title: "Upgrade", Description: "Upgrade the chain"}).Marshal()
+	require.NoError(t, err)
+	endTime := time.Unix(1700000000, 0).UTC()
+	proposals := &govv1beta1.QueryProposalsResponse{
+		Proposals: []govv1beta1.Proposal{
+			{
+				ProposalId:    42,
+				Content:       &codectypes.Any{TypeUrl: "/cosmos.gov.v1beta1.TextProposal", Value: content},
+				Status:        govv1beta1.StatusVotingPeriod,
+				VotingEndTime: endTime,
+			},
+		},
+	}
+	tally := &govv1beta1.QueryTallyResultResponse{
+		Tally: govv1beta1.NewTallyResult(types.NewInt(1000), types.NewInt(10), types.NewInt(200), types.NewInt(0)),
+	}
+	server, close := testtypes.MockJSONRPC(t, []string{
+		unknownQuery(0),
+		abciResponse(1, proposals),
+		unknownQuery(2),
+		abciResponse(3, tally),
+	})
+	defer close()
+	asset := &xc.ChainConfig{Chain: "LUNC", ChainCoin: "uluna", ChainPrefix: "terra", URL: server.URL}
+	cl, _ := client.NewClient(asset)
+
+	result, err := cl.FetchActiveProposals(context.Background())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	require.EqualValues(t, 42, result[0].Id)
+	require.Equal(t, "Upgrade", result[0].Title)
+	require.Equal(t, "Upgrade the chain", result[0].Summary)
+	require.Equal(t, "voting_period", result[0].Status)
+	require.Equal(t, endTime, *result[0].VotingEndTime)
+	require.Equal(t, "1000", result[0].Tally.Yes.String())
+	require.Equal(t, "10", result[0].Tally.Abstain.String())
+	require.Equal(t, "200", result[0].Tally.No.String())
+	require.Equal(t, "0", result[0].Tally.NoWithVeto.String())
+}
+
+func TestFetchGovVersion(t *testing.T) {
+	paramsBz, err := (&govv1.QueryParamsResponse{}).Marshal()
+	require.NoError(t, err)
+	params := base64.StdEncoding.EncodeToString(paramsBz)
+	vectors := []struct {
+		name    string
+		resp    string
+		version builder.GovVersion
+	}{
+		{
+			"v1",
+			`{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"` + params + `","proofOps":null,"height":"2803726","codespace":""}}}`,
+			builder.GovV1,
+		},
+		{
+			// e.g. LUNC
+			"v1beta1 only",
+			`{"jsonrpc":"2.0","id":0,"result":{"response":{"code":6,"log":"unknown query path","info":"","index":"0","key":null,"value":null,"proofOps":null,"height":"2803726","codespace":"sdk"}}}`,
+			builder.GovV1Beta1,
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, close := testtypes.MockJSONRPC(t, v.resp)
+			defer close()
+			asset := &xc.ChainConfig{Chain: "LUNC", ChainCoin: "uluna", ChainPrefix: "terra", URL: server.URL}
+			cl, _ := client.NewClient(asset)
+
+			version, err := cl.FetchGovVersion(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, v.version, version)
+		})
+	}
+}
+
+func TestParseVoteEvents(t *testing.T) {
+	voter := "cosmos1hdvf6vv5amc7wp84js0ls27apekwxpr0wgcdq5"
+	messageEvent := comettypes.Event{Type: "message", Attributes: []comettypes.EventAttribute{
+		{Key: "action", Value: "/cosmos.gov.v1.MsgVote"},
+		{Key: "sender", Value: voter},
+	}}
+	vectors := []struct {
+		name    string
+		attrs   []comettypes.EventAttribute
+		voter   string
+		options []client.VoteOptionEvent
+	}{
+		{
+			name:    "legacy",
+			attrs:   []comettypes.EventAttribute{{Key: "option", Value: "VOTE_OPTION_YES"}, {Key: "proposal_id", Value: "42"}},
+			voter:   voter,
+			options: []client.VoteOptionEvent{{Option: "yes", Weight: "1"}},
+		},
+		{
+			name: "weighted text",
+			attrs: []comettypes.EventAttribute{
+				{Key: "option", Value: "option:VOTE_OPTION_YES weight:\"0.600000000000000000\" \noption:VOTE_OPTION_NO_WITH_VETO weight:\"0.400000000000000000\" "},
+				{Key: "proposal_id", Value: "42"},
+			},
+			voter: voter,
+			options: []client.VoteOptionEvent{
+				{Option: "yes", Weight: "0.600000000000000000"},
+				{Option: "no_with_veto", Weight: "0.400000000000000000"},
+			},
+		},
+		{
+			name: "json",
+			attrs: []comettypes.EventAttribute{
+				{Key: "voter", Value: "cosmos1dp3q305hgttt8n34rt8rg9xpanc42z4yq2t0g6"},
+				{Key: "option", Value: `[{"option":3,"weight":"1.000000000000000000"}]`},
+				{Key: "proposal_id", Value: "42"},
+			},
+			voter:   "cosmos1dp3q305hgttt8n34rt8rg9xpanc42z4yq2t0g6",
+			options: []client.VoteOptionEvent{{Option: "no", Weight: "1.000000000000000000"}},
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			events := client.ParseEvents([]comettypes.Event{
+				messageEvent,
+				{Type: "proposal_vote", Attributes: v.attrs},
+			})
+			require.Len(t, events.Votes, 1)
+			require.Equal(t, "42", events.Votes[0].ProposalId)
+			require.Equal(t, v.voter, events.Votes[0].Voter)
+			require.Equal(t, v.options, events.Votes[0].Options)
+		})
+	}
+}
+
+func TestQuerySmart(t *testing.T) {
+	response := &wasmtypes.QuerySmartContractStateResponse{
+		Data: wasmtypes.RawContractMessage(`{"return_amount":"99","spread_amount":"1"}`),
+	}
+	bz, err := response.Marshal()
+	require.NoError(t, err)
+	server, close := testtypes.MockJSONRPC(t, []string{
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"%s","proofOps":null,"height":"2803726","codespace":""}}}`, base64.StdEncoding.EncodeToString(bz)),
+		`{"jsonrpc":"2.0","id":1,"result":{"response":{"code":1,"log":"Error parsing into type astroport::pair::QueryMsg: unknown variant","info":"","index":"0","key":null,"value":"","proofOps":null,"height":"2803726","codespace":"wasm"}}}`,
+	})
+	defer close()
+	asset := &xc.ChainConfig{Chain: "LUNA", ChainCoin: "uluna", ChainPrefix: "terra", URL: server.URL}
+	cl, _ := client.NewClient(asset)
+	contract := xc.ContractAddress("terra1fd68ah02gr2y8ze7tm9te7m70zlmc7vjyyhs6xlhsdmqqcjud4dql4wpxr")
+
+	res, err := cl.QuerySmart(context.Background(), contract, []byte(`{"simulation":{"offer_asset":{"info":{"native_token":{"denom":"uluna"}},"amount":"100"}}}`))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"return_amount":"99","spread_amount":"1"}`, string(res))
+
+	_, err = cl.QuerySmart(context.Background(), contract, []byte(`{"unknown":{}}`))
+	require.ErrorContains(t, err, "unknown variant")
+
+	_, err = cl.QuerySmart(context.Background(), contract, []byte(`{`))
+	require.ErrorContains(t, err, "json")
+}
+
+func TestFetchTokenMetadata(t *testing.T) {
+	abciResponse := func(t *testing.T, response interface{ Marshal() ([]byte, error) }) string {
+		bz, err := response.Marshal()
+		require.NoError(t, err)
+		return fmt.Sprintf(`{"jsonrpc":"2.0","id":0,"result":{"response":{"code":0,"log":"","info":"","index":"0","key":null,"value":"%s","proofOps":null,"height":"2803726","codespace":""}}}`, base64.StdEncoding.EncodeToString(bz))
+	}
+	vectors := []struct {
+		name     string
+		contract xc.ContractAddress
+		resp     func(t *testing.T) string
+		expected *xcclient.TokenMetadata
+	}{
+		{
+			name:     "cw20",
+			contract: "terra1nsuqsk6kh58ulczatwev87ttq2z6r3pusulg9r24mfj2fvtzd4uq3exn26",
+			resp: func(t *testing.T) string {
+				return abciResponse(t, &wasmtypes.QuerySmartContractStateResponse{
+					Data: wasmtypes.RawContractMessage(`{"name":"Astroport","symbol":"ASTRO","decimals":6,"total_supply":"1000000000000000"}`),
+				})
+			},
+			expected: &xcclient.TokenMetadata{Name: "Astroport", Symbol: "ASTRO", Decimals: 6},
+		},
+		{
+			name:     "bank",
+			contract: "ibc/B3504E092456BA618CC28AC671A71FB08C6CA0FD0BE7C8A5B5A3E2DD933CC9E4",
+			resp: func(t *testing.T) string {
+				return abciResponse(t, &banktypes.QueryDenomMetadataResponse{
+					Metadata: banktypes.Metadata{
+						Name:    "USD Coin",
+						Symbol:  "USDC",
+						Base:    "ibc/B3504E092456BA618CC28AC671A71FB08C6CA0FD0BE7C8A5B5A3E2DD933CC9E4",
+						Display: "usdc",
+						DenomUnits: []*banktypes.DenomUnit{
+							{Denom: "ibc/B3504E092456BA618CC28AC671A71FB08C6CA0FD0BE7C8A5B5A3E2DD933CC9E4", Exponent: 0},
+							{Denom: "usdc", Exponent: 6},
+						},
+					},
+				})
+			},
+			expected: &xcclient.TokenMetadata{Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, close := testtypes.MockJSONRPC(t, v.resp(t))
+			defer close()
+			asset := &xc.ChainConfig{Chain: "LUNA", ChainCoin: "uluna", ChainPrefix: "terra", URL: server.URL}
+			cl, _ := client.NewClient(asset)
+
+			metadata, err := cl.FetchTokenMetadata(context.Background(), v.contract)
+			require.NoError(t, err)
+			v.expected.Contract = v.contract
+			require.Equal(t, v.expected, metadata)
+		})
+	}
+}
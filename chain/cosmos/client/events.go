@@ -1,13 +1,23 @@
 package client
 
 import (
+	"bytes"
 	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"strings"
 
 	comettypes "github.com/cometbft/cometbft/abci/types"
 	xc "github.com/cordialsys/crosschain"
+	ibctransfer "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/apps/transfer/types"
 	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 )
 
+var ibcTransferModuleAddress = authtypes.NewModuleAddress(ibctransfer.ModuleName)
+
 type TransferEvent struct {
 	Recipient string
 	Sender    string
@@ -35,12 +45,52 @@ type UnbondEvent struct {
 	Contract  string
 }
 
+type IbcPacketEvent struct {
+	Sequence         string
+	SrcPort          string
+	SrcChannel       string
+	DstPort          string
+	DstChannel       string
+	TimeoutTimestamp string
+	// From the ICS-20 packet data of the transfer, if any
+	Sender   string
+	Receiver string
+	Denom    string
+	Amount   xc.AmountBlockchain
+	Memo     string
+}
+
+// ICS-20 fungible token packet data, e.g. `{"amount":"100","denom":"uatom","receiver":"...","sender":"..."}`
+type ibcPacketData struct {
+	Amount   string `json:"amount"`
+	Denom    string `json:"denom"`
+	Receiver string `json:"receiver"`
+	Sender   string `json:"sender"`
+	Memo     string `json:"memo"`
+}
+
+type VoteOptionEvent struct {
+	// e.g. "yes", "no", "abstain", "no_with_veto"
+	Option string
+	Weight string
+}
+
+type VoteEvent struct {
+	ProposalId string
+	Voter      string
+	Options    []VoteOptionEvent
+}
+
 type ParsedEvents struct {
 	Transfers []TransferEvent
 	// Every withdraw event also has a transfer event; so far we can ignore these
 	Withdraws []WithdrawRewardsEvent
 	Delegates []DelegateEvent
 	Unbonds   []UnbondEvent
+	// IBC packets sent (e.g. by MsgTransfer)
+	IbcPackets []IbcPacketEvent
+	// x/gov votes
+	Votes []VoteEvent
 }
 
 func DecodeEventAttributes(attrs []comettypes.EventAttribute) {
@@ -73,11 +123,18 @@ func ParseEvents(events []comettypes.Event) ParsedEvents {
 		}
 	}
 	foundMsgEvent := false
+	// the sender of the current message, as the message events precede the events emitted by the message
+	var msgSender string
 	for _, event := range events {
 		DecodeEventAttributes(event.Attributes)
 
 		if event.Type == "message" {
 			foundMsgEvent = true
+			for _, attr := range event.Attributes {
+				if attr.Key == "sender" {
+					msgSender = attr.Value
+				}
+			}
 		}
 
 		if !foundMsgEvent {
@@ -161,6 +218,14 @@ func ParseEvents(events []comettypes.Event) ParsedEvents {
 			}
 			parseEvents.Unbonds = append(parseEvents.Unbonds, unbondEvent)
 		}
+		// parse IBC send packet event
+		if event.Type == "send_packet" {
+			parseEvents.IbcPackets = append(parseEvents.IbcPackets, ParseIbcPacketEvent(event))
+		}
+		// parse gov vote event
+		if event.Type == "proposal_vote" {
+			parseEvents.Votes = append(parseEvents.Votes, ParseVoteEvent(event, msgSender))
+		}
 		// parse wasm CW20 transfer event
 		if event.Type == "wasm" {
 			var action string
@@ -192,3 +257,136 @@ func ParseEvents(events []comettypes.Event) ParsedEvents {
 	}
 	return parseEvents
 }
+
+// IbcTransfers matches the IBC packets to the transfers that sent their funds, by the index of the transfer.
+// The funds of a packet are sent by its sender to the escrow account of the channel (or to the transfer module
+// account, when returning vouchers), with the same amount and denom.
+func (events ParsedEvents) IbcTransfers() map[int]IbcPacketEvent {
+	matched := map[int]IbcPacketEvent{}
+	for _, packet := range events.IbcPackets {
+		if packet.Receiver == "" {
+			continue
+		}
+		// the packet has the full path of the denom, e.g. "transfer/channel-0/uatom" for "ibc/{hash}"
+		denom := ibctransfer.ParseDenomTrace(packet.Denom).IBCDenom()
+		escrow := ibctransfer.GetEscrowAddress(packet.SrcPort, packet.SrcChannel)
+		for i, transfer := range events.Transfers {
+			if _, ok := matched[i]; ok {
+				continue
+			}
+			if transfer.Sender != packet.Sender || transfer.Contract != denom || transfer.Amount.Cmp(&packet.Amount) != 0 {
+				continue
+			}
+			_, recipient, err := bech32.DecodeAndConvert(transfer.Recipient)
+			if err != nil {
+				continue
+			}
+			if bytes.Equal(recipient, escrow) || bytes.Equal(recipient, ibcTransferModuleAddress) {
+				matched[i] = packet
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func ParseIbcPacketEvent(event comettypes.Event) IbcPacketEvent {
+	var packetEvent IbcPacketEvent
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case "packet_sequence":
+			packetEvent.Sequence = attr.Value
+		case "packet_src_port":
+			packetEvent.SrcPort = attr.Value
+		case "packet_src_channel":
+			packetEvent.SrcChannel = attr.Value
+		case "packet_dst_port":
+			packetEvent.DstPort = attr.Value
+		case "packet_dst_channel":
+			packetEvent.DstChannel = attr.Value
+		case "packet_timeout_timestamp":
+			packetEvent.TimeoutTimestamp = attr.Value
+		case "packet_data":
+			var data ibcPacketData
+			if err := json.Unmarshal([]byte(attr.Value), &data); err == nil {
+				packetEvent.Sender = data.Sender
+				packetEvent.Receiver = data.Receiver
+				packetEvent.Denom = data.Denom
+				packetEvent.Amount = xc.NewAmountBlockchainFromStr(data.Amount)
+				packetEvent.Memo = data.Memo
+			}
+		}
+	}
+	return packetEvent
+}
+
+// ParseVoteEvent parses a "proposal_vote" event.  Newer versions of cosmos-sdk include the voter,
+// otherwise the sender of the message is assumed to be the voter.
+func ParseVoteEvent(event comettypes.Event, sender string) VoteEvent {
+	voteEvent := VoteEvent{
+		Voter: sender,
+	}
+	for _, attr := range event.Attributes {
+		switch attr.Key {
+		case "proposal_id":
+			voteEvent.ProposalId = attr.Value
+		case "voter":
+			voteEvent.Voter = attr.Value
+		case "option":
+			voteEvent.Options = parseVoteOptions(attr.Value)
+		}
+	}
+	return voteEvent
+}
+
+var voteOptionTextRegex = regexp.MustCompile(`option:\s*"?(\w+)"?\s+weight:\s*"([^"]*)"`)
+
+// The format of the vote option attribute has changed between cosmos-sdk versions:
+// - "VOTE_OPTION_YES" (single option)
+// - `option:VOTE_OPTION_YES weight:"1.000000000000000000"`, one per line (weighted options)
+// - `{"option":1,"weight":"1.000000000000000000"}` or a list of those (json)
+func parseVoteOptions(value string) []VoteOptionEvent {
+	value = strings.TrimSpace(value)
+	type jsonOption struct {
+		Option json.RawMessage `json:"option"`
+		Weight string          `json:"weight"`
+	}
+	var jsonOptions []jsonOption
+	if strings.HasPrefix(value, "{") {
+		var single jsonOption
+		if err := json.Unmarshal([]byte(value), &single); err == nil {
+			jsonOptions = append(jsonOptions, single)
+		}
+	} else if strings.HasPrefix(value, "[") {
+		_ = json.Unmarshal([]byte(value), &jsonOptions)
+	}
+	if len(jsonOptions) > 0 {
+		options := []VoteOptionEvent{}
+		for _, opt := range jsonOptions {
+			var option string
+			var optionInt int32
+			if err := json.Unmarshal(opt.Option, &optionInt); err == nil {
+				option = govv1.VoteOption(optionInt).String()
+			} else {
+				_ = json.Unmarshal(opt.Option, &option)
+			}
+			options = append(options, VoteOptionEvent{Option: normalizeVoteOption(option), Weight: opt.Weight})
+		}
+		return options
+	}
+
+	matches := voteOptionTextRegex.FindAllStringSubmatch(value, -1)
+	if len(matches) > 0 {
+		options := []VoteOptionEvent{}
+		for _, match := range matches {
+			options = append(options, VoteOptionEvent{Option: normalizeVoteOption(match[1]), Weight: match[2]})
+		}
+		return options
+	}
+	return []VoteOptionEvent{{Option: normalizeVoteOption(value), Weight: "1"}}
+}
+
+// e.g. "VOTE_OPTION_NO_WITH_VETO" -> "no_with_veto"
+func normalizeVoteOption(option string) string {
+	return strings.ToLower(strings.TrimPrefix(option, "VOTE_OPTION_"))
+}
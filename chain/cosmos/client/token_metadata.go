@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+type Cw20TokenInfo struct {
+	Name        string `json:"name"`
+	Symbol      string `json:"symbol"`
+	Decimals    int32  `json:"decimals"`
+	TotalSupply string `json:"total_supply"`
+}
+
+// FetchTokenMetadata queries `token_info` for cw20 contracts, or the bank module's denom metadata for
+// other assets.
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	if _, err := types.GetFromBech32(string(contract), client.Prefix); err == nil {
+		return client.fetchCw20Metadata(ctx, contract)
+	}
+	return client.fetchDenomMetadata(ctx, contract)
+}
+
+func (client *Client) fetchCw20Metadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	resp, err := client.QuerySmart(ctx, contract, []byte(`{"token_info":{}}`))
+	if err != nil {
+		return nil, fmt.Errorf("could not get token info of %s: %v", contract, err)
+	}
+	info := Cw20TokenInfo{}
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return nil, fmt.Errorf("could not parse token info of %s: %v", contract, err)
+	}
+	return &xclient.TokenMetadata{
+		Contract: contract,
+		Name:     info.Name,
+		Symbol:   info.Symbol,
+		Decimals: info.Decimals,
+	}, nil
+}
+
+func (client *Client) fetchDenomMetadata(ctx context.Context, denom xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	resp, err := banktypes.NewQueryClient(client.Ctx).DenomMetadata(ctx, &banktypes.QueryDenomMetadataRequest{
+		Denom: string(denom),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not get denom metadata of %s: %v", denom, err)
+	}
+	metadata := resp.Metadata
+	// the decimals are the exponent of the display unit
+	var decimals int32
+	for _, unit := range metadata.DenomUnits {
+		if unit.Denom == metadata.Display {
+			decimals = int32(unit.Exponent)
+		}
+	}
+	return &xclient.TokenMetadata{
+		Contract: denom,
+		Name:     metadata.Name,
+		Symbol:   metadata.Symbol,
+		Decimals: decimals,
+	}, nil
+}
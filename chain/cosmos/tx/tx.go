@@ -25,6 +25,11 @@ type Tx struct {
 	SigsV2          []signingtypes.SignatureV2
 	InputSignatures []xc.TxSignature
 	TxDataToSign    []byte
+	// Set if another account is paying the fees and must also sign
+	FeePayerDataToSign []byte
+	// The sign docs that hash to the sighashes
+	SignDoc         []byte
+	FeePayerSignDoc []byte
 }
 
 var _ xc.Tx = &Tx{}
@@ -56,9 +61,23 @@ func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
 	if tx.TxDataToSign == nil {
 		return nil, errors.New("transaction not initialized")
 	}
+	if tx.FeePayerDataToSign != nil {
+		return []xc.TxDataToSign{tx.TxDataToSign, tx.FeePayerDataToSign}, nil
+	}
 	return []xc.TxDataToSign{tx.TxDataToSign}, nil
 }
 
+// DisplayPayloads returns the SIGN_MODE_DIRECT sign docs, which hash to the sighashes
+func (tx Tx) DisplayPayloads() ([]xc.TxDataToDisplay, error) {
+	if tx.SignDoc == nil {
+		return nil, errors.New("transaction not initialized")
+	}
+	if tx.FeePayerSignDoc != nil {
+		return []xc.TxDataToDisplay{tx.SignDoc, tx.FeePayerSignDoc}, nil
+	}
+	return []xc.TxDataToDisplay{tx.SignDoc}, nil
+}
+
 // AddSignatures adds a signature to Tx
 func (tx *Tx) AddSignatures(signatures ...xc.TxSignature) error {
 	if tx.SigsV2 == nil || len(tx.SigsV2) < 1 || tx.CosmosTxBuilder == nil {
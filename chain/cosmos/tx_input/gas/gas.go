@@ -69,3 +69,11 @@ func ParseMinGasError(res *sdk.TxResponse, denoms []string) (sdk.Coin, error) {
 	})
 	return maxFees[0], nil
 }
+
+// Simulated gas usage is not exact, as it can change between simulation and execution.
+const SimulatedGasMultiplier = 1.4
+
+// Apply the safety margin to the gas used during simulation
+func SimulatedGasLimit(gasUsed uint64) uint64 {
+	return uint64(float64(gasUsed) * SimulatedGasMultiplier)
+}
@@ -31,6 +31,16 @@ type TxInput struct {
 
 	AssetType CosmoAssetType `json:"asset_type,omitempty"`
 	ChainId   string         `json:"chain_id,omitempty"`
+
+	// Timeout for IBC transfers, in unix nanoseconds, derived from the latest block time
+	IbcTimeoutTimestamp uint64 `json:"ibc_timeout_timestamp,omitempty"`
+	// Timeout height for IBC transfers, on the destination chain
+	IbcTimeoutRevisionNumber uint64 `json:"ibc_timeout_revision_number,omitempty"`
+	IbcTimeoutRevisionHeight uint64 `json:"ibc_timeout_revision_height,omitempty"`
+
+	// Account of the fee payer, if another account is paying for (and co-signing) the transaction
+	FeePayerAccountNumber uint64 `json:"fee_payer_account_number,omitempty"`
+	FeePayerSequence      uint64 `json:"fee_payer_sequence,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -37,6 +37,41 @@ func (msg MsgExecuteContract) ValidateBasic() error {
 	return nil
 }
 
+func (m MsgInstantiateContract) GetSigners() []sdk.AccAddress {
+	signer, _ := sdk.AccAddressFromBech32(m.Sender)
+	return []sdk.AccAddress{
+		signer,
+	}
+}
+
+func (msg MsgInstantiateContract) ValidateBasic() error {
+	if _, err := sdk.AccAddressFromBech32(msg.Sender); err != nil {
+		return errorsmod.Wrap(err, "sender")
+	}
+
+	if msg.CodeID == 0 {
+		return errorsmod.Wrap(ErrEmpty, "code id is required")
+	}
+
+	if msg.Label == "" {
+		return errorsmod.Wrap(ErrEmpty, "label is required")
+	}
+
+	if !msg.Funds.IsValid() {
+		return errorsmod.Wrap(ErrInvalid, "funds")
+	}
+
+	if len(msg.Admin) != 0 {
+		if _, err := sdk.AccAddressFromBech32(msg.Admin); err != nil {
+			return errorsmod.Wrap(err, "admin")
+		}
+	}
+	if err := msg.Msg.ValidateBasic(); err != nil {
+		return errorsmod.Wrap(err, "payload msg")
+	}
+	return nil
+}
+
 // RawContractMessage defines a json message that is sent or returned by a wasm contract.
 // This type can hold any type of bytes. Until validateBasic is called there should not be
 // any assumptions made that the data is valid syntax or semantic.
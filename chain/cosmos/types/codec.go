@@ -14,6 +14,7 @@ import (
 	injethsecp256k1 "github.com/cordialsys/crosschain/chain/cosmos/types/InjectiveLabs/injective-core/injective-chain/crypto/ethsecp256k1"
 	injective "github.com/cordialsys/crosschain/chain/cosmos/types/InjectiveLabs/injective-core/injective-chain/types"
 	terraclassic "github.com/cordialsys/crosschain/chain/cosmos/types/classic-terra/core/v2/x/vesting/types"
+	ibctransfer "github.com/cordialsys/crosschain/chain/cosmos/types/cosmos/ibc-go/v7/modules/apps/transfer/types"
 	"github.com/cordialsys/crosschain/chain/cosmos/types/evmos/ethermint/crypto/ethsecp256k1"
 	etherminttypes "github.com/cordialsys/crosschain/chain/cosmos/types/evmos/ethermint/types"
 	ethermintevm "github.com/cordialsys/crosschain/chain/cosmos/types/evmos/ethermint/x/evm/types"
@@ -31,6 +32,7 @@ func RegisterExternalInterfaces(registry codectypes.InterfaceRegistry) {
 	registerInterfacesCosmosExtra(registry)
 	registerInterfacesInjective(registry)
 	registerInterfacesWasmd(registry)
+	registerInterfacesIbcTransfer(registry)
 }
 func RegisterExternalLegacyAdmino(cdc *codec.LegacyAmino) {
 	registerLegacyAminoTerraClassic(cdc)
@@ -140,7 +142,7 @@ func registerInterfacesWasmd(registry codectypes.InterfaceRegistry) {
 		// to our local types/CosmWasm
 
 		// &wasmd.MsgStoreCode{},
-		// &wasmd.MsgInstantiateContract{},
+		&wasmd.MsgInstantiateContract{},
 		// &wasmd.MsgInstantiateContract2{},
 		&wasmd.MsgExecuteContract{},
 		// &wasmd.MsgMigrateContract{},
@@ -195,3 +197,10 @@ func registerInterfacesWasmd(registry codectypes.InterfaceRegistry) {
 	// 	&ContractMigrationAuthorization{},
 	// )
 }
+
+func registerInterfacesIbcTransfer(registry codectypes.InterfaceRegistry) {
+	registry.RegisterImplementations(
+		(*sdk.Msg)(nil),
+		&ibctransfer.MsgTransfer{},
+	)
+}
@@ -0,0 +1,31 @@
+package types
+
+// Copied missing dependencies from https://github.com/cosmos/ibc-go/blob/v7.3.0/modules/apps/transfer/types
+
+import (
+	"errors"
+
+	errorsmod "cosmossdk.io/errors"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func (msg MsgTransfer) GetSigners() []sdk.AccAddress {
+	signer, _ := sdk.AccAddressFromBech32(msg.Sender)
+	return []sdk.AccAddress{signer}
+}
+
+func (msg MsgTransfer) ValidateBasic() error {
+	if msg.SourcePort == "" || msg.SourceChannel == "" {
+		return errors.New("source port and channel are required")
+	}
+	if !msg.Token.IsValid() || !msg.Token.IsPositive() {
+		return errorsmod.Wrap(errors.New("invalid token"), msg.Token.String())
+	}
+	if msg.Receiver == "" {
+		return errors.New("missing recipient address")
+	}
+	if msg.TimeoutHeight.IsZero() && msg.TimeoutTimestamp == 0 {
+		return errors.New("timeout height or timestamp is required")
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package types
+
+// Copied missing dependencies from https://github.com/cosmos/ibc-go/blob/v7.3.0/modules/apps/transfer/types
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+
+	tmbytes "github.com/cometbft/cometbft/libs/bytes"
+)
+
+const (
+	// DenomPrefix is the prefix used for internal SDK coin representation.
+	DenomPrefix = "ibc"
+	// PortID is the default port id that transfer module binds to
+	PortID = "transfer"
+	// ModuleName defines the IBC transfer name
+	ModuleName = "transfer"
+	// Version defines the current version the IBC tranfer
+	// module supports
+	Version = "ics20-1"
+)
+
+// GetEscrowAddress returns the escrow address for the specified channel.
+// The escrow address follows the format as outlined in ADR 028:
+// https://github.com/cosmos/cosmos-sdk/blob/master/docs/architecture/adr-028-public-key-addresses.md
+func GetEscrowAddress(portID, channelID string) []byte {
+	// a slash is used to create domain separation between port and channel identifiers to
+	// prevent address collisions between escrow addresses created for different channels
+	contents := fmt.Sprintf("%s/%s", portID, channelID)
+
+	// ADR 028 AddressHash construction
+	preImage := []byte(Version)
+	preImage = append(preImage, 0)
+	preImage = append(preImage, contents...)
+	hash := sha256.Sum256(preImage)
+	return hash[:20]
+}
+
+// Traces defines a wrapper type for a slice of DenomTrace.
+type Traces []DenomTrace
+
+// ParseDenomTrace parses a string with the ibc prefix (denom trace) and the base denomination
+// into a DenomTrace type.
+//
+// Examples:
+//
+// - "portidone/channel-0/uatom" => DenomTrace{Path: "portidone/channel-0", BaseDenom: "uatom"}
+// - "portidone/channel-0/portidtwo/channel-1/uatom" => DenomTrace{Path: "portidone/channel-0/portidtwo/channel-1", BaseDenom: "uatom"}
+// - "uatom" => DenomTrace{Path: "", BaseDenom: "uatom"}
+func ParseDenomTrace(rawDenom string) DenomTrace {
+	denomSplit := strings.Split(rawDenom, "/")
+
+	if denomSplit[0] == rawDenom {
+		return DenomTrace{
+			Path:      "",
+			BaseDenom: rawDenom,
+		}
+	}
+
+	path, baseDenom := extractPathAndBaseFromFullDenom(denomSplit)
+	return DenomTrace{
+		Path:      path,
+		BaseDenom: baseDenom,
+	}
+}
+
+// Hash returns the hex bytes of the SHA256 hash of the DenomTrace fields using the following formula:
+//
+// hash = sha256(tracePath + "/" + baseDenom)
+func (dt DenomTrace) Hash() tmbytes.HexBytes {
+	hash := sha256.Sum256([]byte(dt.GetFullDenomPath()))
+	return hash[:]
+}
+
+// GetPrefix returns the receiving denomination prefix composed by the trace info and a separator.
+func (dt DenomTrace) GetPrefix() string {
+	return dt.Path + "/"
+}
+
+// IBCDenom a coin denomination for an ICS20 fungible token in the format
+// 'ibc/{hash(tracePath + baseDenom)}'. If the trace is empty, it will return the base denomination.
+func (dt DenomTrace) IBCDenom() string {
+	if dt.Path != "" {
+		return fmt.Sprintf("%s/%s", DenomPrefix, dt.Hash())
+	}
+	return dt.BaseDenom
+}
+
+// GetFullDenomPath returns the full denomination according to the ICS20 specification:
+// tracePath + "/" + baseDenom
+// If there exists no trace then the base denomination is returned.
+func (dt DenomTrace) GetFullDenomPath() string {
+	if dt.Path == "" {
+		return dt.BaseDenom
+	}
+	return dt.GetPrefix() + dt.BaseDenom
+}
+
+// IsNativeDenom returns true if the denomination is native, thus containing no trace history.
+func (dt DenomTrace) IsNativeDenom() bool {
+	return dt.Path == ""
+}
+
+// IsIBCDenom returns true if the denomination is in the 'ibc/{hash}' format.
+func IsIBCDenom(denom string) bool {
+	parts := strings.Split(denom, "/")
+	return len(parts) == 2 && parts[0] == DenomPrefix && len(parts[1]) == 64
+}
+
+// extractPathAndBaseFromFullDenom returns the trace path and the base denom from
+// the elements that constitute the complete denom.
+func extractPathAndBaseFromFullDenom(fullDenomItems []string) (string, string) {
+	var (
+		path      []string
+		baseDenom []string
+	)
+
+	length := len(fullDenomItems)
+	for i := 0; i < length; i += 2 {
+		if i < length-1 && length > 2 && isValidChannelID(fullDenomItems[i+1]) {
+			path = append(path, fullDenomItems[i], fullDenomItems[i+1])
+		} else {
+			baseDenom = fullDenomItems[i:]
+			break
+		}
+	}
+
+	return strings.Join(path, "/"), strings.Join(baseDenom, "/")
+}
+
+// channel identifiers are in the format 'channel-{N}'
+func isValidChannelID(channelID string) bool {
+	if !strings.HasPrefix(channelID, "channel-") {
+		return false
+	}
+	suffix := strings.TrimPrefix(channelID, "channel-")
+	if suffix == "" {
+		return false
+	}
+	for _, c := range suffix {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
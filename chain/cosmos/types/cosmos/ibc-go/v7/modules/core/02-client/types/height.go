@@ -0,0 +1,50 @@
+package types
+
+// Copied missing dependencies from https://github.com/cosmos/ibc-go/blob/v7.3.0/modules/core/02-client/types
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// IsRevisionFormat checks if a chainID is in the format required for parsing revisions
+// The chainID must be in the form: `{chainID}-{revision}`.
+var IsRevisionFormat = regexp.MustCompile(`^.*[^\n-]-{1}[1-9][0-9]*$`).MatchString
+
+// NewHeight is a constructor for the IBC height type
+func NewHeight(revisionNumber, revisionHeight uint64) Height {
+	return Height{
+		RevisionNumber: revisionNumber,
+		RevisionHeight: revisionHeight,
+	}
+}
+
+// String returns a string representation of Height
+func (h Height) String() string {
+	return fmt.Sprintf("%d-%d", h.RevisionNumber, h.RevisionHeight)
+}
+
+// IsZero returns true if height revision and revision-height are both 0
+func (h Height) IsZero() bool {
+	return h.RevisionNumber == 0 && h.RevisionHeight == 0
+}
+
+// ParseChainID parses a chain ID and returns the revision number.
+// Chain IDs that are not in the revision format default to 0.
+func ParseChainID(chainID string) uint64 {
+	if !IsRevisionFormat(chainID) {
+		return 0
+	}
+	splitStr := strings.Split(chainID, "-")
+	revision, err := strconv.ParseUint(splitStr[len(splitStr)-1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
+
+func (up UpgradeProposal) String() string {
+	return fmt.Sprintf("IBC Upgrade Proposal: %s", up.Title)
+}
@@ -0,0 +1,19 @@
+package types
+
+// Copied missing dependencies from https://github.com/cosmos/ibc-go/blob/v7.3.0/modules/core/23-commitment/types
+
+import (
+	"net/url"
+)
+
+// String implements fmt.Stringer.
+// This represents the path in the same way the tendermint KeyPath will
+// represent a key path. The backslashes partition the key path into
+// the respective stores they belong to.
+func (mp MerklePath) String() string {
+	pathStr := ""
+	for _, k := range mp.KeyPath {
+		pathStr += "/" + url.PathEscape(k)
+	}
+	return pathStr
+}
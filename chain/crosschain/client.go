@@ -17,6 +17,7 @@ import (
 	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/config"
 	"github.com/cordialsys/crosschain/factory/drivers"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -52,7 +53,7 @@ func NewClient(cfgI xc.ITask, apiKey string) (*Client, error) {
 	return &Client{
 		Asset:   cfgI,
 		URL:     url,
-		Http:    &http.Client{},
+		Http:    utils.NewHttpClient(cfgI.GetChain()),
 		Network: network,
 		ApiKey:  apiKey,
 	}, nil
@@ -187,6 +188,7 @@ func (client *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, t
 
 // SubmitTx submits via a Crosschain endpoint
 func (client *Client) SubmitTx(ctx context.Context, txInput xc.Tx) error {
+	ctx = utils.NonIdempotent(ctx)
 	chain := string(client.Asset.GetChain().Chain)
 	data, err := txInput.Serialize()
 	if err != nil {
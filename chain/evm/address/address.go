@@ -51,6 +51,29 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 	}, err
 }
 
+// ValidateAddress checks for 20 hex bytes, and the EIP-55 checksum if the address is mixed case.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	str := strings.TrimSpace(string(address))
+	prefix := "0x"
+	if strings.HasPrefix(str, "xdc") {
+		// XDC chain uses a different prefix
+		prefix = "xdc"
+	}
+	hexS := strings.TrimPrefix(str, prefix)
+	if len(hexS) != 2*common.AddressLength {
+		return xc.InvalidAddress("expected %d hex characters, got %d", 2*common.AddressLength, len(hexS))
+	}
+	if _, err := hex.DecodeString(hexS); err != nil {
+		return xc.InvalidAddress("invalid hex: %v", err)
+	}
+	checksummed := common.HexToAddress(hexS).Hex()
+	isMixedCase := strings.ToLower(hexS) != hexS && strings.ToUpper(hexS) != hexS
+	if isMixedCase && checksummed[2:] != hexS {
+		return xc.InvalidAddress("invalid EIP-55 checksum, expected %s", prefix+checksummed[2:])
+	}
+	return xc.ValidAddress(xc.Address(prefix+checksummed[2:]), xc.AddressTypeDefault)
+}
+
 // FromHex returns a go-ethereum Address decoded Crosschain address (hex string).
 func FromHex(address xc.Address) (common.Address, error) {
 	str := TrimPrefixes(string(address))
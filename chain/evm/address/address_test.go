@@ -71,3 +71,34 @@ func TestHexToAddress(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, common.Address{0x58, 0x91, 0x90, 0x6f, 0xEf, 0x64, 0xA5, 0xae, 0x92, 0x4C, 0x7F, 0xc5, 0xed, 0x48, 0xc0, 0xF6, 0x4a, 0x55, 0xfC, 0xe1}, addr)
 }
+
+func TestValidateAddress(t *testing.T) {
+	builder, _ := address.NewAddressBuilder(&xc.ChainConfig{})
+	vectors := []struct {
+		name       string
+		address    string
+		normalized string
+		reason     string
+	}{
+		{"checksummed", "0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1", "0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1", ""},
+		{"lowercase", "0x5891906fef64a5ae924c7fc5ed48c0f64a55fce1", "0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1", ""},
+		{"no_prefix", "5891906FEF64A5AE924C7FC5ED48C0F64A55FCE1", "0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1", ""},
+		{"xdc", "xdc5891906fef64a5ae924c7fc5ed48c0f64a55fce1", "xdc5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1", ""},
+		{"bad_checksum", "0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCE1", "", "invalid EIP-55 checksum, expected 0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1"},
+		{"too_short", "0x891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1", "", "expected 40 hex characters, got 39"},
+		{"not_hex", "0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCzz", "", "invalid hex: encoding/hex: invalid byte: U+007A 'z'"},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			result := builder.ValidateAddress(xc.Address(v.address))
+			if v.reason != "" {
+				require.False(t, result.Valid)
+				require.Equal(t, v.reason, result.Reason)
+				return
+			}
+			require.True(t, result.Valid, result.Reason)
+			require.Equal(t, xc.Address(v.normalized), result.Normalized)
+			require.Equal(t, xc.AddressTypeDefault, result.Type)
+		})
+	}
+}
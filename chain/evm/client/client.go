@@ -51,6 +51,7 @@ type Client struct {
 
 var _ xclient.FullClient = &Client{}
 var _ xclient.ClientV2 = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 
 // Ethereum does not support full delegated staking, so we can only report balance information.
 // A 3rd party 'staking provider' is required to do the rest.
@@ -102,8 +103,7 @@ func NewClient(asset xc.ITask) (*Client, error) {
 	url := configToEVMClientURL(asset)
 
 	// c, err := rpc.DialContext(context.Background(), url)
-	interceptor := utils.NewHttpInterceptor(ReplaceIncompatiableEvmResponses)
-	// {http.DefaultTransport, false}
+	interceptor := utils.NewChainHttpInterceptor(nativeAsset, ReplaceIncompatiableEvmResponses)
 	httpClient := &http.Client{
 		Transport: interceptor,
 	}
@@ -121,8 +121,14 @@ func NewClient(asset xc.ITask) (*Client, error) {
 	}, nil
 }
 
+// FetchLatestHeight returns the latest block number of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return client.EthClient.BlockNumber(ctx)
+}
+
 // SubmitTx submits a EVM tx
 func (client *Client) SubmitTx(ctx context.Context, trans xc.Tx) error {
+	ctx = utils.NonIdempotent(ctx)
 	switch tx := trans.(type) {
 	case *tx.Tx:
 		err := client.EthClient.SendTransaction(ctx, tx.EthTx)
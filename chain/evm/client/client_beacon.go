@@ -11,6 +11,7 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	xcclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 
 	"github.com/sirupsen/logrus"
 )
@@ -126,7 +127,7 @@ func (cli *Client) Send(method string, path string, requestBody any, response an
 	}
 
 	logrus.WithField("url", url).Debug(method)
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := utils.NewHttpClient(cli.Asset.GetChain()).Do(request)
 	if err != nil {
 		return fmt.Errorf("failed to GET: %v", err)
 	}
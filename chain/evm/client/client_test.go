@@ -658,3 +658,54 @@ func TestFetchTxInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchTokenMetadata(t *testing.T) {
+	decimals := `"0x0000000000000000000000000000000000000000000000000000000000000006"`
+	vectors := []struct {
+		name     string
+		resp     []string
+		expected *xcclient.TokenMetadata
+		err      string
+	}{
+		{
+			name: "erc20",
+			resp: []string{
+				decimals,
+				`"0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000045553444300000000000000000000000000000000000000000000000000000000"`,
+				`"0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000855534420436f696e000000000000000000000000000000000000000000000000"`,
+			},
+			expected: &xcclient.TokenMetadata{Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		},
+		{
+			name: "bytes32",
+			resp: []string{
+				decimals,
+				`"0x4d4b520000000000000000000000000000000000000000000000000000000000"`,
+				`"0x4d616b6572000000000000000000000000000000000000000000000000000000"`,
+			},
+			expected: &xcclient.TokenMetadata{Name: "Maker", Symbol: "MKR", Decimals: 6},
+		},
+		{
+			name: "not_a_token",
+			resp: []string{`"0x"`},
+			err:  "could not get decimals",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, close := testtypes.MockJSONRPC(t, v.resp)
+			defer close()
+			client, _ := client.NewClient(&xc.ChainConfig{URL: server.URL})
+			contract := xc.ContractAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+			metadata, err := client.FetchTokenMetadata(context.Background(), contract)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			v.expected.Contract = contract
+			require.Equal(t, v.expected, metadata)
+		})
+	}
+}
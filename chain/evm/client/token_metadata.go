@@ -0,0 +1,67 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/evm/abi/erc20"
+	"github.com/cordialsys/crosschain/chain/evm/address"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+// FetchTokenMetadata calls decimals(), symbol() and name() on an ERC-20 contract.
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	tokenAddress, err := address.FromHex(xc.Address(contract))
+	if err != nil {
+		return nil, err
+	}
+	instance, err := erc20.NewErc20(tokenAddress, client.EthClient)
+	if err != nil {
+		return nil, err
+	}
+	decimals, err := instance.Decimals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("could not get decimals of %s: %v", contract, err)
+	}
+	symbol, err := client.callStringMethod(ctx, tokenAddress, "symbol")
+	if err != nil {
+		return nil, fmt.Errorf("could not get symbol of %s: %v", contract, err)
+	}
+	// the name is optional in ERC-20
+	name, _ := client.callStringMethod(ctx, tokenAddress, "name")
+	return &xclient.TokenMetadata{
+		Contract: contract,
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: int32(decimals),
+	}, nil
+}
+
+// Some early tokens (e.g. MKR) return bytes32 instead of a string for their name and symbol.
+func (client *Client) callStringMethod(ctx context.Context, contract common.Address, method string) (string, error) {
+	data, err := ERC20.Pack(method)
+	if err != nil {
+		return "", err
+	}
+	result, err := client.EthClient.CallContract(ctx, ethereum.CallMsg{To: &contract, Data: data}, nil)
+	if err != nil {
+		return "", err
+	}
+	values, err := ERC20.Unpack(method, result)
+	if err == nil && len(values) == 1 {
+		if value, ok := values[0].(string); ok {
+			return value, nil
+		}
+	}
+	if len(result) == 32 {
+		return string(bytes.TrimRight(result, "\x00")), nil
+	}
+	return "", fmt.Errorf("invalid %s returned: %x", method, result)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 )
 
 var ERC20 abi.ABI
@@ -56,6 +57,45 @@ func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
 	return []xc.TxDataToSign{sighash}, nil
 }
 
+// DisplayPayloads returns the unsigned encoding of the tx, which hashes to the sighash
+func (tx Tx) DisplayPayloads() ([]xc.TxDataToDisplay, error) {
+	if tx.EthTx == nil || tx.Signer == nil {
+		return []xc.TxDataToDisplay{}, errors.New("transaction not initialized")
+	}
+	payload, err := UnsignedPayload(tx.EthTx, tx.Signer.ChainID())
+	if err != nil {
+		return []xc.TxDataToDisplay{}, err
+	}
+	return []xc.TxDataToDisplay{payload}, nil
+}
+
+// UnsignedPayload encodes a tx the way it's hashed for signing: the EIP-155 RLP for legacy txs, or the
+// type prefixed RLP for typed txs.
+func UnsignedPayload(ethTx *types.Transaction, chainId *big.Int) ([]byte, error) {
+	var fields []interface{}
+	switch ethTx.Type() {
+	case types.LegacyTxType:
+		return rlp.EncodeToBytes([]interface{}{
+			ethTx.Nonce(), ethTx.GasPrice(), ethTx.Gas(), ethTx.To(), ethTx.Value(), ethTx.Data(), chainId, uint(0), uint(0),
+		})
+	case types.AccessListTxType:
+		fields = []interface{}{
+			chainId, ethTx.Nonce(), ethTx.GasPrice(), ethTx.Gas(), ethTx.To(), ethTx.Value(), ethTx.Data(), ethTx.AccessList(),
+		}
+	case types.DynamicFeeTxType:
+		fields = []interface{}{
+			chainId, ethTx.Nonce(), ethTx.GasTipCap(), ethTx.GasFeeCap(), ethTx.Gas(), ethTx.To(), ethTx.Value(), ethTx.Data(), ethTx.AccessList(),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported tx type %d", ethTx.Type())
+	}
+	encoded, err := rlp.EncodeToBytes(fields)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{ethTx.Type()}, encoded...), nil
+}
+
 // AddSignatures adds a signature to Tx
 func (tx *Tx) AddSignatures(signatures ...xc.TxSignature) error {
 	if tx.EthTx == nil {
@@ -1,10 +1,14 @@
 package tx_test
 
 import (
+	"math/big"
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 )
 
@@ -25,3 +29,34 @@ func TestTxAddSignatureEmpty(t *testing.T) {
 	err := tx.AddSignatures([]xc.TxSignature{}...)
 	require.EqualError(t, err, "transaction not initialized")
 }
+
+func TestTxDisplayPayloadsEmpty(t *testing.T) {
+	tx := tx.Tx{}
+	_, err := tx.DisplayPayloads()
+	require.EqualError(t, err, "transaction not initialized")
+}
+
+func TestTxDisplayPayloads(t *testing.T) {
+	to := common.HexToAddress("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")
+	chainId := big.NewInt(5)
+	vectors := []struct {
+		name string
+		tx   *types.Transaction
+	}{
+		{"legacy", types.NewTransaction(1, to, big.NewInt(10), 21000, big.NewInt(100), []byte{1, 2})},
+		{"access_list", types.NewTx(&types.AccessListTx{ChainID: chainId, Nonce: 1, GasPrice: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(10)})},
+		{"dynamic_fee", types.NewTx(&types.DynamicFeeTx{ChainID: chainId, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(10), Data: []byte{1, 2}})},
+		{"contract_creation", types.NewTx(&types.DynamicFeeTx{ChainID: chainId, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, Data: []byte{1, 2}})},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			tx := tx.Tx{EthTx: v.tx, Signer: types.LatestSignerForChainID(chainId)}
+			payloads, err := tx.DisplayPayloads()
+			require.NoError(t, err)
+			require.Len(t, payloads, 1)
+			sighashes, err := tx.Sighashes()
+			require.NoError(t, err)
+			require.Equal(t, []byte(sighashes[0]), crypto.Keccak256(payloads[0]))
+		})
+	}
+}
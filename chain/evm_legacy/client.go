@@ -18,6 +18,8 @@ type Client struct {
 }
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
+var _ xclient.TokenMetadataClient = &Client{}
 
 type TxInput evminput.TxInput
 
@@ -59,6 +61,11 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 	}, nil
 }
 
+// FetchLatestHeight returns the latest block number of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return client.EvmClient.FetchLatestHeight(ctx)
+}
+
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	nativeAsset := client.EvmClient.Asset.GetChain()
 	zero := xc.NewAmountBlockchainFromUint64(0)
@@ -131,3 +138,7 @@ func (client *Client) FetchNativeBalance(ctx context.Context, address xc.Address
 func (client *Client) FetchBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
 	return client.EvmClient.FetchBalance(ctx, address)
 }
+
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	return client.EvmClient.FetchTokenMetadata(ctx, contract)
+}
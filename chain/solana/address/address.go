@@ -5,6 +5,7 @@ import (
 
 	"github.com/btcsuite/btcutil/base58"
 	xc "github.com/cordialsys/crosschain"
+	"github.com/gagliardetto/solana-go"
 )
 
 // AddressBuilder for Solana
@@ -34,3 +35,15 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress decodes the base58 public key.  Addresses off the ed25519 curve are program derived,
+// so no key can sign for them.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	publicKey, err := solana.PublicKeyFromBase58(string(address))
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	result := xc.ValidAddress(xc.Address(publicKey.String()), xc.AddressTypeDefault)
+	result.Contract = !publicKey.IsOnCurve()
+	return result
+}
@@ -6,6 +6,7 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/chain/solana/address"
+	"github.com/gagliardetto/solana-go"
 	"github.com/test-go/testify/require"
 )
 
@@ -44,3 +45,28 @@ func TestGetAllPossibleAddressesFromPublicKey(t *testing.T) {
 	require.Equal(t, xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb"), addresses[0].Address)
 	require.Equal(t, xc.AddressTypeDefault, addresses[0].Type)
 }
+
+func TestValidateAddress(t *testing.T) {
+	builder, _ := address.NewAddressBuilder(&xc.ChainConfig{})
+
+	result := builder.ValidateAddress("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	require.True(t, result.Valid, result.Reason)
+	require.Equal(t, xc.Address("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb"), result.Normalized)
+	require.False(t, result.Contract)
+
+	// associated token accounts are program derived
+	owner := solana.MustPublicKeyFromBase58("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9Ac2HzEXFSGtb")
+	mint := solana.MustPublicKeyFromBase58("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+	ata, _, err := solana.FindAssociatedTokenAddress(owner, mint)
+	require.NoError(t, err)
+	result = builder.ValidateAddress(xc.Address(ata.String()))
+	require.True(t, result.Valid, result.Reason)
+	require.True(t, result.Contract)
+
+	result = builder.ValidateAddress("Hzn3n914JaSpnxo5mBbmuCDmGL6mxWN9")
+	require.False(t, result.Valid)
+	require.NotEmpty(t, result.Reason)
+
+	result = builder.ValidateAddress("0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1")
+	require.False(t, result.Valid)
+}
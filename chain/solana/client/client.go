@@ -17,30 +17,48 @@ import (
 	"github.com/cordialsys/crosschain/chain/solana/tx_input"
 	"github.com/cordialsys/crosschain/chain/solana/types"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	"github.com/cordialsys/crosschain/utils"
 	bin "github.com/gagliardetto/binary"
 	"github.com/gagliardetto/solana-go"
 	"github.com/gagliardetto/solana-go/rpc"
+	"github.com/gagliardetto/solana-go/rpc/jsonrpc"
 )
 
 // Client for Solana
 type Client struct {
 	SolClient *rpc.Client
 	Asset     xc.ITask
+	cache     cache.Cache
 }
 
 var _ xclient.FullClient = &Client{}
 var _ xclient.StakingClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
+var _ cache.Cacheable = &Client{}
 
 // NewClient returns a new JSON-RPC Client to the Solana node
 func NewClient(cfgI xc.ITask) (*Client, error) {
 	cfg := cfgI.GetChain()
-	solClient := rpc.New(cfg.URL)
+	solClient := rpc.NewWithCustomRPCClient(jsonrpc.NewClientWithOpts(cfg.URL, &jsonrpc.RPCClientOpts{
+		HTTPClient: utils.NewHttpClient(cfg),
+	}))
 	return &Client{
 		SolClient: solClient,
 		Asset:     cfgI,
 	}, nil
 }
 
+// SetCache caches the token program of each mint, which never changes
+func (client *Client) SetCache(cache cache.Cache) {
+	client.cache = cache
+}
+
+// FetchLatestHeight returns the latest slot of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return client.SolClient.GetSlot(ctx, rpc.CommitmentConfirmed)
+}
+
 func (client *Client) FetchBaseInput(ctx context.Context, fromAddr xc.Address) (*tx_input.TxInput, error) {
 	txInput := tx_input.NewTxInput()
 
@@ -57,6 +75,18 @@ func (client *Client) FetchBaseInput(ctx context.Context, fromAddr xc.Address) (
 	return txInput, nil
 }
 
+// The token program that owns the mint, e.g. the token or token-2022 program
+func (client *Client) fetchTokenProgram(ctx context.Context, mint solana.PublicKey) (solana.PublicKey, error) {
+	key := cache.Key(client.Asset.GetChain().Chain, "token-program", mint.String())
+	return cache.GetOrFetch(ctx, client.cache, key, cache.NoExpiry, func() (solana.PublicKey, error) {
+		mintInfo, err := client.SolClient.GetAccountInfo(ctx, mint)
+		if err != nil {
+			return solana.PublicKey{}, err
+		}
+		return mintInfo.Value.Owner, nil
+	})
+}
+
 // FetchLegacyTxInput returns tx input for a Solana tx, namely a RecentBlockHash
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	txInput, err := client.FetchBaseInput(ctx, args.GetFrom())
@@ -83,11 +113,11 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	}
 
 	// determine token program for the token
-	mintInfo, err := client.SolClient.GetAccountInfo(ctx, mint)
+	tokenProgram, err := client.fetchTokenProgram(ctx, mint)
 	if err != nil {
 		return nil, err
 	}
-	txInput.TokenProgram = mintInfo.Value.Owner
+	txInput.TokenProgram = tokenProgram
 
 	// get account info - check if to is an owner or ata
 	accountTo, err := solana.PublicKeyFromBase58(string(args.GetTo()))
@@ -107,7 +137,7 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	// for tokens, get ata account info
 	ataTo := accountTo
 	if !txInput.ToIsATA {
-		ataToStr, err := types.FindAssociatedTokenAddress(string(args.GetTo()), contract, mintInfo.Value.Owner)
+		ataToStr, err := types.FindAssociatedTokenAddress(string(args.GetTo()), contract, tokenProgram)
 		if err != nil {
 			return nil, err
 		}
@@ -189,6 +219,7 @@ func (client *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, t
 }
 
 func (client *Client) SubmitTx(ctx context.Context, txInput xc.Tx) error {
+	ctx = utils.NonIdempotent(ctx)
 	txData, err := txInput.Serialize()
 	if err != nil {
 		return fmt.Errorf("send transaction: encode transaction: %w", err)
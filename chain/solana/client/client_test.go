@@ -2,6 +2,8 @@ package client_test
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -624,3 +626,66 @@ func TestFetchTxInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchTokenMetadata(t *testing.T) {
+	accountInfo := func(data []byte, owner string) string {
+		return fmt.Sprintf(`{"context":{"slot":274176079},"value":{"data":["%s","base64"],"executable":false,"lamports":1461600,"owner":"%s","rentEpoch":0}}`,
+			base64.StdEncoding.EncodeToString(data), owner)
+	}
+	// no mint authority, supply, decimals, initialized, no freeze authority
+	mint := make([]byte, 82)
+	mint[44] = 6
+	mint[45] = 1
+	mintInfo := accountInfo(mint, solana.TokenProgramID.String())
+
+	// fixed size strings padded with null bytes
+	padded := func(value string, size int) []byte {
+		bz := binary.LittleEndian.AppendUint32(nil, uint32(size))
+		return append(bz, append([]byte(value), make([]byte, size-len(value))...)...)
+	}
+	metadata := append([]byte{4}, make([]byte, 64)...)
+	metadata = append(metadata, padded("USD Coin", 32)...)
+	metadata = append(metadata, padded("USDC", 10)...)
+	metadata = append(metadata, padded("", 200)...)
+	metadataInfo := accountInfo(metadata, solana.TokenMetadataProgramID.String())
+
+	vectors := []struct {
+		name     string
+		resp     []string
+		expected *xcclient.TokenMetadata
+		err      string
+	}{
+		{
+			name:     "metaplex",
+			resp:     []string{mintInfo, metadataInfo},
+			expected: &xcclient.TokenMetadata{Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+		},
+		{
+			name:     "no_metadata",
+			resp:     []string{mintInfo, `{"context":{"slot":274176079},"value":null}`},
+			expected: &xcclient.TokenMetadata{Decimals: 6},
+		},
+		{
+			name: "no_mint",
+			resp: []string{`{"context":{"slot":274176079},"value":null}`},
+			err:  "could not get mint",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, close := testtypes.MockJSONRPC(t, v.resp)
+			defer close()
+			client, _ := client.NewClient(&xc.ChainConfig{URL: server.URL})
+			contract := xc.ContractAddress("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v")
+
+			metadata, err := client.FetchTokenMetadata(context.Background(), contract)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			v.expected.Contract = contract
+			require.Equal(t, v.expected, metadata)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/solana/types"
+	xclient "github.com/cordialsys/crosschain/client"
+	bin "github.com/gagliardetto/binary"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/token"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+// FetchTokenMetadata reads the decimals from the mint account, and the name and symbol from the
+// Metaplex metadata account of the mint if there is one.
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	mint, err := solana.PublicKeyFromBase58(string(contract))
+	if err != nil {
+		return nil, fmt.Errorf("invalid mint address: %s: %v", contract, err)
+	}
+	mintInfo, err := client.SolClient.GetAccountInfo(ctx, mint)
+	if err != nil {
+		return nil, fmt.Errorf("could not get mint %s: %v", contract, err)
+	}
+	var mintAccount token.Mint
+	if err := bin.NewBinDecoder(mintInfo.GetBinary()).Decode(&mintAccount); err != nil {
+		return nil, fmt.Errorf("could not decode mint %s: %v", contract, err)
+	}
+	metadata := &xclient.TokenMetadata{
+		Contract: contract,
+		Decimals: int32(mintAccount.Decimals),
+	}
+
+	metadataAddress, _, err := solana.FindTokenMetadataAddress(mint)
+	if err != nil {
+		return nil, err
+	}
+	metadataInfo, err := client.SolClient.GetAccountInfo(ctx, metadataAddress)
+	if err != nil {
+		if errors.Is(err, rpc.ErrNotFound) {
+			return metadata, nil
+		}
+		return nil, fmt.Errorf("could not get token metadata of %s: %v", contract, err)
+	}
+	tokenMetadata, err := types.ParseTokenMetadata(metadataInfo.GetBinary())
+	if err != nil {
+		return nil, err
+	}
+	metadata.Name = tokenMetadata.Name
+	metadata.Symbol = tokenMetadata.Symbol
+	return metadata, nil
+}
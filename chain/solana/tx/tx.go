@@ -47,6 +47,15 @@ func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
 	return []xc.TxDataToSign{messageContent}, nil
 }
 
+// DisplayPayloads returns the message, which ed25519 signs directly
+func (tx Tx) DisplayPayloads() ([]xc.TxDataToDisplay, error) {
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return nil, err
+	}
+	return []xc.TxDataToDisplay{xc.TxDataToDisplay(sighashes[0])}, nil
+}
+
 // Some instructions on solana require new accounts to sign the transaction
 // in addition to the funding account.  These are transient signers are not
 // sensitive and the key material only needs to live long enough to sign the transaction.
@@ -22,6 +22,19 @@ func TestTxSighashesErr(t *testing.T) {
 	require.Nil(t, sighashes)
 }
 
+func TestTxDisplayPayloads(t *testing.T) {
+	tx1 := tx.Tx{}
+	_, err := tx1.DisplayPayloads()
+	require.EqualError(t, err, "transaction not initialized")
+
+	tx1 = tx.Tx{SolTx: &solana.Transaction{}}
+	payloads, err := tx1.DisplayPayloads()
+	require.NoError(t, err)
+	sighashes, err := tx1.Sighashes()
+	require.NoError(t, err)
+	require.EqualValues(t, sighashes[0], payloads[0])
+}
+
 func TestTxAddSignatureErr(t *testing.T) {
 	tx1 := tx.Tx{}
 	err := tx1.AddSignatures([]xc.TxSignature{}...)
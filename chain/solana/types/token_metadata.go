@@ -0,0 +1,46 @@
+package types
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// The start of a Metaplex token metadata account
+type TokenMetadata struct {
+	Name   string
+	Symbol string
+	Uri    string
+}
+
+// ParseTokenMetadata decodes the data of a Metaplex token metadata account.  The name, symbol and uri
+// are fixed size strings padded with null bytes.
+func ParseTokenMetadata(data []byte) (*TokenMetadata, error) {
+	// key (1) + update authority (32) + mint (32)
+	offset := 1 + 32 + 32
+	readString := func() (string, error) {
+		if len(data) < offset+4 {
+			return "", errors.New("token metadata account is too short")
+		}
+		size := int(binary.LittleEndian.Uint32(data[offset:]))
+		offset += 4
+		if len(data) < offset+size {
+			return "", errors.New("token metadata account is too short")
+		}
+		value := strings.TrimRight(string(data[offset:offset+size]), "\x00")
+		offset += size
+		return value, nil
+	}
+	var metadata TokenMetadata
+	var err error
+	if metadata.Name, err = readString(); err != nil {
+		return nil, err
+	}
+	if metadata.Symbol, err = readString(); err != nil {
+		return nil, err
+	}
+	if metadata.Uri, err = readString(); err != nil {
+		return nil, err
+	}
+	return &metadata, nil
+}
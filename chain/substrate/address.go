@@ -44,3 +44,18 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress checks the SS58 checksum and that the network prefix matches the chain.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	prefix, publicKey, err := subkey.SS58Decode(string(address))
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	if prefix != ab.chainPrefix {
+		return xc.InvalidAddress("expected network prefix %d, got %d", ab.chainPrefix, prefix)
+	}
+	if len(publicKey) != 32 {
+		return xc.InvalidAddress("expected 32 byte public key, got %d", len(publicKey))
+	}
+	return xc.ValidAddress(xc.Address(subkey.SS58Encode(publicKey, prefix)), xc.AddressTypeDefault)
+}
@@ -46,6 +46,28 @@ func (s *CrosschainTestSuite) TestGetAllPossibleAddressesFromPublicKey() {
 	require.Equal(xc.AddressTypeDefault, addresses[0].Type)
 }
 
+func (s *CrosschainTestSuite) TestValidateAddress() {
+	require := s.Require()
+	builder, _ := substrate.NewAddressBuilder(&xc.ChainConfig{ChainPrefix: "0"})
+
+	result := builder.ValidateAddress("1a1LcBX6hGPKg5aQ6DXZpAHCCzWjckhea4sz3P1PvL3oc4F")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("1a1LcBX6hGPKg5aQ6DXZpAHCCzWjckhea4sz3P1PvL3oc4F"), result.Normalized)
+
+	result = builder.ValidateAddress("1a1LcBX6hGPKg5aQ6DXZpAHCCzWjckhea4sz3P1PvL3oc4G")
+	require.False(result.Valid)
+	require.NotEmpty(result.Reason)
+
+	// same key on another network
+	kusamaBuilder, _ := substrate.NewAddressBuilder(&xc.ChainConfig{ChainPrefix: "2"})
+	bytes, _ := hex.DecodeString("192c3c7e5789b461fbf1c7f614ba5eed0b22efc507cda60a5e7fda8e046bcdce")
+	kusamaAddress, err := kusamaBuilder.GetAddressFromPublicKey(bytes)
+	require.NoError(err)
+	result = builder.ValidateAddress(kusamaAddress)
+	require.False(result.Valid)
+	require.Equal("expected network prefix 0, got 2", result.Reason)
+}
+
 func (s *CrosschainTestSuite) TestSubstrateChainsHavePrefix() {
 	require := s.Require()
 
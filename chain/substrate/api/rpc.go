@@ -24,3 +24,49 @@ type AccountInfoMinimal struct {
 		// skip fields after this point as we don't need them
 	}
 }
+
+// Subset of pallet_assets::AssetAccount
+type AssetAccountMinimal struct {
+	Balance types.U128
+	// skip fields after this point as we don't need them
+}
+
+type UnbondingEra struct {
+	Era     types.U32
+	Balance types.U128
+}
+
+// pallet_nomination_pools::PoolMember
+type PoolMember struct {
+	PoolId                    types.U32
+	Points                    types.U128
+	LastRecordedRewardCounter types.U128
+	UnbondingEras             []UnbondingEra
+}
+
+type UnlockChunk struct {
+	Value types.UCompact
+	Era   types.UCompact
+}
+
+// Subset of pallet_staking::StakingLedger
+type StakingLedgerMinimal struct {
+	Stash     types.AccountID
+	Total     types.UCompact
+	Active    types.UCompact
+	Unlocking []UnlockChunk
+	// skip fields after this point as we don't need them
+}
+
+// Subset of pallet_staking::ActiveEraInfo
+type ActiveEraMinimal struct {
+	Index types.U32
+}
+
+// Subset of pallet_staking::slashing::SlashingSpans
+type SlashingSpansMinimal struct {
+	SpanIndex        types.U32
+	LastStart        types.U32
+	LastNonzeroSlash types.U32
+	Prior            []types.U32
+}
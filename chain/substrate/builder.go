@@ -2,6 +2,7 @@ package substrate
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
@@ -37,26 +38,40 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 // Old transfer interface
 func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
 	txInput := input.(*TxInput)
+	receiver, err := DecodeMultiAddress(to)
+	if err != nil {
+		return &Tx{}, err
+	}
+
+	var call types.Call
 	switch asset := txBuilder.Asset.(type) {
 	case *xc.ChainConfig:
-		// ok
+		// We use transfer_keep_alive to avoid accounts being reaped for sending too much balance that it no longer has the
+		// existential deposit. This would cause the account to get reaped, which can cause future TXs to have duped hashes
+		call, err = NewCall(&txInput.Meta, "Balances.transfer_keep_alive", receiver, types.NewUCompact(amount.Int()))
 	case *xc.TokenAssetConfig:
-		return nil, fmt.Errorf("NewTransfer not implemented for tokens on substrate yet")
+		call, err = txBuilder.newAssetTransferCall(txInput, asset.GetContract(), receiver, amount)
 	default:
 		return nil, fmt.Errorf("NewTransfer not implemented for %T", asset)
 	}
-	sender, err := types.NewMultiAddressFromAccountID(base58.Decode(string(from))[1:33])
 	if err != nil {
 		return &Tx{}, err
 	}
-	receiver, err := types.NewMultiAddressFromAccountID(base58.Decode(string(to))[1:33])
+
+	return txBuilder.newTx(from, call, txInput)
+}
+
+// Tokens on Asset Hub (e.g. USDT, USDC) are managed by the assets pallet, where the contract is the asset ID.
+func (txBuilder TxBuilder) newAssetTransferCall(txInput *TxInput, contract string, receiver types.MultiAddress, amount xc.AmountBlockchain) (types.Call, error) {
+	assetId, err := strconv.ParseUint(contract, 10, 32)
 	if err != nil {
-		return &Tx{}, err
+		return types.Call{}, fmt.Errorf("invalid substrate asset id '%s': %v", contract, err)
 	}
+	return NewCall(&txInput.Meta, "Assets.transfer_keep_alive", types.NewUCompactFromUInt(assetId), receiver, types.NewUCompact(amount.Int()))
+}
 
-	// We use transfer_keep_alive to avoid accounts being reaped for sending too much balance that it no longer has the
-	// existential deposit. This would cause the account to get reaped, which can cause future TXs to have duped hashes
-	call, err := NewCall(&txInput.Meta, "Balances.transfer_keep_alive", receiver, types.NewUCompactFromUInt(amount.Uint64()))
+func (txBuilder TxBuilder) newTx(from xc.Address, call types.Call, txInput *TxInput) (*Tx, error) {
+	sender, err := DecodeMultiAddress(from)
 	if err != nil {
 		return &Tx{}, err
 	}
@@ -69,3 +84,19 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 
 	return NewTx(extrinsic.NewDynamicExtrinsic(&call), sender, tip, txInput)
 }
+
+func DecodeAccountId(address xc.Address) ([]byte, error) {
+	decoded := base58.Decode(string(address))
+	if len(decoded) < 33 {
+		return nil, fmt.Errorf("invalid substrate address: %s", address)
+	}
+	return decoded[1:33], nil
+}
+
+func DecodeMultiAddress(address xc.Address) (types.MultiAddress, error) {
+	accountId, err := DecodeAccountId(address)
+	if err != nil {
+		return types.MultiAddress{}, err
+	}
+	return types.NewMultiAddressFromAccountID(accountId)
+}
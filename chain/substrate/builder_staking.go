@@ -0,0 +1,144 @@
+package substrate
+
+import (
+	"fmt"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+)
+
+var _ xcbuilder.Staking = &TxBuilder{}
+
+// https://paritytech.github.io/polkadot-sdk/master/pallet_staking/enum.RewardDestination.html
+var RewardDestinationStaked = types.NewU8(0)
+
+// https://paritytech.github.io/polkadot-sdk/master/pallet_nomination_pools/enum.BondExtra.html
+type BondExtraFreeBalance struct {
+	// FreeBalance is variant 0
+	Variant types.U8
+	Amount  types.U128
+}
+
+func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
+	stakeInput, ok := input.(*StakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &StakingInput{})
+	}
+	meta := &stakeInput.Meta
+	amount := args.GetAmount()
+
+	var call types.Call
+	var err error
+	switch stakeInput.Kind {
+	case PoolStaking:
+		if stakeInput.Bonded {
+			call, err = NewCall(meta, "NominationPools.bond_extra", BondExtraFreeBalance{types.NewU8(0), types.NewU128(*amount.Int())})
+		} else {
+			call, err = NewCall(meta, "NominationPools.join", types.NewUCompact(amount.Int()), types.NewU32(stakeInput.PoolId))
+		}
+	case DirectStaking:
+		call, err = newBondAndNominateCall(meta, stakeInput.Validator, amount, stakeInput.Bonded)
+	case BittensorStaking:
+		call, err = newBittensorStakeCall(meta, "SubtensorModule.add_stake", stakeInput.Validator, stakeInput.Netuid, amount)
+	default:
+		return nil, fmt.Errorf("unsupported substrate staking kind: %s", stakeInput.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newTx(args.GetFrom(), call, &stakeInput.TxInput)
+}
+
+// Unstake starts unbonding the amount, which may be withdrawn after the unbonding period.
+func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
+	unstakeInput, ok := input.(*UnstakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &UnstakingInput{})
+	}
+	meta := &unstakeInput.Meta
+	amount := args.GetAmount()
+
+	var call types.Call
+	var err error
+	switch unstakeInput.Kind {
+	case PoolStaking:
+		var member types.MultiAddress
+		member, err = DecodeMultiAddress(args.GetFrom())
+		if err == nil {
+			// pool points are 1:1 with the balance unless the pool has been slashed
+			call, err = NewCall(meta, "NominationPools.unbond", member, types.NewUCompact(amount.Int()))
+		}
+	case DirectStaking:
+		call, err = NewCall(meta, "Staking.unbond", types.NewUCompact(amount.Int()))
+	case BittensorStaking:
+		call, err = newBittensorStakeCall(meta, "SubtensorModule.remove_stake", unstakeInput.Validator, unstakeInput.Netuid, amount)
+	default:
+		return nil, fmt.Errorf("unsupported substrate staking kind: %s", unstakeInput.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newTx(args.GetFrom(), call, &unstakeInput.TxInput)
+}
+
+func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
+	withdrawInput, ok := input.(*WithdrawInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &WithdrawInput{})
+	}
+	meta := &withdrawInput.Meta
+
+	var call types.Call
+	var err error
+	switch withdrawInput.Kind {
+	case PoolStaking:
+		var member types.MultiAddress
+		member, err = DecodeMultiAddress(args.GetFrom())
+		if err == nil {
+			call, err = NewCall(meta, "NominationPools.withdraw_unbonded", member, types.NewU32(withdrawInput.NumSlashingSpans))
+		}
+	case DirectStaking:
+		call, err = NewCall(meta, "Staking.withdraw_unbonded", types.NewU32(withdrawInput.NumSlashingSpans))
+	default:
+		return nil, fmt.Errorf("withdrawing is not needed for substrate staking kind: %s", withdrawInput.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.newTx(args.GetFrom(), call, &withdrawInput.TxInput)
+}
+
+// Bonding and nominating are batched so the account is never left bonded without a nomination.
+func newBondAndNominateCall(meta *Metadata, validator xc.Address, amount xc.AmountBlockchain, bonded bool) (types.Call, error) {
+	target, err := DecodeMultiAddress(validator)
+	if err != nil {
+		return types.Call{}, err
+	}
+	var bond types.Call
+	if bonded {
+		bond, err = NewCall(meta, "Staking.bond_extra", types.NewUCompact(amount.Int()))
+	} else {
+		bond, err = NewCall(meta, "Staking.bond", types.NewUCompact(amount.Int()), RewardDestinationStaked)
+	}
+	if err != nil {
+		return types.Call{}, err
+	}
+	nominate, err := NewCall(meta, "Staking.nominate", []types.MultiAddress{target})
+	if err != nil {
+		return types.Call{}, err
+	}
+	return NewBatchAllCall(meta, bond, nominate)
+}
+
+func newBittensorStakeCall(meta *Metadata, method string, hotkey xc.Address, netuid uint16, amount xc.AmountBlockchain) (types.Call, error) {
+	hotkeyBz, err := DecodeAccountId(hotkey)
+	if err != nil {
+		return types.Call{}, fmt.Errorf("invalid hotkey: %v", err)
+	}
+	hotkeyId, err := types.NewAccountID(hotkeyBz)
+	if err != nil {
+		return types.Call{}, err
+	}
+	return NewCall(meta, method, *hotkeyId, types.NewU16(netuid), types.NewU64(amount.Uint64()))
+}
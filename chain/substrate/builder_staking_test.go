@@ -0,0 +1,206 @@
+package substrate_test
+
+import (
+	"encoding/json"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/extrinsic/extensions"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/substrate"
+)
+
+var stakingTestCalls = []*substrate.CallMeta{
+	{Name: "Balances.transfer_keep_alive", SectionIndex: 5, MethodIndex: 3},
+	{Name: "Assets.transfer_keep_alive", SectionIndex: 50, MethodIndex: 9},
+	{Name: "Utility.batch_all", SectionIndex: 26, MethodIndex: 2},
+	{Name: "Staking.bond", SectionIndex: 7, MethodIndex: 0},
+	{Name: "Staking.bond_extra", SectionIndex: 7, MethodIndex: 1},
+	{Name: "Staking.nominate", SectionIndex: 7, MethodIndex: 5},
+	{Name: "Staking.unbond", SectionIndex: 7, MethodIndex: 2},
+	{Name: "Staking.withdraw_unbonded", SectionIndex: 7, MethodIndex: 3},
+	{Name: "NominationPools.join", SectionIndex: 39, MethodIndex: 0},
+	{Name: "NominationPools.bond_extra", SectionIndex: 39, MethodIndex: 1},
+	{Name: "NominationPools.unbond", SectionIndex: 39, MethodIndex: 3},
+	{Name: "NominationPools.withdraw_unbonded", SectionIndex: 39, MethodIndex: 5},
+	{Name: "SubtensorModule.add_stake", SectionIndex: 7, MethodIndex: 2},
+	{Name: "SubtensorModule.remove_stake", SectionIndex: 7, MethodIndex: 3},
+}
+
+func newTestInput(s *CrosschainTestSuite) substrate.TxInput {
+	input := substrate.NewTxInput()
+	input.Meta = substrate.Metadata{
+		Calls:            stakingTestCalls,
+		SignedExtensions: []extensions.SignedExtensionName{"CheckNonZeroSender", "CheckSpecVersion", "CheckTxVersion", "CheckGenesis", "CheckMortality", "CheckNonce", "CheckWeight", "ChargeTransactionPayment"},
+	}
+	input.Nonce = 3
+	input.CurrentHeight = 100
+	return *input
+}
+
+func encodeArgs(s *CrosschainTestSuite, args ...interface{}) []byte {
+	bz := []byte{}
+	for _, arg := range args {
+		argBz, err := codec.Encode(arg)
+		s.Require().NoError(err)
+		bz = append(bz, argBz...)
+	}
+	return bz
+}
+
+func decodeCall(s *CrosschainTestSuite, tx xc.Tx) types.Call {
+	require := s.Require()
+	err := tx.AddSignatures(make([]byte, 64))
+	require.NoError(err)
+	bz, err := tx.Serialize()
+	require.NoError(err)
+	ext := types.Extrinsic{}
+	err = codec.Decode(bz, &ext)
+	require.NoError(err)
+	require.EqualValues(3, ext.Signature.Nonce.Int64())
+	return ext.Method
+}
+
+func (s *CrosschainTestSuite) TestNewAssetTransfer() {
+	require := s.Require()
+	chain := &xc.ChainConfig{Chain: xc.DOT, Decimals: 10}
+	builder, _ := substrate.NewTxBuilder(&xc.TokenAssetConfig{Contract: "1984", Decimals: 6, ChainConfig: chain})
+	from := xc.Address("5GL7deqCmoKpgmhq3b12DXSAu62VQ3DCqN3Z7Bet6fx9qAyb")
+	to := xc.Address("5FUh5YJztrDvQe58YcDr5rDhkx1kSZcxQFu81wamrPuVyZSW")
+	amount := xc.NewAmountBlockchainFromUint64(1_500_000)
+	input := newTestInput(s)
+
+	tx, err := builder.NewTransfer(from, to, amount, &input)
+	require.NoError(err)
+	call := decodeCall(s, tx)
+	require.Equal(types.CallIndex{SectionIndex: 50, MethodIndex: 9}, call.CallIndex)
+	receiver, _ := substrate.DecodeMultiAddress(to)
+	require.Equal(encodeArgs(s, types.NewUCompactFromUInt(1984), receiver, types.NewUCompactFromUInt(1_500_000)), []byte(call.Args))
+
+	builder, _ = substrate.NewTxBuilder(&xc.TokenAssetConfig{Contract: "not-an-id", ChainConfig: chain})
+	_, err = builder.NewTransfer(from, to, amount, &input)
+	require.ErrorContains(err, "invalid substrate asset id")
+}
+
+func (s *CrosschainTestSuite) TestStake() {
+	require := s.Require()
+	builder, _ := substrate.NewTxBuilder(&xc.ChainConfig{Chain: xc.DOT, Decimals: 10})
+	from := xc.Address("5GL7deqCmoKpgmhq3b12DXSAu62VQ3DCqN3Z7Bet6fx9qAyb")
+	validator := xc.Address("5FUh5YJztrDvQe58YcDr5rDhkx1kSZcxQFu81wamrPuVyZSW")
+	validatorAddress, _ := substrate.DecodeMultiAddress(validator)
+	validatorBz, _ := substrate.DecodeAccountId(validator)
+	validatorId, _ := types.NewAccountID(validatorBz)
+	amount := xc.NewAmountBlockchainFromUint64(10_000_000_000)
+	args, err := xcbuilder.NewStakeArgs(xc.DOT, from, amount)
+	require.NoError(err)
+
+	type testcase struct {
+		name          string
+		input         substrate.StakingInput
+		expectedIndex types.CallIndex
+		expectedArgs  []byte
+	}
+	for _, tc := range []testcase{
+		{
+			name:          "join pool",
+			input:         substrate.StakingInput{Kind: substrate.PoolStaking, PoolId: 12},
+			expectedIndex: types.CallIndex{SectionIndex: 39, MethodIndex: 0},
+			expectedArgs:  encodeArgs(s, types.NewUCompactFromUInt(10_000_000_000), types.NewU32(12)),
+		},
+		{
+			name:          "bond extra to pool",
+			input:         substrate.StakingInput{Kind: substrate.PoolStaking, PoolId: 12, Bonded: true},
+			expectedIndex: types.CallIndex{SectionIndex: 39, MethodIndex: 1},
+			expectedArgs:  encodeArgs(s, types.NewU8(0), types.NewU128(*amount.Int())),
+		},
+		{
+			name:          "bond and nominate",
+			input:         substrate.StakingInput{Kind: substrate.DirectStaking, Validator: validator},
+			expectedIndex: types.CallIndex{SectionIndex: 26, MethodIndex: 2},
+			expectedArgs: encodeArgs(s,
+				// vec of 2 calls
+				types.NewUCompactFromUInt(2),
+				types.CallIndex{SectionIndex: 7, MethodIndex: 0}, types.NewUCompactFromUInt(10_000_000_000), types.NewU8(0),
+				types.CallIndex{SectionIndex: 7, MethodIndex: 5}, []types.MultiAddress{validatorAddress},
+			),
+		},
+		{
+			name:          "bond extra and nominate",
+			input:         substrate.StakingInput{Kind: substrate.DirectStaking, Validator: validator, Bonded: true},
+			expectedIndex: types.CallIndex{SectionIndex: 26, MethodIndex: 2},
+			expectedArgs: encodeArgs(s,
+				types.NewUCompactFromUInt(2),
+				types.CallIndex{SectionIndex: 7, MethodIndex: 1}, types.NewUCompactFromUInt(10_000_000_000),
+				types.CallIndex{SectionIndex: 7, MethodIndex: 5}, []types.MultiAddress{validatorAddress},
+			),
+		},
+		{
+			name:          "bittensor",
+			input:         substrate.StakingInput{Kind: substrate.BittensorStaking, Validator: validator, Netuid: 1},
+			expectedIndex: types.CallIndex{SectionIndex: 7, MethodIndex: 2},
+			expectedArgs:  encodeArgs(s, *validatorId, types.NewU16(1), types.NewU64(10_000_000_000)),
+		},
+	} {
+		s.Run(tc.name, func() {
+			require := s.Require()
+			input := tc.input
+			input.TxInput = newTestInput(s)
+			tx, err := builder.Stake(args, &input)
+			require.NoError(err)
+			call := decodeCall(s, tx)
+			require.Equal(tc.expectedIndex, call.CallIndex)
+			require.Equal(tc.expectedArgs, []byte(call.Args))
+		})
+	}
+
+	_, err = builder.Stake(args, &substrate.StakingInput{TxInput: newTestInput(s), Kind: "other"})
+	require.ErrorContains(err, "unsupported substrate staking kind")
+}
+
+func (s *CrosschainTestSuite) TestUnstakeAndWithdraw() {
+	require := s.Require()
+	builder, _ := substrate.NewTxBuilder(&xc.ChainConfig{Chain: xc.DOT, Decimals: 10})
+	from := xc.Address("5GL7deqCmoKpgmhq3b12DXSAu62VQ3DCqN3Z7Bet6fx9qAyb")
+	member, _ := substrate.DecodeMultiAddress(from)
+	amount := xc.NewAmountBlockchainFromUint64(5_000_000_000)
+	args, err := xcbuilder.NewStakeArgs(xc.DOT, from, amount)
+	require.NoError(err)
+
+	tx, err := builder.Unstake(args, &substrate.UnstakingInput{TxInput: newTestInput(s), Kind: substrate.PoolStaking})
+	require.NoError(err)
+	call := decodeCall(s, tx)
+	require.Equal(types.CallIndex{SectionIndex: 39, MethodIndex: 3}, call.CallIndex)
+	require.Equal(encodeArgs(s, member, types.NewUCompactFromUInt(5_000_000_000)), []byte(call.Args))
+
+	tx, err = builder.Unstake(args, &substrate.UnstakingInput{TxInput: newTestInput(s), Kind: substrate.DirectStaking})
+	require.NoError(err)
+	call = decodeCall(s, tx)
+	require.Equal(types.CallIndex{SectionIndex: 7, MethodIndex: 2}, call.CallIndex)
+	require.Equal(encodeArgs(s, types.NewUCompactFromUInt(5_000_000_000)), []byte(call.Args))
+
+	tx, err = builder.Withdraw(args, &substrate.WithdrawInput{TxInput: newTestInput(s), Kind: substrate.PoolStaking})
+	require.NoError(err)
+	call = decodeCall(s, tx)
+	require.Equal(types.CallIndex{SectionIndex: 39, MethodIndex: 5}, call.CallIndex)
+	require.Equal(encodeArgs(s, member, types.NewU32(0)), []byte(call.Args))
+
+	tx, err = builder.Withdraw(args, &substrate.WithdrawInput{TxInput: newTestInput(s), Kind: substrate.DirectStaking, NumSlashingSpans: 2})
+	require.NoError(err)
+	call = decodeCall(s, tx)
+	require.Equal(types.CallIndex{SectionIndex: 7, MethodIndex: 3}, call.CallIndex)
+	require.Equal(encodeArgs(s, types.NewU32(2)), []byte(call.Args))
+
+	_, err = builder.Withdraw(args, &substrate.WithdrawInput{TxInput: newTestInput(s), Kind: substrate.BittensorStaking})
+	require.ErrorContains(err, "withdrawing is not needed")
+}
+
+func (s *CrosschainTestSuite) TestStakingInputSerialization() {
+	require := s.Require()
+	input := &substrate.StakingInput{TxInput: newTestInput(s), Kind: substrate.PoolStaking, PoolId: 7, Bonded: true}
+	bz, err := json.Marshal(input)
+	require.NoError(err)
+	decoded := &substrate.StakingInput{}
+	require.NoError(json.Unmarshal(bz, decoded))
+	require.Equal(input, decoded)
+}
@@ -13,6 +13,19 @@ import (
 var usedSubstrateCalls = []string{
 	"Balances.transfer_keep_alive",
 	"Assets.transfer",
+	"Assets.transfer_keep_alive",
+	"Utility.batch_all",
+	"Staking.bond",
+	"Staking.bond_extra",
+	"Staking.nominate",
+	"Staking.unbond",
+	"Staking.withdraw_unbonded",
+	"NominationPools.join",
+	"NominationPools.bond_extra",
+	"NominationPools.unbond",
+	"NominationPools.withdraw_unbonded",
+	"SubtensorModule.add_stake",
+	"SubtensorModule.remove_stake",
 }
 
 type CallMeta struct {
@@ -25,6 +38,11 @@ type Metadata struct {
 	SignedExtensions []extensions.SignedExtensionName `json:"signed_extensions"`
 }
 
+func (m *Metadata) HasCall(name string) bool {
+	_, err := m.FindCallIndex(name)
+	return err == nil
+}
+
 func (m *Metadata) FindCallIndex(name string) (types.CallIndex, error) {
 	for _, call := range m.Calls {
 		if call.Name == name {
@@ -83,6 +101,11 @@ func NewCall(m *Metadata, call string, args ...interface{}) (types.Call, error)
 	return types.Call{CallIndex: c, Args: a}, nil
 }
 
+// NewBatchAllCall groups the calls into a single call that succeeds or fails atomically.
+func NewBatchAllCall(m *Metadata, calls ...types.Call) (types.Call, error) {
+	return NewCall(m, "Utility.batch_all", calls)
+}
+
 var LocalPayloadMutatorFns = map[extensions.SignedExtensionName]extrinsic.PayloadMutatorFn{
 	// do nothing
 	"SubtensorSignedExtension":   func(payload *extrinsic.Payload) {},
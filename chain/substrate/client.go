@@ -8,10 +8,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/btcsuite/btcutil/base58"
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gsrpcclient "github.com/centrifuge/go-substrate-rpc-client/v4/client"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	xc "github.com/cordialsys/crosschain"
@@ -19,6 +23,7 @@ import (
 	"github.com/cordialsys/crosschain/chain/substrate/api"
 	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/factory/drivers/registry"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/shopspring/decimal"
 	"github.com/sirupsen/logrus"
 )
@@ -34,6 +39,7 @@ type Client struct {
 const IndexerSubQuery = "subquery"
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 
 // TxInput for Substrate
 type TxInput struct {
@@ -88,15 +94,13 @@ func (input *TxInput) SafeFromDoubleSend(others ...xc.TxInput) (safe bool) {
 
 // NewClient returns a new Substrate Client
 func NewClient(cfgI xc.ITask) (*Client, error) {
-	rpcurl := cfgI.GetChain().URL
-
 	txInfoClientI, err := NewTxInfoClient(cfgI)
 	if err != nil {
 		return nil, err
 	}
 	txInfoClient := txInfoClientI.(*Client)
 
-	client, err := gsrpc.NewSubstrateAPI(rpcurl)
+	client, err := dialSubstrate(cfgI.GetChain())
 	return &Client{
 		DotClient:  client,
 		Asset:      cfgI,
@@ -105,6 +109,47 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 	}, err
 }
 
+// FetchLatestHeight returns the latest block number of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	header, err := client.DotClient.RPC.Chain.GetHeaderLatest()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(header.Number), nil
+}
+
+var _ gsrpcclient.Client = &httpRpcClient{}
+
+// Implements the gsrpc client, so we can connect using our own http client
+type httpRpcClient struct {
+	*gethrpc.Client
+	url string
+}
+
+func (c *httpRpcClient) URL() string {
+	return c.url
+}
+
+func dialSubstrate(cfg *xc.ChainConfig) (*gsrpc.SubstrateAPI, error) {
+	if !strings.HasPrefix(cfg.URL, "http://") && !strings.HasPrefix(cfg.URL, "https://") {
+		// websocket
+		return gsrpc.NewSubstrateAPI(cfg.URL)
+	}
+	rpcClient, err := gethrpc.DialHTTPWithClient(cfg.URL, utils.NewHttpClient(cfg))
+	if err != nil {
+		return nil, err
+	}
+	cl := &httpRpcClient{rpcClient, cfg.URL}
+	newRPC, err := rpc.NewRPC(cl)
+	if err != nil {
+		return nil, err
+	}
+	return &gsrpc.SubstrateAPI{
+		RPC:    newRPC,
+		Client: cl,
+	}, nil
+}
+
 type TxInfoClient interface {
 	// Fetching transaction info - legacy endpoint
 	FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (xc.LegacyTxInfo, error)
@@ -257,7 +302,7 @@ func (client *Client) SubmitTx(ctx context.Context, txInput xc.Tx) error {
 	var res string
 	encoded := codec.HexEncodeToString(data)
 	logrus.WithField("tx", encoded).Debug("submitting tx")
-	err = client.DotClient.Client.Call(&res, "author_submitExtrinsic", encoded)
+	err = client.DotClient.Client.CallContext(utils.NonIdempotent(ctx), &res, "author_submitExtrinsic", encoded)
 	if err != nil {
 		return AsRpcErrorMaybe(err)
 	}
@@ -275,8 +320,7 @@ func (client *Client) post(ctx context.Context, url string, inputJson []byte, ou
 		req.Header.Add("X-API-Key", client.apiKey)
 	}
 
-	explorerClient := &http.Client{}
-	resp, err := explorerClient.Do(req)
+	resp, err := utils.NewHttpClient(client.Asset.GetChain()).Do(req)
 	if err != nil {
 		return err
 	}
@@ -452,8 +496,32 @@ func (client *Client) FetchBalance(ctx context.Context, address xc.Address) (xc.
 	if client.Asset.GetContract() == "" {
 		return client.FetchNativeBalance(ctx, address)
 	} else {
-		return xc.AmountBlockchain{}, errors.New("unsupported asset")
+		return client.FetchAssetBalance(ctx, address)
+	}
+}
+
+// FetchAssetBalance fetches the balance of an asset managed by the assets pallet, where the contract is the asset ID
+func (client *Client) FetchAssetBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	zero := xc.NewAmountBlockchainFromUint64(0)
+	assetId, err := strconv.ParseUint(client.Asset.GetContract(), 10, 32)
+	if err != nil {
+		return zero, fmt.Errorf("invalid substrate asset id '%s': %v", client.Asset.GetContract(), err)
+	}
+	accountId, err := DecodeAccountId(address)
+	if err != nil {
+		return zero, err
+	}
+	meta, err := client.DotClient.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return zero, err
+	}
+
+	var assetAccount api.AssetAccountMinimal
+	ok, err := client.getStorage(meta, "Assets", "Account", &assetAccount, encodeU32(uint32(assetId)), accountId)
+	if err != nil || !ok {
+		return zero, err
 	}
+	return xc.AmountBlockchain(*assetAccount.Balance.Int), nil
 }
 
 // EstimateTip looks at the latest extrinsics to try to calculate an average tip paid
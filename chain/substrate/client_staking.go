@@ -0,0 +1,281 @@
+package substrate
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/substrate/api"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.StakingClient = &Client{}
+
+// getStorage reads a storage item, returning false if it does not exist.
+func (client *Client) getStorage(meta *types.Metadata, pallet string, item string, target interface{}, args ...[]byte) (bool, error) {
+	key, err := types.CreateStorageKey(meta, pallet, item, args...)
+	if err != nil {
+		return false, err
+	}
+	return client.DotClient.RPC.State.GetStorageLatest(key, target)
+}
+
+func encodeU32(value uint32) []byte {
+	bz := make([]byte, 4)
+	binary.LittleEndian.PutUint32(bz, value)
+	return bz
+}
+
+// The staking kind is inferred from the chain and the validator option:
+//   - bittensor chains stake to a hotkey
+//   - a numeric validator is the ID of a nomination pool to join
+//   - otherwise the validator is nominated directly
+//
+// When no validator is given, an existing pool membership is used.
+func (client *Client) resolveStakingKind(meta *types.Metadata, txInput *TxInput, from []byte, validator string) (StakingKind, *api.PoolMember, error) {
+	if txInput.Meta.HasCall("SubtensorModule.add_stake") {
+		return BittensorStaking, nil, nil
+	}
+	member := &api.PoolMember{}
+	isMember, err := client.getStorage(meta, "NominationPools", "PoolMembers", member, from)
+	if err != nil {
+		return "", nil, err
+	}
+	if !isMember {
+		member = nil
+	}
+	if validator == "" {
+		if member != nil {
+			return PoolStaking, member, nil
+		}
+		return DirectStaking, nil, nil
+	}
+	if _, err := strconv.ParseUint(validator, 10, 32); err == nil {
+		return PoolStaking, member, nil
+	}
+	return DirectStaking, member, nil
+}
+
+func (client *Client) fetchStakingBaseInput(ctx context.Context, from xc.Address) (*types.Metadata, *TxInput, error) {
+	meta, txInput, err := client.FetchTxInputChain()
+	if err != nil {
+		return nil, nil, err
+	}
+	txInput.Nonce, err = client.FetchAccountNonce(*meta, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	txInput.Tip, _ = client.EstimateTip(ctx)
+	return meta, txInput, nil
+}
+
+func (client *Client) FetchStakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.StakeTxInput, error) {
+	from, err := DecodeAccountId(args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	meta, txInput, err := client.fetchStakingBaseInput(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	validator, _ := args.GetValidator()
+	kind, member, err := client.resolveStakingKind(meta, txInput, from, validator)
+	if err != nil {
+		return nil, err
+	}
+	input := &StakingInput{
+		TxInput:   *txInput,
+		Kind:      kind,
+		Validator: xc.Address(validator),
+	}
+	switch kind {
+	case BittensorStaking:
+		if validator == "" {
+			return nil, fmt.Errorf("must provide the hotkey to stake to as the validator")
+		}
+		input.Netuid, _ = args.GetSubnet()
+	case PoolStaking:
+		if member != nil {
+			if validator != "" && validator != strconv.Itoa(int(member.PoolId)) {
+				return nil, fmt.Errorf("account is already a member of nomination pool %d", member.PoolId)
+			}
+			input.PoolId = uint32(member.PoolId)
+			input.Bonded = true
+		} else {
+			poolId, _ := strconv.ParseUint(validator, 10, 32)
+			input.PoolId = uint32(poolId)
+		}
+		input.Validator = ""
+	case DirectStaking:
+		if validator == "" {
+			return nil, fmt.Errorf("must provide a validator to nominate, or a nomination pool ID to join")
+		}
+		if member != nil {
+			return nil, fmt.Errorf("account is a member of nomination pool %d and cannot also stake directly", member.PoolId)
+		}
+		var controller types.AccountID
+		input.Bonded, err = client.getStorage(meta, "Staking", "Bonded", &controller, from)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+func (client *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.UnstakeTxInput, error) {
+	from, err := DecodeAccountId(args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	meta, txInput, err := client.fetchStakingBaseInput(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	validator, _ := args.GetValidator()
+	kind, _, err := client.resolveStakingKind(meta, txInput, from, validator)
+	if err != nil {
+		return nil, err
+	}
+	input := &UnstakingInput{
+		TxInput: *txInput,
+		Kind:    kind,
+	}
+	if kind == BittensorStaking {
+		if validator == "" {
+			return nil, fmt.Errorf("must provide the hotkey to unstake from as the validator")
+		}
+		input.Validator = xc.Address(validator)
+		input.Netuid, _ = args.GetSubnet()
+	}
+	return input, nil
+}
+
+func (client *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.WithdrawTxInput, error) {
+	from, err := DecodeAccountId(args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	meta, txInput, err := client.fetchStakingBaseInput(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	validator, _ := args.GetValidator()
+	kind, _, err := client.resolveStakingKind(meta, txInput, from, validator)
+	if err != nil {
+		return nil, err
+	}
+	input := &WithdrawInput{
+		TxInput: *txInput,
+		Kind:    kind,
+	}
+	if kind == DirectStaking {
+		var spans api.SlashingSpansMinimal
+		slashed, err := client.getStorage(meta, "Staking", "SlashingSpans", &spans, from)
+		if err != nil {
+			return nil, err
+		}
+		if slashed {
+			input.NumSlashingSpans = uint32(len(spans.Prior) + 1)
+		}
+	}
+	return input, nil
+}
+
+// FetchStakeBalance reports stake in a nomination pool, with the pool ID as the validator, and stake
+// bonded directly.  Unbonding chunks are inactive once the active era passes their unlock era.
+func (client *Client) FetchStakeBalance(ctx context.Context, args xclient.StakedBalanceArgs) ([]*xclient.StakedBalance, error) {
+	from, err := DecodeAccountId(args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	meta, err := client.DotClient.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := ParseMeta(meta)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.HasCall("SubtensorModule.add_stake") {
+		return nil, fmt.Errorf("fetching stake balances is not supported for bittensor")
+	}
+
+	var activeEra api.ActiveEraMinimal
+	_, err = client.getStorage(meta, "Staking", "ActiveEra", &activeEra)
+	if err != nil {
+		return nil, err
+	}
+	balances := []*xclient.StakedBalance{}
+
+	member := &api.PoolMember{}
+	isMember, err := client.getStorage(meta, "NominationPools", "PoolMembers", member, from)
+	if err != nil {
+		return nil, err
+	}
+	if isMember {
+		// pool points are reported as balance, which are 1:1 unless the pool has been slashed
+		state := xclient.StakedBalanceState{
+			Active: xc.AmountBlockchain(*member.Points.Int),
+		}
+		for _, unbonding := range member.UnbondingEras {
+			addUnbonding(&state, uint32(unbonding.Era), unbonding.Balance.Int, uint32(activeEra.Index))
+		}
+		balances = append(balances, xclient.NewStakedBalances(state, strconv.Itoa(int(member.PoolId)), ""))
+	}
+
+	var controller types.AccountID
+	bonded, err := client.getStorage(meta, "Staking", "Bonded", &controller, from)
+	if err != nil {
+		return nil, err
+	}
+	if bonded {
+		var ledger api.StakingLedgerMinimal
+		_, err = client.getStorage(meta, "Staking", "Ledger", &ledger, controller[:])
+		if err != nil {
+			return nil, err
+		}
+		state := xclient.StakedBalanceState{
+			Active: xc.AmountBlockchain(ledger.Active),
+		}
+		for _, chunk := range ledger.Unlocking {
+			era := (*big.Int)(&chunk.Era)
+			addUnbonding(&state, uint32(era.Uint64()), (*big.Int)(&chunk.Value), uint32(activeEra.Index))
+		}
+		validator := ""
+		var nominations struct {
+			Targets []types.AccountID
+		}
+		nominating, err := client.getStorage(meta, "Staking", "Nominators", &nominations, from)
+		if err != nil {
+			return nil, err
+		}
+		if nominating && len(nominations.Targets) == 1 {
+			validator, _ = client.encodeAccountId(nominations.Targets[0])
+		}
+		balances = append(balances, xclient.NewStakedBalances(state, validator, ""))
+	}
+	return balances, nil
+}
+
+func addUnbonding(state *xclient.StakedBalanceState, era uint32, amount *big.Int, activeEra uint32) {
+	value := xc.AmountBlockchain(*amount)
+	if era > activeEra {
+		state.Deactivating = state.Deactivating.Add(&value)
+	} else {
+		state.Inactive = state.Inactive.Add(&value)
+	}
+}
+
+func (client *Client) encodeAccountId(accountId types.AccountID) (string, error) {
+	addressBuilder, err := NewAddressBuilder(client.Asset)
+	if err != nil {
+		return "", err
+	}
+	address, err := addressBuilder.GetAddressFromPublicKey(accountId[:])
+	return string(address), err
+}
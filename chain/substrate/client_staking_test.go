@@ -0,0 +1,58 @@
+package substrate_test
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/substrate"
+	"github.com/cordialsys/crosschain/chain/substrate/api"
+	xclient "github.com/cordialsys/crosschain/client"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+)
+
+const nullRpcResult = `{"jsonrpc":"2.0", "result":null,"id":1}`
+
+func (s *CrosschainTestSuite) TestFetchStakeBalance() {
+	require := s.Require()
+	member := api.PoolMember{
+		PoolId:                    types.NewU32(12),
+		Points:                    types.NewU128(*big.NewInt(100)),
+		LastRecordedRewardCounter: types.NewU128(*big.NewInt(0)),
+		UnbondingEras: []api.UnbondingEra{
+			{Era: types.NewU32(5), Balance: types.NewU128(*big.NewInt(20))},
+			{Era: types.NewU32(10), Balance: types.NewU128(*big.NewInt(30))},
+		},
+	}
+	rpc, rpcClose := testtypes.MockJSONRPC(s.T(), []string{
+		RPC_META_RESPONSE,
+		RPC_META_RESPONSE,
+		// active era
+		asScaleRpcResult(api.ActiveEraMinimal{Index: types.NewU32(8)}),
+		// pool member
+		asScaleRpcResult(member),
+		// not bonded directly
+		nullRpcResult,
+	})
+	defer rpcClose()
+
+	client, err := substrate.NewClient(&xc.ChainConfig{
+		Chain:       "DOT",
+		Driver:      "substrate",
+		URL:         rpc.URL,
+		IndexerUrl:  "aaa",
+		AuthSecret:  "aaa",
+		ChainPrefix: "0",
+		Decimals:    10,
+	})
+	require.NoError(err)
+
+	args, _ := xclient.NewStakeBalanceArgs("12nr7GiDrYHzAYT9L8HdeXnMfWcBuYfAXpgfzf3upujeCciz")
+	balances, err := client.FetchStakeBalance(s.Ctx, args)
+	require.NoError(err)
+	require.Len(balances, 1)
+	require.Equal("12", balances[0].Validator)
+	require.Equal("100", balances[0].Balance.Active.String())
+	require.Equal("30", balances[0].Balance.Deactivating.String())
+	require.Equal("20", balances[0].Balance.Inactive.String())
+}
@@ -302,11 +302,17 @@ func (s *CrosschainTestSuite) TestFetchTxInput() {
 
 	expectedMeta := substrate.Metadata{
 		Calls: []*substrate.CallMeta{
-			{
-				Name:         "Balances.transfer_keep_alive",
-				SectionIndex: 5,
-				MethodIndex:  3,
-			},
+			{Name: "Balances.transfer_keep_alive", SectionIndex: 5, MethodIndex: 3},
+			{Name: "Utility.batch_all", SectionIndex: 26, MethodIndex: 2},
+			{Name: "Staking.bond", SectionIndex: 7, MethodIndex: 0},
+			{Name: "Staking.bond_extra", SectionIndex: 7, MethodIndex: 1},
+			{Name: "Staking.nominate", SectionIndex: 7, MethodIndex: 5},
+			{Name: "Staking.unbond", SectionIndex: 7, MethodIndex: 2},
+			{Name: "Staking.withdraw_unbonded", SectionIndex: 7, MethodIndex: 3},
+			{Name: "NominationPools.join", SectionIndex: 39, MethodIndex: 0},
+			{Name: "NominationPools.bond_extra", SectionIndex: 39, MethodIndex: 1},
+			{Name: "NominationPools.unbond", SectionIndex: 39, MethodIndex: 3},
+			{Name: "NominationPools.withdraw_unbonded", SectionIndex: 39, MethodIndex: 5},
 		},
 		SignedExtensions: []extensions.SignedExtensionName{
 			"CheckNonZeroSender",
@@ -0,0 +1,82 @@
+package substrate
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+)
+
+// StakingKind is the way stake is delegated on a substrate chain
+type StakingKind string
+
+const (
+	// Nominating validators directly using the staking pallet
+	DirectStaking StakingKind = "direct"
+	// Joining a nomination pool, which nominates on behalf of its members
+	PoolStaking StakingKind = "pool"
+	// Bittensor stakes to a hotkey in a subnet
+	BittensorStaking StakingKind = "bittensor"
+)
+
+func init() {
+	registry.RegisterTxVariantInput(&StakingInput{})
+	registry.RegisterTxVariantInput(&UnstakingInput{})
+	registry.RegisterTxVariantInput(&WithdrawInput{})
+}
+
+type StakingInput struct {
+	TxInput
+	Kind StakingKind `json:"kind"`
+	// The nomination pool to join
+	PoolId uint32 `json:"pool_id,omitempty"`
+	// The validator to nominate, or the hotkey for bittensor
+	Validator xc.Address `json:"validator,omitempty"`
+	// Set if the account is already bonded, or is already a member of the pool
+	Bonded bool `json:"bonded,omitempty"`
+	// The bittensor subnet, set using the subnet option.  The root network is 0.
+	Netuid uint16 `json:"netuid,omitempty"`
+}
+
+var _ xc.TxVariantInput = &StakingInput{}
+var _ xc.StakeTxInput = &StakingInput{}
+
+func (*StakingInput) Staking() {}
+
+func (*StakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewStakingInputType(xc.DriverSubstrate, string(xc.Native))
+}
+
+type UnstakingInput struct {
+	TxInput
+	Kind StakingKind `json:"kind"`
+	// The hotkey for bittensor
+	Validator xc.Address `json:"validator,omitempty"`
+	// The bittensor subnet
+	Netuid uint16 `json:"netuid,omitempty"`
+}
+
+var _ xc.TxVariantInput = &UnstakingInput{}
+var _ xc.UnstakeTxInput = &UnstakingInput{}
+
+func (*UnstakingInput) Unstaking() {}
+
+func (*UnstakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewUnstakingInputType(xc.DriverSubstrate, string(xc.Native))
+}
+
+// Withdraws unbonded stake that has passed the unbonding period.  Bittensor stake is available
+// immediately after unstaking, so it does not need to be withdrawn.
+type WithdrawInput struct {
+	TxInput
+	Kind StakingKind `json:"kind"`
+	// Needed by the staking pallet to clean up the stash if it's fully unbonded
+	NumSlashingSpans uint32 `json:"num_slashing_spans,omitempty"`
+}
+
+var _ xc.TxVariantInput = &WithdrawInput{}
+var _ xc.WithdrawTxInput = &WithdrawInput{}
+
+func (*WithdrawInput) Withdrawing() {}
+
+func (*WithdrawInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewWithdrawingInputType(xc.DriverSubstrate, string(xc.Native))
+}
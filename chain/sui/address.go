@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 
 	xc "github.com/cordialsys/crosschain"
 	"golang.org/x/crypto/blake2b"
@@ -53,3 +54,16 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress accepts 32 byte hex addresses, padding shortened ones (e.g. 0x2) with leading zeros.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	hexS := strings.TrimPrefix(strings.ToLower(string(address)), "0x")
+	if len(hexS) == 0 || len(hexS) > ADDRESS_LENGTH {
+		return xc.InvalidAddress("expected 1 to %d hex characters, got %d", ADDRESS_LENGTH, len(hexS))
+	}
+	hexS = fmt.Sprintf("%0*s", ADDRESS_LENGTH, hexS)
+	if _, err := hex.DecodeString(hexS); err != nil {
+		return xc.InvalidAddress("invalid hex: %v", err)
+	}
+	return xc.ValidAddress(xc.Address("0x"+hexS), xc.AddressTypeDefault)
+}
@@ -56,3 +56,24 @@ func (s *CrosschainTestSuite) TestGetAllPossibleAddressesFromPublicKey() {
 	require.Equal(xc.Address("0x086d8e59c3ef72ccc8cbf74c55e7f611b0ee9eba788c7153924c4e4a32449a8e"), addresses[0].Address)
 	require.Equal(xc.AddressTypeDefault, addresses[0].Type)
 }
+
+func (s *CrosschainTestSuite) TestValidateAddress() {
+	require := s.Require()
+	builder, _ := sui.NewAddressBuilder(&xc.ChainConfig{})
+
+	result := builder.ValidateAddress("0xBB8A8269CF96BA2B8FB0C0AF4EB42F42D2A8F2F13B1C0E4F9B5B36BA1C4C2E21")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("0xbb8a8269cf96ba2b8fb0c0af4eb42f42d2a8f2f13b1c0e4f9b5b36ba1c4c2e21"), result.Normalized)
+
+	result = builder.ValidateAddress("0x2")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("0x0000000000000000000000000000000000000000000000000000000000000002"), result.Normalized)
+
+	result = builder.ValidateAddress("")
+	require.False(result.Valid)
+	require.Equal("expected 1 to 64 hex characters, got 0", result.Reason)
+
+	result = builder.ValidateAddress("0x2::sui::SUI")
+	require.False(result.Valid)
+	require.NotEmpty(result.Reason)
+}
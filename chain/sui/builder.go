@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/coming-chat/go-sui/v2/types"
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
@@ -25,7 +26,31 @@ func NewTxBuilder(asset xc.ITask) (*TxBuilder, error) {
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
-	return txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	feePayer, ok := args.GetFeePayer()
+	if !ok {
+		return txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	}
+	feePayerPublicKey, _ := args.GetFeePayerPublicKey()
+	if len(feePayerPublicKey) == 0 {
+		return nil, fmt.Errorf("associated public key for fee payer %s is required", feePayer)
+	}
+	local_input, ok := input.(*TxInput)
+	if !ok {
+		return &Tx{}, errors.New("xc.TxInput is not from an sui chain")
+	}
+	if !local_input.IsSponsored() {
+		return &Tx{}, fmt.Errorf("no gas coins for fee payer %s in sui tx input", feePayer)
+	}
+	tf, err := txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	if err != nil {
+		return tf, err
+	}
+	tx := tf.(*Tx)
+	err = tx.setFeePayer(feePayer, feePayerPublicKey, local_input.FeePayerGasCoins)
+	if err != nil {
+		return &Tx{}, err
+	}
+	return tx, nil
 }
 
 // Old transfer interface
@@ -42,29 +67,34 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 	// from = xc.Address(strings.Replace(string(from), "0x", "", 1))
 	// to = xc.Address(strings.Replace(string(to), "0x", "", 1))
 
-	fromData, err := HexToAddress(string(from))
-	if err != nil {
-		return &Tx{}, fmt.Errorf("could not decode from address: %v", err)
-	}
 	toPure, err := HexToPure(string(to))
 	if err != nil {
 		return &Tx{}, fmt.Errorf("could not decode to address: %v", err)
 	}
 
-	gasObjectId, err := HexToObjectID(local_input.GasCoin.CoinObjectId.String())
+	ptx, coinResult, err := NewSplitCoinTransaction(local_input, amount)
 	if err != nil {
-		return &Tx{}, fmt.Errorf("could not decode gas coin object id: %v", err)
+		return &Tx{}, err
 	}
-	local_input.GasCoin.Digest.Data()
-	gasDigest, err := Base58ToObjectDigest(
-		local_input.GasCoin.Digest.String(),
-		// string(local_input.GasCoin.Digest.Data()),
-	)
-	if err != nil {
-		return &Tx{}, fmt.Errorf("could not decode gas coin digest: %v", err)
-	}
-	gasVersion := local_input.GasCoin.Version.Uint64()
 
+	// IV. send the new split object
+	ptx.Commands = append(ptx.Commands, &bcs.Command__TransferObjects{
+		Field0: []bcs.Argument{
+			coinResult,
+		},
+		Field1: ArgumentInput(uint16(len(ptx.Inputs))),
+	})
+	ptx.Inputs = append(ptx.Inputs, toPure)
+
+	return txBuilder.newTx(from, local_input, ptx)
+}
+
+// NewSplitCoinTransaction starts a programmable transaction that produces a coin of `amount`, returning
+// the argument referring to the new coin.  The gas budget on the input is lowered if needed.
+func NewSplitCoinTransaction(local_input *TxInput, amount xc.AmountBlockchain) (*bcs.ProgrammableTransaction, bcs.Argument, error) {
+	if local_input.IsSponsored() {
+		return newSponsoredSplitCoinTransaction(local_input, amount)
+	}
 	local_input.ExcludeGasCoin()
 	// Our gas budget should be the minimum of:
 	//  - normal budget (2sui)
@@ -76,7 +106,7 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 	total_remainder := gas_coin_balance
 	if local_input.IsNativeTransfer() {
 		if local_input.TotalBalance().Uint64() < amount.Uint64() {
-			return &Tx{}, fmt.Errorf("not enough funds to send after paying for sui gas: budget=%d tf=%d", local_input.GasBudget, amount.Uint64())
+			return nil, nil, fmt.Errorf("not enough funds to send after paying for sui gas: budget=%d tf=%d", local_input.GasBudget, amount.Uint64())
 		}
 		total_remainder = local_input.TotalBalance().Uint64() - amount.Uint64()
 	}
@@ -132,7 +162,7 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 
 		obj, err := CoinToObject(local_input.Coins[0])
 		if err != nil {
-			return &Tx{}, err
+			return nil, nil, err
 		}
 		cmd_inputs = append(cmd_inputs, &bcs.CallArg__Object{
 			Value: obj,
@@ -150,7 +180,7 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 			}
 			obj, err := CoinToObject(coin)
 			if err != nil {
-				return &Tx{}, err
+				return nil, nil, err
 			}
 			merge_inputs = append(merge_inputs, ArgumentInput(uint16(len(cmd_inputs))))
 
@@ -171,7 +201,7 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 		primaryCoinInput = &bcs.Argument__GasCoin{}
 	}
 
-	// now let's spend the primary coin by splitting `amt` from it
+	// III. now let's spend the primary coin by splitting `amt` from it
 	commands = append(commands, &bcs.Command__SplitCoins{
 		Field0: primaryCoinInput,
 		Field1: []bcs.Argument{
@@ -181,46 +211,113 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 	})
 	cmd_inputs = append(cmd_inputs, U64ToPure(amount.Uint64()))
 
-	// send the new split object
-	commands = append(commands, &bcs.Command__TransferObjects{
-		Field0: []bcs.Argument{
-			// last cmd result has the coin to send
-			ArgumentResult(uint16(len(commands) - 1)),
+	return &bcs.ProgrammableTransaction{
+		Inputs:   cmd_inputs,
+		Commands: commands,
+	}, ArgumentResult(uint16(len(commands) - 1)), nil
+}
+
+// For sponsored transactions the gas coin belongs to the fee payer, so it cannot be used for the transfer.
+// Instead all of the sender's coins are merged and the amount is split from them.
+func newSponsoredSplitCoinTransaction(local_input *TxInput, amount xc.AmountBlockchain) (*bcs.ProgrammableTransaction, bcs.Argument, error) {
+	if len(local_input.Coins) == 0 {
+		return nil, nil, errors.New("no coins to transfer for sponsored sui transaction")
+	}
+	if local_input.TotalBalance().Uint64() < amount.Uint64() {
+		return nil, nil, fmt.Errorf("not enough funds to send: balance=%d tf=%d", local_input.TotalBalance().Uint64(), amount.Uint64())
+	}
+	// lower the gas budget to what the fee payer can afford
+	feePayerBalance := local_input.FeePayerBalance().Uint64()
+	if feePayerBalance < local_input.GasBudget {
+		local_input.GasBudget = feePayerBalance
+	}
+
+	cmd_inputs := []bcs.CallArg{}
+	commands := []bcs.Command{}
+	merge_inputs := []bcs.Argument{}
+	for i, coin := range local_input.Coins {
+		if i > MaxCoinObjects {
+			break
+		}
+		obj, err := CoinToObject(coin)
+		if err != nil {
+			return nil, nil, err
+		}
+		if i > 0 {
+			merge_inputs = append(merge_inputs, ArgumentInput(uint16(len(cmd_inputs))))
+		}
+		cmd_inputs = append(cmd_inputs, &bcs.CallArg__Object{
+			Value: obj,
+		})
+	}
+	primaryCoinInput := ArgumentInput(0)
+	if len(merge_inputs) > 0 {
+		commands = append(commands, &bcs.Command__MergeCoins{
+			Field0: primaryCoinInput,
+			Field1: merge_inputs,
+		})
+	}
+	commands = append(commands, &bcs.Command__SplitCoins{
+		Field0: primaryCoinInput,
+		Field1: []bcs.Argument{
+			ArgumentInput(uint16(len(cmd_inputs))),
 		},
-		Field1: ArgumentInput(uint16(len(cmd_inputs))),
 	})
-	cmd_inputs = append(cmd_inputs, toPure)
+	cmd_inputs = append(cmd_inputs, U64ToPure(amount.Uint64()))
 
+	return &bcs.ProgrammableTransaction{
+		Inputs:   cmd_inputs,
+		Commands: commands,
+	}, ArgumentResult(uint16(len(commands) - 1)), nil
+}
+
+// newTx wraps the programmable transaction, paying gas with the gas coin from the input.
+func (txBuilder TxBuilder) newTx(from xc.Address, local_input *TxInput, ptx *bcs.ProgrammableTransaction) (*Tx, error) {
+	fromData, err := HexToAddress(string(from))
+	if err != nil {
+		return &Tx{}, fmt.Errorf("could not decode from address: %v", err)
+	}
 	// expires after current epoch
 	expiration := bcs.TransactionExpiration__Epoch(local_input.CurrentEpoch)
 
-	gasCoin := ObjectRef{
-		Field0: gasObjectId,
-		Field1: bcs.SequenceNumber(gasVersion),
-		Field2: gasDigest,
+	payment := []struct {
+		Field0 bcs.ObjectID
+		Field1 bcs.SequenceNumber
+		Field2 bcs.ObjectDigest
+	}{}
+	// sponsored transactions are paid for using the fee payer's coins, which are set later.
+	if !local_input.IsSponsored() {
+		gasObjectId, err := HexToObjectID(local_input.GasCoin.CoinObjectId.String())
+		if err != nil {
+			return &Tx{}, fmt.Errorf("could not decode gas coin object id: %v", err)
+		}
+		gasDigest, err := Base58ToObjectDigest(
+			local_input.GasCoin.Digest.String(),
+		)
+		if err != nil {
+			return &Tx{}, fmt.Errorf("could not decode gas coin digest: %v", err)
+		}
+		gasVersion := local_input.GasCoin.Version.Uint64()
+
+		payment = append(payment, ObjectRef{
+			Field0: gasObjectId,
+			Field1: bcs.SequenceNumber(gasVersion),
+			Field2: gasDigest,
+		})
 	}
 
 	tx := bcs.TransactionData__V1{
 		Value: bcs.TransactionDataV1{
 			GasData: bcs.GasData{
-				Payment: []struct {
-					Field0 bcs.ObjectID
-					Field1 bcs.SequenceNumber
-					Field2 bcs.ObjectDigest
-				}{
-					gasCoin,
-				},
-				Owner:  fromData,
-				Price:  local_input.GasPrice,
-				Budget: local_input.GasBudget,
+				Payment: payment,
+				Owner:   fromData,
+				Price:   local_input.GasPrice,
+				Budget:  local_input.GasBudget,
 			},
 			Sender:     fromData,
 			Expiration: &expiration,
 			Kind: &bcs.TransactionKind__ProgrammableTransaction{
-				Value: bcs.ProgrammableTransaction{
-					Inputs:   cmd_inputs,
-					Commands: commands,
-				},
+				Value: *ptx,
 			},
 		},
 	}
@@ -238,3 +335,31 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 	// The token is already in the coins in the tx_input so txbuilding is the exact same.
 	return txBuilder.NewTransfer(from, to, amount, input)
 }
+
+// setFeePayer changes the gas owner and payment to the fee payer, who then must also sign.
+func (tx *Tx) setFeePayer(feePayer xc.Address, feePayerPublicKey []byte, gasCoins []*types.Coin) error {
+	feePayerData, err := HexToAddress(string(feePayer))
+	if err != nil {
+		return fmt.Errorf("could not decode fee payer address: %v", err)
+	}
+	payment := []struct {
+		Field0 bcs.ObjectID
+		Field1 bcs.SequenceNumber
+		Field2 bcs.ObjectDigest
+	}{}
+	for _, coin := range gasCoins {
+		obj, err := CoinToObject(coin)
+		if err != nil {
+			return err
+		}
+		payment = append(payment, ObjectRef{
+			Field0: obj.Field0,
+			Field1: obj.Field1,
+			Field2: obj.Field2,
+		})
+	}
+	tx.Tx.Value.GasData.Owner = feePayerData
+	tx.Tx.Value.GasData.Payment = payment
+	tx.fee_payer_public_key = feePayerPublicKey
+	return nil
+}
@@ -0,0 +1,179 @@
+package sui
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
+)
+
+var _ xcbuilder.Staking = &TxBuilder{}
+
+const SuiSystemStateObjectId = "0x0000000000000000000000000000000000000000000000000000000000000005"
+const SuiSystemPackageId = "0x0000000000000000000000000000000000000000000000000000000000000003"
+
+// The system state object was shared at genesis
+const SuiSystemStateInitialVersion = 1
+
+// MIN_STAKING_THRESHOLD of the staking pool: a StakedSui can't be split into parts smaller than 1 SUI
+const MinStakingThreshold = 1_000_000_000
+
+func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
+	stakeInput, ok := input.(*StakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &StakingInput{})
+	}
+	if len(stakeInput.Pubkey) == 0 {
+		return &Tx{}, errors.New("must set public key on TxInput for SUI")
+	}
+	validator, ok := args.GetValidator()
+	if !ok {
+		return nil, errors.New("must provide the validator address to stake to")
+	}
+	validatorPure, err := HexToPure(validator)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode validator address: %v", err)
+	}
+
+	ptx, coinResult, err := NewSplitCoinTransaction(&stakeInput.TxInput, args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	systemState, err := addSystemStateInput(ptx)
+	if err != nil {
+		return nil, err
+	}
+	ptx.Commands = append(ptx.Commands, newSystemMoveCall("request_add_stake", systemState, coinResult, ArgumentInput(uint16(len(ptx.Inputs)))))
+	ptx.Inputs = append(ptx.Inputs, validatorPure)
+
+	return txBuilder.newTx(args.GetFrom(), &stakeInput.TxInput, ptx)
+}
+
+// Unstake withdraws StakedSui objects until the amount is covered, splitting the last object if needed.
+// If splitting would leave less than MinStakingThreshold staked, the whole object is withdrawn instead.
+// The principal and rewards are returned to the owner immediately.
+func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
+	unstakeInput, ok := input.(*UnstakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &UnstakingInput{})
+	}
+	if len(unstakeInput.Pubkey) == 0 {
+		return &Tx{}, errors.New("must set public key on TxInput for SUI")
+	}
+	remaining := args.GetAmount().Uint64()
+	if remaining == 0 {
+		return nil, errors.New("must unstake a non-zero amount")
+	}
+	// gas is paid using the gas coin alone, so lower the gas budget to its balance
+	gasCoinBalance := unstakeInput.GasCoin.Balance.Uint64()
+	if gasCoinBalance == 0 {
+		return nil, errors.New("no SUI to pay for gas to unstake")
+	}
+	if gasCoinBalance < unstakeInput.GasBudget {
+		unstakeInput.GasBudget = gasCoinBalance
+	}
+
+	ptx := &bcs.ProgrammableTransaction{}
+	systemState, err := addSystemStateInput(ptx)
+	if err != nil {
+		return nil, err
+	}
+	for _, stakedSui := range unstakeInput.StakedSuis {
+		if remaining == 0 {
+			break
+		}
+		obj, err := stakedSui.ToObject()
+		if err != nil {
+			return nil, err
+		}
+		stakedSuiArg := bcs.Argument(ArgumentInput(uint16(len(ptx.Inputs))))
+		ptx.Inputs = append(ptx.Inputs, &bcs.CallArg__Object{Value: obj})
+
+		if stakedSui.Principal > remaining && stakedSui.Principal-remaining < MinStakingThreshold {
+			// the rest would be too small to stay staked, so withdraw all of it
+			remaining = 0
+		} else if stakedSui.Principal > remaining {
+			if remaining < MinStakingThreshold {
+				return nil, fmt.Errorf("cannot split less than %d off of staked sui %s, unstake at least %d more", MinStakingThreshold, stakedSui.ObjectId, MinStakingThreshold-remaining)
+			}
+			// split off the remaining amount into a new StakedSui to withdraw
+			ptx.Commands = append(ptx.Commands, &bcs.Command__MoveCall{
+				Value: bcs.ProgrammableMoveCall{
+					Package:       mustObjectId(SuiSystemPackageId),
+					Module:        "staking_pool",
+					Function:      "split",
+					TypeArguments: []bcs.TypeTag{},
+					Arguments:     []bcs.Argument{stakedSuiArg, ArgumentInput(uint16(len(ptx.Inputs)))},
+				},
+			})
+			ptx.Inputs = append(ptx.Inputs, U64ToPure(remaining))
+			stakedSuiArg = ArgumentResult(uint16(len(ptx.Commands) - 1))
+			remaining = 0
+		} else {
+			remaining -= stakedSui.Principal
+		}
+		ptx.Commands = append(ptx.Commands, newSystemMoveCall("request_withdraw_stake", systemState, stakedSuiArg))
+	}
+	if remaining > 0 {
+		return nil, fmt.Errorf("not enough stake to withdraw, short by %d", remaining)
+	}
+
+	return txBuilder.newTx(args.GetFrom(), &unstakeInput.TxInput, ptx)
+}
+
+func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
+	return nil, errors.New("sui returns the stake when unstaking, there is no need to withdraw")
+}
+
+func (stakedSui *StakedSui) ToObject() (*bcs.ObjectArg__ImmOrOwnedObject, error) {
+	id, err := HexToObjectID(stakedSui.ObjectId)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode staked sui id: %v", err)
+	}
+	digest, err := Base58ToObjectDigest(stakedSui.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode staked sui digest: %v", err)
+	}
+	return &bcs.ObjectArg__ImmOrOwnedObject{
+		Field0: id,
+		Field1: bcs.SequenceNumber(stakedSui.Version),
+		Field2: digest,
+	}, nil
+}
+
+func addSystemStateInput(ptx *bcs.ProgrammableTransaction) (bcs.Argument, error) {
+	id, err := HexToObjectID(SuiSystemStateObjectId)
+	if err != nil {
+		return nil, err
+	}
+	ptx.Inputs = append(ptx.Inputs, &bcs.CallArg__Object{
+		Value: &bcs.ObjectArg__SharedObject{
+			Id:                   id,
+			InitialSharedVersion: SuiSystemStateInitialVersion,
+			Mutable:              true,
+		},
+	})
+	return ArgumentInput(uint16(len(ptx.Inputs) - 1)), nil
+}
+
+func newSystemMoveCall(function string, args ...bcs.Argument) bcs.Command {
+	return &bcs.Command__MoveCall{
+		Value: bcs.ProgrammableMoveCall{
+			Package:       mustObjectId(SuiSystemPackageId),
+			Module:        "sui_system",
+			Function:      bcs.Identifier(function),
+			TypeArguments: []bcs.TypeTag{},
+			Arguments:     args,
+		},
+	}
+}
+
+func mustObjectId(str string) bcs.ObjectID {
+	id, err := HexToObjectID(str)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
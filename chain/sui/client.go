@@ -14,6 +14,7 @@ import (
 	xc "github.com/cordialsys/crosschain"
 	xcbuilder "github.com/cordialsys/crosschain/builder"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -26,7 +27,7 @@ type Client struct {
 // NewClient returns a new Sui Client
 func NewClient(cfgI xc.ITask) (*Client, error) {
 	cfg := cfgI.GetChain()
-	client, err := client.Dial(cfg.URL)
+	client, err := client.DialWithClient(cfg.URL, utils.NewHttpClient(cfg))
 	return &Client{
 		Asset:     cfgI,
 		SuiClient: client,
@@ -34,16 +35,20 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 }
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 
+// 2 SUI
+const GAS_BUDGET = uint64(2_000_000_000)
 const GAS_BUDGET_PER_COIN = uint64(20_000_000)
 
 type SuiMethod string
 
 var (
 	// getTransactionBlock SuiMethod = "sui_getTransactionBlock"
-	getCheckpoint  SuiMethod = "sui_getCheckpoint"
-	getCheckpoints SuiMethod = "sui_getCheckpoints"
-	MaxCoinObjects int       = 50
+	getCheckpoint   SuiMethod = "sui_getCheckpoint"
+	getCheckpoints  SuiMethod = "sui_getCheckpoints"
+	getCoinMetadata SuiMethod = "suix_getCoinMetadata"
+	MaxCoinObjects  int       = 50
 )
 
 func (m SuiMethod) String() string {
@@ -80,6 +85,15 @@ func (c *Client) FetchLatestCheckpoint(ctx context.Context) (*Checkpoint, error)
 	return resp.Data[0], err
 }
 
+// FetchLatestHeight returns the latest checkpoint of the node
+func (c *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	checkpoint, err := c.FetchLatestCheckpoint(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return checkpoint.GetSequenceNumber(), nil
+}
+
 func (c *Client) FetchCheckpoint(ctx context.Context, checkpoint uint64) (*Checkpoint, error) {
 	resp := &Checkpoint{}
 	// get last 1 checkpoint, descending order
@@ -303,7 +317,21 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 		input.Coins = input.Coins[:MaxCoinObjects]
 	}
 
-	if contract == native {
+	if feePayer, ok := args.GetFeePayer(); ok {
+		// gas is paid using the fee payer's coins, so all of the sender's coins can be spent
+		feePayerCoins, err := c.GetAllCoinsFor(ctx, feePayer, native)
+		if err != nil {
+			return &TxInput{}, err
+		}
+		SortCoins(feePayerCoins)
+		if len(feePayerCoins) > MaxCoinObjects {
+			feePayerCoins = feePayerCoins[:MaxCoinObjects]
+		}
+		if len(feePayerCoins) == 0 {
+			return &TxInput{}, fmt.Errorf("fee payer %s has no sui to pay for gas", feePayer)
+		}
+		input.FeePayerGasCoins = feePayerCoins
+	} else if contract == native {
 		// gas coin should just be the largest object
 		if len(input.Coins) > 0 {
 			input.GasCoin = *input.Coins[0]
@@ -330,8 +358,7 @@ func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Transfer
 		input.GasPrice = uint64(defaultgas)
 	}
 	input.GasPrice = gasPrice.Uint64()
-	// 2 SUI
-	input.GasBudget = 2_000_000_000
+	input.GasBudget = GAS_BUDGET
 
 	// Incrementally increase budget per additional coin being consumed
 	input.GasBudget = input.GasBudget + GAS_BUDGET_PER_COIN*uint64(len(input.Coins))
@@ -348,6 +375,7 @@ func (c *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.
 
 // SubmitTx submits a Sui tx
 func (c *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
+	ctx = utils.NonIdempotent(ctx)
 	tx_bz, err := tx.Serialize()
 	if err != nil {
 		return err
@@ -0,0 +1,163 @@
+package sui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/coming-chat/go-sui/v2/move_types"
+	"github.com/coming-chat/go-sui/v2/sui_types"
+	"github.com/coming-chat/go-sui/v2/types"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.StakingClient = &Client{}
+
+// FetchStakeBalance reports each StakedSui object owned by the address, with the object ID as the account.
+func (c *Client) FetchStakeBalance(ctx context.Context, args xclient.StakedBalanceArgs) ([]*xclient.StakedBalance, error) {
+	stakes, err := c.fetchStakes(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	validatorFilter, _ := args.GetValidator()
+	balances := []*xclient.StakedBalance{}
+	for _, delegated := range stakes {
+		validator := delegated.ValidatorAddress.String()
+		if validatorFilter != "" && !sameAddress(validatorFilter, validator) {
+			continue
+		}
+		for _, stake := range delegated.Stakes {
+			state := xclient.Active
+			if stake.Data.StakeStatus != nil {
+				if stake.Data.StakeStatus.Data.Pending != nil {
+					state = xclient.Activating
+				} else if stake.Data.StakeStatus.Data.Unstaked != nil {
+					state = xclient.Inactive
+				}
+			}
+			balance := xc.NewAmountBlockchainFromUint64(stake.Data.Principal.Uint64())
+			balances = append(balances, xclient.NewStakedBalance(balance, state, validator, stake.Data.StakedSuiId.String()))
+		}
+	}
+	return balances, nil
+}
+
+func (c *Client) FetchStakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.StakeTxInput, error) {
+	input, err := c.fetchNativeInput(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingInput{TxInput: *input}, nil
+}
+
+// Selects StakedSui objects to withdraw, preferring the given validator and the largest objects first.
+func (c *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.UnstakeTxInput, error) {
+	input, err := c.fetchNativeInput(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	// Only the gas coin is used, the unstaked SUI is returned from the StakedSui objects
+	input.Coins = nil
+	input.GasBudget = GAS_BUDGET
+	if input.GasCoin.Balance.Uint64() == 0 {
+		return nil, fmt.Errorf("no SUI to pay for gas to unstake, %s must have a SUI balance", args.GetFrom())
+	}
+	stakes, err := c.fetchStakes(ctx, args.GetFrom())
+	if err != nil {
+		return nil, err
+	}
+	validatorFilter, _ := args.GetValidator()
+	stakedSuis := []*StakedSui{}
+	for _, delegated := range stakes {
+		validator := delegated.ValidatorAddress.String()
+		if validatorFilter != "" && !sameAddress(validatorFilter, validator) {
+			continue
+		}
+		for _, stake := range delegated.Stakes {
+			stakedSuis = append(stakedSuis, &StakedSui{
+				ObjectId:  stake.Data.StakedSuiId.String(),
+				Principal: stake.Data.Principal.Uint64(),
+				Validator: validator,
+			})
+		}
+	}
+	if len(stakedSuis) == 0 {
+		return nil, errors.New("no StakedSui objects found to unstake")
+	}
+	sort.SliceStable(stakedSuis, func(i, j int) bool {
+		return stakedSuis[i].Principal > stakedSuis[j].Principal
+	})
+	if len(stakedSuis) > MaxCoinObjects {
+		stakedSuis = stakedSuis[:MaxCoinObjects]
+	}
+
+	// the object version and digest are not reported by suix_getStakes
+	ids := make([]sui_types.ObjectID, len(stakedSuis))
+	for i, stakedSui := range stakedSuis {
+		id, err := sui_types.NewObjectIdFromHex(stakedSui.ObjectId)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = *id
+	}
+	objects, err := c.SuiClient.MultiGetObjects(ctx, ids, &types.SuiObjectDataOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(objects) != len(stakedSuis) {
+		return nil, fmt.Errorf("expected %d StakedSui objects, got %d", len(stakedSuis), len(objects))
+	}
+	for i, object := range objects {
+		if object.Data == nil {
+			return nil, fmt.Errorf("could not load StakedSui object %s", stakedSuis[i].ObjectId)
+		}
+		stakedSuis[i].Version = object.Data.Version.Uint64()
+		stakedSuis[i].Digest = object.Data.Digest.String()
+	}
+
+	return &UnstakingInput{
+		TxInput:    *input,
+		StakedSuis: stakedSuis,
+	}, nil
+}
+
+func (c *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.WithdrawTxInput, error) {
+	return nil, errors.New("sui returns the stake when unstaking, there is no need to withdraw")
+}
+
+// Gas is always paid in SUI, so the inputs are the same as a native transfer
+func (c *Client) fetchNativeInput(ctx context.Context, args xcbuilder.StakeArgs) (*TxInput, error) {
+	transferArgs, err := xcbuilder.NewTransferArgs(args.GetFrom(), args.GetFrom(), args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	native := &Client{
+		Asset:     c.Asset.GetChain(),
+		SuiClient: c.SuiClient,
+	}
+	input, err := native.FetchTransferInput(ctx, transferArgs)
+	if err != nil {
+		return nil, err
+	}
+	return input.(*TxInput), nil
+}
+
+func (c *Client) fetchStakes(ctx context.Context, address xc.Address) ([]types.DelegatedStake, error) {
+	owner, err := move_types.NewAccountAddressHex(string(address))
+	if err != nil {
+		return nil, err
+	}
+	return c.SuiClient.GetStakes(ctx, *owner)
+}
+
+func sameAddress(a string, b string) bool {
+	aAddr, err1 := move_types.NewAccountAddressHex(a)
+	bAddr, err2 := move_types.NewAccountAddressHex(b)
+	if err1 != nil || err2 != nil {
+		return a == b
+	}
+	return *aAddr == *bAddr
+}
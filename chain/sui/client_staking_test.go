@@ -0,0 +1,203 @@
+package sui_test
+
+import (
+	"context"
+	"encoding/hex"
+
+	"github.com/coming-chat/go-sui/v2/types"
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	. "github.com/cordialsys/crosschain/chain/sui"
+	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
+	xclient "github.com/cordialsys/crosschain/client"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+)
+
+const stakingValidator = "0x44b1b319e23495995fc837dafd28fc6af8b645edddff0fc1467f1ad631362c23"
+const stakedSuiId1 = "0x1111111111111111111111111111111111111111111111111111111111111111"
+const stakedSuiId2 = "0x2222222222222222222222222222222222222222222222222222222222222222"
+
+var getStakesResponse = `[{"validatorAddress":"` + stakingValidator + `","stakingPool":"0x3333333333333333333333333333333333333333333333333333333333333333","stakes":[
+	{"stakedSuiId":"` + stakedSuiId1 + `","stakeRequestEpoch":"10","stakeActiveEpoch":"11","principal":"3000000000","status":"Active","estimatedReward":"1000"},
+	{"stakedSuiId":"` + stakedSuiId2 + `","stakeRequestEpoch":"20","stakeActiveEpoch":"21","principal":"5000000000","status":"Pending"}
+]}]`
+
+func mustSystemState() *bcs.CallArg__Object {
+	id, _ := HexToObjectID(SuiSystemStateObjectId)
+	return &bcs.CallArg__Object{Value: &bcs.ObjectArg__SharedObject{Id: id, InitialSharedVersion: 1, Mutable: true}}
+}
+
+func systemCall(function string, args ...bcs.Argument) *bcs.Command__MoveCall {
+	pkg, _ := HexToObjectID(SuiSystemPackageId)
+	return &bcs.Command__MoveCall{Value: bcs.ProgrammableMoveCall{
+		Package:       pkg,
+		Module:        "sui_system",
+		Function:      bcs.Identifier(function),
+		TypeArguments: []bcs.TypeTag{},
+		Arguments:     args,
+	}}
+}
+
+func (s *CrosschainTestSuite) TestFetchStakeBalance() {
+	require := s.Require()
+	server, close := testtypes.MockJSONRPC(s.T(), []string{getStakesResponse})
+	defer close()
+	client, err := NewClient(&xc.ChainConfig{Chain: xc.SUI, URL: server.URL})
+	require.NoError(err)
+
+	args, _ := xclient.NewStakeBalanceArgs("0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9")
+	balances, err := client.FetchStakeBalance(context.Background(), args)
+	require.NoError(err)
+	require.Len(balances, 2)
+	require.Equal(stakingValidator, balances[0].Validator)
+	require.Equal(stakedSuiId1, balances[0].Account)
+	require.Equal("3000000000", balances[0].Balance.Active.String())
+	require.Equal(stakedSuiId2, balances[1].Account)
+	require.Equal("5000000000", balances[1].Balance.Activating.String())
+}
+
+func (s *CrosschainTestSuite) TestStake() {
+	require := s.Require()
+	from := "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+	from_pk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	builder, _ := NewTxBuilder(&xc.ChainConfig{Chain: xc.SUI})
+
+	input := &StakingInput{TxInput: TxInput{
+		TxInputEnvelope: *xc.NewTxInputEnvelope(xc.DriverSui),
+		GasBudget:       100,
+		GasPrice:        100,
+		Pubkey:          from_pk,
+		GasCoin:         *suiCoin("0x8192d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998", "HmMNQCsgudhDdXGe9X75WVyPbJnjFApq1EvFhaRzNB1n", 10_000_000_000, 1852477),
+		CurrentEpoch:    20,
+	}}
+	args, _ := xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(2_000_000_000), xcbuilder.OptionValidator(stakingValidator))
+	tx, err := builder.Stake(args, input)
+	require.NoError(err)
+
+	ptx := tx.(*Tx).Tx.Value.Kind.(*bcs.TransactionKind__ProgrammableTransaction).Value
+	require.Equal([]bcs.CallArg{
+		U64ToPure(2_000_000_000),
+		mustSystemState(),
+		mustHexToPure(stakingValidator),
+	}, ptx.Inputs)
+	require.Equal([]bcs.Command{
+		// split the stake from the gas coin
+		&bcs.Command__SplitCoins{Field0: &bcs.Argument__GasCoin{}, Field1: []bcs.Argument{ArgumentInput(0)}},
+		systemCall("request_add_stake", ArgumentInput(1), ArgumentResult(0), ArgumentInput(2)),
+	}, ptx.Commands)
+
+	args, _ = xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(2_000_000_000))
+	_, err = builder.Stake(args, input)
+	require.ErrorContains(err, "must provide the validator")
+}
+
+func (s *CrosschainTestSuite) TestUnstake() {
+	require := s.Require()
+	from := "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+	from_pk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	server, close := testtypes.MockJSONRPC(s.T(), []string{
+		// get coins
+		`{"data":[{"coinType":"0x2::sui::SUI","coinObjectId":"0x8192d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998","version":"1852497","digest":"FL9GS4b72Ay3Lwc55Q9A9DMLDTXUQ5ancKnTfL6WD8JL","balance":"1997992240","previousTransaction":"7g2RPre2F7WJxYBG5urbZvjKev8YpfMxRT8GL8HCshv5"},{"coinType":"0x2::sui::SUI","coinObjectId":"0x9992d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998","version":"1852497","digest":"FL9GS4b72Ay3Lwc55Q9A9DMLDTXUQ5ancKnTfL6WD8JL","balance":"1000","previousTransaction":"7g2RPre2F7WJxYBG5urbZvjKev8YpfMxRT8GL8HCshv5"}],"nextCursor":null,"hasNextPage":false}`,
+		// get checkpoint
+		`{"data":[{"epoch":"21","sequenceNumber":"2206686","digest":"HtsAAgd1ajMR8qMocnNF6XbAtiBHrxdauGhWtXqKouF3","networkTotalTransactions":"5164703","previousDigest":"H8oYvb73KoG7TWXpw4JPy2qZk7ddvHY3rYQ8kHcNmcua","timestampMs":"1683320609521","transactions":[],"checkpointCommitments":[]}],"nextCursor":"2206686","hasNextPage":true}`,
+		// reference gas
+		"1000",
+		getStakesResponse,
+		// staked sui objects, largest first
+		`[{"data":{"objectId":"` + stakedSuiId2 + `","version":"30","digest":"7epS94m8djHYKu4V29DSTfkm6mJ6TvZAzwY6ntA65v9A"}},{"data":{"objectId":"` + stakedSuiId1 + `","version":"12","digest":"FL9GS4b72Ay3Lwc55Q9A9DMLDTXUQ5ancKnTfL6WD8JL"}}]`,
+	})
+	defer close()
+	client, err := NewClient(&xc.ChainConfig{Chain: xc.SUI, URL: server.URL})
+	require.NoError(err)
+
+	args, _ := xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(6_000_000_000))
+	inputI, err := client.FetchUnstakingInput(context.Background(), args)
+	require.NoError(err)
+	input := inputI.(*UnstakingInput)
+	require.Len(input.StakedSuis, 2)
+	require.Equal(stakedSuiId2, input.StakedSuis[0].ObjectId)
+	require.EqualValues(30, input.StakedSuis[0].Version)
+	require.EqualValues(12, input.StakedSuis[1].Version)
+	// only the gas coin is used, and isn't charged for the other coins
+	require.Empty(input.Coins)
+	require.EqualValues(1997992240, input.GasCoin.Balance.Uint64())
+	require.Equal(GAS_BUDGET, input.GasBudget)
+	input.Pubkey = from_pk
+
+	builder, _ := NewTxBuilder(&xc.ChainConfig{Chain: xc.SUI})
+	tx, err := builder.Unstake(args, input)
+	require.NoError(err)
+	// the gas budget is limited to the gas coin balance
+	require.EqualValues(1997992240, tx.(*Tx).Tx.Value.GasData.Budget)
+	ptx := tx.(*Tx).Tx.Value.Kind.(*bcs.TransactionKind__ProgrammableTransaction).Value
+	obj1, _ := input.StakedSuis[0].ToObject()
+	obj2, _ := input.StakedSuis[1].ToObject()
+	require.Equal([]bcs.CallArg{
+		mustSystemState(),
+		&bcs.CallArg__Object{Value: obj1},
+		&bcs.CallArg__Object{Value: obj2},
+		U64ToPure(1_000_000_000),
+	}, ptx.Inputs)
+	pkg, _ := HexToObjectID(SuiSystemPackageId)
+	require.Equal([]bcs.Command{
+		systemCall("request_withdraw_stake", ArgumentInput(0), ArgumentInput(1)),
+		// split the remaining 1 SUI off of the second object
+		&bcs.Command__MoveCall{Value: bcs.ProgrammableMoveCall{
+			Package:       pkg,
+			Module:        "staking_pool",
+			Function:      "split",
+			TypeArguments: []bcs.TypeTag{},
+			Arguments:     []bcs.Argument{ArgumentInput(2), ArgumentInput(3)},
+		}},
+		systemCall("request_withdraw_stake", ArgumentInput(0), ArgumentResult(1)),
+	}, ptx.Commands)
+
+	// splitting 2.5 SUI would leave 0.5 SUI staked, so the second object is withdrawn in full
+	args, _ = xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(7_500_000_000))
+	tx, err = builder.Unstake(args, input)
+	require.NoError(err)
+	ptx = tx.(*Tx).Tx.Value.Kind.(*bcs.TransactionKind__ProgrammableTransaction).Value
+	require.Equal([]bcs.CallArg{
+		mustSystemState(),
+		&bcs.CallArg__Object{Value: obj1},
+		&bcs.CallArg__Object{Value: obj2},
+	}, ptx.Inputs)
+	require.Equal([]bcs.Command{
+		systemCall("request_withdraw_stake", ArgumentInput(0), ArgumentInput(1)),
+		systemCall("request_withdraw_stake", ArgumentInput(0), ArgumentInput(2)),
+	}, ptx.Commands)
+
+	// 0.5 SUI can't be split off
+	args, _ = xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(5_500_000_000))
+	_, err = builder.Unstake(args, input)
+	require.ErrorContains(err, "cannot split less than 1000000000")
+
+	args, _ = xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(9_000_000_000))
+	_, err = builder.Unstake(args, input)
+	require.ErrorContains(err, "not enough stake")
+
+	input.GasCoin = types.Coin{}
+	args, _ = xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(6_000_000_000))
+	_, err = builder.Unstake(args, input)
+	require.ErrorContains(err, "no SUI to pay for gas")
+}
+
+func (s *CrosschainTestSuite) TestFetchUnstakingInputWithoutSui() {
+	require := s.Require()
+	from := "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+	server, close := testtypes.MockJSONRPC(s.T(), []string{
+		// get coins
+		`{"data":[],"nextCursor":null,"hasNextPage":false}`,
+		// get checkpoint
+		`{"data":[{"epoch":"21","sequenceNumber":"2206686","digest":"HtsAAgd1ajMR8qMocnNF6XbAtiBHrxdauGhWtXqKouF3","networkTotalTransactions":"5164703","previousDigest":"H8oYvb73KoG7TWXpw4JPy2qZk7ddvHY3rYQ8kHcNmcua","timestampMs":"1683320609521","transactions":[],"checkpointCommitments":[]}],"nextCursor":"2206686","hasNextPage":true}`,
+		// reference gas
+		"1000",
+	})
+	defer close()
+	client, err := NewClient(&xc.ChainConfig{Chain: xc.SUI, URL: server.URL})
+	require.NoError(err)
+
+	args, _ := xcbuilder.NewStakeArgs(xc.SUI, xc.Address(from), xc.NewAmountBlockchainFromUint64(6_000_000_000))
+	_, err = client.FetchUnstakingInput(context.Background(), args)
+	require.ErrorContains(err, "no SUI to pay for gas")
+}
@@ -2,6 +2,7 @@ package sui_test
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
@@ -9,8 +10,10 @@ import (
 	"github.com/coming-chat/go-sui/v2/move_types"
 	"github.com/coming-chat/go-sui/v2/types"
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	. "github.com/cordialsys/crosschain/chain/sui"
 	"github.com/cordialsys/crosschain/chain/sui/generated/bcs"
+	xclient "github.com/cordialsys/crosschain/client"
 	testtypes "github.com/cordialsys/crosschain/testutil/types"
 	"github.com/shopspring/decimal"
 )
@@ -804,3 +807,120 @@ func (s *CrosschainTestSuite) TestFetchBalance() {
 		require.EqualValues(v.amount, bal.ToHuman(9).String())
 	}
 }
+
+func (s *CrosschainTestSuite) TestSponsoredTransfer() {
+	require := s.Require()
+
+	from := "0xbb8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de66b9"
+	from_pk, _ := hex.DecodeString("6a03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+	to := "0xaa8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de6600"
+	feePayer := "0xcc8a8269cf96ba2ec27dc9becd79836394dbe7946c7ac211928be4a0b1de6611"
+	feePayer_pk, _ := hex.DecodeString("7b03aadd27a3753c3af2d676591528f3d8209f337b9506163479bc5e61f67ebd")
+
+	server, close := testtypes.MockJSONRPC(s.T(), []string{
+		// sender coins
+		`{"data":[
+			{"coinType":"0x2::sui::SUI","coinObjectId":"0x1cdc19f7751451412d090632bb1ca2c845a9c8f6cd8798d99d304571cfea1ca6","version":"1852477","digest":"u6uSbWNMxkRkCqkjSTbsMeWMYB2VK7pbAo6vFoaMzSo","balance":"2000000000","previousTransaction":"AtPwJTvPfAd47yjBmJCGCJEB7E2XmoJ6aB23XX1o6c4M"},
+			{"coinType":"0x2::sui::SUI","coinObjectId":"0x418ca9b7e3bf4bd3ecdb2d45daae92b2428a3488670e28a620ee7ee870f46b2d","version":"1852477","digest":"SwXnkbcrycgr6unAXdcJQ5jfo9dMNMkztMWc3ZxNjL3","balance":"1000000000","previousTransaction":"AtPwJTvPfAd47yjBmJCGCJEB7E2XmoJ6aB23XX1o6c4M"}
+		],"nextCursor":null,"hasNextPage":false}`,
+		// checkpoint
+		`{"data":[{"epoch":"21","sequenceNumber":"2206686","digest":"HtsAAgd1ajMR8qMocnNF6XbAtiBHrxdauGhWtXqKouF3","networkTotalTransactions":"5164703","previousDigest":"H8oYvb73KoG7TWXpw4JPy2qZk7ddvHY3rYQ8kHcNmcua","epochRollingGasCostSummary":{"computationCost":"130960164300","storageCost":"499151462400","storageRebate":"422717709348","nonRefundableStorageFee":"4269875852"},"timestampMs":"1683320609521","transactions":[],"checkpointCommitments":[],"validatorSignature":"i3aT5RVtIOvX0pEc/HU+xFTHbw2zV5SdT7q5n6GfS+e85CtkC8qqseeK2Hx9Nhia"}],"nextCursor":"2206686","hasNextPage":true}`,
+		// fee payer coins
+		`{"data":[
+			{"coinType":"0x2::sui::SUI","coinObjectId":"0x8192d5c2b5722c60866761927d5a0737cd55d0c2b1150eabf818253795b38998","version":"1852505","digest":"5MYcnxjPkzxG3bwPaLkDKG9snzeZVLFwQ25pePL1vDH7","balance":"500000000","previousTransaction":"APAAcvLGmcXFTjMwv7iAJ2hwETQyQFDkfVzs4tEyE43F"}
+		],"nextCursor":null,"hasNextPage":false}`,
+		// reference gas
+		"1000",
+	})
+	defer close()
+	asset := &xc.ChainConfig{Chain: xc.SUI, Net: "devnet", URL: server.URL}
+	client, err := NewClient(asset)
+	require.NoError(err)
+
+	args, err := xcbuilder.NewTransferArgs(xc.Address(from), xc.Address(to), xc.NewAmountBlockchainFromUint64(2_500_000_000), xcbuilder.OptionFeePayer(xc.Address(feePayer), feePayer_pk))
+	require.NoError(err)
+	input, err := client.FetchTransferInput(context.Background(), args)
+	require.NoError(err)
+	local_input := input.(*TxInput)
+	local_input.SetPublicKey(from_pk)
+	// all of the sender's coins can be spent
+	require.Len(local_input.Coins, 2)
+	require.Len(local_input.FeePayerGasCoins, 1)
+	require.True(local_input.IsSponsored())
+
+	builder, err := NewTxBuilder(asset)
+	require.NoError(err)
+	tx, err := builder.Transfer(args, input)
+	require.NoError(err)
+	suiTx := tx.(*Tx).Tx
+
+	fromData, _ := HexToAddress(from)
+	feePayerData, _ := HexToAddress(feePayer)
+	require.EqualValues(fromData, suiTx.Value.Sender)
+	require.EqualValues(feePayerData, suiTx.Value.GasData.Owner)
+	// budget is limited by the fee payer's balance
+	require.EqualValues(500_000_000, suiTx.Value.GasData.Budget)
+	feePayerCoin := mustCoinToObject(local_input.FeePayerGasCoins[0])
+	require.EqualValues([]struct {
+		Field0 bcs.ObjectID
+		Field1 bcs.SequenceNumber
+		Field2 bcs.ObjectDigest
+	}{
+		{Field0: feePayerCoin.Field0, Field1: feePayerCoin.Field1, Field2: feePayerCoin.Field2},
+	}, suiTx.Value.GasData.Payment)
+
+	// the gas coin is not used by the sender
+	commands := suiTx.Value.Kind.(*bcs.TransactionKind__ProgrammableTransaction).Value.Commands
+	require.Equal([]bcs.Command{
+		&bcs.Command__MergeCoins{
+			Field0: ArgumentInput(0),
+			Field1: []bcs.Argument{ArgumentInput(1)},
+		},
+		&bcs.Command__SplitCoins{
+			Field0: ArgumentInput(0),
+			Field1: []bcs.Argument{ArgumentInput(2)},
+		},
+		&bcs.Command__TransferObjects{
+			Field0: []bcs.Argument{ArgumentResult(1)},
+			Field1: ArgumentInput(3),
+		},
+	}, commands)
+
+	sighashes, err := tx.Sighashes()
+	require.NoError(err)
+	require.Len(sighashes, 2)
+	require.Equal(sighashes[0], sighashes[1])
+
+	sig := make([]byte, 64)
+	err = tx.AddSignatures(sig)
+	require.ErrorContains(err, "expecting 2 signatures")
+	err = tx.AddSignatures(sig, sig)
+	require.NoError(err)
+	sigs := tx.GetSignatures()
+	require.Len(sigs, 2)
+	require.Equal(from_pk, []byte(sigs[0][65:]))
+	require.Equal(feePayer_pk, []byte(sigs[1][65:]))
+
+	// fee payer must have a public key
+	args, _ = xcbuilder.NewTransferArgs(xc.Address(from), xc.Address(to), xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionFeePayer(xc.Address(feePayer), nil))
+	_, err = builder.Transfer(args, input)
+	require.ErrorContains(err, "public key for fee payer")
+}
+
+func (s *CrosschainTestSuite) TestFetchTokenMetadata() {
+	require := s.Require()
+	server, close := testtypes.MockJSONRPC(s.T(), []string{
+		`{"decimals":6,"name":"USD Coin","symbol":"USDC","description":"USDC is a US dollar-backed stablecoin issued by Circle.","iconUrl":"https://www.circle.com/hubfs/logo-usdc.svg","id":"0x69b7a7c3c200439c1b5f3b19d7d495d5966d5f08de66c69276152f8db3992ec6"}`,
+		`null`,
+	})
+	defer close()
+	client, _ := NewClient(&xc.ChainConfig{Chain: xc.SUI, URL: server.URL})
+	contract := xc.ContractAddress("0xdba34672e30cb065b1f93e3ab55318768fd6fef66c15942c9f7cb846e2f900e7::usdc::USDC")
+
+	metadata, err := client.FetchTokenMetadata(s.Ctx, contract)
+	require.NoError(err)
+	require.Equal(&xclient.TokenMetadata{Contract: contract, Name: "USD Coin", Symbol: "USDC", Decimals: 6}, metadata)
+
+	_, err = client.FetchTokenMetadata(s.Ctx, "0x1::unknown::UNKNOWN")
+	require.ErrorContains(err, "no coin metadata found")
+}
@@ -0,0 +1,30 @@
+package sui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coming-chat/go-sui/v2/types"
+	xc "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+// FetchTokenMetadata looks up the metadata of a coin type, e.g. `0x2::sui::SUI`.
+func (c *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	var metadata *types.SuiCoinMetadata
+	err := c.SuiClient.CallContext(ctx, &metadata, getCoinMetadata, string(contract))
+	if err != nil {
+		return nil, fmt.Errorf("could not get coin metadata of %s: %v", contract, err)
+	}
+	if metadata == nil {
+		return nil, fmt.Errorf("no coin metadata found for %s", contract)
+	}
+	return &xclient.TokenMetadata{
+		Contract: contract,
+		Name:     metadata.Name,
+		Symbol:   metadata.Symbol,
+		Decimals: int32(metadata.Decimals),
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"sort"
 
 	"github.com/btcsuite/btcutil/base58"
@@ -27,6 +28,8 @@ type TxInput struct {
 	Coins []*types.Coin `json:"coins,omitempty"`
 	// current epoch
 	CurrentEpoch uint64 `json:"current_epoch,omitempty"`
+	// Native Sui objects owned by the sponsor, used to pay gas for sponsored transactions
+	FeePayerGasCoins []*types.Coin `json:"fee_payer_gas_coins,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -67,25 +70,29 @@ func CoinEqual(coin1 *types.Coin, coin2 *types.Coin) bool {
 		bytes.Equal(coin1.Digest.Data(), coin2.Digest.Data())
 }
 
-func (input *TxInput) coinsDisjoint(other *TxInput) (disjoint bool) {
-	if CoinEqual(&input.GasCoin, &other.GasCoin) {
-		return false
+func (input *TxInput) hasGasCoin() bool {
+	return len(input.GasCoin.Digest) > 0
+}
+
+// all of the objects that the transaction would consume
+func (input *TxInput) allCoins() []*types.Coin {
+	coins := []*types.Coin{}
+	if input.hasGasCoin() {
+		coins = append(coins, &input.GasCoin)
 	}
+	coins = append(coins, input.Coins...)
+	coins = append(coins, input.FeePayerGasCoins...)
+	return coins
+}
 
-	for _, coinOther := range other.Coins {
-		for _, coinInput := range input.Coins {
+func (input *TxInput) coinsDisjoint(other *TxInput) (disjoint bool) {
+	for _, coinOther := range other.allCoins() {
+		for _, coinInput := range input.allCoins() {
 			// sui object id's are globally unique.
 			if CoinEqual(coinOther, coinInput) {
 				// not disjoint
 				return false
 			}
-			// double check against the gas coin
-			if CoinEqual(coinOther, &input.GasCoin) {
-				return false
-			}
-			if CoinEqual(coinInput, &other.GasCoin) {
-				return false
-			}
 		}
 	}
 	return true
@@ -169,6 +176,20 @@ func (input *TxInput) SortCoins() {
 	SortCoins(input.Coins)
 }
 
+// IsSponsored returns true if the gas is paid by a different account (the fee payer)
+func (input *TxInput) IsSponsored() bool {
+	return len(input.FeePayerGasCoins) > 0
+}
+
+func (input *TxInput) FeePayerBalance() xc.AmountBlockchain {
+	amount := xc.NewAmountBlockchainFromUint64(0)
+	for _, coin := range input.FeePayerGasCoins {
+		coinBal := xc.NewAmountBlockchainFromUint64(coin.Balance.Uint64())
+		amount = amount.Add(&coinBal)
+	}
+	return amount
+}
+
 func (input *TxInput) IsNativeTransfer() bool {
 	if len(input.Coins) > 0 && input.Coins[0].CoinType != input.GasCoin.CoinType {
 		return false
@@ -188,7 +209,9 @@ type Tx struct {
 	// Input      TxInput
 	signatures [][]byte
 	public_key []byte
-	Tx         bcs.TransactionData__V1
+	// set for sponsored transactions, where the fee payer must also sign
+	fee_payer_public_key []byte
+	Tx                   bcs.TransactionData__V1
 }
 
 var _ xc.Tx = &Tx{}
@@ -216,14 +239,30 @@ func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
 	if err != nil {
 		return []xc.TxDataToSign{}, err
 	}
+	if tx.IsSponsored() {
+		// the sender and fee payer sign the same payload
+		return []xc.TxDataToSign{hash[:], hash[:]}, nil
+	}
 	return []xc.TxDataToSign{hash[:]}, nil
 }
+
+func (tx Tx) IsSponsored() bool {
+	return len(tx.fee_payer_public_key) > 0
+}
+
 func (tx *Tx) AddSignatures(signatures ...xc.TxSignature) error {
-	for _, sig := range signatures {
+	if tx.IsSponsored() && len(signatures) != 2 {
+		return errors.New("expecting 2 signatures (sender and fee payer)")
+	}
+	for i, sig := range signatures {
+		public_key := tx.public_key
+		if i == 1 && tx.IsSponsored() {
+			public_key = tx.fee_payer_public_key
+		}
 		// sui expects signature to be {0, signature, public_key}
 		sui_sig := []byte{0}
 		sui_sig = append(sui_sig, sig...)
-		sui_sig = append(sui_sig, tx.public_key...)
+		sui_sig = append(sui_sig, public_key...)
 		tx.signatures = append(tx.signatures, sui_sig)
 	}
 	return nil
@@ -0,0 +1,49 @@
+package sui
+
+import (
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+)
+
+func init() {
+	registry.RegisterTxVariantInput(&StakingInput{})
+	registry.RegisterTxVariantInput(&UnstakingInput{})
+}
+
+// Staking uses the same coins as a native transfer, the split coin is passed to request_add_stake.
+type StakingInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &StakingInput{}
+var _ xc.StakeTxInput = &StakingInput{}
+
+func (*StakingInput) Staking() {}
+
+func (*StakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewStakingInputType(xc.DriverSui, string(xc.Native))
+}
+
+// A StakedSui object owned by the staker
+type StakedSui struct {
+	ObjectId  string `json:"object_id"`
+	Version   uint64 `json:"version"`
+	Digest    string `json:"digest"`
+	Principal uint64 `json:"principal"`
+	Validator string `json:"validator"`
+}
+
+type UnstakingInput struct {
+	TxInput
+	// The StakedSui objects to withdraw, in the order they should be used
+	StakedSuis []*StakedSui `json:"staked_suis"`
+}
+
+var _ xc.TxVariantInput = &UnstakingInput{}
+var _ xc.UnstakeTxInput = &UnstakingInput{}
+
+func (*UnstakingInput) Unstaking() {}
+
+func (*UnstakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewUnstakingInputType(xc.DriverSui, string(xc.Native))
+}
@@ -61,6 +61,31 @@ func (s *CrosschainTestSuite) TestTxInputConflicts() {
 	}
 
 	vectors := []testcase{
+		{
+			// sponsored inputs have no gas coin of their own
+			newInput: &sui.TxInput{
+				Coins:            []*types.Coin{newPoint("00", 1)},
+				FeePayerGasCoins: []*types.Coin{newPoint("00", 100)},
+			},
+			oldInput: &sui.TxInput{
+				Coins:            []*types.Coin{newPoint("00", 2)},
+				FeePayerGasCoins: []*types.Coin{newPoint("00", 101)},
+			},
+			independent:     true,
+			doubleSpendSafe: false,
+		},
+		{
+			newInput: &sui.TxInput{
+				Coins:            []*types.Coin{newPoint("00", 1)},
+				FeePayerGasCoins: []*types.Coin{newPoint("00", 100)},
+			},
+			oldInput: &sui.TxInput{
+				Coins:            []*types.Coin{newPoint("00", 2)},
+				FeePayerGasCoins: []*types.Coin{newPoint("00", 100)}, // conflict
+			},
+			independent:     false,
+			doubleSpendSafe: true,
+		},
 		{
 			newInput: &sui.TxInput{
 				GasCoin: *newPoint("00", 100),
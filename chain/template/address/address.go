@@ -32,3 +32,8 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress checks that the address is valid for the chain
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	return xc.InvalidAddress("not implemented")
+}
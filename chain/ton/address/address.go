@@ -1,52 +1,255 @@
 package address
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"fmt"
 	"strings"
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
 	"github.com/xssnick/tonutils-go/ton/wallet"
 )
 
 // TON prescibes using this subwallet for importing compatibility
 const DefaultSubwalletId = 698983191
 
+// WalletVersion is the version of the wallet contract deployed for an address.  Each version
+// results in a different address for the same public key.
+type WalletVersion string
+
+const (
+	WalletVersionV3R2 WalletVersion = "v3r2"
+	WalletVersionV4R2 WalletVersion = "v4r2"
+	// W5, the "v5r1" final release
+	WalletVersionW5 WalletVersion = "w5"
+)
+
+// All wallet versions supported, in order of preference for discovery
+var WalletVersions = []WalletVersion{
+	WalletVersionV3R2,
+	WalletVersionV4R2,
+	WalletVersionW5,
+}
+
 // Most stable TON wallet version
-const DefaultWalletVersion = wallet.V3
+const DefaultWalletVersion = WalletVersionV3R2
+
+// Address types reported by GetAllPossibleAddressesFromPublicKey
+const (
+	AddressTypeV3R2 xc.AddressType = "V3R2"
+	AddressTypeV4R2 xc.AddressType = "V4R2"
+	AddressTypeW5   xc.AddressType = "W5"
+)
+
+// Formats a TON address can be written in
+const (
+	AddressTypeBounceable    xc.AddressType = "Bounceable"
+	AddressTypeNonBounceable xc.AddressType = "NonBounceable"
+	AddressTypeRaw           xc.AddressType = "Raw"
+)
 
-// AddressBuilder for Template
+// Network ids used by W5 wallets for replay protection
+const (
+	MainnetGlobalId = -239
+	TestnetGlobalId = -3
+)
+
+func (version WalletVersion) Valid() bool {
+	for _, v := range WalletVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (version WalletVersion) AddressType() xc.AddressType {
+	switch version {
+	case WalletVersionV4R2:
+		return AddressTypeV4R2
+	case WalletVersionW5:
+		return AddressTypeW5
+	default:
+		return AddressTypeV3R2
+	}
+}
+
+// MaxMessages returns the max number of messages that can be sent in a single transaction
+func (version WalletVersion) MaxMessages() int {
+	if version == WalletVersionW5 {
+		return 255
+	}
+	return 4
+}
+
+// SubwalletId returns the subwallet id used by default for the wallet version.  W5 wallets
+// encode the network, workchain and subwallet number into their wallet id.
+func (version WalletVersion) SubwalletId(net string) uint32 {
+	if version == WalletVersionW5 {
+		return wallet.V5R1ID{
+			NetworkGlobalID: networkGlobalId(net),
+			WorkChain:       0,
+			SubwalletNumber: 0,
+			WalletVersion:   0,
+		}.Serialized()
+	}
+	return DefaultSubwalletId
+}
+
+func (version WalletVersion) config(net string) (wallet.VersionConfig, uint32, error) {
+	switch version {
+	case WalletVersionV3R2:
+		return wallet.V3R2, DefaultSubwalletId, nil
+	case WalletVersionV4R2:
+		return wallet.V4R2, DefaultSubwalletId, nil
+	case WalletVersionW5:
+		return wallet.ConfigV5R1Final{
+			NetworkGlobalID: networkGlobalId(net),
+			Workchain:       0,
+		}, 0, nil
+	default:
+		return nil, 0, fmt.Errorf("unsupported TON wallet version: %s", version)
+	}
+}
+
+func networkGlobalId(net string) int32 {
+	if net == "testnet" {
+		return TestnetGlobalId
+	}
+	return MainnetGlobalId
+}
+
+// GetStateInit returns the initial state (code + data) of the wallet contract, needed to deploy
+// the wallet on its first transaction.
+func GetStateInit(publicKey []byte, version WalletVersion, net string) (*tlb.StateInit, error) {
+	config, subwallet, err := version.config(net)
+	if err != nil {
+		return nil, err
+	}
+	return wallet.GetStateInit(ed25519.PublicKey(publicKey), config, subwallet)
+}
+
+// VersionFromCode detects the wallet version from the code deployed on an account.
+func VersionFromCode(codeHash []byte) (WalletVersion, bool) {
+	// The code is the same regardless of public key or network
+	dummyKey := make([]byte, ed25519.PublicKeySize)
+	for _, version := range WalletVersions {
+		stateInit, err := GetStateInit(dummyKey, version, "")
+		if err != nil {
+			continue
+		}
+		if bytes.Equal(stateInit.Code.Hash(), codeHash) {
+			return version, true
+		}
+	}
+	return "", false
+}
+
+// AddressBuilder for TON
 type AddressBuilder struct {
-	Asset xc.ITask
+	Asset   xc.ITask
+	Version WalletVersion
 }
 
 var _ xc.AddressBuilder = AddressBuilder{}
 
-// NewAddressBuilder creates a new Template AddressBuilder
+// NewAddressBuilder creates a new TON AddressBuilder, using the DefaultWalletVersion
 func NewAddressBuilder(cfgI xc.ITask) (xc.AddressBuilder, error) {
-	return AddressBuilder{cfgI}, nil
+	return AddressBuilder{cfgI, DefaultWalletVersion}, nil
+}
+
+// NewAddressBuilderForVersion creates a new TON AddressBuilder for a specific wallet version
+func NewAddressBuilderForVersion(cfgI xc.ITask, version WalletVersion) (AddressBuilder, error) {
+	if !version.Valid() {
+		return AddressBuilder{}, fmt.Errorf("unsupported TON wallet version: %s", version)
+	}
+	return AddressBuilder{cfgI, version}, nil
 }
 
 // GetAddressFromPublicKey returns an Address given a public key
 func (ab AddressBuilder) GetAddressFromPublicKey(publicKeyBytes []byte) (xc.Address, error) {
-	addr, err := wallet.AddressFromPubKey(publicKeyBytes, DefaultWalletVersion, DefaultSubwalletId)
+	version := ab.Version
+	if version == "" {
+		version = DefaultWalletVersion
+	}
+	return ab.getAddress(publicKeyBytes, version)
+}
+
+func (ab AddressBuilder) getAddress(publicKeyBytes []byte, version WalletVersion) (xc.Address, error) {
+	net := ab.Asset.GetChain().Net
+	config, subwallet, err := version.config(net)
 	if err != nil {
 		return "", err
 	}
-	if ab.Asset.GetChain().Net == "testnet" {
+	addr, err := wallet.AddressFromPubKey(publicKeyBytes, config, subwallet)
+	if err != nil {
+		return "", err
+	}
+	if net == "testnet" {
 		addr.SetTestnetOnly(true)
 	}
 	return xc.Address(addr.String()), nil
 }
 
-// GetAllPossibleAddressesFromPublicKey returns all PossubleAddress(es) given a public key
+// GetAllPossibleAddressesFromPublicKey returns the address of every supported wallet version
 func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []byte) ([]xc.PossibleAddress, error) {
-	address, err := ab.GetAddressFromPublicKey(publicKeyBytes)
-	return []xc.PossibleAddress{
-		{
+	possibles := []xc.PossibleAddress{}
+	for _, version := range WalletVersions {
+		address, err := ab.getAddress(publicKeyBytes, version)
+		if err != nil {
+			return nil, err
+		}
+		possibles = append(possibles, xc.PossibleAddress{
 			Address: address,
-			Type:    xc.AddressTypeDefault,
-		},
-	}, err
+			Type:    version.AddressType(),
+		})
+	}
+	return possibles, nil
+}
+
+// VersionForAddress returns the wallet version that the address was derived with from the public key
+func (ab AddressBuilder) VersionForAddress(addr xc.Address, publicKeyBytes []byte) (WalletVersion, error) {
+	net := ab.Asset.GetChain().Net
+	target, err := ParseAddress(addr, net)
+	if err != nil {
+		return "", err
+	}
+	for _, version := range WalletVersions {
+		derived, err := ab.getAddress(publicKeyBytes, version)
+		if err != nil {
+			return "", err
+		}
+		derivedAddr, err := ParseAddress(derived, net)
+		if err != nil {
+			return "", err
+		}
+		if derivedAddr.Workchain() == target.Workchain() && bytes.Equal(derivedAddr.Data(), target.Data()) {
+			return version, nil
+		}
+	}
+	return "", fmt.Errorf("address %s is not derived from the public key by any supported wallet version", addr)
+}
+
+// ValidateAddress parses raw and user-friendly addresses, checking the CRC of the latter.  Raw
+// addresses are normalized to the bounceable format.
+func (ab AddressBuilder) ValidateAddress(addr xc.Address) *xc.AddressValidation {
+	net := ab.Asset.GetChain().Net
+	parsed, err := ParseAddress(addr, net)
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	if parsed.IsTestnetOnly() && net != "testnet" {
+		return xc.InvalidAddress("address is for testnet only")
+	}
+	addressType := AddressTypeNonBounceable
+	if len(strings.Split(string(addr), ":")) == 2 {
+		addressType = AddressTypeRaw
+	} else if parsed.IsBounceable() {
+		addressType = AddressTypeBounceable
+	}
+	return xc.ValidAddress(xc.Address(parsed.String()), addressType)
 }
 
 func ParseAddress(addr xc.Address, net string) (*address.Address, error) {
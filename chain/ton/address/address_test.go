@@ -68,3 +68,100 @@ func TestParseTestnetAddress(t *testing.T) {
 
 // 	require.Equal(t, addr1, addr2)
 // }
+
+func TestGetAllPossibleAddressesFromPublicKey(t *testing.T) {
+	builder, _ := address.NewAddressBuilder(&xc.ChainConfig{})
+	bytes, _ := hex.DecodeString("c1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1")
+	possibles, err := builder.GetAllPossibleAddressesFromPublicKey(bytes)
+	require.NoError(t, err)
+	require.Len(t, possibles, 3)
+	require.Equal(t, xc.PossibleAddress{Address: "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", Type: address.AddressTypeV3R2}, possibles[0])
+	require.Equal(t, address.AddressTypeV4R2, possibles[1].Type)
+	require.Equal(t, address.AddressTypeW5, possibles[2].Type)
+
+	unique := map[xc.Address]bool{}
+	for i, version := range address.WalletVersions {
+		versionBuilder, err := address.NewAddressBuilderForVersion(&xc.ChainConfig{}, version)
+		require.NoError(t, err)
+		addr, err := versionBuilder.GetAddressFromPublicKey(bytes)
+		require.NoError(t, err)
+		require.Equal(t, possibles[i].Address, addr)
+		unique[addr] = true
+
+		detected, err := versionBuilder.VersionForAddress(addr, bytes)
+		require.NoError(t, err)
+		require.Equal(t, version, detected)
+	}
+	require.Len(t, unique, 3)
+
+	_, err = address.NewAddressBuilderForVersion(&xc.ChainConfig{}, "v2")
+	require.ErrorContains(t, err, "unsupported")
+
+	// unrelated address
+	_, err = builder.(address.AddressBuilder).VersionForAddress("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm", bytes)
+	require.ErrorContains(t, err, "not derived from the public key")
+}
+
+func TestW5AddressDependsOnNetwork(t *testing.T) {
+	bytes, _ := hex.DecodeString("c1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1")
+	mainnet, _ := address.NewAddressBuilderForVersion(&xc.ChainConfig{}, address.WalletVersionW5)
+	testnet, _ := address.NewAddressBuilderForVersion(&xc.ChainConfig{Net: "testnet"}, address.WalletVersionW5)
+	mainnetAddr, err := mainnet.GetAddressFromPublicKey(bytes)
+	require.NoError(t, err)
+	testnetAddr, err := testnet.GetAddressFromPublicKey(bytes)
+	require.NoError(t, err)
+
+	a, _ := address.ParseAddress(mainnetAddr, "")
+	b, _ := address.ParseAddress(testnetAddr, "testnet")
+	require.NotEqual(t, a.Data(), b.Data())
+
+	// the wallet id encodes the network
+	require.EqualValues(t, 2147483409, address.WalletVersionW5.SubwalletId(""))
+	require.EqualValues(t, 2147483645, address.WalletVersionW5.SubwalletId("testnet"))
+	require.EqualValues(t, address.DefaultSubwalletId, address.WalletVersionV4R2.SubwalletId(""))
+}
+
+func TestVersionFromCode(t *testing.T) {
+	for _, version := range address.WalletVersions {
+		stateInit, err := address.GetStateInit(make([]byte, 32), version, "testnet")
+		require.NoError(t, err)
+		detected, ok := address.VersionFromCode(stateInit.Code.Hash())
+		require.True(t, ok)
+		require.Equal(t, version, detected)
+	}
+	_, ok := address.VersionFromCode(make([]byte, 32))
+	require.False(t, ok)
+}
+
+func TestValidateAddress(t *testing.T) {
+	vectors := []struct {
+		name        string
+		net         string
+		address     string
+		normalized  string
+		addressType xc.AddressType
+		reason      string
+	}{
+		{"bounceable", "mainnet", "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", address.AddressTypeBounceable, ""},
+		{"non_bounceable", "mainnet", "UQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSktz", "UQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSktz", address.AddressTypeNonBounceable, ""},
+		{"raw", "mainnet", "0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A", "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", address.AddressTypeRaw, ""},
+		{"testnet", "testnet", "kQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSq08", "kQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSq08", address.AddressTypeBounceable, ""},
+		{"testnet_on_mainnet", "mainnet", "kQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSq08", "", "", "address is for testnet only"},
+		{"bad_crc", "mainnet", "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha3", "", "", "invalid address"},
+		{"bad_raw", "mainnet", "0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE", "", "", "incorrect address data length"},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			builder, _ := address.NewAddressBuilder(&xc.ChainConfig{Net: v.net})
+			result := builder.ValidateAddress(xc.Address(v.address))
+			if v.reason != "" {
+				require.False(t, result.Valid)
+				require.Equal(t, v.reason, result.Reason)
+				return
+			}
+			require.True(t, result.Valid, result.Reason)
+			require.Equal(t, xc.Address(v.normalized), result.Normalized)
+			require.Equal(t, v.addressType, result.Type)
+		})
+	}
+}
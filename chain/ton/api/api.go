@@ -106,6 +106,14 @@ type GetMethod string
 var GetPublicKeyMethod GetMethod = "get_public_key"
 var GetSequenceMethod GetMethod = "seqno"
 var GetWalletAddressMethod GetMethod = "get_wallet_address"
+var GetJettonDataMethod GetMethod = "get_jetton_data"
+var GetWalletDataMethod GetMethod = "get_wallet_data"
+
+// Staking pool methods
+var GetRolesMethod GetMethod = "get_roles"
+var GetPoolDataMethod GetMethod = "get_pool_data"
+var GetNominatorDataMethod GetMethod = "get_nominator_data"
+var GetPoolFullDataMethod GetMethod = "get_pool_full_data"
 
 type GetMethodRequest struct {
 	Address string      `json:"address"`
@@ -1,6 +1,10 @@
 package api
 
-import xc "github.com/cordialsys/crosschain"
+import (
+	"encoding/json"
+
+	xc "github.com/cordialsys/crosschain"
+)
 
 type JettonTransfer struct {
 	QueryID             string              `json:"query_id"`
@@ -21,3 +25,22 @@ type JettonTransfer struct {
 type JettonTransfersResponse struct {
 	JettonTransfers []JettonTransfer `json:"jetton_transfers"`
 }
+
+// Off-chain jetton metadata, as defined by TEP-64
+type JettonMetadata struct {
+	Name        string `json:"name"`
+	Symbol      string `json:"symbol"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	// TEP-64 specifies decimals as a string, but some jettons use a number
+	Decimals json.Number `json:"decimals"`
+}
+
+type JettonMaster struct {
+	Address      string `json:"address"`
+	AdminAddress string `json:"admin_address"`
+}
+
+type JettonMastersResponse struct {
+	JettonMasters []JettonMaster `json:"jetton_masters"`
+}
@@ -1,7 +1,6 @@
 package ton
 
 import (
-	"crypto/ed25519"
 	"errors"
 	"fmt"
 	"math/big"
@@ -46,18 +45,11 @@ func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInpu
 func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
 	txInput := input.(*TxInput)
 
-	var stateInit *tlb.StateInit
-	var err error
-	if txInput.AccountStatus != api.Active {
-		if len(txInput.PublicKey) == 0 {
-			return nil, fmt.Errorf("did not set public-key in tx-input for new ton account %s", from)
-		}
-		stateInit, err = wallet.GetStateInit(ed25519.PublicKey(txInput.PublicKey), tonaddress.DefaultWalletVersion, tonaddress.DefaultSubwalletId)
-		if err != nil {
-			return nil, err
-		}
-	}
 	net := txBuilder.Asset.GetChain().Net
+	version, stateInit, err := txBuilder.walletState(from, txInput)
+	if err != nil {
+		return nil, err
+	}
 
 	toAddr, err := tonaddress.ParseAddress(to, net)
 	if err != nil {
@@ -80,13 +72,7 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 		if err != nil {
 			return nil, fmt.Errorf("invalid TON token address %s: %v", txInput.TokenWallet, err)
 		}
-		// Spend max 0.2 TON per Jetton transfer.  If we don't have 0.2 TON, we should
-		// lower the max to our balance less max-fees.
-		maxJettonFee := xc.NewAmountBlockchainFromUint64(200000000)
-		remainingTonBal := txInput.TonBalance.Sub(&txInput.EstimatedMaxFee)
-		if maxJettonFee.Cmp(&remainingTonBal) > 0 && remainingTonBal.Cmp(&Zero) > 0 {
-			maxJettonFee = remainingTonBal
-		}
+		maxJettonFee := MaxJettonFee(txInput)
 
 		tfMsg, err := BuildJettonTransfer(uint64(txInput.Timestamp), fromAddr, tokenAddr, toAddr, amountTlb, tlb.FromNanoTON(maxJettonFee.Int()), txInput.Memo)
 		if err != nil {
@@ -103,18 +89,93 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 		msgs = append(msgs, tfMsg)
 	}
 
+	return txBuilder.buildTx(fromAddr, txInput, msgs, stateInit, version)
+}
+
+// NewJettonBurn creates a transaction burning jettons held by the sender.  The input should be
+// fetched for the token asset, so the sender's jetton wallet is set.
+func (txBuilder TxBuilder) NewJettonBurn(from xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	txInput := input.(*TxInput)
+	net := txBuilder.Asset.GetChain().Net
+	version, stateInit, err := txBuilder.walletState(from, txInput)
+	if err != nil {
+		return nil, err
+	}
+	fromAddr, err := tonaddress.ParseAddress(from, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", from, err)
+	}
+	tokenAddr, err := tonaddress.ParseAddress(txInput.TokenWallet, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON token address %s: %v", txInput.TokenWallet, err)
+	}
+	maxJettonFee := MaxJettonFee(txInput)
+
+	burnMsg, err := BuildJettonBurn(uint64(txInput.Timestamp), fromAddr, tokenAddr, tlb.FromNanoTON(amount.Int()), tlb.FromNanoTON(maxJettonFee.Int()))
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.buildTx(fromAddr, txInput, []*wallet.Message{burnMsg}, stateInit, version)
+}
+
+func (txBuilder TxBuilder) buildTx(fromAddr *address.Address, txInput *TxInput, msgs []*wallet.Message, stateInit *tlb.StateInit, version tonaddress.WalletVersion) (xc.Tx, error) {
+	net := txBuilder.Asset.GetChain().Net
 	logrus.WithFields(logrus.Fields{
 		"messages":   len(msgs),
 		"state-init": stateInit != nil,
+		"version":    version,
 		"chain":      txBuilder.Asset.GetChain().Chain,
 	}).Debug("building tx")
 
-	cellBuilder, err := BuildV3UnsignedMessage(txInput, msgs)
+	cellBuilder, err := BuildUnsignedMessage(version, net, txInput, msgs)
 	if err != nil {
 		return nil, err
 	}
 
-	return tontx.NewTx(fromAddr, cellBuilder, stateInit), nil
+	tx := tontx.NewTx(fromAddr, cellBuilder, stateInit)
+	tx.SignatureLast = version == tonaddress.WalletVersionW5
+	return tx, nil
+}
+
+// Returns the wallet version of the sender, and the state-init if the wallet still needs to be deployed.
+func (txBuilder TxBuilder) walletState(from xc.Address, txInput *TxInput) (tonaddress.WalletVersion, *tlb.StateInit, error) {
+	version, err := txBuilder.walletVersion(from, txInput)
+	if err != nil {
+		return "", nil, err
+	}
+	if txInput.AccountStatus != api.Active {
+		if len(txInput.PublicKey) == 0 {
+			return "", nil, fmt.Errorf("did not set public-key in tx-input for new ton account %s", from)
+		}
+		stateInit, err := tonaddress.GetStateInit(txInput.PublicKey, version, txBuilder.Asset.GetChain().Net)
+		if err != nil {
+			return "", nil, err
+		}
+		return version, stateInit, nil
+	}
+	return version, nil, nil
+}
+
+// Use the version of the deployed wallet contract if known, otherwise figure out which version
+// the address was derived with.  Addresses not derived by any supported version (e.g. v4r1 or custom
+// wallets) use the default version.
+func (txBuilder TxBuilder) walletVersion(from xc.Address, txInput *TxInput) (tonaddress.WalletVersion, error) {
+	if txInput.WalletVersion != "" {
+		if !txInput.WalletVersion.Valid() {
+			return "", fmt.Errorf("unsupported TON wallet version: %s", txInput.WalletVersion)
+		}
+		return txInput.WalletVersion, nil
+	}
+	if len(txInput.PublicKey) == 0 {
+		return tonaddress.DefaultWalletVersion, nil
+	}
+	addressBuilder, _ := tonaddress.NewAddressBuilderForVersion(txBuilder.Asset, tonaddress.DefaultWalletVersion)
+	version, err := addressBuilder.VersionForAddress(from, txInput.PublicKey)
+	if err != nil {
+		logrus.WithError(err).Warn("using the default wallet version")
+		return tonaddress.DefaultWalletVersion, nil
+	}
+	return version, nil
 }
 
 // NewNativeTransfer creates a new transfer for a native asset
@@ -175,26 +236,123 @@ func BuildJettonTransfer(randomInt uint64, from *address.Address, tokenWallet *a
 	return wallet.SimpleMessage(tokenWallet, maxFee, tokenBody), nil
 }
 
+// BuildJettonBurn burns jettons from the sender's jetton wallet.  Excess TON is returned to the sender.
+func BuildJettonBurn(randomInt uint64, from *address.Address, tokenWallet *address.Address, amount tlb.Coins, maxFee tlb.Coins) (*wallet.Message, error) {
+	burnBody, err := tlb.ToCell(jetton.BurnPayload{
+		QueryID:             randomInt,
+		Amount:              amount,
+		ResponseDestination: from,
+		CustomPayload:       nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SimpleMessage(tokenWallet, maxFee, burnBody), nil
+}
+
+// Spend max 0.2 TON per Jetton message.  If we don't have 0.2 TON, we should
+// lower the max to our balance less max-fees.
+func MaxJettonFee(txInput *TxInput) xc.AmountBlockchain {
+	maxJettonFee := xc.NewAmountBlockchainFromUint64(200000000)
+	remainingTonBal := txInput.TonBalance.Sub(&txInput.EstimatedMaxFee)
+	if maxJettonFee.Cmp(&remainingTonBal) > 0 && remainingTonBal.Cmp(&Zero) > 0 {
+		maxJettonFee = remainingTonBal
+	}
+	return maxJettonFee
+}
+
+// BuildUnsignedMessage builds the payload to be signed for the given wallet version
+func BuildUnsignedMessage(version tonaddress.WalletVersion, net string, txInput *TxInput, messages []*wallet.Message) (*cell.Builder, error) {
+	switch version {
+	case tonaddress.WalletVersionV3R2:
+		return BuildV3UnsignedMessage(txInput, messages)
+	case tonaddress.WalletVersionV4R2:
+		return BuildV4R2UnsignedMessage(txInput, messages)
+	case tonaddress.WalletVersionW5:
+		return BuildW5UnsignedMessage(net, txInput, messages)
+	default:
+		return nil, fmt.Errorf("unsupported TON wallet version: %s", version)
+	}
+}
+
 func BuildV3UnsignedMessage(txInput *TxInput, messages []*wallet.Message) (*cell.Builder, error) {
 	// TON v3 wallets have a max of 4 messages
-	if len(messages) > 4 {
+	if len(messages) > tonaddress.WalletVersionV3R2.MaxMessages() {
+		return nil, errors.New("for this type of wallet max 4 messages can be sent in the same time")
+	}
+
+	payload := cell.BeginCell().MustStoreUInt(tonaddress.DefaultSubwalletId, 32).
+		MustStoreUInt(uint64(expiration(txInput)), 32).
+		MustStoreUInt(uint64(txInput.Sequence), 32)
+
+	return storeMessages(payload, messages)
+}
+
+func BuildV4R2UnsignedMessage(txInput *TxInput, messages []*wallet.Message) (*cell.Builder, error) {
+	// TON v4 wallets have a max of 4 messages
+	if len(messages) > tonaddress.WalletVersionV4R2.MaxMessages() {
 		return nil, errors.New("for this type of wallet max 4 messages can be sent in the same time")
 	}
 
-	seq := txInput.Sequence
-	expiration := time.Unix(txInput.Timestamp, 0).Add(2 * time.Hour).Unix()
 	payload := cell.BeginCell().MustStoreUInt(tonaddress.DefaultSubwalletId, 32).
-		MustStoreUInt(uint64(expiration), 32).
-		MustStoreUInt(uint64(seq), 32)
+		MustStoreUInt(uint64(expiration(txInput)), 32).
+		MustStoreUInt(uint64(txInput.Sequence), 32).
+		// op 0 is a simple send, other ops are for plugins
+		MustStoreUInt(0, 8)
 
+	return storeMessages(payload, messages)
+}
+
+// W5 wallets sign a list of "out actions" rather than a list of messages, and the signature
+// is expected at the end of the body.
+func BuildW5UnsignedMessage(net string, txInput *TxInput, messages []*wallet.Message) (*cell.Builder, error) {
+	if len(messages) > tonaddress.WalletVersionW5.MaxMessages() {
+		return nil, errors.New("for this type of wallet max 255 messages can be sent in the same time")
+	}
+
+	// out_list_empty$_ = OutList 0;
+	// out_list$_ {n:#} prev:^(OutList n) action:OutAction = OutList (n + 1);
+	// action_send_msg#0ec3c86d mode:(## 8) out_msg:^(MessageRelaxed Any) = OutAction;
+	actions := cell.BeginCell().EndCell()
 	for i, message := range messages {
 		intMsg, err := tlb.ToCell(message.InternalMessage)
 		if err != nil {
 			return nil, fmt.Errorf("failed to convert internal message %d to cell: %w", i, err)
 		}
-		payload.MustStoreUInt(uint64(message.Mode), 8).MustStoreRef(intMsg)
+		actions = cell.BeginCell().MustStoreRef(actions).
+			MustStoreUInt(0x0ec3c86d, 32).
+			MustStoreUInt(uint64(message.Mode), 8).
+			MustStoreRef(intMsg).
+			EndCell()
 	}
 
+	payload := cell.BeginCell().
+		// external signed request op
+		MustStoreUInt(0x7369676e, 32).
+		MustStoreUInt(uint64(tonaddress.WalletVersionW5.SubwalletId(net)), 32).
+		MustStoreUInt(uint64(expiration(txInput)), 32).
+		MustStoreUInt(uint64(txInput.Sequence), 32).
+		// Maybe ^OutList
+		MustStoreUInt(1, 1).
+		MustStoreRef(actions).
+		// no extended actions
+		MustStoreUInt(0, 1)
+
+	return payload, nil
+}
+
+func expiration(txInput *TxInput) int64 {
+	return time.Unix(txInput.Timestamp, 0).Add(2 * time.Hour).Unix()
+}
+
+func storeMessages(payload *cell.Builder, messages []*wallet.Message) (*cell.Builder, error) {
+	for i, message := range messages {
+		intMsg, err := tlb.ToCell(message.InternalMessage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert internal message %d to cell: %w", i, err)
+		}
+		payload.MustStoreUInt(uint64(message.Mode), 8).MustStoreRef(intMsg)
+	}
 	return payload, nil
 }
 
@@ -0,0 +1,174 @@
+package ton
+
+import (
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton/jetton"
+	"github.com/xssnick/tonutils-go/ton/wallet"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+const (
+	// Single nominator owner request to withdraw from the pool
+	OpSingleNominatorWithdraw = 0x1000
+	// Deposit into a liquid staking pool
+	OpLiquidPoolDeposit = 0x47d54391
+	OpJettonBurn        = 0x595f07bc
+)
+
+// Nominator pools require 1 TON to be attached to process a withdraw request, any excess is returned
+var NominatorPoolFee = tlb.MustFromTON("1")
+
+// Attached to the single nominator withdraw request to pay for gas
+var SingleNominatorWithdrawFee = tlb.MustFromTON("0.1")
+
+var _ xcbuilder.Staking = &TxBuilder{}
+
+func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
+	stakeInput, ok := input.(*StakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &StakingInput{})
+	}
+	net := txBuilder.Asset.GetChain().Net
+	pool, err := tonaddress.ParseAddress(stakeInput.Pool, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON pool address %s: %v", stakeInput.Pool, err)
+	}
+	from, err := tonaddress.ParseAddress(args.GetFrom(), net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", args.GetFrom(), err)
+	}
+	amount := args.GetAmount()
+	amountTlb := tlb.FromNanoTON(amount.Int())
+
+	var msg *wallet.Message
+	switch stakeInput.PoolKind {
+	case SingleNominatorPool:
+		msg, err = BuildTransfer(pool, amountTlb, true, "")
+	case NominatorPool:
+		if err := CheckNominator(from); err != nil {
+			return nil, err
+		}
+		msg, err = BuildTransfer(pool, amountTlb, true, "d")
+	case LiquidPool:
+		msg, err = BuildLiquidPoolDeposit(uint64(stakeInput.Timestamp), pool, amountTlb)
+	default:
+		return nil, fmt.Errorf("unsupported TON pool kind: %s", stakeInput.PoolKind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.buildStakingTx(args.GetFrom(), &stakeInput.TxInput, msg)
+}
+
+// Unstake requests a withdrawal from the pool.  Nominator pools can only withdraw the full stake,
+// and liquid pools unstake by burning the given amount of the pool jetton.
+func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
+	unstakeInput, ok := input.(*UnstakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &UnstakingInput{})
+	}
+	net := txBuilder.Asset.GetChain().Net
+	pool, err := tonaddress.ParseAddress(unstakeInput.Pool, net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON pool address %s: %v", unstakeInput.Pool, err)
+	}
+	from, err := tonaddress.ParseAddress(args.GetFrom(), net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", args.GetFrom(), err)
+	}
+	amount := args.GetAmount()
+	amountTlb := tlb.FromNanoTON(amount.Int())
+
+	var msg *wallet.Message
+	switch unstakeInput.PoolKind {
+	case SingleNominatorPool:
+		msg, err = BuildSingleNominatorWithdraw(uint64(unstakeInput.Timestamp), pool, amountTlb)
+	case NominatorPool:
+		if err := CheckNominator(from); err != nil {
+			return nil, err
+		}
+		msg, err = BuildTransfer(pool, NominatorPoolFee, true, "w")
+	case LiquidPool:
+		tokenWallet, err := tonaddress.ParseAddress(unstakeInput.TokenWallet, net)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TON token address %s: %v", unstakeInput.TokenWallet, err)
+		}
+		maxFee := MaxJettonFee(&unstakeInput.TxInput)
+		msg, err = BuildLiquidPoolUnstake(uint64(unstakeInput.Timestamp), from, tokenWallet, amountTlb, tlb.FromNanoTON(maxFee.Int()))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported TON pool kind: %s", unstakeInput.PoolKind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return txBuilder.buildStakingTx(args.GetFrom(), &unstakeInput.TxInput, msg)
+}
+
+func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
+	return nil, errors.New("TON pools return the stake automatically after unstaking, there is no need to withdraw")
+}
+
+// CheckNominator checks that the address can be a nominator of a nominator-pool.  Nominator pools only
+// accept nominators on the masterchain, deposits from other workchains are bounced.
+func CheckNominator(nominator *address.Address) error {
+	if nominator.Workchain() != address.MasterchainID {
+		return fmt.Errorf("nominator pools only accept masterchain wallets, %s is on workchain %d", nominator.String(), nominator.Workchain())
+	}
+	return nil
+}
+
+func (txBuilder TxBuilder) buildStakingTx(from xc.Address, txInput *TxInput, msg *wallet.Message) (xc.Tx, error) {
+	version, stateInit, err := txBuilder.walletState(from, txInput)
+	if err != nil {
+		return nil, err
+	}
+	fromAddr, err := tonaddress.ParseAddress(from, txBuilder.Asset.GetChain().Net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", from, err)
+	}
+	return txBuilder.buildTx(fromAddr, txInput, []*wallet.Message{msg}, stateInit, version)
+}
+
+// withdraw#1000 query_id:uint64 amount:Coins
+func BuildSingleNominatorWithdraw(queryId uint64, pool *address.Address, amount tlb.Coins) (*wallet.Message, error) {
+	body := cell.BeginCell().
+		MustStoreUInt(OpSingleNominatorWithdraw, 32).
+		MustStoreUInt(queryId, 64).
+		MustStoreBigCoins(amount.Nano()).
+		EndCell()
+	return wallet.SimpleMessage(pool, SingleNominatorWithdrawFee, body), nil
+}
+
+// deposit#47d54391 query_id:uint64
+func BuildLiquidPoolDeposit(queryId uint64, pool *address.Address, amount tlb.Coins) (*wallet.Message, error) {
+	body := cell.BeginCell().
+		MustStoreUInt(OpLiquidPoolDeposit, 32).
+		MustStoreUInt(queryId, 64).
+		EndCell()
+	return wallet.SimpleMessage(pool, amount, body), nil
+}
+
+// Liquid pools unstake by burning the pool jetton.  The custom payload requests
+// (wait_till_round_end:Bool fill_or_kill:Bool), which are both false to withdraw as soon as possible.
+func BuildLiquidPoolUnstake(queryId uint64, from *address.Address, tokenWallet *address.Address, amount tlb.Coins, maxFee tlb.Coins) (*wallet.Message, error) {
+	burnBody, err := tlb.ToCell(jetton.BurnPayload{
+		QueryID:             queryId,
+		Amount:              amount,
+		ResponseDestination: from,
+		CustomPayload:       cell.BeginCell().MustStoreBoolBit(false).MustStoreBoolBit(false).EndCell(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wallet.SimpleMessage(tokenWallet, maxFee, burnBody), nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -19,6 +20,8 @@ import (
 	"github.com/cordialsys/crosschain/chain/ton/api"
 	tontx "github.com/cordialsys/crosschain/chain/ton/tx"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 	"github.com/xssnick/tonutils-go/address"
 	"github.com/xssnick/tonutils-go/tlb"
@@ -28,12 +31,18 @@ import (
 
 // Client for Template
 type Client struct {
-	Url    string
-	Asset  xc.ITask
-	ApiKey string
+	Url        string
+	Asset      xc.ITask
+	ApiKey     string
+	HttpClient *http.Client
+	// Used for off-chain jetton metadata, which may be hosted anywhere
+	MetadataHttpClient *http.Client
+	cache              cache.Cache
 }
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
+var _ cache.Cacheable = &Client{}
 
 // NewClient returns a new Template Client
 func NewClient(cfgI xc.ITask) (*Client, error) {
@@ -41,25 +50,42 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 	url = strings.TrimSuffix(url, "/")
 	apiKey := cfgI.GetChain().AuthSecret
 
-	return &Client{url, cfgI, apiKey}, nil
+	httpClient := utils.NewHttpClient(cfgI.GetChain())
+	metadataHttpClient := utils.NewPublicHttpClient(string(cfgI.GetChain().Chain))
+	return &Client{url, cfgI, apiKey, httpClient, metadataHttpClient, nil}, nil
+}
+
+// FetchLatestHeight returns the latest masterchain block of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	chainInfo := &api.MasterChainInfo{}
+	err := client.get("/api/v3/masterchainInfo", chainInfo)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(chainInfo.Last.Seqno), nil
+}
+
+// SetCache caches jetton wallet addresses, which never change, and jetton metadata
+func (cli *Client) SetCache(cache cache.Cache) {
+	cli.cache = cache
 }
 
 func (cli *Client) get(path string, response any) error {
-	return cli.send("GET", path, nil, response)
+	return cli.send(context.Background(), "GET", path, nil, response)
 }
 func (cli *Client) post(path string, requestBody any, response any) error {
-	return cli.send("POST", path, requestBody, response)
+	return cli.send(context.Background(), "POST", path, requestBody, response)
 }
-func (cli *Client) send(method string, path string, requestBody any, response any) error {
+func (cli *Client) send(ctx context.Context, method string, path string, requestBody any, response any) error {
 	path = strings.TrimPrefix(path, "/")
 	url := fmt.Sprintf("%s/%s", cli.Url, path)
 	var request *http.Request
 	var err error
 	if requestBody == nil {
-		request, err = http.NewRequest(method, url, nil)
+		request, err = http.NewRequestWithContext(ctx, method, url, nil)
 	} else {
 		bz, _ := json.Marshal(requestBody)
-		request, err = http.NewRequest(method, url, bytes.NewBuffer(bz))
+		request, err = http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(bz))
 		if err == nil {
 			request.Header.Add("content-type", "application/json")
 		}
@@ -71,7 +97,7 @@ func (cli *Client) send(method string, path string, requestBody any, response an
 		request.Header.Add("X-API-Key", cli.ApiKey)
 	}
 	logrus.WithField("url", url).Debug(method)
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := cli.HttpClient.Do(request)
 	if err != nil {
 		return fmt.Errorf("failed to GET: %v", err)
 	}
@@ -111,7 +137,16 @@ func (cli *Client) send(method string, path string, requestBody any, response an
 		return fmt.Errorf("unknown ton error (%d)", resp.StatusCode)
 	}
 }
+
+// GetTokenWallet returns the jetton wallet of the owner for the jetton master contract
 func (client *Client) GetTokenWallet(ctx context.Context, from xc.Address, contract xc.ContractAddress) (xc.Address, error) {
+	key := cache.Key(client.Asset.GetChain().Chain, "token-wallet", string(contract), string(from))
+	return cache.GetOrFetch(ctx, client.cache, key, cache.NoExpiry, func() (xc.Address, error) {
+		return client.getTokenWallet(ctx, from, contract)
+	})
+}
+
+func (client *Client) getTokenWallet(ctx context.Context, from xc.Address, contract xc.ContractAddress) (xc.Address, error) {
 	net := client.Asset.GetChain().Net
 	ownerAddr, err := tonaddress.ParseAddress(from, net)
 	if err != nil {
@@ -121,7 +156,7 @@ func (client *Client) GetTokenWallet(ctx context.Context, from xc.Address, contr
 	// .BeginParse()
 	getTokenWalletResponse := &api.GetMethodResponse{}
 	err = client.post("api/v3/runGetMethod", &api.GetMethodRequest{
-		Address: string(client.Asset.GetContract()),
+		Address: string(contract),
 		Method:  api.GetWalletAddressMethod,
 		Stack: []api.StackItem{
 			{
@@ -216,6 +251,13 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 		Sequence:        sequence,
 		TonBalance:      xc.NewAmountBlockchainFromStr(acc.Balance),
 	}
+	if acc.Status == api.Active && acc.Code != "" {
+		// Wallets we don't recognize (e.g. v4r1, highload) fall back to the version the address was derived with
+		input.WalletVersion, err = DetectWalletVersion(acc.Code)
+		if err != nil {
+			logrus.WithError(err).WithField("address", args.GetFrom()).Warn("could not detect wallet version")
+		}
+	}
 
 	if client.Asset.GetContract() != "" {
 		input.TokenWallet, err = client.GetTokenWallet(ctx, args.GetFrom(), xc.ContractAddress(client.Asset.GetContract()))
@@ -249,6 +291,24 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 
 	return input, nil
 }
+
+// DetectWalletVersion detects the version of a wallet contract from its code (base64 boc)
+func DetectWalletVersion(code string) (tonaddress.WalletVersion, error) {
+	boc, err := base64.StdEncoding.DecodeString(code)
+	if err != nil {
+		return "", fmt.Errorf("invalid base64: %v", err)
+	}
+	codeCell, err := cell.FromBOC(boc)
+	if err != nil {
+		return "", fmt.Errorf("invalid boc: %v", err)
+	}
+	version, ok := tonaddress.VersionFromCode(codeCell.Hash())
+	if !ok {
+		return "", fmt.Errorf("unsupported wallet contract (code hash %s)", hex.EncodeToString(codeCell.Hash()))
+	}
+	return version, nil
+}
+
 func (client *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
 	// No way to pass the amount in the input using legacy interface, so we estimate using min amount.
 	args, _ := xcbuilder.NewTransferArgs(from, to, xc.NewAmountBlockchainFromUint64(1))
@@ -263,7 +323,7 @@ func (client *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
 	}
 	bzBase64 := base64.StdEncoding.EncodeToString(bz)
 	resp := &api.SubmitMessageResponse{}
-	err = client.post("api/v3/message", &api.SubmitMessageRequest{
+	err = client.send(utils.NonIdempotent(ctx), "POST", "api/v3/message", &api.SubmitMessageRequest{
 		Boc: bzBase64,
 	}, resp)
 	if err != nil {
@@ -517,6 +577,7 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		info.To = info.Destinations[0].Address
 		info.Amount = info.Destinations[0].Amount
 	}
+	client.DetectStakingEvents(ctx, &tx, addrBook, &info)
 
 	return info, nil
 }
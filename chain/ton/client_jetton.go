@@ -0,0 +1,293 @@
+package ton
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
+	"github.com/cordialsys/crosschain/chain/ton/api"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	"github.com/sirupsen/logrus"
+	"github.com/xssnick/tonutils-go/ton/nft"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+// Jettons default to 9 decimals when not specified in the metadata (TEP-64)
+const DefaultJettonDecimals = 9
+
+// Page size used when listing jetton wallets
+const jettonWalletsPageSize = 100
+
+// Gateway used to resolve ipfs:// metadata uri's
+var IpfsGateway = "https://ipfs.io/ipfs/"
+
+// Off-chain metadata larger than this is ignored
+var MaxJettonMetadataSize int64 = 64 * 1024
+
+type JettonMetadata struct {
+	Name        string `json:"name"`
+	Symbol      string `json:"symbol"`
+	Description string `json:"description,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Decimals    int32  `json:"decimals"`
+	// Set if some or all of the metadata is stored off-chain
+	Uri string `json:"uri,omitempty"`
+}
+
+type JettonData struct {
+	Master      xc.ContractAddress  `json:"master"`
+	TotalSupply xc.AmountBlockchain `json:"total_supply"`
+	Mintable    bool                `json:"mintable"`
+	Admin       xc.Address          `json:"admin,omitempty"`
+	Metadata    JettonMetadata      `json:"metadata"`
+}
+
+type JettonBalance struct {
+	Master  xc.ContractAddress  `json:"master"`
+	Wallet  xc.Address          `json:"wallet"`
+	Balance xc.AmountBlockchain `json:"balance"`
+}
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+// FetchTokenMetadata returns the metadata of a jetton master contract.
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	data, err := client.FetchJettonData(ctx, contract)
+	if err != nil {
+		return nil, err
+	}
+	return &xclient.TokenMetadata{
+		Contract: contract,
+		Name:     data.Metadata.Name,
+		Symbol:   data.Metadata.Symbol,
+		Decimals: data.Metadata.Decimals,
+	}, nil
+}
+
+// FetchJettonData runs `get_jetton_data` on a jetton master contract, and resolves its metadata
+// which may be stored on-chain, off-chain, or both.
+func (client *Client) FetchJettonData(ctx context.Context, master xc.ContractAddress) (*JettonData, error) {
+	// the total supply can change, so this expires
+	key := cache.Key(client.Asset.GetChain().Chain, "jetton-data", string(master))
+	return cache.GetOrFetch(ctx, client.cache, key, cache.DefaultMetadataTTL, func() (*JettonData, error) {
+		return client.fetchJettonData(ctx, master)
+	})
+}
+
+func (client *Client) fetchJettonData(ctx context.Context, master xc.ContractAddress) (*JettonData, error) {
+	resp := &api.GetMethodResponse{}
+	err := client.post("api/v3/runGetMethod", &api.GetMethodRequest{
+		Address: string(master),
+		Method:  api.GetJettonDataMethod,
+		Stack:   []api.StackItem{},
+	}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("could not get jetton data: %v", err)
+	}
+	// (total_supply, mintable, admin_address, jetton_content, jetton_wallet_code)
+	if resp.ExitCode != 0 || len(resp.Stack) < 4 {
+		return nil, fmt.Errorf("could not get jetton data for %s (%d)", master, resp.ExitCode)
+	}
+	mintable := xc.NewAmountBlockchainFromStr(resp.Stack[1].Value)
+	data := &JettonData{
+		Master:      master,
+		TotalSupply: xc.NewAmountBlockchainFromStr(resp.Stack[0].Value),
+		Mintable:    !mintable.IsZero(),
+	}
+
+	adminCell, err := decodeStackCell(resp.Stack[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid jetton admin: %v", err)
+	}
+	// The admin may be removed, leaving an empty address
+	if admin, err := adminCell.BeginParse().LoadAddr(); err == nil && !admin.IsAddrNone() {
+		data.Admin = xc.Address(admin.String())
+	}
+
+	contentCell, err := decodeStackCell(resp.Stack[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid jetton content: %v", err)
+	}
+	content, err := nft.ContentFromCell(contentCell)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jetton content: %v", err)
+	}
+	data.Metadata, err = client.resolveJettonMetadata(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func (client *Client) resolveJettonMetadata(ctx context.Context, content nft.ContentAny) (JettonMetadata, error) {
+	metadata := JettonMetadata{
+		Decimals: DefaultJettonDecimals,
+	}
+	var onchain *nft.ContentOnchain
+	switch content := content.(type) {
+	case *nft.ContentOffchain:
+		metadata.Uri = content.URI
+	case *nft.ContentSemichain:
+		metadata.Uri = content.URI
+		onchain = &content.ContentOnchain
+	case *nft.ContentOnchain:
+		onchain = content
+	}
+
+	if metadata.Uri != "" {
+		// The uri is set by the jetton deployer and may be unavailable, so only the on-chain values are used then
+		offchain, err := client.fetchOffchainMetadata(ctx, metadata.Uri)
+		if err != nil {
+			logrus.WithError(err).WithField("uri", metadata.Uri).Warn("could not fetch off-chain jetton metadata")
+		} else {
+			metadata.Name = offchain.Name
+			metadata.Symbol = offchain.Symbol
+			metadata.Description = offchain.Description
+			metadata.Image = offchain.Image
+			if offchain.Decimals != "" {
+				if err := setDecimals(&metadata, offchain.Decimals.String()); err != nil {
+					logrus.WithError(err).WithField("uri", metadata.Uri).Warn("invalid off-chain jetton metadata")
+				}
+			}
+		}
+	}
+
+	// On-chain values take precedence over off-chain values
+	if onchain != nil {
+		for _, field := range []struct {
+			key   string
+			value *string
+		}{
+			{"name", &metadata.Name},
+			{"symbol", &metadata.Symbol},
+			{"description", &metadata.Description},
+			{"image", &metadata.Image},
+		} {
+			if value := onchain.GetAttribute(field.key); value != "" {
+				*field.value = value
+			}
+		}
+		if decimals := onchain.GetAttribute("decimals"); decimals != "" {
+			if err := setDecimals(&metadata, decimals); err != nil {
+				return metadata, err
+			}
+		}
+	}
+	return metadata, nil
+}
+
+func setDecimals(metadata *JettonMetadata, decimalsS string) error {
+	var decimals int32
+	if _, err := fmt.Sscanf(strings.TrimSpace(decimalsS), "%d", &decimals); err != nil || decimals < 0 || decimals > 255 {
+		return fmt.Errorf("invalid jetton decimals: %s", decimalsS)
+	}
+	metadata.Decimals = decimals
+	return nil
+}
+
+func (client *Client) fetchOffchainMetadata(ctx context.Context, uri string) (*api.JettonMetadata, error) {
+	// the gateway is configured rather than set by the jetton, so its scheme isn't checked
+	if strings.HasPrefix(uri, "ipfs://") {
+		uri = IpfsGateway + strings.TrimPrefix(uri, "ipfs://")
+	} else if !strings.HasPrefix(uri, "https://") {
+		return nil, fmt.Errorf("unsupported uri, only https:// and ipfs:// are supported")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	logrus.WithField("url", uri).Debug("GET")
+	resp, err := client.MetadataHttpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxJettonMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > MaxJettonMetadataSize {
+		return nil, fmt.Errorf("metadata exceeds %d bytes", MaxJettonMetadataSize)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+	metadata := &api.JettonMetadata{}
+	if err := json.Unmarshal(body, metadata); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %v", err)
+	}
+	return metadata, nil
+}
+
+// FetchJettonBalances lists all of the jetton wallets owned by an address, with their balances.
+func (client *Client) FetchJettonBalances(ctx context.Context, owner xc.Address) ([]*JettonBalance, error) {
+	balances := []*JettonBalance{}
+	for offset := 0; ; offset += jettonWalletsPageSize {
+		resp := &api.JettonWalletsResponse{}
+		err := client.get(fmt.Sprintf("/api/v3/jetton/wallets?owner_address=%s&limit=%d&offset=%d", owner, jettonWalletsPageSize, offset), resp)
+		if err != nil {
+			return nil, fmt.Errorf("could not list jetton wallets: %v", err)
+		}
+		for _, wallet := range resp.JettonWallets {
+			master, err := client.normalizeRawAddress(wallet.Jetton)
+			if err != nil {
+				return nil, err
+			}
+			address, err := client.normalizeRawAddress(wallet.Address)
+			if err != nil {
+				return nil, err
+			}
+			balances = append(balances, &JettonBalance{
+				Master:  xc.ContractAddress(master),
+				Wallet:  xc.Address(address),
+				Balance: xc.NewAmountBlockchainFromStr(wallet.Balance),
+			})
+		}
+		if len(resp.JettonWallets) < jettonWalletsPageSize {
+			break
+		}
+	}
+	return balances, nil
+}
+
+// FetchJettonBurnInput fetches the input needed to burn the jetton of the client's token asset
+func (client *Client) FetchJettonBurnInput(ctx context.Context, from xc.Address) (xc.TxInput, error) {
+	if client.Asset.GetContract() == "" {
+		return nil, fmt.Errorf("jetton contract is not set for burning")
+	}
+	// Excess TON from the burn returns to the sender
+	args, err := xcbuilder.NewTransferArgs(from, from, xc.NewAmountBlockchainFromUint64(1))
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchTransferInput(ctx, args)
+}
+
+// Toncenter reports addresses in the raw "0:<hex>" format
+func (client *Client) normalizeRawAddress(addr string) (string, error) {
+	parsed, err := tonaddress.ParseAddress(xc.Address(addr), client.Asset.GetChain().Net)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %v", addr, err)
+	}
+	return parsed.String(), nil
+}
+
+// Toncenter encodes cells and slices in the stack as base64 boc's
+func decodeStackCell(item api.StackItem) (*cell.Cell, error) {
+	bz, err := base64.StdEncoding.DecodeString(item.Value)
+	if err != nil {
+		bz, err = base64.RawStdEncoding.DecodeString(item.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64: %v", err)
+		}
+	}
+	return cell.FromBOC(bz)
+}
@@ -0,0 +1,200 @@
+package ton_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/ton"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	"github.com/stretchr/testify/require"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/ton/nft"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+func stackCell(c *cell.Cell) string {
+	return base64.StdEncoding.EncodeToString(c.ToBOC())
+}
+
+func jettonDataResponse(t *testing.T, admin *address.Address, content nft.ContentAny) string {
+	contentCell, err := content.ContentCell()
+	require.NoError(t, err)
+	adminCell := cell.BeginCell().MustStoreAddr(admin).EndCell()
+	return fmt.Sprintf(`{"gas_used":3000,"exit_code":0,"stack":[{"type":"num","value":"0x2386f26fc10000"},{"type":"num","value":"-0x1"},{"type":"slice","value":"%s"},{"type":"cell","value":"%s"},{"type":"cell","value":"%s"}]}`,
+		stackCell(adminCell), stackCell(contentCell), stackCell(cell.BeginCell().EndCell()),
+	)
+}
+
+func TestFetchJettonData(t *testing.T) {
+	master := xc.ContractAddress("EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs")
+	admin, _ := tonaddress.ParseAddress("EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2", "")
+
+	onchain := &nft.ContentOnchain{}
+	require.NoError(t, onchain.SetAttribute("name", "Tether USD"))
+	require.NoError(t, onchain.SetAttribute("symbol", "USD₮"))
+	require.NoError(t, onchain.SetAttribute("decimals", "6"))
+
+	t.Run("onchain", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, []string{jettonDataResponse(t, admin, onchain)}, 200)
+		defer close()
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+		data, err := client.FetchJettonData(context.Background(), master)
+		require.NoError(t, err)
+		require.Equal(t, master, data.Master)
+		require.Equal(t, "10000000000000000", data.TotalSupply.String())
+		require.True(t, data.Mintable)
+		require.Equal(t, xc.Address(admin.String()), data.Admin)
+		require.Equal(t, ton.JettonMetadata{Name: "Tether USD", Symbol: "USD₮", Decimals: 6}, data.Metadata)
+	})
+
+	t.Run("cached", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, []string{jettonDataResponse(t, admin, onchain)}, 200)
+		defer close()
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+		client.SetCache(cache.NewLRU(0))
+
+		for i := 0; i < 2; i++ {
+			data, err := client.FetchJettonData(context.Background(), master)
+			require.NoError(t, err)
+			require.Equal(t, "10000000000000000", data.TotalSupply.String())
+			require.Equal(t, "Tether USD", data.Metadata.Name)
+		}
+		require.Equal(t, 1, server.Counter)
+	})
+
+	t.Run("token_metadata", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, []string{jettonDataResponse(t, admin, onchain)}, 200)
+		defer close()
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+		metadata, err := client.FetchTokenMetadata(context.Background(), master)
+		require.NoError(t, err)
+		require.Equal(t, &xclient.TokenMetadata{Contract: master, Name: "Tether USD", Symbol: "USD₮", Decimals: 6}, metadata)
+	})
+
+	// serve ipfs:// uri's from the mock server
+	useGateway := func(server *testtypes.MockHTTPServer, client *ton.Client) func() {
+		gateway := ton.IpfsGateway
+		ton.IpfsGateway = server.URL + "/"
+		// the default client only connects to public addresses
+		client.MetadataHttpClient = http.DefaultClient
+		return func() { ton.IpfsGateway = gateway }
+	}
+
+	t.Run("offchain", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, nil, 200)
+		defer close()
+		server.Response = []string{
+			jettonDataResponse(t, address.NewAddressNone(), &nft.ContentOffchain{URI: "ipfs://jetton.json"}),
+			`{"name":"Notcoin","symbol":"NOT","decimals":"9","image":"https://cdn.joincommunity.xyz/clicker/not_logo.png"}`,
+		}
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+		defer useGateway(server, client)()
+
+		data, err := client.FetchJettonData(context.Background(), master)
+		require.NoError(t, err)
+		require.Equal(t, xc.Address(""), data.Admin)
+		require.Equal(t, "Notcoin", data.Metadata.Name)
+		require.Equal(t, "NOT", data.Metadata.Symbol)
+		require.EqualValues(t, 9, data.Metadata.Decimals)
+		require.Equal(t, "ipfs://jetton.json", data.Metadata.Uri)
+	})
+
+	t.Run("offchain_not_fetched", func(t *testing.T) {
+		for _, uri := range []string{"http://example.com/jetton.json", "https://127.0.0.1/jetton.json", "file:///etc/passwd"} {
+			server, close := testtypes.MockHTTP(t, []string{jettonDataResponse(t, admin, &nft.ContentOffchain{URI: uri})}, 200)
+			client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+			// metadata that can't be fetched is skipped
+			data, err := client.FetchJettonData(context.Background(), master)
+			require.NoError(t, err, uri)
+			require.Equal(t, "", data.Metadata.Name, uri)
+			require.Equal(t, uri, data.Metadata.Uri)
+			require.EqualValues(t, ton.DefaultJettonDecimals, data.Metadata.Decimals)
+			close()
+		}
+	})
+
+	t.Run("offchain_too_large", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, nil, 200)
+		defer close()
+		server.Response = []string{
+			jettonDataResponse(t, admin, &nft.ContentOffchain{URI: "ipfs://jetton.json"}),
+			fmt.Sprintf(`{"name":"%s"}`, strings.Repeat("a", int(ton.MaxJettonMetadataSize))),
+		}
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+		defer useGateway(server, client)()
+
+		data, err := client.FetchJettonData(context.Background(), master)
+		require.NoError(t, err)
+		require.Equal(t, "", data.Metadata.Name)
+	})
+
+	t.Run("semichain", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, nil, 200)
+		defer close()
+		semichain := &nft.ContentSemichain{ContentOnchain: *onchain, ContentOffchain: nft.ContentOffchain{URI: "ipfs://jetton.json"}}
+		server.Response = []string{
+			jettonDataResponse(t, admin, semichain),
+			// on-chain values take precedence
+			`{"name":"Other","symbol":"OTHER","decimals":9,"description":"a stablecoin"}`,
+		}
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+		defer useGateway(server, client)()
+
+		data, err := client.FetchJettonData(context.Background(), master)
+		require.NoError(t, err)
+		require.Equal(t, "Tether USD", data.Metadata.Name)
+		require.Equal(t, "USD₮", data.Metadata.Symbol)
+		require.Equal(t, "a stablecoin", data.Metadata.Description)
+		require.EqualValues(t, 6, data.Metadata.Decimals)
+	})
+
+	t.Run("no_decimals", func(t *testing.T) {
+		noDecimals := &nft.ContentOnchain{}
+		require.NoError(t, noDecimals.SetAttribute("symbol", "TKN"))
+		server, close := testtypes.MockHTTP(t, []string{jettonDataResponse(t, admin, noDecimals)}, 200)
+		defer close()
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+		data, err := client.FetchJettonData(context.Background(), master)
+		require.NoError(t, err)
+		require.EqualValues(t, ton.DefaultJettonDecimals, data.Metadata.Decimals)
+	})
+
+	t.Run("not_a_jetton", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, []string{`{"gas_used":549,"exit_code":-13,"stack":[]}`}, 200)
+		defer close()
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+		_, err := client.FetchJettonData(context.Background(), master)
+		require.ErrorContains(t, err, "could not get jetton data")
+	})
+}
+
+func TestFetchJettonBalances(t *testing.T) {
+	server, close := testtypes.MockHTTP(t, []string{
+		`{"jetton_wallets":[{"address":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1","balance":"22000000","owner":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","jetton":"0:226E80C4BFFA91ADC11DAD87706D52CD397047C128456ED2866D0549D8E2B163","last_transaction_lt":"23694319000003","code_hash":"","data_hash":""},{"address":"0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3","balance":"5","owner":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","jetton":"0:B113A994B5024A16719F69139328EB759596C38A25F59028B146FECDC3621DFE","last_transaction_lt":"23694319000004","code_hash":"","data_hash":""}]}`,
+	}, 200)
+	defer close()
+	client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+	balances, err := client.FetchJettonBalances(context.Background(), "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2")
+	require.NoError(t, err)
+	require.Len(t, balances, 2)
+	require.Equal(t, &ton.JettonBalance{
+		Master:  "EQAiboDEv_qRrcEdrYdwbVLNOXBHwShFbtKGbQVJ2OKxY0to",
+		Wallet:  "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM",
+		Balance: xc.NewAmountBlockchainFromUint64(22000000),
+	}, balances[0])
+	require.Equal(t, xc.ContractAddress("EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs"), balances[1].Master)
+	require.Equal(t, "5", balances[1].Balance.String())
+}
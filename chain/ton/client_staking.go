@@ -0,0 +1,475 @@
+package ton
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
+	"github.com/cordialsys/crosschain/chain/ton/api"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	"github.com/sirupsen/logrus"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+var _ xclient.StakingClient = &Client{}
+
+func (client *Client) runGetMethod(address string, method api.GetMethod, stack []api.StackItem) (*api.GetMethodResponse, error) {
+	resp := &api.GetMethodResponse{}
+	err := client.post("api/v3/runGetMethod", &api.GetMethodRequest{
+		Address: address,
+		Method:  method,
+		Stack:   stack,
+	}, resp)
+	if err != nil {
+		return nil, fmt.Errorf("could not run %s on %s: %v", method, address, err)
+	}
+	return resp, nil
+}
+
+// Checks if the contract implements the given get-method without error
+func (client *Client) hasGetMethod(address string, method api.GetMethod) (bool, error) {
+	resp, err := client.runGetMethod(address, method, []api.StackItem{})
+	if err != nil {
+		return false, err
+	}
+	return resp.ExitCode == 0, nil
+}
+
+// DetectPoolKind determines the type of staking pool by probing the get-methods it implements.
+func (client *Client) DetectPoolKind(ctx context.Context, pool xc.Address) (PoolKind, error) {
+	probes := []struct {
+		method api.GetMethod
+		kind   PoolKind
+	}{
+		{api.GetPoolFullDataMethod, LiquidPool},
+		{api.GetRolesMethod, SingleNominatorPool},
+		{api.GetPoolDataMethod, NominatorPool},
+	}
+	for _, probe := range probes {
+		ok, err := client.hasGetMethod(string(pool), probe.method)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return probe.kind, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a supported TON staking pool", pool)
+}
+
+// Looks up the jetton minted by a liquid staking pool, which is administered by the pool.
+func (client *Client) liquidPoolJetton(ctx context.Context, pool xc.Address) (xc.ContractAddress, error) {
+	resp := &api.JettonMastersResponse{}
+	err := client.get(fmt.Sprintf("/api/v3/jetton/masters?admin_address=%s&limit=1", pool), resp)
+	if err != nil {
+		return "", fmt.Errorf("could not lookup jetton for pool %s: %v", pool, err)
+	}
+	if len(resp.JettonMasters) == 0 {
+		return "", fmt.Errorf("no jetton found for liquid staking pool %s", pool)
+	}
+	master, err := client.normalizeRawAddress(resp.JettonMasters[0].Address)
+	if err != nil {
+		return "", err
+	}
+	return xc.ContractAddress(master), nil
+}
+
+// Returns the owner of a single-nominator pool
+func (client *Client) singleNominatorOwner(ctx context.Context, pool xc.Address) (*address.Address, error) {
+	resp, err := client.runGetMethod(string(pool), api.GetRolesMethod, []api.StackItem{})
+	if err != nil {
+		return nil, err
+	}
+	// (owner_address, validator_address)
+	if resp.ExitCode != 0 || len(resp.Stack) < 2 {
+		return nil, fmt.Errorf("could not get roles of single nominator pool %s (%d)", pool, resp.ExitCode)
+	}
+	ownerCell, err := decodeStackCell(resp.Stack[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool owner: %v", err)
+	}
+	owner, err := ownerCell.BeginParse().LoadAddr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid pool owner: %v", err)
+	}
+	return owner, nil
+}
+
+type nominatorData struct {
+	Balance         xc.AmountBlockchain
+	PendingDeposit  xc.AmountBlockchain
+	WithdrawPending bool
+}
+
+// Returns the state of a nominator in a nominator-pool, or nil if they are not a nominator.
+func (client *Client) nominatorData(ctx context.Context, pool xc.Address, nominator *address.Address) (*nominatorData, error) {
+	if nominator.Workchain() != address.MasterchainID {
+		// nominators must be on the masterchain, so there cannot be a stake
+		return nil, nil
+	}
+	hash := new(big.Int).SetBytes(nominator.Data())
+	resp, err := client.runGetMethod(string(pool), api.GetNominatorDataMethod, []api.StackItem{
+		{Type: "num", Value: "0x" + hash.Text(16)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The method throws if the address is not a nominator
+	// (amount, pending_deposit_amount, withdraw_found)
+	if resp.ExitCode != 0 || len(resp.Stack) < 3 {
+		return nil, nil
+	}
+	withdraw := xc.NewAmountBlockchainFromStr(resp.Stack[2].Value)
+	return &nominatorData{
+		Balance:         xc.NewAmountBlockchainFromStr(resp.Stack[0].Value),
+		PendingDeposit:  xc.NewAmountBlockchainFromStr(resp.Stack[1].Value),
+		WithdrawPending: !withdraw.IsZero(),
+	}, nil
+}
+
+func (client *Client) fetchJettonBalance(ctx context.Context, owner xc.Address, master xc.ContractAddress) (xc.AmountBlockchain, error) {
+	resp := &api.JettonWalletsResponse{}
+	err := client.get(fmt.Sprintf("/api/v3/jetton/wallets?owner_address=%s&jetton_address=%s", owner, master), resp)
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+	sum := xc.NewAmountBlockchainFromUint64(0)
+	for _, wallet := range resp.JettonWallets {
+		bal := xc.NewAmountBlockchainFromStr(wallet.Balance)
+		sum = sum.Add(&bal)
+	}
+	return sum, nil
+}
+
+// FetchStakeBalance reports the stake in a given pool.  There's no index of pools a wallet has
+// delegated to, so the pool must be passed as the validator.
+// For liquid pools, the balance is reported in units of the pool jetton.
+func (client *Client) FetchStakeBalance(ctx context.Context, args xclient.StakedBalanceArgs) ([]*xclient.StakedBalance, error) {
+	pool, ok := args.GetValidator()
+	if !ok {
+		return nil, errors.New("the pool address must be provided as the validator to lookup TON stake")
+	}
+	net := client.Asset.GetChain().Net
+	from, err := tonaddress.ParseAddress(args.GetFrom(), net)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TON address %s: %v", args.GetFrom(), err)
+	}
+	kind, err := client.DetectPoolKind(ctx, xc.Address(pool))
+	if err != nil {
+		return nil, err
+	}
+
+	balances := []*xclient.StakedBalance{}
+	switch kind {
+	case SingleNominatorPool:
+		owner, err := client.singleNominatorOwner(ctx, xc.Address(pool))
+		if err != nil {
+			return nil, err
+		}
+		if !owner.Equals(from) {
+			return balances, nil
+		}
+		// The entire pool balance belongs to the owner
+		balance, err := client.FetchNativeBalance(ctx, xc.Address(pool))
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, xclient.NewStakedBalance(balance, xclient.Active, pool, ""))
+	case NominatorPool:
+		data, err := client.nominatorData(ctx, xc.Address(pool), from)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			return balances, nil
+		}
+		state := xclient.StakedBalanceState{
+			Activating: data.PendingDeposit,
+		}
+		if data.WithdrawPending {
+			state.Deactivating = data.Balance
+		} else {
+			state.Active = data.Balance
+		}
+		balances = append(balances, xclient.NewStakedBalances(state, pool, ""))
+	case LiquidPool:
+		master, err := client.liquidPoolJetton(ctx, xc.Address(pool))
+		if err != nil {
+			return nil, err
+		}
+		tokenWallet, err := client.GetTokenWallet(ctx, args.GetFrom(), master)
+		if err != nil {
+			return nil, err
+		}
+		balance, err := client.fetchJettonBalance(ctx, args.GetFrom(), master)
+		if err != nil {
+			return nil, err
+		}
+		balances = append(balances, xclient.NewStakedBalance(balance, xclient.Active, pool, string(tokenWallet)))
+	}
+	return balances, nil
+}
+
+func (client *Client) FetchStakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.StakeTxInput, error) {
+	pool, ok := args.GetValidator()
+	if !ok {
+		return nil, errors.New("the pool address must be provided as the validator to stake on TON")
+	}
+	kind, err := client.DetectPoolKind(ctx, xc.Address(pool))
+	if err != nil {
+		return nil, err
+	}
+	if kind == NominatorPool {
+		from, err := tonaddress.ParseAddress(args.GetFrom(), client.Asset.GetChain().Net)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TON address %s: %v", args.GetFrom(), err)
+		}
+		if err := CheckNominator(from); err != nil {
+			return nil, err
+		}
+	}
+	txInput, err := client.fetchStakeTxInput(ctx, args.GetFrom(), xc.Address(pool), args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+	return &StakingInput{
+		TxInput:  *txInput,
+		PoolKind: kind,
+		Pool:     xc.Address(pool),
+	}, nil
+}
+
+func (client *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.UnstakeTxInput, error) {
+	pool, ok := args.GetValidator()
+	if !ok {
+		return nil, errors.New("the pool address must be provided as the validator to unstake on TON")
+	}
+	kind, err := client.DetectPoolKind(ctx, xc.Address(pool))
+	if err != nil {
+		return nil, err
+	}
+	txInput, err := client.fetchStakeTxInput(ctx, args.GetFrom(), xc.Address(pool), args.GetAmount())
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case SingleNominatorPool:
+		net := client.Asset.GetChain().Net
+		from, err := tonaddress.ParseAddress(args.GetFrom(), net)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TON address %s: %v", args.GetFrom(), err)
+		}
+		owner, err := client.singleNominatorOwner(ctx, xc.Address(pool))
+		if err != nil {
+			return nil, err
+		}
+		if !owner.Equals(from) {
+			return nil, fmt.Errorf("%s is not the owner of single nominator pool %s", args.GetFrom(), pool)
+		}
+	case LiquidPool:
+		master, err := client.liquidPoolJetton(ctx, xc.Address(pool))
+		if err != nil {
+			return nil, err
+		}
+		txInput.TokenWallet, err = client.GetTokenWallet(ctx, args.GetFrom(), master)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &UnstakingInput{
+		TxInput:  *txInput,
+		PoolKind: kind,
+		Pool:     xc.Address(pool),
+	}, nil
+}
+
+func (client *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.WithdrawTxInput, error) {
+	return nil, errors.New("TON pools return the stake automatically after unstaking, there is no need to withdraw")
+}
+
+func (client *Client) fetchStakeTxInput(ctx context.Context, from xc.Address, pool xc.Address, amount xc.AmountBlockchain) (*TxInput, error) {
+	transferArgs, err := xcbuilder.NewTransferArgs(from, pool, amount)
+	if err != nil {
+		return nil, err
+	}
+	input, err := client.FetchTransferInput(ctx, transferArgs)
+	if err != nil {
+		return nil, err
+	}
+	return input.(*TxInput), nil
+}
+
+// Parse stake and unstake requests from the messages sent by a wallet.
+// Note that deposits to single nominator pools are plain transfers, so they can't be told apart.
+// Only jetton burns need a lookup, to check if the jetton is minted by a liquid pool, which is cached.
+// Detection is best-effort: messages that can't be looked up are logged and skipped.
+func (client *Client) DetectStakingEvents(ctx context.Context, tx *api.Transaction, book api.AddressBook, info *xc.LegacyTxInfo) {
+	for _, msg := range tx.OutMsgs {
+		if msg.Bounced != nil && *msg.Bounced {
+			continue
+		}
+		if msg.Source == nil || msg.Destination == nil || msg.Value == nil || msg.MessageContent.Body == "" {
+			continue
+		}
+		bodyBz, err := base64.StdEncoding.DecodeString(msg.MessageContent.Body)
+		if err != nil {
+			continue
+		}
+		body, err := cell.FromBOC(bodyBz)
+		if err != nil {
+			continue
+		}
+		slice := body.BeginParse()
+		op, err := slice.LoadUInt(32)
+		if err != nil {
+			continue
+		}
+		from, err := client.substituteOrParse(book, *msg.Source)
+		if err != nil {
+			continue
+		}
+		pool, err := client.substituteOrParse(book, *msg.Destination)
+		if err != nil {
+			continue
+		}
+		log := logrus.WithField("pool", pool.String())
+		value := xc.NewAmountBlockchainFromStr(*msg.Value)
+
+		switch op {
+		case 0:
+			// text comment used by the nominator-pool
+			comment, err := slice.LoadStringSnake()
+			if err != nil {
+				continue
+			}
+			comment = strings.TrimSpace(comment)
+			if comment != "d" && comment != "w" {
+				continue
+			}
+			// Nominator pools and their nominators are on the masterchain, which wallets otherwise don't use,
+			// so the pool isn't looked up
+			if from.Workchain() != address.MasterchainID || pool.Workchain() != address.MasterchainID {
+				continue
+			}
+			if comment == "d" {
+				info.AddStakeEvent(&xclient.Stake{
+					Balance:   value,
+					Validator: pool.String(),
+					Address:   from.String(),
+				})
+			} else {
+				// withdraws are always for the full balance
+				info.AddStakeEvent(&xclient.Unstake{
+					Balance:   xc.NewAmountBlockchainFromUint64(0),
+					Validator: pool.String(),
+					Address:   from.String(),
+				})
+			}
+		case OpLiquidPoolDeposit:
+			info.AddStakeEvent(&xclient.Stake{
+				Balance:   value,
+				Validator: pool.String(),
+				Address:   from.String(),
+			})
+		case OpSingleNominatorWithdraw:
+			_, err = slice.LoadUInt(64)
+			if err != nil {
+				continue
+			}
+			amount, err := slice.LoadBigCoins()
+			if err != nil {
+				continue
+			}
+			info.AddStakeEvent(&xclient.Unstake{
+				Balance:   xc.AmountBlockchain(*amount),
+				Validator: pool.String(),
+				Address:   from.String(),
+			})
+		case OpJettonBurn:
+			liquidPool, err := client.liquidPoolForJettonWallet(ctx, pool)
+			if err != nil {
+				log.WithError(err).Warn("could not detect liquid staking pool")
+				continue
+			}
+			if liquidPool == "" {
+				continue
+			}
+			_, err = slice.LoadUInt(64)
+			if err != nil {
+				continue
+			}
+			amount, err := slice.LoadBigCoins()
+			if err != nil {
+				continue
+			}
+			info.AddStakeEvent(&xclient.Unstake{
+				Balance:   xc.AmountBlockchain(*amount),
+				Validator: string(liquidPool),
+				Account:   pool.String(),
+				Address:   from.String(),
+			})
+		}
+	}
+}
+
+// Returns the liquid staking pool that minted the jetton of a jetton wallet, if any.  This doesn't change
+// for a jetton wallet, so it's cached.
+func (client *Client) liquidPoolForJettonWallet(ctx context.Context, tokenWallet *address.Address) (xc.Address, error) {
+	key := cache.Key(client.Asset.GetChain().Chain, "liquid-pool", tokenWallet.String())
+	return cache.GetOrFetch(ctx, client.cache, key, cache.NoExpiry, func() (xc.Address, error) {
+		return client.fetchLiquidPoolForJettonWallet(ctx, tokenWallet)
+	})
+}
+
+func (client *Client) fetchLiquidPoolForJettonWallet(ctx context.Context, tokenWallet *address.Address) (xc.Address, error) {
+	resp, err := client.runGetMethod(tokenWallet.String(), api.GetWalletDataMethod, []api.StackItem{})
+	if err != nil {
+		return "", err
+	}
+	// (balance, owner, jetton_master, jetton_wallet_code)
+	if resp.ExitCode != 0 || len(resp.Stack) < 3 {
+		return "", nil
+	}
+	masterCell, err := decodeStackCell(resp.Stack[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid jetton master: %v", err)
+	}
+	master, err := masterCell.BeginParse().LoadAddr()
+	if err != nil {
+		return "", fmt.Errorf("invalid jetton master: %v", err)
+	}
+	// (total_supply, mintable, admin_address, jetton_content, jetton_wallet_code)
+	resp, err = client.runGetMethod(master.String(), api.GetJettonDataMethod, []api.StackItem{})
+	if err != nil {
+		return "", err
+	}
+	if resp.ExitCode != 0 || len(resp.Stack) < 3 {
+		return "", nil
+	}
+	adminCell, err := decodeStackCell(resp.Stack[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid jetton admin: %v", err)
+	}
+	admin, err := adminCell.BeginParse().LoadAddr()
+	if err != nil || admin.IsAddrNone() {
+		return "", nil
+	}
+	isLiquidPool, err := client.hasGetMethod(admin.String(), api.GetPoolFullDataMethod)
+	if err != nil {
+		return "", err
+	}
+	if !isLiquidPool {
+		return "", nil
+	}
+	return xc.Address(admin.String()), nil
+}
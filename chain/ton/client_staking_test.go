@@ -0,0 +1,361 @@
+package ton_test
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/ton"
+	"github.com/cordialsys/crosschain/chain/ton/api"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	"github.com/stretchr/testify/require"
+	"github.com/xssnick/tonutils-go/address"
+	"github.com/xssnick/tonutils-go/tvm/cell"
+)
+
+const getMethodFailed = `{"gas_used":0,"exit_code":11,"stack":[]}`
+
+func rolesResponse(owner *address.Address) string {
+	ownerCell := cell.BeginCell().MustStoreAddr(owner).EndCell()
+	validatorCell := cell.BeginCell().MustStoreAddr(owner).EndCell()
+	return fmt.Sprintf(`{"gas_used":500,"exit_code":0,"stack":[{"type":"slice","value":"%s"},{"type":"slice","value":"%s"}]}`,
+		stackCell(ownerCell), stackCell(validatorCell),
+	)
+}
+
+func TestFetchStakeBalance(t *testing.T) {
+	pool := "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM"
+	owner := address.MustParseAddr("EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2")
+	nominator := address.NewAddress(0, 0xff, owner.Data())
+	tokenWallet := address.MustParseRawAddr("0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3")
+
+	vectors := []struct {
+		desc     string
+		from     string
+		resp     []string
+		balances []*xclient.StakedBalance
+		err      string
+	}{
+		{
+			desc: "single_nominator",
+			from: owner.String(),
+			resp: []string{
+				getMethodFailed,
+				rolesResponse(owner),
+				rolesResponse(owner),
+				`{"balance":"50000000000","status":"active"}`,
+			},
+			balances: []*xclient.StakedBalance{
+				xclient.NewStakedBalance(xc.NewAmountBlockchainFromUint64(50_000_000_000), xclient.Active, pool, ""),
+			},
+		},
+		{
+			desc: "single_nominator_not_owner",
+			from: "EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs",
+			resp: []string{
+				getMethodFailed,
+				rolesResponse(owner),
+				rolesResponse(owner),
+			},
+			balances: []*xclient.StakedBalance{},
+		},
+		{
+			desc: "nominator_pool",
+			from: nominator.String(),
+			resp: []string{
+				getMethodFailed,
+				getMethodFailed,
+				`{"gas_used":500,"exit_code":0,"stack":[]}`,
+				`{"gas_used":500,"exit_code":0,"stack":[{"type":"num","value":"0x2540be400"},{"type":"num","value":"0x3b9aca00"},{"type":"num","value":"0x0"}]}`,
+			},
+			balances: []*xclient.StakedBalance{
+				xclient.NewStakedBalances(xclient.StakedBalanceState{
+					Active:     xc.NewAmountBlockchainFromUint64(10_000_000_000),
+					Activating: xc.NewAmountBlockchainFromUint64(1_000_000_000),
+				}, pool, ""),
+			},
+		},
+		{
+			desc: "nominator_pool_withdrawing",
+			from: nominator.String(),
+			resp: []string{
+				getMethodFailed,
+				getMethodFailed,
+				`{"gas_used":500,"exit_code":0,"stack":[]}`,
+				`{"gas_used":500,"exit_code":0,"stack":[{"type":"num","value":"0x2540be400"},{"type":"num","value":"0x0"},{"type":"num","value":"-0x1"}]}`,
+			},
+			balances: []*xclient.StakedBalance{
+				xclient.NewStakedBalances(xclient.StakedBalanceState{
+					Activating:   xc.NewAmountBlockchainFromStr("0x0"),
+					Deactivating: xc.NewAmountBlockchainFromUint64(10_000_000_000),
+				}, pool, ""),
+			},
+		},
+		{
+			desc: "nominator_pool_basechain_address",
+			from: owner.String(),
+			resp: []string{
+				getMethodFailed,
+				getMethodFailed,
+				`{"gas_used":500,"exit_code":0,"stack":[]}`,
+			},
+			balances: []*xclient.StakedBalance{},
+		},
+		{
+			desc: "liquid",
+			from: owner.String(),
+			resp: []string{
+				`{"gas_used":500,"exit_code":0,"stack":[]}`,
+				`{"jetton_masters":[{"address":"0:B113A994B5024A16719F69139328EB759596C38A25F59028B146FECDC3621DFE","admin_address":"0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1"}]}`,
+				fmt.Sprintf(`{"gas_used":500,"exit_code":0,"stack":[{"type":"slice","value":"%s"}]}`,
+					base64.RawStdEncoding.EncodeToString(cell.BeginCell().MustStoreAddr(tokenWallet).EndCell().ToBOC()),
+				),
+				`{"jetton_wallets":[{"address":"0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3","balance":"9500000000","owner":"0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A","jetton":"0:B113A994B5024A16719F69139328EB759596C38A25F59028B146FECDC3621DFE"}]}`,
+			},
+			balances: []*xclient.StakedBalance{
+				xclient.NewStakedBalance(xc.NewAmountBlockchainFromUint64(9_500_000_000), xclient.Active, pool, tokenWallet.String()),
+			},
+		},
+		{
+			desc: "not_a_pool",
+			from: owner.String(),
+			resp: []string{getMethodFailed, getMethodFailed, getMethodFailed},
+			err:  "not a supported TON staking pool",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.desc, func(t *testing.T) {
+			server, close := testtypes.MockHTTP(t, v.resp, 200)
+			defer close()
+			client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+			args, err := xclient.NewStakeBalanceArgs(xc.Address(v.from), xclient.StakeBalanceOptionValidator(pool))
+			require.NoError(t, err)
+			balances, err := client.FetchStakeBalance(context.Background(), args)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, v.balances, balances)
+		})
+	}
+
+	t.Run("requires_pool", func(t *testing.T) {
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON})
+		args, _ := xclient.NewStakeBalanceArgs(xc.Address(owner.String()))
+		_, err := client.FetchStakeBalance(context.Background(), args)
+		require.ErrorContains(t, err, "pool address must be provided")
+	})
+}
+
+func TestFetchStakingInput(t *testing.T) {
+	pool := "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM"
+	owner := address.MustParseAddr("EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2")
+	transferInput := []string{
+		// get account
+		`{"balance":"587833680","status":"uninit"}`,
+		// get sequence
+		getMethodFailed,
+		// get public-key
+		getMethodFailed,
+	}
+
+	server, close := testtypes.MockHTTP(t, nil, 200)
+	defer close()
+	client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+	args, err := xcbuilder.NewStakeArgs(xc.TON, xc.Address(owner.String()), xc.NewAmountBlockchainFromUint64(10_000_000_000), xcbuilder.OptionValidator(pool))
+	require.NoError(t, err)
+
+	nominatorPoolKind := []string{getMethodFailed, getMethodFailed, `{"gas_used":500,"exit_code":0,"stack":[]}`}
+	// nominator pools only accept masterchain wallets
+	server.Response = nominatorPoolKind
+	_, err = client.FetchStakingInput(context.Background(), args)
+	require.ErrorContains(t, err, "nominator pools only accept masterchain wallets")
+
+	nominator := address.NewAddress(0, 0xff, owner.Data())
+	nominatorArgs, err := xcbuilder.NewStakeArgs(xc.TON, xc.Address(nominator.String()), xc.NewAmountBlockchainFromUint64(10_000_000_000), xcbuilder.OptionValidator(pool))
+	require.NoError(t, err)
+	server.Counter = 0
+	server.Response = append(nominatorPoolKind, transferInput...)
+	input, err := client.FetchStakingInput(context.Background(), nominatorArgs)
+	require.NoError(t, err)
+	require.Equal(t, ton.NominatorPool, input.(*ton.StakingInput).PoolKind)
+	require.Equal(t, xc.Address(pool), input.(*ton.StakingInput).Pool)
+	require.Equal(t, "587833680", input.(*ton.StakingInput).TonBalance.String())
+
+	// single nominator pools may only be unstaked by the owner
+	server.Counter = 0
+	server.Response = append(append([]string{getMethodFailed, rolesResponse(owner)}, transferInput...), rolesResponse(owner))
+	unstakeInput, err := client.FetchUnstakingInput(context.Background(), args)
+	require.NoError(t, err)
+	require.Equal(t, ton.SingleNominatorPool, unstakeInput.(*ton.UnstakingInput).PoolKind)
+
+	other := address.MustParseAddr("EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs")
+	server.Counter = 0
+	server.Response = append(append([]string{getMethodFailed, rolesResponse(other)}, transferInput...), rolesResponse(other))
+	_, err = client.FetchUnstakingInput(context.Background(), args)
+	require.ErrorContains(t, err, "is not the owner")
+
+	_, err = client.FetchWithdrawInput(context.Background(), args)
+	require.Error(t, err)
+}
+
+func TestDetectStakingEvents(t *testing.T) {
+	from := "0:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A"
+	pool := "0:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1"
+	// nominator pools and their nominators are on the masterchain
+	nominator := "-1:237E5119FFA2A028CC4F95C9CA37566852F1DD4D3EA15704D6F791065507DE4A"
+	nominatorPool := "-1:3D340B150EAC0392F5F428ABFFDF3C33BED68468A7F7F607C4A13D8013EF56B1"
+	tokenWallet := "0:A1A2DCA202D48FAACD016CF0FCE75332B3DFCB74C54CECDF9AB6C158BD431CE3"
+	book := api.AddressBook{
+		from:          {UserFriendly: "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2"},
+		pool:          {UserFriendly: "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM"},
+		nominator:     {UserFriendly: "Ef8jflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSun-"},
+		nominatorPool: {UserFriendly: "Ef89NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsamE"},
+		tokenWallet:   {UserFriendly: "EQChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc4yQp"},
+	}
+	outMsg := func(src string, dst string, value string, body *cell.Cell) api.OutMsg {
+		msg := api.OutMsg{}
+		msg.Source = &src
+		msg.Destination = &dst
+		msg.Value = &value
+		msg.MessageContent.Body = base64.StdEncoding.EncodeToString(body.ToBOC())
+		return msg
+	}
+	comment := func(text string) *cell.Cell {
+		return cell.BeginCell().MustStoreUInt(0, 32).MustStoreStringSnake(text).EndCell()
+	}
+	burn := cell.BeginCell().
+		MustStoreUInt(ton.OpJettonBurn, 32).
+		MustStoreUInt(1, 64).
+		MustStoreCoins(5_000_000_000).
+		MustStoreAddr(address.MustParseRawAddr(from)).
+		EndCell()
+	// the jetton wallet, its master, and the admin of the master being a liquid pool
+	master := address.MustParseAddr("EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs")
+	burnLookups := []string{
+		fmt.Sprintf(`{"gas_used":500,"exit_code":0,"stack":[{"type":"num","value":"0x12a05f200"},{"type":"slice","value":"%s"},{"type":"slice","value":"%s"}]}`,
+			stackCell(cell.BeginCell().MustStoreAddr(address.MustParseRawAddr(from)).EndCell()),
+			stackCell(cell.BeginCell().MustStoreAddr(master).EndCell()),
+		),
+		fmt.Sprintf(`{"gas_used":500,"exit_code":0,"stack":[{"type":"num","value":"0x0"},{"type":"num","value":"-0x1"},{"type":"slice","value":"%s"}]}`,
+			stackCell(cell.BeginCell().MustStoreAddr(address.MustParseRawAddr(pool)).EndCell()),
+		),
+		`{"gas_used":500,"exit_code":0,"stack":[]}`,
+	}
+
+	vectors := []struct {
+		desc     string
+		msg      api.OutMsg
+		resp     []string
+		stakes   []*xclient.Stake
+		unstakes []*xclient.Unstake
+	}{
+		{
+			desc: "nominator_pool_deposit",
+			msg:  outMsg(nominator, nominatorPool, "10000000000", comment("d")),
+			stakes: []*xclient.Stake{
+				{Balance: xc.NewAmountBlockchainFromUint64(10_000_000_000), Validator: book[nominatorPool].UserFriendly, Address: book[nominator].UserFriendly},
+			},
+		},
+		{
+			desc: "nominator_pool_withdraw",
+			msg:  outMsg(nominator, nominatorPool, "1000000000", comment("w")),
+			unstakes: []*xclient.Unstake{
+				{Balance: xc.NewAmountBlockchainFromUint64(0), Validator: book[nominatorPool].UserFriendly, Address: book[nominator].UserFriendly},
+			},
+		},
+		{
+			// nominator pools don't accept basechain wallets
+			desc: "comment_on_basechain",
+			msg:  outMsg(from, pool, "1000000000", comment("d")),
+		},
+		{
+			desc: "other_comment",
+			msg:  outMsg(nominator, nominatorPool, "1000000000", comment("hello")),
+		},
+		{
+			desc: "liquid_pool_deposit",
+			msg:  outMsg(from, pool, "10000000000", cell.BeginCell().MustStoreUInt(ton.OpLiquidPoolDeposit, 32).MustStoreUInt(1, 64).EndCell()),
+			stakes: []*xclient.Stake{
+				{Balance: xc.NewAmountBlockchainFromUint64(10_000_000_000), Validator: book[pool].UserFriendly, Address: book[from].UserFriendly},
+			},
+		},
+		{
+			desc: "liquid_pool_burn",
+			msg:  outMsg(from, tokenWallet, "100000000", burn),
+			resp: burnLookups,
+			unstakes: []*xclient.Unstake{
+				{Balance: xc.NewAmountBlockchainFromUint64(5_000_000_000), Validator: book[pool].UserFriendly, Account: book[tokenWallet].UserFriendly, Address: book[from].UserFriendly},
+			},
+		},
+		{
+			desc: "other_jetton_burn",
+			msg:  outMsg(from, tokenWallet, "100000000", burn),
+			resp: []string{burnLookups[0], burnLookups[1], getMethodFailed},
+		},
+		{
+			// detection is skipped rather than failing the tx lookup
+			desc: "pool_lookup_failed",
+			msg:  outMsg(from, tokenWallet, "100000000", burn),
+			resp: []string{`not json`},
+		},
+		{
+			desc: "single_nominator_withdraw",
+			msg:  outMsg(from, pool, "100000000", cell.BeginCell().MustStoreUInt(ton.OpSingleNominatorWithdraw, 32).MustStoreUInt(1, 64).MustStoreCoins(5_000_000_000).EndCell()),
+			unstakes: []*xclient.Unstake{
+				{Balance: xc.NewAmountBlockchainFromUint64(5_000_000_000), Validator: book[pool].UserFriendly, Address: book[from].UserFriendly},
+			},
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.desc, func(t *testing.T) {
+			server, close := testtypes.MockHTTP(t, v.resp, 200)
+			defer close()
+			client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+			info := &xc.LegacyTxInfo{}
+			client.DetectStakingEvents(context.Background(), &api.Transaction{OutMsgs: []api.OutMsg{v.msg}}, book, info)
+			stakes := []*xclient.Stake{}
+			unstakes := []*xclient.Unstake{}
+			for _, ev := range info.GetStakeEvents() {
+				switch ev := ev.(type) {
+				case *xclient.Stake:
+					stakes = append(stakes, ev)
+				case *xclient.Unstake:
+					unstakes = append(unstakes, ev)
+				}
+			}
+			if v.stakes == nil {
+				v.stakes = []*xclient.Stake{}
+			}
+			if v.unstakes == nil {
+				v.unstakes = []*xclient.Unstake{}
+			}
+			require.Equal(t, v.stakes, stakes)
+			require.Equal(t, v.unstakes, unstakes)
+			require.Equal(t, len(v.resp), server.Counter)
+		})
+	}
+
+	t.Run("cached_liquid_pool", func(t *testing.T) {
+		server, close := testtypes.MockHTTP(t, burnLookups, 200)
+		defer close()
+		client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+		client.SetCache(cache.NewLRU(0))
+
+		for i := 0; i < 2; i++ {
+			info := &xc.LegacyTxInfo{}
+			client.DetectStakingEvents(context.Background(), &api.Transaction{OutMsgs: []api.OutMsg{outMsg(from, tokenWallet, "100000000", burn)}}, book, info)
+			require.Len(t, info.GetStakeEvents(), 1)
+		}
+		// the pool is only looked up once
+		require.Equal(t, len(burnLookups), server.Counter)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/chain/ton"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
 	"github.com/cordialsys/crosschain/chain/ton/api"
 	xcclient "github.com/cordialsys/crosschain/client"
 	testtypes "github.com/cordialsys/crosschain/testutil/types"
@@ -65,6 +66,27 @@ func TestFetchTxInput(t *testing.T) {
 				TokenWallet:     "",
 				EstimatedMaxFee: xc.AmountBlockchain{},
 				TonBalance:      xc.NewAmountBlockchainFromStr("587833680"),
+				WalletVersion:   tonaddress.WalletVersionV3R2,
+			},
+		},
+		{
+			asset: &chain,
+			desc:  "unknown_wallet_contract",
+			resp: []string{
+				// get account, with code that isn't a known wallet
+				`{"balance":"587833680","code":"te6cckEBAQEAKgAAUAAAABEpqaMXwRcreSYRbSo5a9fWm5iAzAZX6LotufYrTCEMUYMhyLF+KRtN","data":"te6cckEBAQEAKgAAUAAAABEpqaMXwRcreSYRbSo5a9fWm5iAzAZX6LotufYrTCEMUYMhyLF+KRtN","last_transaction_lt":"23693722000001","last_transaction_hash":"mVuNwFVC4eIWjS+lIAkfinkXUQz8k1lqFZ+lQqvAZK8=","frozen_hash":null,"status":"active"}`,
+				// get sequence
+				`{"gas_used":549,"exit_code":0,"stack":[{"type":"num","value":"0x11"}]}`,
+				// get public-key
+				`{"gas_used":549,"exit_code":0,"stack":[{"type":"num","value":"0xc1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1"}]}`,
+			},
+			// the version is left to the builder
+			txInput: &ton.TxInput{
+				TxInputEnvelope: ton.NewTxInput().TxInputEnvelope,
+				AccountStatus:   api.Active,
+				Sequence:        0x11,
+				PublicKey:       fromHex("0xc1172b7926116d2a396bd7d69b9880cc0657e8ba2db9f62b4c210c518321c8b1"),
+				TonBalance:      xc.NewAmountBlockchainFromStr("587833680"),
 			},
 		},
 		{
@@ -370,3 +392,13 @@ func TestFetchTxInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestFetchLatestHeight(t *testing.T) {
+	server, close := testtypes.MockHTTP(t, `{"last":{"workchain":-1,"shard":"8000000000000000","seqno":21082664},"first":{"workchain":-1,"shard":"8000000000000000","seqno":3}}`, 200)
+	defer close()
+	client, _ := ton.NewClient(&xc.ChainConfig{Chain: xc.TON, URL: server.URL})
+
+	height, err := client.FetchLatestHeight(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 21082664, height)
+}
@@ -16,7 +16,9 @@ import (
 type Tx struct {
 	CellBuilder     *cell.Builder
 	ExternalMessage *tlb.ExternalMessage
-	signatures      []xc.TxSignature
+	// W5 wallets expect the signature to be appended after the payload, rather than prepended
+	SignatureLast bool
+	signatures    []xc.TxSignature
 }
 
 func NewTx(fromAddr *address.Address, cellBuilder *cell.Builder, stateInitMaybe *tlb.StateInit) *Tx {
@@ -68,7 +70,12 @@ func (tx *Tx) AddSignatures(sigs ...xc.TxSignature) error {
 	}
 
 	tx.signatures = sigs
-	msg := cell.BeginCell().MustStoreSlice(sigs[0], 512).MustStoreBuilder(tx.CellBuilder).EndCell()
+	var msg *cell.Cell
+	if tx.SignatureLast {
+		msg = cell.BeginCell().MustStoreBuilder(tx.CellBuilder).MustStoreSlice(sigs[0], 512).EndCell()
+	} else {
+		msg = cell.BeginCell().MustStoreSlice(sigs[0], 512).MustStoreBuilder(tx.CellBuilder).EndCell()
+	}
 	tx.ExternalMessage.Body = msg
 	return nil
 }
@@ -1,13 +1,21 @@
 package tx_test
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
 	"testing"
 
 	"github.com/cordialsys/crosschain"
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/ton"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
+	"github.com/cordialsys/crosschain/chain/ton/api"
+	tontx "github.com/cordialsys/crosschain/chain/ton/tx"
 	"github.com/stretchr/testify/require"
+	"github.com/xssnick/tonutils-go/tlb"
+	"github.com/xssnick/tonutils-go/ton/jetton"
+	"github.com/xssnick/tonutils-go/ton/wallet"
 )
 
 func TestNativeTx(t *testing.T) {
@@ -93,3 +101,247 @@ func TestTokenTx(t *testing.T) {
 		hex.EncodeToString(bz))
 
 }
+
+func TestWalletVersionTx(t *testing.T) {
+	chain := &crosschain.ChainConfig{Chain: xc.TON, Decimals: 9}
+	builder, err := ton.NewTxBuilder(chain)
+	require.NoError(t, err)
+	privateKey := ed25519.NewKeyFromSeed(make([]byte, 32))
+	publicKey := privateKey.Public().(ed25519.PublicKey)
+	to := "0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm"
+
+	for _, version := range tonaddress.WalletVersions {
+		t.Run(string(version), func(t *testing.T) {
+			addressBuilder, _ := tonaddress.NewAddressBuilderForVersion(chain, version)
+			from, err := addressBuilder.GetAddressFromPublicKey(publicKey)
+			require.NoError(t, err)
+
+			// version is determined from the address for new accounts
+			input := ton.NewTxInput()
+			input.PublicKey = publicKey
+			xcTx, err := builder.NewTransfer(from, xc.Address(to), xc.NewAmountBlockchainFromUint64(10), input)
+			require.NoError(t, err)
+			tonTx := xcTx.(*tontx.Tx)
+			stateInit, _ := tonaddress.GetStateInit(publicKey, version, "")
+			require.Equal(t, stateInit.Code.Hash(), tonTx.ExternalMessage.StateInit.Code.Hash())
+
+			hashes, err := tonTx.Sighashes()
+			require.NoError(t, err)
+			sig := ed25519.Sign(privateKey, hashes[0])
+			require.NoError(t, tonTx.AddSignatures(sig))
+
+			body := tonTx.ExternalMessage.Body.BeginParse()
+			if version == tonaddress.WalletVersionW5 {
+				op, _ := body.LoadUInt(32)
+				require.EqualValues(t, 0x7369676e, op)
+				walletId, _ := body.LoadUInt(32)
+				require.EqualValues(t, version.SubwalletId(""), walletId)
+				// signature is the last 512 bits
+				bits := body.BitsLeft()
+				_, _ = body.LoadSlice(bits - 512)
+				lastSig, _ := body.LoadSlice(512)
+				require.EqualValues(t, sig, lastSig)
+			} else {
+				firstSig, _ := body.LoadSlice(512)
+				require.EqualValues(t, sig, firstSig)
+				subwallet, _ := body.LoadUInt(32)
+				require.EqualValues(t, tonaddress.DefaultSubwalletId, subwallet)
+			}
+
+			// deployed accounts use the detected version
+			input = ton.NewTxInput()
+			input.AccountStatus = api.Active
+			input.WalletVersion = version
+			xcTx, err = builder.NewTransfer(from, xc.Address(to), xc.NewAmountBlockchainFromUint64(10), input)
+			require.NoError(t, err)
+			require.Nil(t, xcTx.(*tontx.Tx).ExternalMessage.StateInit)
+			require.Equal(t, version == tonaddress.WalletVersionW5, xcTx.(*tontx.Tx).SignatureLast)
+		})
+	}
+
+	// wallets of other versions (e.g. v4r1) use the default version
+	input := ton.NewTxInput()
+	input.AccountStatus = api.Active
+	input.PublicKey = make([]byte, ed25519.PublicKeySize)
+	xcTx, err := builder.NewTransfer(xc.Address(to), xc.Address(to), xc.NewAmountBlockchainFromUint64(10), input)
+	require.NoError(t, err)
+	require.False(t, xcTx.(*tontx.Tx).SignatureLast)
+}
+
+func TestWalletVersionMaxMessages(t *testing.T) {
+	to, _ := tonaddress.ParseAddress("0QChotyiAtSPqs0BbPD851Mys9_LdMVM7N-atsFYvUMc48Jm", "")
+	msg, err := ton.BuildTransfer(to, tlb.MustFromTON("0.1"), false, "")
+	require.NoError(t, err)
+	input := ton.NewTxInput()
+
+	for _, tc := range []struct {
+		version tonaddress.WalletVersion
+		count   int
+		err     bool
+	}{
+		{tonaddress.WalletVersionV3R2, 4, false},
+		{tonaddress.WalletVersionV3R2, 5, true},
+		{tonaddress.WalletVersionV4R2, 4, false},
+		{tonaddress.WalletVersionV4R2, 5, true},
+		{tonaddress.WalletVersionW5, 5, false},
+		{tonaddress.WalletVersionW5, 255, false},
+		{tonaddress.WalletVersionW5, 256, true},
+	} {
+		msgs := make([]*wallet.Message, tc.count)
+		for i := range msgs {
+			msgs[i] = msg
+		}
+		_, err := ton.BuildUnsignedMessage(tc.version, "", input, msgs)
+		if tc.err {
+			require.Error(t, err, "%s with %d messages", tc.version, tc.count)
+		} else {
+			require.NoError(t, err, "%s with %d messages", tc.version, tc.count)
+		}
+	}
+}
+
+func TestJettonBurnTx(t *testing.T) {
+	chain := &crosschain.ChainConfig{Chain: xc.TON, Decimals: 9}
+	builder, err := ton.NewTxBuilder(&crosschain.TokenAssetConfig{Chain: xc.TON, Decimals: 6, Contract: "kQAiboDEv_qRrcEdrYdwbVLNOXBHwShFbtKGbQVJ2OKxY_Di", ChainConfig: chain})
+	require.NoError(t, err)
+
+	from := "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2"
+	tokenWallet := "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM"
+	input := ton.NewTxInput()
+	input.AccountStatus = api.Active
+	input.Timestamp = 1721068295
+	input.TokenWallet = xc.Address(tokenWallet)
+	input.TonBalance = xc.NewAmountBlockchainFromUint64(1_000_000_000)
+
+	xcTx, err := builder.NewJettonBurn(xc.Address(from), xc.NewAmountBlockchainFromUint64(1_500_000), input)
+	require.NoError(t, err)
+
+	// v3 payload: subwallet, expiration, seqno, then (mode, ^message)
+	payload := xcTx.(*tontx.Tx).CellBuilder.EndCell().BeginParse()
+	_, _ = payload.LoadSlice(96)
+	_, _ = payload.LoadUInt(8)
+	msgCell, err := payload.LoadRef()
+	require.NoError(t, err)
+	msg := &tlb.InternalMessage{}
+	require.NoError(t, tlb.LoadFromCell(msg, msgCell))
+	require.Equal(t, tokenWallet, msg.DstAddr.String())
+	require.Equal(t, "200000000", msg.Amount.Nano().String())
+
+	burn := &jetton.BurnPayload{}
+	require.NoError(t, tlb.LoadFromCell(burn, msg.Body.BeginParse()))
+	require.EqualValues(t, 1721068295, burn.QueryID)
+	require.Equal(t, "1500000", burn.Amount.Nano().String())
+	require.Equal(t, from, burn.ResponseDestination.String())
+
+	// token wallet is required
+	input.TokenWallet = ""
+	_, err = builder.NewJettonBurn(xc.Address(from), xc.NewAmountBlockchainFromUint64(1_500_000), input)
+	require.ErrorContains(t, err, "invalid TON token address")
+}
+
+func TestStakingTxs(t *testing.T) {
+	chain := &crosschain.ChainConfig{Chain: xc.TON, Decimals: 9}
+	builder, err := ton.NewTxBuilder(chain)
+	require.NoError(t, err)
+
+	from := "EQAjflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSha2"
+	pool := "EQA9NAsVDqwDkvX0KKv_3zwzvtaEaKf39gfEoT2AE-9WsVbM"
+	tokenWallet := "EQCxE6mUtQJKFnGfaROTKOt1lZbDiiX1kCixRv7Nw2Id_sDs"
+	newInput := func() ton.TxInput {
+		input := ton.NewTxInput()
+		input.AccountStatus = api.Active
+		input.Timestamp = 1721068295
+		input.TonBalance = xc.NewAmountBlockchainFromUint64(100_000_000_000)
+		return *input
+	}
+	args, err := xcbuilder.NewStakeArgs(xc.TON, xc.Address(from), xc.NewAmountBlockchainFromUint64(10_000_000_000), xcbuilder.OptionValidator(pool))
+	require.NoError(t, err)
+
+	loadMessage := func(xcTx xc.Tx) *tlb.InternalMessage {
+		// v3 payload: subwallet, expiration, seqno, then (mode, ^message)
+		payload := xcTx.(*tontx.Tx).CellBuilder.EndCell().BeginParse()
+		_, _ = payload.LoadSlice(96)
+		_, _ = payload.LoadUInt(8)
+		msgCell, err := payload.LoadRef()
+		require.NoError(t, err)
+		msg := &tlb.InternalMessage{}
+		require.NoError(t, tlb.LoadFromCell(msg, msgCell))
+		return msg
+	}
+
+	t.Run("single_nominator", func(t *testing.T) {
+		xcTx, err := builder.Stake(args, &ton.StakingInput{TxInput: newInput(), PoolKind: ton.SingleNominatorPool, Pool: xc.Address(pool)})
+		require.NoError(t, err)
+		msg := loadMessage(xcTx)
+		require.Equal(t, pool, msg.DstAddr.String())
+		require.True(t, msg.Bounce)
+		require.Equal(t, "10000000000", msg.Amount.Nano().String())
+
+		xcTx, err = builder.Unstake(args, &ton.UnstakingInput{TxInput: newInput(), PoolKind: ton.SingleNominatorPool, Pool: xc.Address(pool)})
+		require.NoError(t, err)
+		msg = loadMessage(xcTx)
+		require.Equal(t, pool, msg.DstAddr.String())
+		require.Equal(t, "100000000", msg.Amount.Nano().String())
+		body := msg.Body.BeginParse()
+		require.EqualValues(t, ton.OpSingleNominatorWithdraw, body.MustLoadUInt(32))
+		require.EqualValues(t, 1721068295, body.MustLoadUInt(64))
+		require.Equal(t, "10000000000", body.MustLoadBigCoins().String())
+	})
+
+	t.Run("nominator_pool", func(t *testing.T) {
+		// nominator pools only accept masterchain wallets
+		_, err := builder.Stake(args, &ton.StakingInput{TxInput: newInput(), PoolKind: ton.NominatorPool, Pool: xc.Address(pool)})
+		require.ErrorContains(t, err, "nominator pools only accept masterchain wallets")
+		_, err = builder.Unstake(args, &ton.UnstakingInput{TxInput: newInput(), PoolKind: ton.NominatorPool, Pool: xc.Address(pool)})
+		require.ErrorContains(t, err, "nominator pools only accept masterchain wallets")
+
+		args, err := xcbuilder.NewStakeArgs(xc.TON, xc.Address("Ef8jflEZ_6KgKMxPlcnKN1ZoUvHdTT6hVwTW95EGVQfeSun-"), xc.NewAmountBlockchainFromUint64(10_000_000_000), xcbuilder.OptionValidator(pool))
+		require.NoError(t, err)
+		xcTx, err := builder.Stake(args, &ton.StakingInput{TxInput: newInput(), PoolKind: ton.NominatorPool, Pool: xc.Address(pool)})
+		require.NoError(t, err)
+		msg := loadMessage(xcTx)
+		require.Equal(t, pool, msg.DstAddr.String())
+		require.Equal(t, "10000000000", msg.Amount.Nano().String())
+		require.Equal(t, "d", msg.Comment())
+
+		xcTx, err = builder.Unstake(args, &ton.UnstakingInput{TxInput: newInput(), PoolKind: ton.NominatorPool, Pool: xc.Address(pool)})
+		require.NoError(t, err)
+		msg = loadMessage(xcTx)
+		require.Equal(t, "1000000000", msg.Amount.Nano().String())
+		require.Equal(t, "w", msg.Comment())
+	})
+
+	t.Run("liquid", func(t *testing.T) {
+		xcTx, err := builder.Stake(args, &ton.StakingInput{TxInput: newInput(), PoolKind: ton.LiquidPool, Pool: xc.Address(pool)})
+		require.NoError(t, err)
+		msg := loadMessage(xcTx)
+		require.Equal(t, pool, msg.DstAddr.String())
+		require.Equal(t, "10000000000", msg.Amount.Nano().String())
+		body := msg.Body.BeginParse()
+		require.EqualValues(t, ton.OpLiquidPoolDeposit, body.MustLoadUInt(32))
+
+		unstakeInput := &ton.UnstakingInput{TxInput: newInput(), PoolKind: ton.LiquidPool, Pool: xc.Address(pool)}
+		unstakeInput.TokenWallet = xc.Address(tokenWallet)
+		xcTx, err = builder.Unstake(args, unstakeInput)
+		require.NoError(t, err)
+		msg = loadMessage(xcTx)
+		require.Equal(t, tokenWallet, msg.DstAddr.String())
+		burn := &jetton.BurnPayload{}
+		require.NoError(t, tlb.LoadFromCell(burn, msg.Body.BeginParse()))
+		require.Equal(t, "10000000000", burn.Amount.Nano().String())
+		require.Equal(t, from, burn.ResponseDestination.String())
+		require.NotNil(t, burn.CustomPayload)
+
+		// the pool jetton wallet is required
+		unstakeInput.TokenWallet = ""
+		_, err = builder.Unstake(args, unstakeInput)
+		require.ErrorContains(t, err, "invalid TON token address")
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := builder.Stake(args, &ton.StakingInput{TxInput: newInput(), PoolKind: "other", Pool: xc.Address(pool)})
+		require.ErrorContains(t, err, "unsupported TON pool kind")
+		_, err = builder.Withdraw(args, &ton.WithdrawInput{TxInput: newInput()})
+		require.Error(t, err)
+	})
+}
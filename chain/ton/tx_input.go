@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	xc "github.com/cordialsys/crosschain"
+	tonaddress "github.com/cordialsys/crosschain/chain/ton/address"
 	"github.com/cordialsys/crosschain/chain/ton/api"
 	"github.com/cordialsys/crosschain/factory/drivers/registry"
 	"github.com/shopspring/decimal"
@@ -24,6 +25,9 @@ type TxInput struct {
 	TokenWallet     xc.Address          `json:"token_wallet"`
 	EstimatedMaxFee xc.AmountBlockchain `json:"estimated_max_fee"`
 	TonBalance      xc.AmountBlockchain `json:"ton_balance"`
+	// Version of the wallet contract deployed on the sender account.  If not set (e.g. the account
+	// is not yet deployed), the version is determined by matching the address against the public key.
+	WalletVersion tonaddress.WalletVersion `json:"wallet_version,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -33,6 +37,9 @@ var _ xc.TxInputWithMemo = &TxInput{}
 
 func init() {
 	registry.RegisterTxBaseInput(&TxInput{})
+	registry.RegisterTxVariantInput(&StakingInput{})
+	registry.RegisterTxVariantInput(&UnstakingInput{})
+	registry.RegisterTxVariantInput(&WithdrawInput{})
 }
 
 func NewTxInput() *TxInput {
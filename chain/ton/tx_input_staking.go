@@ -0,0 +1,64 @@
+package ton
+
+import (
+	xc "github.com/cordialsys/crosschain"
+)
+
+// PoolKind is the type of staking pool contract that is delegated to
+type PoolKind string
+
+const (
+	// A single-nominator pool, owned by a single staker.  Stake is deposited with a simple transfer.
+	SingleNominatorPool PoolKind = "single-nominator"
+	// The TON foundation nominator-pool, shared by up to 40 nominators.  Uses "d" and "w" comments.
+	NominatorPool PoolKind = "nominator-pool"
+	// Tonstakers liquid staking pools (tsTON), which mint a pool jetton in exchange for deposits.
+	// bemo (stTON) uses a different protocol, and is not supported.
+	LiquidPool PoolKind = "liquid"
+)
+
+type StakingInput struct {
+	TxInput
+	PoolKind PoolKind   `json:"pool_kind"`
+	Pool     xc.Address `json:"pool"`
+}
+
+var _ xc.TxVariantInput = &StakingInput{}
+var _ xc.StakeTxInput = &StakingInput{}
+
+func (*StakingInput) Staking() {}
+
+func (*StakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewStakingInputType(xc.DriverTon, string(xc.Native))
+}
+
+// For liquid pools, the `TokenWallet` is set to the staker's wallet of the pool jetton, which gets burned to unstake.
+type UnstakingInput struct {
+	TxInput
+	PoolKind PoolKind   `json:"pool_kind"`
+	Pool     xc.Address `json:"pool"`
+}
+
+var _ xc.TxVariantInput = &UnstakingInput{}
+var _ xc.UnstakeTxInput = &UnstakingInput{}
+
+func (*UnstakingInput) Unstaking() {}
+
+func (*UnstakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewUnstakingInputType(xc.DriverTon, string(xc.Native))
+}
+
+// TON pools return the stake automatically once unstaked, so there's no separate withdraw step.
+// This is only here to satisfy the interface.
+type WithdrawInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &WithdrawInput{}
+var _ xc.WithdrawTxInput = &WithdrawInput{}
+
+func (*WithdrawInput) Withdrawing() {}
+
+func (*WithdrawInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewWithdrawingInputType(xc.DriverTon, string(xc.Native))
+}
@@ -3,11 +3,16 @@ package tron
 import (
 	"encoding/hex"
 	"fmt"
+	"strings"
 
+	"github.com/btcsuite/btcutil/base58"
 	xc "github.com/cordialsys/crosschain"
 	"github.com/okx/go-wallet-sdk/coins/tron"
 )
 
+// Version byte that all tron addresses start with
+const AddressVersion = 0x41
+
 // AddressBuilder for Template
 type AddressBuilder struct {
 }
@@ -36,3 +41,22 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress accepts base58 addresses, or the hex format starting with 41, normalizing to base58.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	var addressBytes []byte
+	var err error
+	if len(address) == 42 && strings.HasPrefix(string(address), "41") {
+		addressBytes, err = hex.DecodeString(string(address))
+	} else {
+		addressBytes, err = tron.GetAddressHash(string(address))
+	}
+	if err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	if len(addressBytes) != 21 || addressBytes[0] != AddressVersion {
+		return xc.InvalidAddress("expected 21 bytes starting with %x", AddressVersion)
+	}
+	normalized := base58.CheckEncode(addressBytes[1:], AddressVersion)
+	return xc.ValidAddress(xc.Address(normalized), xc.AddressTypeDefault)
+}
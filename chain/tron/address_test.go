@@ -45,3 +45,25 @@ func (s *CrosschainTestSuite) TestGetAddressFromPublicKeyErr() {
 // 	require.Equal(xc.Address("0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1"), addresses[0].Address)
 // 	require.Equal(xc.AddressTypeDefault, addresses[0].Type)
 // }
+
+func (s *CrosschainTestSuite) TestValidateAddress() {
+	require := s.Require()
+	builder, _ := NewAddressBuilder(&xc.ChainConfig{})
+
+	result := builder.ValidateAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"), result.Normalized)
+
+	result = builder.ValidateAddress("41a614f803b6fd780986a42c78ec9c7f77e6ded13c")
+	require.True(result.Valid, result.Reason)
+	require.Equal(xc.Address("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"), result.Normalized)
+
+	result = builder.ValidateAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6u")
+	require.False(result.Valid)
+	require.Equal("checksum error", result.Reason)
+
+	// bitcoin address
+	result = builder.ValidateAddress("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+	require.False(result.Valid)
+	require.Equal("expected 21 bytes starting with 41", result.Reason)
+}
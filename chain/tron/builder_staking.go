@@ -0,0 +1,176 @@
+package tron
+
+import (
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/okx/go-wallet-sdk/coins/tron"
+	core "github.com/okx/go-wallet-sdk/coins/tron/pb"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// Stake 2.0 contract types, which are not included in the tron protobuf package we use.
+// https://github.com/tronprotocol/protocol/blob/master/core/contract/balance_contract.proto
+const (
+	FreezeBalanceV2Contract        core.Transaction_Contract_ContractType = 54
+	UnfreezeBalanceV2Contract      core.Transaction_Contract_ContractType = 55
+	WithdrawExpireUnfreezeContract core.Transaction_Contract_ContractType = 56
+	DelegateResourceContract       core.Transaction_Contract_ContractType = 57
+	UnDelegateResourceContract     core.Transaction_Contract_ContractType = 58
+)
+
+var contractTypeNames = map[core.Transaction_Contract_ContractType]string{
+	FreezeBalanceV2Contract:        "FreezeBalanceV2Contract",
+	UnfreezeBalanceV2Contract:      "UnfreezeBalanceV2Contract",
+	WithdrawExpireUnfreezeContract: "WithdrawExpireUnfreezeContract",
+	DelegateResourceContract:       "DelegateResourceContract",
+	UnDelegateResourceContract:     "UnDelegateResourceContract",
+}
+
+var _ xcbuilder.Staking = &TxBuilder{}
+
+func (txBuilder TxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
+	stakeInput, ok := input.(*StakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &StakingInput{})
+	}
+	resource, err := stakeInput.Resource.Code()
+	if err != nil {
+		return nil, err
+	}
+	owner, err := tron.GetAddressHash(string(args.GetFrom()))
+	if err != nil {
+		return nil, err
+	}
+	amount := args.GetAmount()
+
+	var contract *core.Transaction_Contract
+	if stakeInput.Receiver != "" {
+		receiver, err := tron.GetAddressHash(string(stakeInput.Receiver))
+		if err != nil {
+			return nil, err
+		}
+		contract, err = newContract(DelegateResourceContract, delegateResourceParams(owner, resource, amount.Int().Int64(), receiver))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		contract, err = newContract(FreezeBalanceV2Contract, balanceV2Params(owner, amount.Int().Int64(), resource))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stakeInput.newTx(contract), nil
+}
+
+func (txBuilder TxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
+	unstakeInput, ok := input.(*UnstakingInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &UnstakingInput{})
+	}
+	resource, err := unstakeInput.Resource.Code()
+	if err != nil {
+		return nil, err
+	}
+	owner, err := tron.GetAddressHash(string(args.GetFrom()))
+	if err != nil {
+		return nil, err
+	}
+	amount := args.GetAmount()
+
+	var contract *core.Transaction_Contract
+	if unstakeInput.Receiver != "" {
+		receiver, err := tron.GetAddressHash(string(unstakeInput.Receiver))
+		if err != nil {
+			return nil, err
+		}
+		// same fields as delegating, without the lock
+		contract, err = newContract(UnDelegateResourceContract, delegateResourceParams(owner, resource, amount.Int().Int64(), receiver))
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		contract, err = newContract(UnfreezeBalanceV2Contract, balanceV2Params(owner, amount.Int().Int64(), resource))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return unstakeInput.newTx(contract), nil
+}
+
+func (txBuilder TxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
+	withdrawInput, ok := input.(*WithdrawInput)
+	if !ok {
+		return nil, fmt.Errorf("invalid input type %T, expected %T", input, &WithdrawInput{})
+	}
+	owner, err := tron.GetAddressHash(string(args.GetFrom()))
+	if err != nil {
+		return nil, err
+	}
+	// owner_address = 1
+	params := protowire.AppendTag(nil, 1, protowire.BytesType)
+	params = protowire.AppendBytes(params, owner)
+	contract, err := newContract(WithdrawExpireUnfreezeContract, params)
+	if err != nil {
+		return nil, err
+	}
+	return withdrawInput.newTx(contract), nil
+}
+
+func (input *TxInput) newTx(contract *core.Transaction_Contract) *Tx {
+	tx := &core.Transaction{}
+	tx.RawData = input.ToRawData(contract)
+	return &Tx{
+		tronTx: tx,
+	}
+}
+
+func newContract(contractType core.Transaction_Contract_ContractType, params []byte) (*core.Transaction_Contract, error) {
+	name, ok := contractTypeNames[contractType]
+	if !ok {
+		return nil, fmt.Errorf("unknown tron contract type %d", contractType)
+	}
+	return &core.Transaction_Contract{
+		Type: contractType,
+		Parameter: &anypb.Any{
+			TypeUrl: "type.googleapis.com/protocol." + name,
+			Value:   params,
+		},
+	}, nil
+}
+
+// Encodes FreezeBalanceV2Contract or UnfreezeBalanceV2Contract, which have the same layout:
+// owner_address = 1, balance = 2, resource = 3.
+func balanceV2Params(owner []byte, balance int64, resource core.ResourceCode) []byte {
+	bz := protowire.AppendTag(nil, 1, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, owner)
+	if balance != 0 {
+		bz = protowire.AppendTag(bz, 2, protowire.VarintType)
+		bz = protowire.AppendVarint(bz, uint64(balance))
+	}
+	if resource != core.ResourceCode_BANDWIDTH {
+		bz = protowire.AppendTag(bz, 3, protowire.VarintType)
+		bz = protowire.AppendVarint(bz, uint64(resource))
+	}
+	return bz
+}
+
+// Encodes DelegateResourceContract or UnDelegateResourceContract:
+// owner_address = 1, resource = 2, balance = 3, receiver_address = 4.
+func delegateResourceParams(owner []byte, resource core.ResourceCode, balance int64, receiver []byte) []byte {
+	bz := protowire.AppendTag(nil, 1, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, owner)
+	if resource != core.ResourceCode_BANDWIDTH {
+		bz = protowire.AppendTag(bz, 2, protowire.VarintType)
+		bz = protowire.AppendVarint(bz, uint64(resource))
+	}
+	if balance != 0 {
+		bz = protowire.AppendTag(bz, 3, protowire.VarintType)
+		bz = protowire.AppendVarint(bz, uint64(balance))
+	}
+	bz = protowire.AppendTag(bz, 4, protowire.BytesType)
+	bz = protowire.AppendBytes(bz, receiver)
+	return bz
+}
@@ -13,11 +13,14 @@ import (
 	httpclient "github.com/cordialsys/crosschain/chain/tron/http_client"
 	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/factory/drivers/registry"
+	"github.com/cordialsys/crosschain/utils"
 	core "github.com/okx/go-wallet-sdk/coins/tron/pb"
 	"github.com/okx/go-wallet-sdk/crypto/base58"
+	"github.com/sirupsen/logrus"
 )
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 
 const TRANSFER_EVENT_HASH_HEX = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
 const TX_TIMEOUT = 2 * time.Hour
@@ -45,6 +48,13 @@ type TxInput struct {
 	Expiration int64 `json:"expiration,omitempty"`
 	// Transaction creation time (seconds)
 	Timestamp int64 `json:"timestamp,omitempty"`
+
+	// Maximum TRX (in SUN) that may be spent on energy for contract calls
+	FeeLimit int64 `json:"fee_limit,omitempty"`
+	// Estimated energy used by the transaction
+	EnergyEstimate int64 `json:"energy_estimate,omitempty"`
+	// Estimated TRX (in SUN) burned for the energy & bandwidth not covered by the account's resources
+	EstimatedFee int64 `json:"estimated_fee,omitempty"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -76,6 +86,20 @@ func (input *TxInput) SetGasFeePriority(other xc.GasFeePriority) error {
 	return nil
 }
 
+// Returns the fee limit for contract calls, using the estimate if available.
+// It will not go above the max price for safety concerns.
+func (input *TxInput) GetFeeLimit(chain *xc.ChainConfig) int64 {
+	max := int64(chain.ChainMaxGasPrice)
+	if max == 0 {
+		// 2k tron sanity limit
+		max = 2000000000
+	}
+	if input.FeeLimit > 0 && input.FeeLimit < max {
+		return input.FeeLimit
+	}
+	return max
+}
+
 func (input *TxInput) SetUnix(unix int64) {
 	input.Timestamp = unix
 	input.Expiration = unix + int64((TX_TIMEOUT).Seconds())
@@ -118,7 +142,7 @@ func (input *TxInput) ToRawData(contract *core.Transaction_Contract) *core.Trans
 func NewClient(cfgI xc.ITask) (*Client, error) {
 	cfg := cfgI.GetChain()
 
-	client, err := httpclient.NewHttpClient(cfg.URL)
+	client, err := httpclient.NewHttpClient(cfg.URL, utils.NewHttpClient(cfg))
 	// client := client.NewGrpcClient(cfg.URL)
 	// err := client.Start(grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -133,6 +157,15 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 	}, nil
 }
 
+// FetchLatestHeight returns the latest block number of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	block, err := client.client.GetNowBlock()
+	if err != nil {
+		return 0, err
+	}
+	return block.BlockHeader.RawData.Number, nil
+}
+
 func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
 	input := new(TxInput)
 
@@ -149,6 +182,12 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	input.Timestamp = time.Now().Unix()
 	input.Expiration = time.Now().Add(TX_TIMEOUT).Unix()
 
+	err = client.estimateFees(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	if err != nil {
+		// Without an estimate, the fee limit falls back to the configured max (see GetFeeLimit)
+		logrus.WithError(err).Warn("could not estimate tron fees, using the max fee limit")
+	}
+
 	return input, nil
 }
 
@@ -166,7 +205,7 @@ func (client *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
 		return err
 	}
 
-	_, err = client.client.BroadcastHex(hex.EncodeToString(bz))
+	_, err = client.client.BroadcastHex(utils.NonIdempotent(ctx), hex.EncodeToString(bz))
 
 	return err
 }
@@ -192,7 +231,8 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 	var amount xc.AmountBlockchain
 	sources, destinations := deserialiseTransactionEvents(info.Logs)
 	// If we cannot retrieve transaction events, we can infer that the TX is a native transfer
-	if len(sources) == 0 && len(destinations) == 0 {
+	stakingContract := getStakingContract(tx)
+	if len(sources) == 0 && len(destinations) == 0 && stakingContract == nil {
 		from, to, amount, err = deserialiseNativeTransfer(tx)
 		if err != nil {
 			return xc.LegacyTxInfo{}, err
@@ -233,6 +273,12 @@ func (client *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (
 		TimeReceived:    0,
 		Error:           "",
 	}
+	if stakingContract != nil {
+		err = addStakingEvents(stakingContract, &txInfo)
+		if err != nil {
+			return xc.LegacyTxInfo{}, err
+		}
+	}
 
 	return txInfo, nil
 }
@@ -0,0 +1,91 @@
+package tron
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/okx/go-wallet-sdk/crypto/base58"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// Approximate size in bytes of a signed transaction, which is charged as bandwidth
+	NativeTransferBandwidth = 268
+	TokenTransferBandwidth  = 345
+
+	// Defaults used if the chain parameters are not available (in SUN)
+	DefaultEnergyFee      = 420
+	DefaultBandwidthFee   = 1000
+	EnergyFeeParameter    = "getEnergyFee"
+	BandwidthFeeParameter = "getTransactionFee"
+)
+
+// The energy used by a contract call can vary slightly between estimation and execution,
+// so the fee limit includes some margin.
+var FeeLimitMargin = decimal.NewFromFloat(1.2)
+
+// Estimates the resources needed for a transfer, and converts any that are not covered by
+// the account's staked or free resources to TRX using the chain energy & bandwidth prices.
+// The input is only updated if the estimate succeeds.
+func (client *Client) estimateFees(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input *TxInput) error {
+	params, err := client.client.GetChainParameters()
+	if err != nil {
+		return err
+	}
+	energyFee, ok := params.Get(EnergyFeeParameter)
+	if !ok {
+		energyFee = DefaultEnergyFee
+	}
+	bandwidthFee, ok := params.Get(BandwidthFeeParameter)
+	if !ok {
+		bandwidthFee = DefaultBandwidthFee
+	}
+	resources, err := client.client.GetAccountResource(string(from))
+	if err != nil {
+		return err
+	}
+
+	bandwidth := int64(NativeTransferBandwidth)
+	energy := int64(0)
+	if client.contract != "" {
+		bandwidth = TokenTransferBandwidth
+		energy, err = client.estimateTokenTransferEnergy(from, to, amount)
+		if err != nil {
+			return err
+		}
+	}
+
+	fee := int64(0)
+	availableEnergy := max(resources.EnergyLimit-resources.EnergyUsed, 0)
+	if energy > availableEnergy {
+		fee += (energy - availableEnergy) * energyFee
+	}
+	// Bandwidth cannot be partially covered, if there's not enough then the entire size is burned
+	availableBandwidth := max(resources.FreeNetLimit-resources.FreeNetUsed, 0) + max(resources.NetLimit-resources.NetUsed, 0)
+	if bandwidth > availableBandwidth {
+		fee += bandwidth * bandwidthFee
+	}
+
+	input.EnergyEstimate = energy
+	input.EstimatedFee = fee
+	// The fee limit is for the total energy used by the contract, regardless if it's covered by staked energy.
+	input.FeeLimit = decimal.NewFromInt(energy * energyFee).Mul(FeeLimitMargin).IntPart()
+	return nil
+}
+
+// Simulates the TRC20 transfer to determine the energy it will use.
+func (client *Client) estimateTokenTransferEnergy(from xc.Address, to xc.Address, amount xc.AmountBlockchain) (int64, error) {
+	toBz, _, err := base58.CheckDecode(string(to))
+	if err != nil {
+		return 0, fmt.Errorf("invalid tron address %s: %v", to, err)
+	}
+	toHex := hex.EncodeToString(toBz)
+	param := strings.Repeat("0", 64-len(toHex)) + toHex + fmt.Sprintf("%064x", amount.Int())
+	resp, err := client.client.TriggerConstantContracts(string(from), string(client.contract), "transfer(address,uint256)", param)
+	if err != nil {
+		return 0, fmt.Errorf("could not estimate energy: %v", err)
+	}
+	return resp.EnergyUsed, nil
+}
@@ -0,0 +1,189 @@
+package tron
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	httpclient "github.com/cordialsys/crosschain/chain/tron/http_client"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.StakingClient = &Client{}
+
+// Uses the latest block as the reference block
+func (client *Client) fetchBaseInput(ctx context.Context) (*TxInput, error) {
+	block, err := client.client.GetNowBlock()
+	if err != nil {
+		return nil, err
+	}
+	blockId, err := hex.DecodeString(block.BlockId)
+	if err != nil || len(blockId) < 16 {
+		return nil, fmt.Errorf("invalid block id: %s", block.BlockId)
+	}
+	heightBz := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBz, block.BlockHeader.RawData.Number)
+
+	input := NewTxInput()
+	input.RefBlockBytes = heightBz[6:8]
+	input.RefBlockHash = blockId[8:16]
+	input.Timestamp = time.Now().Unix()
+	input.Expiration = time.Now().Add(TX_TIMEOUT).Unix()
+	return input, nil
+}
+
+// FetchStakeBalance reports the TRX frozen by the account (Stake 2.0).  Frozen TRX that is
+// delegated to another account is reported with the receiver as the validator.
+func (client *Client) FetchStakeBalance(ctx context.Context, args xclient.StakedBalanceArgs) ([]*xclient.StakedBalance, error) {
+	from := string(args.GetFrom())
+	balances := []*xclient.StakedBalance{}
+
+	receivers := []string{}
+	if receiver, ok := args.GetValidator(); ok {
+		receivers = append(receivers, receiver)
+	} else {
+		account, err := client.client.GetAccount(from)
+		if err != nil {
+			return nil, err
+		}
+		state := xclient.StakedBalanceState{}
+		active := int64(0)
+		for _, frozen := range account.FrozenV2 {
+			active += frozen.Amount
+		}
+		state.Active = xc.NewAmountBlockchainFromUint64(uint64(active))
+		deactivating := int64(0)
+		inactive := int64(0)
+		nowMs := time.Now().UnixMilli()
+		for _, unfrozen := range account.UnfrozenV2 {
+			if unfrozen.UnfreezeExpireTime > nowMs {
+				deactivating += unfrozen.UnfreezeAmount
+			} else {
+				inactive += unfrozen.UnfreezeAmount
+			}
+		}
+		state.Deactivating = xc.NewAmountBlockchainFromUint64(uint64(deactivating))
+		state.Inactive = xc.NewAmountBlockchainFromUint64(uint64(inactive))
+		balances = append(balances, xclient.NewStakedBalances(state, "", ""))
+
+		delegated := account.DelegatedFrozenV2BalanceForBandwidth + account.AccountResource.DelegatedFrozenV2BalanceForEnergy
+		if delegated > 0 {
+			index, err := client.client.GetDelegatedResourceAccountIndexV2(from)
+			if err != nil {
+				return nil, err
+			}
+			receivers = index.ToAccounts
+		}
+	}
+
+	for _, receiver := range receivers {
+		resp, err := client.client.GetDelegatedResourceV2(from, receiver)
+		if err != nil {
+			return nil, err
+		}
+		delegated := int64(0)
+		for _, resource := range resp.DelegatedResource {
+			delegated += resource.FrozenBalanceForEnergy + resource.FrozenBalanceForBandwidth
+		}
+		if delegated > 0 {
+			balance := xc.NewAmountBlockchainFromUint64(uint64(delegated))
+			balances = append(balances, xclient.NewStakedBalance(balance, xclient.Active, receiver, ""))
+		}
+	}
+	return balances, nil
+}
+
+// The validator option is used as the receiver of delegated resources.
+func (client *Client) FetchStakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.StakeTxInput, error) {
+	input, err := client.fetchBaseInput(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receiver, _ := args.GetValidator()
+	return &StakingInput{
+		TxInput:  *input,
+		Resource: Energy,
+		Receiver: xc.Address(receiver),
+	}, nil
+}
+
+func (client *Client) FetchUnstakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.UnstakeTxInput, error) {
+	input, err := client.fetchBaseInput(ctx)
+	if err != nil {
+		return nil, err
+	}
+	receiver, _ := args.GetValidator()
+	return &UnstakingInput{
+		TxInput:  *input,
+		Resource: Energy,
+		Receiver: xc.Address(receiver),
+	}, nil
+}
+
+func (client *Client) FetchWithdrawInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.WithdrawTxInput, error) {
+	input, err := client.fetchBaseInput(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &WithdrawInput{
+		TxInput: *input,
+	}, nil
+}
+
+type resourceContract struct {
+	Owner           string `json:"owner_address"`
+	Receiver        string `json:"receiver_address"`
+	FrozenBalance   int64  `json:"frozen_balance"`
+	UnfreezeBalance int64  `json:"unfreeze_balance"`
+	Balance         int64  `json:"balance"`
+}
+
+// Returns the Stake 2.0 contract of the transaction, if it is one.
+func getStakingContract(tx *httpclient.GetTransactionIDResponse) *httpclient.ContractData {
+	if len(tx.RawData.Contract) != 1 {
+		return nil
+	}
+	contract := &tx.RawData.Contract[0]
+	for _, name := range contractTypeNames {
+		if contract.Type == name {
+			return contract
+		}
+	}
+	return nil
+}
+
+func addStakingEvents(contract *httpclient.ContractData, info *xc.LegacyTxInfo) error {
+	params := &resourceContract{}
+	if err := contract.ParseParameter(params); err != nil {
+		return fmt.Errorf("invalid %s: %v", contract.Type, err)
+	}
+	switch contract.Type {
+	case contractTypeNames[FreezeBalanceV2Contract]:
+		info.AddStakeEvent(&xclient.Stake{
+			Balance: xc.NewAmountBlockchainFromUint64(uint64(params.FrozenBalance)),
+			Address: params.Owner,
+		})
+	case contractTypeNames[UnfreezeBalanceV2Contract]:
+		info.AddStakeEvent(&xclient.Unstake{
+			Balance: xc.NewAmountBlockchainFromUint64(uint64(params.UnfreezeBalance)),
+			Address: params.Owner,
+		})
+	case contractTypeNames[DelegateResourceContract]:
+		info.AddStakeEvent(&xclient.Stake{
+			Balance:   xc.NewAmountBlockchainFromUint64(uint64(params.Balance)),
+			Validator: params.Receiver,
+			Address:   params.Owner,
+		})
+	case contractTypeNames[UnDelegateResourceContract]:
+		info.AddStakeEvent(&xclient.Unstake{
+			Balance:   xc.NewAmountBlockchainFromUint64(uint64(params.Balance)),
+			Validator: params.Receiver,
+			Address:   params.Owner,
+		})
+	}
+	return nil
+}
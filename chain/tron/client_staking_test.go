@@ -0,0 +1,245 @@
+package tron
+
+import (
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	"github.com/golang/protobuf/proto"
+	"github.com/okx/go-wallet-sdk/coins/tron"
+	core "github.com/okx/go-wallet-sdk/coins/tron/pb"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const testOwner = "TDpBe64DqirkKWj6HWuR1pWgmnhw2wDacE"
+const testReceiver = "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"
+const testContract = "TXLAQ63Xg1NAzckPwKHvzw7CSEmLMEqcdj"
+
+var createTransactionResp = `{"raw_data":{"ref_block_bytes":"a1b2","ref_block_hash":"0102030405060708"}}`
+var chainParametersResp = `{"chainParameter":[{"key":"getTransactionFee","value":1000},{"key":"getEnergyFee","value":210}]}`
+
+func (s *CrosschainTestSuite) TestFetchTransferInputFees() {
+	require := s.Require()
+
+	type testcase struct {
+		name     string
+		contract string
+		resp     []string
+		energy   int64
+		feeLimit int64
+		fee      int64
+	}
+	vectors := []testcase{
+		{
+			name: "native transfer with free bandwidth",
+			resp: []string{
+				createTransactionResp,
+				chainParametersResp,
+				`{"freeNetLimit":600,"freeNetUsed":0}`,
+			},
+			fee: 0,
+		},
+		{
+			name: "native transfer without bandwidth",
+			resp: []string{
+				createTransactionResp,
+				chainParametersResp,
+				`{"freeNetLimit":600,"freeNetUsed":500}`,
+			},
+			fee: NativeTransferBandwidth * 1000,
+		},
+		{
+			name:     "token transfer burning energy",
+			contract: testContract,
+			resp: []string{
+				createTransactionResp,
+				chainParametersResp,
+				`{"freeNetLimit":600,"freeNetUsed":0}`,
+				`{"result":{"result":true},"energy_used":64285,"constant_result":["0000000000000000000000000000000000000000000000000000000000000001"]}`,
+			},
+			energy: 64285,
+			// 64285 * 210 * 1.2
+			feeLimit: 16199820,
+			fee:      64285 * 210,
+		},
+		{
+			name:     "token transfer with staked energy & bandwidth",
+			contract: testContract,
+			resp: []string{
+				createTransactionResp,
+				chainParametersResp,
+				`{"freeNetLimit":600,"freeNetUsed":600,"NetLimit":1000,"NetUsed":100,"EnergyLimit":100000,"EnergyUsed":50000}`,
+				`{"result":{"result":true},"energy_used":64285}`,
+			},
+			energy:   64285,
+			feeLimit: 16199820,
+			fee:      (64285 - 50000) * 210,
+		},
+		{
+			// the fee limit falls back to the max
+			name:     "token transfer failing estimate",
+			contract: testContract,
+			resp: []string{
+				createTransactionResp,
+				chainParametersResp,
+				`{"freeNetLimit":600,"freeNetUsed":0}`,
+				`{"result":{"code":"CONTRACT_VALIDATE_ERROR","message":"636f6e7472616374206e6f7420666f756e64"}}`,
+			},
+			energy:   0,
+			feeLimit: 0,
+			fee:      0,
+		},
+		{
+			name: "native transfer failing chain parameters",
+			resp: []string{
+				createTransactionResp,
+				`{"Error":"service unavailable"}`,
+			},
+		},
+	}
+	for _, v := range vectors {
+		server, close := testtypes.MockHTTP(s.T(), v.resp, 200)
+		asset := xc.ITask(&xc.ChainConfig{URL: server.URL})
+		if v.contract != "" {
+			asset = &xc.TokenAssetConfig{Contract: v.contract, ChainConfig: &xc.ChainConfig{URL: server.URL}}
+		}
+		client, err := NewClient(asset)
+		require.NoError(err)
+		args, _ := xcbuilder.NewTransferArgs(testOwner, testReceiver, xc.NewAmountBlockchainFromUint64(100))
+		input, err := client.FetchTransferInput(s.Ctx, args)
+		close()
+		require.NoError(err, v.name)
+		tronInput := input.(*TxInput)
+		require.Equal(v.energy, tronInput.EnergyEstimate, v.name)
+		require.Equal(v.feeLimit, tronInput.FeeLimit, v.name)
+		require.Equal(v.fee, tronInput.EstimatedFee, v.name)
+		require.Equal(len(v.resp), server.Counter, v.name)
+	}
+}
+
+func (s *CrosschainTestSuite) TestGetFeeLimit() {
+	require := s.Require()
+	chain := &xc.ChainConfig{}
+	require.EqualValues(2000000000, (&TxInput{}).GetFeeLimit(chain))
+	require.EqualValues(1000, (&TxInput{FeeLimit: 1000}).GetFeeLimit(chain))
+	chain.ChainMaxGasPrice = 500
+	require.EqualValues(500, (&TxInput{FeeLimit: 1000}).GetFeeLimit(chain))
+}
+
+func (s *CrosschainTestSuite) TestFetchStakeBalance() {
+	require := s.Require()
+	future := time.Now().Add(time.Hour).UnixMilli()
+	server, close := testtypes.MockHTTP(s.T(), []string{
+		`{"address":"` + testOwner + `","balance":1000,
+		  "frozenV2":[{"amount":300},{"type":"ENERGY","amount":700}],
+		  "unfrozenV2":[{"type":"ENERGY","unfreeze_amount":50,"unfreeze_expire_time":1000},{"unfreeze_amount":20,"unfreeze_expire_time":` + xc.NewAmountBlockchainFromUint64(uint64(future)).String() + `}],
+		  "account_resource":{"delegated_frozenV2_balance_for_energy":400}}`,
+		`{"account":"` + testOwner + `","toAccounts":["` + testReceiver + `"]}`,
+		`{"delegatedResource":[{"from":"` + testOwner + `","to":"` + testReceiver + `","frozen_balance_for_energy":400}]}`,
+	}, 200)
+	defer close()
+	client, _ := NewClient(&xc.ChainConfig{URL: server.URL})
+
+	args, _ := xclient.NewStakeBalanceArgs(testOwner)
+	balances, err := client.FetchStakeBalance(s.Ctx, args)
+	require.NoError(err)
+	require.Len(balances, 2)
+	require.Equal("", balances[0].Validator)
+	require.EqualValues(1000, balances[0].Balance.Active.Uint64())
+	require.EqualValues(20, balances[0].Balance.Deactivating.Uint64())
+	require.EqualValues(50, balances[0].Balance.Inactive.Uint64())
+	require.Equal(testReceiver, balances[1].Validator)
+	require.EqualValues(400, balances[1].Balance.Active.Uint64())
+}
+
+func (s *CrosschainTestSuite) TestStakingTxs() {
+	require := s.Require()
+	builder, _ := NewTxBuilder(&xc.ChainConfig{})
+	owner, _ := tron.GetAddressHash(testOwner)
+	receiver, _ := tron.GetAddressHash(testReceiver)
+	input := &TxInput{Timestamp: 1000, Expiration: 2000}
+
+	decode := func(tx xc.Tx) (core.Transaction_Contract_ContractType, map[protowire.Number]any) {
+		rawData := tx.(*Tx).tronTx.RawData
+		require.Len(rawData.Contract, 1)
+		contract := rawData.Contract[0]
+		fields := map[protowire.Number]any{}
+		bz := contract.Parameter.Value
+		for len(bz) > 0 {
+			num, typ, n := protowire.ConsumeTag(bz)
+			require.Greater(n, 0)
+			bz = bz[n:]
+			switch typ {
+			case protowire.VarintType:
+				v, n := protowire.ConsumeVarint(bz)
+				fields[num] = int64(v)
+				bz = bz[n:]
+			case protowire.BytesType:
+				v, n := protowire.ConsumeBytes(bz)
+				fields[num] = v
+				bz = bz[n:]
+			default:
+				require.Fail("unexpected wire type")
+			}
+		}
+		// must serialize
+		_, err := proto.Marshal(tx.(*Tx).tronTx)
+		require.NoError(err)
+		return contract.Type, fields
+	}
+
+	args, _ := xcbuilder.NewStakeArgs(xc.TRX, testOwner, xc.NewAmountBlockchainFromUint64(5_000_000))
+	tx, err := builder.Stake(args, &StakingInput{TxInput: *input, Resource: Energy})
+	require.NoError(err)
+	contractType, fields := decode(tx)
+	require.Equal(FreezeBalanceV2Contract, contractType)
+	require.Equal(map[protowire.Number]any{1: owner, 2: int64(5_000_000), 3: int64(core.ResourceCode_ENERGY)}, fields)
+
+	tx, err = builder.Stake(args, &StakingInput{TxInput: *input, Resource: Energy, Receiver: testReceiver})
+	require.NoError(err)
+	contractType, fields = decode(tx)
+	require.Equal(DelegateResourceContract, contractType)
+	require.Equal(map[protowire.Number]any{1: owner, 2: int64(core.ResourceCode_ENERGY), 3: int64(5_000_000), 4: receiver}, fields)
+
+	// bandwidth is the default value, so it's omitted
+	tx, err = builder.Unstake(args, &UnstakingInput{TxInput: *input, Resource: Bandwidth})
+	require.NoError(err)
+	contractType, fields = decode(tx)
+	require.Equal(UnfreezeBalanceV2Contract, contractType)
+	require.Equal(map[protowire.Number]any{1: owner, 2: int64(5_000_000)}, fields)
+
+	tx, err = builder.Unstake(args, &UnstakingInput{TxInput: *input, Resource: Energy, Receiver: testReceiver})
+	require.NoError(err)
+	contractType, _ = decode(tx)
+	require.Equal(UnDelegateResourceContract, contractType)
+
+	tx, err = builder.Withdraw(args, &WithdrawInput{TxInput: *input})
+	require.NoError(err)
+	contractType, fields = decode(tx)
+	require.Equal(WithdrawExpireUnfreezeContract, contractType)
+	require.Equal(map[protowire.Number]any{1: owner}, fields)
+
+	_, err = builder.Stake(args, &StakingInput{TxInput: *input, Resource: "storage"})
+	require.ErrorContains(err, "unsupported tron resource")
+}
+
+func (s *CrosschainTestSuite) TestFetchStakingInput() {
+	require := s.Require()
+	server, close := testtypes.MockHTTP(s.T(), []string{
+		`{"blockID":"0000000003a4b5c6d1d2d3d4d5d6d7d8e1e2e3e4e5e6e7e8f1f2f3f4f5f6f7f8","block_header":{"raw_data":{"number":61126086}}}`,
+	}, 200)
+	defer close()
+	client, _ := NewClient(&xc.ChainConfig{URL: server.URL})
+
+	args, _ := xcbuilder.NewStakeArgs(xc.TRX, testOwner, xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionValidator(testReceiver))
+	input, err := client.FetchStakingInput(s.Ctx, args)
+	require.NoError(err)
+	stakingInput := input.(*StakingInput)
+	require.Equal([]byte{0xb5, 0xc6}, stakingInput.RefBlockBytes)
+	require.Equal([]byte{0xd1, 0xd2, 0xd3, 0xd4, 0xd5, 0xd6, 0xd7, 0xd8}, stakingInput.RefBlockHash)
+	require.Equal(xc.Address(testReceiver), stakingInput.Receiver)
+	require.Equal(Energy, stakingInput.Resource)
+	require.Equal(xc.DriverTron, stakingInput.GetDriver())
+}
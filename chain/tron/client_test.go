@@ -1,5 +1,11 @@
 package tron
 
+import (
+	xc "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+)
+
 // func TestDeserialiseTransactionEvents(t *testing.T) {
 // 	dummyEvent := new(core.TransactionInfo_Log)
 // 	dummyEvent.Address, _ = address.Base58ToAddress("TKHN9ED3N4psUbKs6sCGKPuoLxFWdb3Ud5")
@@ -10,3 +16,23 @@ package tron
 // 	hex.Decode(dummyEvent.Topics[1], []byte("41b737f97351c2a20fd7de320221a774c3ca837b94"))
 // 	hex.Decode(dummyEvent.Data, []byte("0x000000000000000000000000000000000000000000000000000000000015f900"))
 // }
+
+func (s *CrosschainTestSuite) TestFetchTokenMetadata() {
+	require := s.Require()
+	constantResult := func(result string) string {
+		return `{"result":{"result":true},"energy_used":500,"constant_result":["` + result + `"]}`
+	}
+	server, close := testtypes.MockHTTP(s.T(), []string{
+		constantResult("0000000000000000000000000000000000000000000000000000000000000006"),
+		constantResult("000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000045553445400000000000000000000000000000000000000000000000000000000"),
+		constantResult("0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000a54657468657220555344000000000000000000000000000000000000000000000000"),
+	}, 200)
+	defer close()
+	client, err := NewClient(&xc.ChainConfig{Chain: xc.TRX, URL: server.URL})
+	require.NoError(err)
+
+	usdt := xc.ContractAddress("TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	metadata, err := client.FetchTokenMetadata(s.Ctx, usdt)
+	require.NoError(err)
+	require.Equal(&xclient.TokenMetadata{Contract: usdt, Name: "Tether USD", Symbol: "USDT", Decimals: 6}, metadata)
+}
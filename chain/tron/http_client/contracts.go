@@ -10,11 +10,15 @@ type transferContract struct {
 
 func (c *ContractData) AsTransferContract() (*transferContract, error) {
 	data := &transferContract{}
+	err := c.ParseParameter(data)
+	return data, err
+}
+
+// Decodes the contract parameter value into the given struct
+func (c *ContractData) ParseParameter(dest any) error {
 	bz, err := json.Marshal(c.Parameter.Value)
 	if err != nil {
-		return data, err
+		return err
 	}
-
-	err = json.Unmarshal(bz, data)
-	return data, err
+	return json.Unmarshal(bz, dest)
 }
@@ -2,6 +2,7 @@ package httpclient
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -116,27 +117,91 @@ type BlockResponse struct {
 	BlockId     string      `json:"blockID"`
 }
 
+type TriggerConstantContractResult struct {
+	Result  bool   `json:"result"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 type TriggerConstantContractResponse struct {
 	Error
-	ConstantResult []Bytes `json:"constant_result"`
+	Result         TriggerConstantContractResult `json:"result"`
+	ConstantResult []Bytes                       `json:"constant_result"`
+	EnergyUsed     int64                         `json:"energy_used"`
+}
+
+// Stake 2.0 frozen balance.  The type is omitted for bandwidth.
+type FrozenV2 struct {
+	Type   string `json:"type"`
+	Amount int64  `json:"amount"`
+}
+
+// Stake 2.0 balance that is being unfrozen, and can be withdrawn after the expire time (milliseconds).
+type UnfrozenV2 struct {
+	Type               string `json:"type"`
+	UnfreezeAmount     int64  `json:"unfreeze_amount"`
+	UnfreezeExpireTime int64  `json:"unfreeze_expire_time"`
+}
+
+type AccountResource struct {
+	DelegatedFrozenV2BalanceForEnergy int64 `json:"delegated_frozenV2_balance_for_energy"`
 }
 
 type GetAccountResponse struct {
 	Error
-	Balance uint64 `json:"balance"`
-	Address string `json:"address"`
+	Balance                              uint64          `json:"balance"`
+	Address                              string          `json:"address"`
+	FrozenV2                             []FrozenV2      `json:"frozenV2"`
+	UnfrozenV2                           []UnfrozenV2    `json:"unfrozenV2"`
+	DelegatedFrozenV2BalanceForBandwidth int64           `json:"delegated_frozenV2_balance_for_bandwidth"`
+	AccountResource                      AccountResource `json:"account_resource"`
+}
+
+type GetAccountResourceResponse struct {
+	Error
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	NetUsed      int64 `json:"NetUsed"`
+	NetLimit     int64 `json:"NetLimit"`
+	EnergyUsed   int64 `json:"EnergyUsed"`
+	EnergyLimit  int64 `json:"EnergyLimit"`
+}
+
+type ChainParameter struct {
+	Key   string `json:"key"`
+	Value int64  `json:"value"`
+}
+
+type GetChainParametersResponse struct {
+	Error
+	ChainParameter []ChainParameter `json:"chainParameter"`
 }
 
-func NewHttpClient(baseUrl string) (*Client, error) {
+type DelegatedResource struct {
+	From                      string `json:"from"`
+	To                        string `json:"to"`
+	FrozenBalanceForBandwidth int64  `json:"frozen_balance_for_bandwidth"`
+	FrozenBalanceForEnergy    int64  `json:"frozen_balance_for_energy"`
+}
+
+type GetDelegatedResourceResponse struct {
+	Error
+	DelegatedResource []DelegatedResource `json:"delegatedResource"`
+}
+
+type GetDelegatedResourceAccountIndexResponse struct {
+	Error
+	Account      string   `json:"account"`
+	ToAccounts   []string `json:"toAccounts"`
+	FromAccounts []string `json:"fromAccounts"`
+}
+
+func NewHttpClient(baseUrl string, client *http.Client) (*Client, error) {
 	baseUrl = strings.TrimSuffix(baseUrl, "/")
 	baseUrl = strings.TrimSuffix(baseUrl, "/wallet")
 	baseUrl = strings.TrimSuffix(baseUrl, "/jsonrpc")
 	u, err := url.Parse(baseUrl)
 
-	// may want to pass externally to support additional
-	// headers or something.
-	client := &http.Client{}
-
 	return &Client{
 		baseUrl: u,
 		client:  client,
@@ -211,7 +276,7 @@ func (c *Client) CreateTransaction(from string, to string, amount int) (*CreateT
 	return parsed, nil
 }
 
-func (c *Client) BroadcastHex(txHex string) (*CreateTransactionResponse, error) {
+func (c *Client) BroadcastHex(ctx context.Context, txHex string) (*CreateTransactionResponse, error) {
 	req, err := postRequest(c.Url("wallet/broadcasthex"), map[string]interface{}{
 		"transaction": txHex,
 	})
@@ -219,6 +284,7 @@ func (c *Client) BroadcastHex(txHex string) (*CreateTransactionResponse, error)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	resp, err := c.client.Do(req)
 	if err != nil {
@@ -321,6 +387,31 @@ func (c *Client) GetBlockByNum(num uint64) (*BlockResponse, error) {
 	return parsed, nil
 }
 
+func (c *Client) GetNowBlock() (*BlockResponse, error) {
+	req, err := postRequest(c.Url("wallet/getnowblock"), map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &BlockResponse{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+	if len(parsed.BlockId) == 0 {
+		return parsed, fmt.Errorf("could not get latest block")
+	}
+
+	return parsed, nil
+}
+
 func (c *Client) TriggerConstantContracts(ownerAddress string, contract string, funcSelector string, param string) (*TriggerConstantContractResponse, error) {
 	req, err := postRequest(c.Url("wallet/triggerconstantcontract"), map[string]interface{}{
 		"owner_address":     ownerAddress,
@@ -347,6 +438,14 @@ func (c *Client) TriggerConstantContracts(ownerAddress string, contract string,
 	if err != nil {
 		return parsed, err
 	}
+	if len(parsed.Result.Code) > 0 {
+		// the message is usually hex encoded
+		message := parsed.Result.Message
+		if bz, err := hex.DecodeString(message); err == nil {
+			message = string(bz)
+		}
+		return parsed, fmt.Errorf("%s: %s", parsed.Result.Code, message)
+	}
 
 	return parsed, nil
 }
@@ -406,3 +505,115 @@ func (c *Client) GetAccount(address string) (*GetAccountResponse, error) {
 
 	return parsed, nil
 }
+
+func (c *Client) GetAccountResource(address string) (*GetAccountResourceResponse, error) {
+	req, err := postRequest(c.Url("wallet/getaccountresource"), map[string]interface{}{
+		"address": address,
+		"visible": true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &GetAccountResourceResponse{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
+
+func (c *Client) GetChainParameters() (*GetChainParametersResponse, error) {
+	req, err := http.NewRequest("GET", c.Url("wallet/getchainparameters"), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &GetChainParametersResponse{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
+
+// Returns the value of a chain parameter, e.g. "getEnergyFee"
+func (res *GetChainParametersResponse) Get(key string) (int64, bool) {
+	for _, param := range res.ChainParameter {
+		if param.Key == key {
+			return param.Value, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Client) GetDelegatedResourceV2(from string, to string) (*GetDelegatedResourceResponse, error) {
+	req, err := postRequest(c.Url("wallet/getdelegatedresourcev2"), map[string]interface{}{
+		"fromAddress": from,
+		"toAddress":   to,
+		"visible":     true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &GetDelegatedResourceResponse{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
+
+func (c *Client) GetDelegatedResourceAccountIndexV2(address string) (*GetDelegatedResourceAccountIndexResponse, error) {
+	req, err := postRequest(c.Url("wallet/getdelegatedresourceaccountindexv2"), map[string]interface{}{
+		"value":   address,
+		"visible": true,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := parseResponse(resp, &GetDelegatedResourceAccountIndexResponse{})
+	if err != nil {
+		return nil, err
+	}
+	err = checkError(parsed.Error)
+	if err != nil {
+		return parsed, err
+	}
+
+	return parsed, nil
+}
@@ -0,0 +1,61 @@
+package tron
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	xc "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+var _ xclient.TokenMetadataClient = &Client{}
+
+// FetchTokenMetadata calls decimals(), symbol() and name() on a TRC-20 contract.
+func (client *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	decimals, err := client.callConstant(contract, "decimals()")
+	if err != nil {
+		return nil, fmt.Errorf("could not get decimals of %s: %v", contract, err)
+	}
+	symbol, err := client.callConstant(contract, "symbol()")
+	if err != nil {
+		return nil, fmt.Errorf("could not get symbol of %s: %v", contract, err)
+	}
+	// the name is optional
+	name, _ := client.callConstant(contract, "name()")
+	return &xclient.TokenMetadata{
+		Contract: contract,
+		Name:     decodeAbiString(name),
+		Symbol:   decodeAbiString(symbol),
+		Decimals: int32(new(big.Int).SetBytes(decimals).Int64()),
+	}, nil
+}
+
+func (client *Client) callConstant(contract xc.ContractAddress, method string) ([]byte, error) {
+	// any owner can be used for a read-only call
+	resp, err := client.client.TriggerConstantContracts(string(contract), string(contract), method, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.ConstantResult) == 0 || len(resp.ConstantResult[0]) == 0 {
+		return nil, fmt.Errorf("no result returned calling %s", method)
+	}
+	return resp.ConstantResult[0], nil
+}
+
+// Decodes an abi encoded string, or a bytes32 as returned by some older tokens.
+func decodeAbiString(data []byte) string {
+	if len(data) >= 64 {
+		length := big.NewInt(int64(len(data)))
+		offset := new(big.Int).SetBytes(data[:32])
+		if new(big.Int).Add(offset, big.NewInt(32)).Cmp(length) <= 0 {
+			start := offset.Int64() + 32
+			size := new(big.Int).SetBytes(data[start-32 : start])
+			if new(big.Int).Add(size, big.NewInt(start)).Cmp(length) <= 0 {
+				return string(data[start : start+size.Int64()])
+			}
+		}
+	}
+	return string(bytes.TrimRight(data, "\x00"))
+}
@@ -0,0 +1,83 @@
+package tron
+
+import (
+	"fmt"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/drivers/registry"
+	core "github.com/okx/go-wallet-sdk/coins/tron/pb"
+)
+
+// Resource that staked TRX is frozen for
+type Resource string
+
+const (
+	Energy    Resource = "ENERGY"
+	Bandwidth Resource = "BANDWIDTH"
+)
+
+func (r Resource) Code() (core.ResourceCode, error) {
+	switch Resource(strings.ToUpper(string(r))) {
+	case Energy, "":
+		return core.ResourceCode_ENERGY, nil
+	case Bandwidth:
+		return core.ResourceCode_BANDWIDTH, nil
+	default:
+		return 0, fmt.Errorf("unsupported tron resource: %s", r)
+	}
+}
+
+func init() {
+	registry.RegisterTxVariantInput(&StakingInput{})
+	registry.RegisterTxVariantInput(&UnstakingInput{})
+	registry.RegisterTxVariantInput(&WithdrawInput{})
+}
+
+// Staking freezes TRX for a resource (Stake 2.0).  If a receiver is set, the resource of
+// already frozen TRX is delegated to the receiver instead.
+type StakingInput struct {
+	TxInput
+	Resource Resource   `json:"resource"`
+	Receiver xc.Address `json:"receiver,omitempty"`
+}
+
+var _ xc.TxVariantInput = &StakingInput{}
+var _ xc.StakeTxInput = &StakingInput{}
+
+func (*StakingInput) Staking() {}
+
+func (*StakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewStakingInputType(xc.DriverTron, string(xc.Native))
+}
+
+// Unstaking unfreezes TRX, which can be withdrawn after the unfreezing period.  If a receiver
+// is set, the resource delegated to the receiver is reclaimed instead.
+type UnstakingInput struct {
+	TxInput
+	Resource Resource   `json:"resource"`
+	Receiver xc.Address `json:"receiver,omitempty"`
+}
+
+var _ xc.TxVariantInput = &UnstakingInput{}
+var _ xc.UnstakeTxInput = &UnstakingInput{}
+
+func (*UnstakingInput) Unstaking() {}
+
+func (*UnstakingInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewUnstakingInputType(xc.DriverTron, string(xc.Native))
+}
+
+// Withdraws all unfrozen TRX that has passed the unfreezing period.
+type WithdrawInput struct {
+	TxInput
+}
+
+var _ xc.TxVariantInput = &WithdrawInput{}
+var _ xc.WithdrawTxInput = &WithdrawInput{}
+
+func (*WithdrawInput) Withdrawing() {}
+
+func (*WithdrawInput) GetVariant() xc.TxVariantInputType {
+	return xc.NewWithdrawingInputType(xc.DriverTron, string(xc.Native))
+}
@@ -10,13 +10,14 @@ import (
 
 // AddressBuilder for XRP
 type AddressBuilder struct {
+	net string
 }
 
 var _ xc.AddressBuilder = AddressBuilder{}
 
 // NewAddressBuilder creates a new XRP AddressBuilder
 func NewAddressBuilder(cfgI xc.ITask) (xc.AddressBuilder, error) {
-	return AddressBuilder{}, nil
+	return AddressBuilder{net: cfgI.GetChain().Net}, nil
 }
 
 // Custom base58 dictionary for XRP
@@ -94,3 +95,24 @@ func (ab AddressBuilder) GetAllPossibleAddressesFromPublicKey(publicKeyBytes []b
 		},
 	}, err
 }
+
+// ValidateAddress accepts classic addresses, and X-addresses which are normalized to the classic
+// address and their destination tag.
+func (ab AddressBuilder) ValidateAddress(address xc.Address) *xc.AddressValidation {
+	if IsXAddress(address) {
+		classic, tag, testnet, err := DecodeXAddress(address)
+		if err != nil {
+			return xc.InvalidAddress("%v", err)
+		}
+		if testnet != (ab.net == "testnet") {
+			return xc.InvalidAddress("X-address is for a different network")
+		}
+		result := xc.ValidAddress(classic, AddressTypeXAddress)
+		result.Tag = tag
+		return result
+	}
+	if _, err := DecodeClassicAddress(address); err != nil {
+		return xc.InvalidAddress("%v", err)
+	}
+	return xc.ValidAddress(address, AddressTypeClassic)
+}
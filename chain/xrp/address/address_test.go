@@ -32,3 +32,39 @@ func TestGetAllPossibleAddressesFromPublicKey(t *testing.T) {
 	require.Equal(t, xc.Address("rLETt614usCXtkc8YcQmrzachrCaDjACjP"), addresses[0].Address)
 	require.Equal(t, xc.AddressTypeDefault, addresses[0].Type)
 }
+
+func TestValidateAddress(t *testing.T) {
+	tag := func(tag uint64) *uint64 { return &tag }
+	vectors := []struct {
+		name        string
+		net         string
+		address     string
+		normalized  string
+		addressType xc.AddressType
+		tag         *uint64
+		reason      string
+	}{
+		{"classic", "mainnet", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", address.AddressTypeClassic, nil, ""},
+		{"x_address", "mainnet", "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", address.AddressTypeXAddress, nil, ""},
+		{"x_address_tag", "mainnet", "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", address.AddressTypeXAddress, tag(1), ""},
+		{"x_address_testnet", "testnet", "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", address.AddressTypeXAddress, nil, ""},
+		{"x_address_wrong_network", "mainnet", "T719a5UwUCnEs54UsxG9CJYYDhwmFCqkr7wxCcNcfZ6p5GZ", "", "", nil, "X-address is for a different network"},
+		{"bad_checksum", "mainnet", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpg", "", "", nil, "invalid checksum"},
+		{"bad_character", "mainnet", "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYp0", "", "", nil, "invalid base58 character '0'"},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			builder, _ := address.NewAddressBuilder(&xc.ChainConfig{Net: v.net})
+			result := builder.ValidateAddress(xc.Address(v.address))
+			if v.reason != "" {
+				require.False(t, result.Valid)
+				require.Equal(t, v.reason, result.Reason)
+				return
+			}
+			require.True(t, result.Valid, result.Reason)
+			require.Equal(t, xc.Address(v.normalized), result.Normalized)
+			require.Equal(t, v.addressType, result.Type)
+			require.Equal(t, v.tag, result.Tag)
+		})
+	}
+}
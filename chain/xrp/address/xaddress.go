@@ -0,0 +1,124 @@
+package address
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcutil/base58"
+	xc "github.com/cordialsys/crosschain"
+)
+
+// Formats an XRP address can be written in
+const (
+	AddressTypeClassic  xc.AddressType = "Classic"
+	AddressTypeXAddress xc.AddressType = "XAddress"
+)
+
+// X-addresses (XLS-5d) start with a prefix for the network they are for
+var (
+	xAddressMainnetPrefix = []byte{0x05, 0x44}
+	xAddressTestnetPrefix = []byte{0x04, 0x93}
+)
+
+// Classic addresses are a version byte, the 20 byte account id, and a checksum
+const classicAddressLength = 1 + 20 + 4
+
+// X-addresses are a network prefix, the account id, a tag flag, a 64 bit tag, and a checksum
+const xAddressLength = 2 + 20 + 1 + 8 + 4
+
+const bitcoinBase58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// DecodeBase58 decodes a string encoded with the XRP base58 dictionary.
+func DecodeBase58(input string) ([]byte, error) {
+	translated := make([]byte, len(input))
+	for i := 0; i < len(input); i++ {
+		index := strings.IndexByte(xrpBase58Alphabet, input[i])
+		if index < 0 {
+			return nil, fmt.Errorf("invalid base58 character %q", input[i])
+		}
+		translated[i] = bitcoinBase58Alphabet[index]
+	}
+	return base58.Decode(string(translated)), nil
+}
+
+func checksum(payload []byte) []byte {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	return second[:4]
+}
+
+func decodeChecked(address xc.Address, length int) ([]byte, error) {
+	decoded, err := DecodeBase58(string(address))
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) != length {
+		return nil, fmt.Errorf("expected %d bytes, got %d", length, len(decoded))
+	}
+	payload := decoded[:length-4]
+	if !bytes.Equal(checksum(payload), decoded[length-4:]) {
+		return nil, errors.New("invalid checksum")
+	}
+	return payload, nil
+}
+
+// DecodeClassicAddress returns the account id of a classic "r..." address.
+func DecodeClassicAddress(address xc.Address) ([]byte, error) {
+	payload, err := decodeChecked(address, classicAddressLength)
+	if err != nil {
+		return nil, err
+	}
+	if payload[0] != 0x00 {
+		return nil, fmt.Errorf("invalid address version %d", payload[0])
+	}
+	return payload[1:], nil
+}
+
+// IsXAddress returns true for addresses in the X-address format, which start with X on mainnet and T on testnet.
+func IsXAddress(address xc.Address) bool {
+	return strings.HasPrefix(string(address), "X") || strings.HasPrefix(string(address), "T")
+}
+
+// DecodeXAddress returns the classic address and destination tag encoded in an X-address.
+func DecodeXAddress(address xc.Address) (classic xc.Address, tag *uint64, testnet bool, err error) {
+	payload, err := decodeChecked(address, xAddressLength)
+	if err != nil {
+		return "", nil, false, err
+	}
+	switch {
+	case bytes.Equal(payload[:2], xAddressMainnetPrefix):
+		testnet = false
+	case bytes.Equal(payload[:2], xAddressTestnetPrefix):
+		testnet = true
+	default:
+		return "", nil, false, fmt.Errorf("invalid X-address prefix %x", payload[:2])
+	}
+	accountId := payload[2:22]
+	flag := payload[22]
+	tagValue := binary.LittleEndian.Uint64(payload[23:31])
+	switch flag {
+	case 0:
+		if tagValue != 0 {
+			return "", nil, false, errors.New("X-address without a tag flag has a tag")
+		}
+	case 1:
+		// tags are 32 bits, the rest is reserved
+		if tagValue > 0xffffffff {
+			return "", nil, false, errors.New("X-address tag is more than 32 bits")
+		}
+		tag = &tagValue
+	default:
+		return "", nil, false, fmt.Errorf("invalid X-address tag flag %d", flag)
+	}
+	return EncodeClassicAddress(accountId), tag, testnet, nil
+}
+
+// EncodeClassicAddress returns the classic "r..." address of an account id.
+func EncodeClassicAddress(accountId []byte) xc.Address {
+	payload := append([]byte{0x00}, accountId...)
+	return xc.Address(EncodeBase58(append(payload, checksum(payload)...)))
+}
@@ -35,16 +35,20 @@ func NewTxBuilder(asset xc.ITask) (*TxBuilder, error) {
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
-	return txBuilder.NewTransfer(args.GetFrom(), args.GetTo(), args.GetAmount(), input)
+	return txBuilder.transfer(args.GetFrom(), args.GetTo(), args.GetAmount(), &args, input.(*TxInput))
 }
 
 // NewTransfer creates a new transfer for an Asset, either native or token
 func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.transfer(from, to, amount, nil, input.(*TxInput))
+}
+
+func (txBuilder TxBuilder) transfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, args *xcbuilder.TransferArgs, txInput *TxInput) (xc.Tx, error) {
 	switch asset := txBuilder.Asset.(type) {
 	case *xc.ChainConfig:
-		return txBuilder.NewNativeTransfer(from, to, amount, input)
+		return txBuilder.newNativeTransfer(from, to, amount, args, txInput)
 	case *xc.TokenAssetConfig:
-		return txBuilder.NewTokenTransfer(from, to, amount, input)
+		return txBuilder.newTokenTransfer(from, to, amount, args, txInput)
 	default:
 		contract := asset.GetContract()
 		logrus.WithFields(logrus.Fields{
@@ -53,17 +57,41 @@ func (txBuilder TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc
 			"asset_type": fmt.Sprintf("%T", asset),
 		}).Warn("new transfer for unknown asset type")
 		if contract != "" {
-			return txBuilder.NewTokenTransfer(from, to, amount, input)
+			return txBuilder.newTokenTransfer(from, to, amount, args, txInput)
 		} else {
-			return txBuilder.NewNativeTransfer(from, to, amount, input)
+			return txBuilder.newNativeTransfer(from, to, amount, args, txInput)
 		}
 	}
 }
 
+// The destination tag is set using the transfer option.  Token transfers have always used the memo as
+// the destination tag, so if memoAsTag is set it's still used when the option isn't.
+func getDestinationTag(args *xcbuilder.TransferArgs, txInput *TxInput, memoAsTag bool) (int64, error) {
+	if args != nil {
+		if tag, ok := args.GetDestinationTag(); ok {
+			return int64(tag), nil
+		}
+	}
+	if memoAsTag && txInput.LegacyMemo != "" {
+		destinationTag, err := strconv.ParseUint(txInput.LegacyMemo, 10, 32)
+		if err != nil {
+			return 0, fmt.Errorf("error converting memo to a destination tag: %v", err)
+		}
+		return int64(destinationTag), nil
+	}
+	return 0, nil
+}
+
 // NewNativeTransfer creates a new transfer for a native asset
 func (txBuilder TxBuilder) NewNativeTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
-	txInput := input.(*TxInput)
+	return txBuilder.newNativeTransfer(from, to, amount, nil, input.(*TxInput))
+}
 
+func (txBuilder TxBuilder) newNativeTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, args *xcbuilder.TransferArgs, txInput *TxInput) (xc.Tx, error) {
+	destinationTag, err := getDestinationTag(args, txInput, false)
+	if err != nil {
+		return nil, err
+	}
 	XRPAmount := xrptx.AmountBlockchain{
 		XRPAmount: amount.String(),
 	}
@@ -72,7 +100,8 @@ func (txBuilder TxBuilder) NewNativeTransfer(from xc.Address, to xc.Address, amo
 		Account:            from,
 		Amount:             XRPAmount,
 		Destination:        to,
-		Fee:                "10",
+		DestinationTag:     destinationTag,
+		Fee:                txBuilder.fee(txInput),
 		Flags:              0,
 		LastLedgerSequence: txInput.LastLedgerSequence,
 		Sequence:           txInput.Sequence,
@@ -80,16 +109,20 @@ func (txBuilder TxBuilder) NewNativeTransfer(from xc.Address, to xc.Address, amo
 		TransactionType:    xrptx.PAYMENT,
 	}
 
-	return &xrptx.Tx{
-		XRPTx:      &xrpTx,
-		SignPubKey: txInput.PublicKey,
-	}, nil
+	return txBuilder.newTx(&xrpTx, txInput), nil
 }
 
 // NewTokenTransfer creates a new transfer for a token asset
 func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	return txBuilder.newTokenTransfer(from, to, amount, nil, input.(*TxInput))
+}
+
+func (txBuilder TxBuilder) newTokenTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, args *xcbuilder.TransferArgs, txInput *TxInput) (xc.Tx, error) {
 	asset := txBuilder.Asset
-	txInput := input.(*TxInput)
+	destinationTag, err := getDestinationTag(args, txInput, true)
+	if err != nil {
+		return nil, err
+	}
 
 	assetContract := asset.GetContract()
 	if assetContract == "" {
@@ -112,14 +145,6 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 		},
 	}
 
-	var destinationTag int64
-	if txInput.LegacyMemo != "" {
-		destinationTag, err = strconv.ParseInt(txInput.LegacyMemo, 10, 64)
-		if err != nil {
-			return nil, fmt.Errorf("error converting destinationTag to int64: %v", err)
-		}
-	}
-
 	// We permit spending an additional amount (10%) in order to send the target amount.
 	// This is needed because XRP tokens can have their own fees.
 	// https://xrpl.org/docs/concepts/payment-types/partial-payments#without-partial-payments
@@ -139,7 +164,7 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 		Amount:             XRPAmount,
 		SendMax:            sendMax,
 		Destination:        to,
-		Fee:                "10",
+		Fee:                txBuilder.fee(txInput),
 		Flags:              0,
 		LastLedgerSequence: txInput.LastLedgerSequence,
 		Sequence:           txInput.Sequence,
@@ -148,8 +173,127 @@ func (txBuilder TxBuilder) NewTokenTransfer(from xc.Address, to xc.Address, amou
 		DestinationTag:     destinationTag,
 	}
 
+	return txBuilder.newTx(&xrpTx, txInput), nil
+}
+
+// NewTrustSet creates or updates a trust line to the issuer of the builder's token asset, which is required
+// before the token can be received.  Setting a limit of zero removes the trust line (once the balance is zero).
+func (txBuilder TxBuilder) NewTrustSet(from xc.Address, limit xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	txInput := input.(*TxInput)
+	assetContract := txBuilder.Asset.GetContract()
+	if assetContract == "" {
+		return nil, fmt.Errorf("asset does not have a contract")
+	}
+	tokenAsset, tokenContract, err := contract.ExtractAssetAndContract(assetContract)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse and extract asset and contract: %w", err)
+	}
+
+	xrpTx := xrptx.XRPTransaction{
+		Account: from,
+		LimitAmount: &xrptx.Amount{
+			Currency: tokenAsset,
+			Issuer:   tokenContract,
+			Value:    limit.ToHuman(types.TRUSTLINE_DECIMALS).String(),
+		},
+		Fee:                txBuilder.fee(txInput),
+		Flags:              xrptx.TF_SET_NO_RIPPLE,
+		LastLedgerSequence: txInput.LastLedgerSequence,
+		Sequence:           txInput.Sequence,
+		SigningPubKey:      hex.EncodeToString(txInput.PublicKey),
+		TransactionType:    xrptx.TRUST_SET,
+	}
+
+	return txBuilder.newTx(&xrpTx, txInput), nil
+}
+
+// NewAccountDelete deletes the account and sends the remaining XRP, including the reserve, to the destination.
+// The input fee must cover the owner reserve, see `FetchAccountDeleteInput`.
+func (txBuilder TxBuilder) NewAccountDelete(from xc.Address, to xc.Address, input xc.TxInput) (xc.Tx, error) {
+	txInput := input.(*TxInput)
+	if txInput.Fee.IsZero() {
+		return nil, fmt.Errorf("the fee must be set to the owner reserve to delete an account")
+	}
+	// the owner reserve is burned, so it's still subject to the max fee rather than being lowered to it
+	fee := txInput.GetLimitedFee(txBuilder.Asset.GetChain())
+	if fee < txInput.Fee.Uint64() {
+		return nil, fmt.Errorf("the owner reserve of %s drops exceeds the max fee of %d drops", txInput.Fee.String(), fee)
+	}
+	// there are no transfer options for deletes, so the memo is the only way to set the tag
+	destinationTag, err := getDestinationTag(nil, txInput, true)
+	if err != nil {
+		return nil, err
+	}
+
+	xrpTx := xrptx.XRPTransaction{
+		Account:            from,
+		Destination:        to,
+		DestinationTag:     destinationTag,
+		Fee:                txBuilder.multiSignFee(fee, xrptxinput.DefaultFee, txInput),
+		LastLedgerSequence: txInput.LastLedgerSequence,
+		Sequence:           txInput.Sequence,
+		SigningPubKey:      hex.EncodeToString(txInput.PublicKey),
+		TransactionType:    xrptx.ACCOUNT_DELETE,
+	}
+
+	return txBuilder.newTx(&xrpTx, txInput), nil
+}
+
+// NewSignerListSet sets the signer list of the account, which enables multi-signing.
+// Setting a quorum of zero with no entries removes the signer list.
+func (txBuilder TxBuilder) NewSignerListSet(from xc.Address, quorum uint32, entries []xrptx.SignerEntry, input xc.TxInput) (xc.Tx, error) {
+	txInput := input.(*TxInput)
+	if quorum == 0 && len(entries) > 0 {
+		return nil, fmt.Errorf("signer quorum must be greater than zero")
+	}
+	totalWeight := uint32(0)
+	for _, entry := range entries {
+		if entry.Account == from {
+			return nil, fmt.Errorf("account may not be a member of its own signer list")
+		}
+		totalWeight += uint32(entry.SignerWeight)
+	}
+	if totalWeight < quorum {
+		return nil, fmt.Errorf("signer quorum %d cannot be reached with total signer weight %d", quorum, totalWeight)
+	}
+
+	xrpTx := xrptx.XRPTransaction{
+		Account:            from,
+		Fee:                txBuilder.fee(txInput),
+		LastLedgerSequence: txInput.LastLedgerSequence,
+		Sequence:           txInput.Sequence,
+		SigningPubKey:      hex.EncodeToString(txInput.PublicKey),
+		TransactionType:    xrptx.SIGNER_LIST_SET,
+		SignerQuorum:       quorum,
+		SignerEntries:      entries,
+	}
+
+	return txBuilder.newTx(&xrpTx, txInput), nil
+}
+
+func (txBuilder TxBuilder) newTx(xrpTx *xrptx.XRPTransaction, txInput *TxInput) *xrptx.Tx {
+	if txInput.IsMultiSigned() {
+		// Multi-signed transactions must have an empty signing public key
+		xrpTx.SigningPubKey = ""
+		return &xrptx.Tx{
+			XRPTx:        xrpTx,
+			MultiSigners: txInput.MultiSigners,
+		}
+	}
 	return &xrptx.Tx{
-		XRPTx:      &xrpTx,
+		XRPTx:      xrpTx,
 		SignPubKey: txInput.PublicKey,
-	}, nil
+	}
+}
+
+func (txBuilder TxBuilder) fee(txInput *TxInput) string {
+	fee := txInput.GetLimitedFee(txBuilder.Asset.GetChain())
+	return txBuilder.multiSignFee(fee, fee, txInput)
+}
+
+// A multi-signed transaction costs an additional base fee for each signature.
+// https://xrpl.org/docs/concepts/transactions/transaction-cost#special-transaction-costs
+func (txBuilder TxBuilder) multiSignFee(fee uint64, baseFee uint64, txInput *TxInput) string {
+	fee += baseFee * uint64(len(txInput.MultiSigners))
+	return strconv.FormatUint(fee, 10)
 }
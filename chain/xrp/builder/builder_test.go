@@ -4,10 +4,11 @@ import (
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	"github.com/cordialsys/crosschain/chain/xrp/builder"
 	"github.com/cordialsys/crosschain/chain/xrp/tx"
 	"github.com/cordialsys/crosschain/chain/xrp/tx_input"
-	"github.com/test-go/testify/require"
+	"github.com/stretchr/testify/require"
 )
 
 type TxInput = tx_input.TxInput
@@ -89,3 +90,188 @@ func TestNewTransfer(t *testing.T) {
 	require.Equal(t, xrpTx.Amount.TokenAmount.Issuer, "rKcAJWccYkYr7Mh2ZYmZFyLzhZD23DvTvB")
 	require.Equal(t, xrpTx.Amount.TokenAmount.Value, "12")
 }
+
+func TestTransferFeeAndDestinationTag(t *testing.T) {
+	txBuilder, _ := builder.NewTxBuilder(&xc.ChainConfig{})
+	from := xc.Address("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	to := xc.Address("rMCcNuTcajgw7YTgBy1sys3b89QqjUrMpH")
+	amount := xc.NewAmountBlockchainFromUint64(12)
+
+	// defaults to the minimum fee
+	input := &TxInput{}
+	xcTx, err := txBuilder.NewTransfer(from, to, amount, input)
+	require.NoError(t, err)
+	require.Equal(t, "10", xcTx.(*Tx).XRPTx.Fee)
+	require.EqualValues(t, 0, xcTx.(*Tx).XRPTx.DestinationTag)
+
+	// fetched fee, limited to 1 XRP
+	input.Fee = xc.NewAmountBlockchainFromUint64(5000)
+	xcTx, err = txBuilder.NewTransfer(from, to, amount, input)
+	require.NoError(t, err)
+	require.Equal(t, "5000", xcTx.(*Tx).XRPTx.Fee)
+	input.Fee = xc.NewAmountBlockchainFromUint64(5_000_000)
+	xcTx, err = txBuilder.NewTransfer(from, to, amount, input)
+	require.NoError(t, err)
+	require.Equal(t, "1000000", xcTx.(*Tx).XRPTx.Fee)
+
+	// destination tag option
+	args, err := xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionDestinationTag(123456))
+	require.NoError(t, err)
+	xcTx, err = txBuilder.Transfer(args, &TxInput{})
+	require.NoError(t, err)
+	require.EqualValues(t, 123456, xcTx.(*Tx).XRPTx.DestinationTag)
+
+	// native transfers don't use the memo as the destination tag
+	args, _ = xcbuilder.NewTransferArgs(from, to, amount)
+	xcTx, err = txBuilder.Transfer(args, &TxInput{LegacyMemo: "42"})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, xcTx.(*Tx).XRPTx.DestinationTag)
+	_, err = txBuilder.Transfer(args, &TxInput{LegacyMemo: "not-a-tag"})
+	require.NoError(t, err)
+
+	// token transfers use the memo as the destination tag, unless the option is set
+	tokenBuilder, _ := builder.NewTxBuilder(&xc.TokenAssetConfig{
+		Contract:    "FMT-rKcAJWccYkYr7Mh2ZYmZFyLzhZD23DvTvB",
+		Decimals:    6,
+		ChainConfig: &xc.ChainConfig{},
+	})
+	xcTx, err = tokenBuilder.Transfer(args, &TxInput{LegacyMemo: "42"})
+	require.NoError(t, err)
+	require.EqualValues(t, 42, xcTx.(*Tx).XRPTx.DestinationTag)
+	args, _ = xcbuilder.NewTransferArgs(from, to, amount, xcbuilder.OptionDestinationTag(123456))
+	xcTx, err = tokenBuilder.Transfer(args, &TxInput{LegacyMemo: "42"})
+	require.NoError(t, err)
+	require.EqualValues(t, 123456, xcTx.(*Tx).XRPTx.DestinationTag)
+
+	args, _ = xcbuilder.NewTransferArgs(from, to, amount)
+	_, err = tokenBuilder.Transfer(args, &TxInput{LegacyMemo: "not-a-tag"})
+	require.ErrorContains(t, err, "destination tag")
+	// destination tags are uint32
+	xcTx, err = tokenBuilder.Transfer(args, &TxInput{LegacyMemo: "4294967295"})
+	require.NoError(t, err)
+	require.EqualValues(t, 4294967295, xcTx.(*Tx).XRPTx.DestinationTag)
+	_, err = tokenBuilder.Transfer(args, &TxInput{LegacyMemo: "4294967296"})
+	require.ErrorContains(t, err, "destination tag")
+	_, err = tokenBuilder.Transfer(args, &TxInput{LegacyMemo: "-1"})
+	require.ErrorContains(t, err, "destination tag")
+}
+
+func TestNewTrustSet(t *testing.T) {
+	txBuilder, _ := builder.NewTxBuilder(&xc.TokenAssetConfig{
+		Contract:    "FMT-rKcAJWccYkYr7Mh2ZYmZFyLzhZD23DvTvB",
+		ChainConfig: &xc.ChainConfig{},
+	})
+	from := xc.Address("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	limit := xc.NewAmountBlockchainFromUint64(1_000_000_000_000_000_000)
+	xcTx, err := txBuilder.NewTrustSet(from, limit, &TxInput{Sequence: 5, LastLedgerSequence: 100})
+	require.NoError(t, err)
+	xrpTx := xcTx.(*Tx).XRPTx
+	require.Equal(t, tx.TRUST_SET, xrpTx.TransactionType)
+	require.Equal(t, "FMT", xrpTx.LimitAmount.Currency)
+	require.Equal(t, "rKcAJWccYkYr7Mh2ZYmZFyLzhZD23DvTvB", xrpTx.LimitAmount.Issuer)
+	require.Equal(t, "1000", xrpTx.LimitAmount.Value)
+	require.EqualValues(t, tx.TF_SET_NO_RIPPLE, xrpTx.Flags)
+
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	// requires a token asset
+	nativeBuilder, _ := builder.NewTxBuilder(&xc.ChainConfig{})
+	_, err = nativeBuilder.NewTrustSet(from, limit, &TxInput{})
+	require.ErrorContains(t, err, "asset does not have a contract")
+}
+
+func TestNewAccountDelete(t *testing.T) {
+	txBuilder, _ := builder.NewTxBuilder(&xc.ChainConfig{})
+	from := xc.Address("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	to := xc.Address("rMCcNuTcajgw7YTgBy1sys3b89QqjUrMpH")
+
+	input := &TxInput{Sequence: 5, LastLedgerSequence: 100, Fee: xc.NewAmountBlockchainFromUint64(200000), LegacyMemo: "7"}
+	xcTx, err := txBuilder.NewAccountDelete(from, to, input)
+	require.NoError(t, err)
+	xrpTx := xcTx.(*Tx).XRPTx
+	require.Equal(t, tx.ACCOUNT_DELETE, xrpTx.TransactionType)
+	require.Equal(t, to, xrpTx.Destination)
+	require.EqualValues(t, 7, xrpTx.DestinationTag)
+	require.Equal(t, "200000", xrpTx.Fee)
+
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	// the fee must be set to the owner reserve
+	_, err = txBuilder.NewAccountDelete(from, to, &TxInput{})
+	require.ErrorContains(t, err, "owner reserve")
+
+	// the owner reserve is still limited by the max fee
+	_, err = txBuilder.NewAccountDelete(from, to, &TxInput{Fee: xc.NewAmountBlockchainFromUint64(2_000_000)})
+	require.ErrorContains(t, err, "exceeds the max fee")
+	limitedBuilder, _ := builder.NewTxBuilder(&xc.ChainConfig{ChainMaxGasPrice: 100000})
+	_, err = limitedBuilder.NewAccountDelete(from, to, input)
+	require.ErrorContains(t, err, "exceeds the max fee")
+}
+
+func TestNewSignerListSet(t *testing.T) {
+	txBuilder, _ := builder.NewTxBuilder(&xc.ChainConfig{})
+	from := xc.Address("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe")
+	entries := []tx.SignerEntry{
+		{Account: "rsA2LpzuawewSBQXkiju3YQTMzW13pAAdW", SignerWeight: 1},
+		{Account: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", SignerWeight: 1},
+	}
+	xcTx, err := txBuilder.NewSignerListSet(from, 2, entries, &TxInput{Sequence: 5, LastLedgerSequence: 100})
+	require.NoError(t, err)
+	xrpTx := xcTx.(*Tx).XRPTx
+	require.Equal(t, tx.SIGNER_LIST_SET, xrpTx.TransactionType)
+	require.EqualValues(t, 2, xrpTx.SignerQuorum)
+	require.Equal(t, entries, xrpTx.SignerEntries)
+
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+
+	_, err = txBuilder.NewSignerListSet(from, 3, entries, &TxInput{})
+	require.ErrorContains(t, err, "cannot be reached")
+	_, err = txBuilder.NewSignerListSet(from, 1, []tx.SignerEntry{{Account: from, SignerWeight: 1}}, &TxInput{})
+	require.ErrorContains(t, err, "own signer list")
+
+	// removing the signer list
+	_, err = txBuilder.NewSignerListSet(from, 0, nil, &TxInput{})
+	require.NoError(t, err)
+}
+
+func TestMultiSignedTransfer(t *testing.T) {
+	txBuilder, _ := builder.NewTxBuilder(&xc.ChainConfig{})
+	input := &TxInput{
+		Sequence:           5,
+		LastLedgerSequence: 100,
+		PublicKey:          []byte{1, 2, 3},
+		Fee:                xc.NewAmountBlockchainFromUint64(12),
+	}
+	publicKeyA := make([]byte, 33)
+	publicKeyA[0] = 0x02
+	publicKeyB := make([]byte, 33)
+	publicKeyB[0] = 0x03
+	require.NoError(t, input.SetMultiSigners(publicKeyA, publicKeyB))
+	require.True(t, input.IsMultiSigned())
+
+	xcTx, err := txBuilder.NewNativeTransfer("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", xc.NewAmountBlockchainFromUint64(1000), input)
+	require.NoError(t, err)
+	xrpTx := xcTx.(*Tx).XRPTx
+	// the base fee is paid once more for each signer
+	require.Equal(t, "36", xrpTx.Fee)
+	require.Equal(t, "", xrpTx.SigningPubKey)
+
+	sighashes, err := xcTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 2)
+
+	// the owner reserve is only paid once when deleting an account
+	input.Fee = xc.NewAmountBlockchainFromUint64(200_000)
+	xcTx, err = txBuilder.NewAccountDelete("rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe", "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", input)
+	require.NoError(t, err)
+	require.Equal(t, "200020", xcTx.(*Tx).XRPTx.Fee)
+
+	err = input.SetMultiSigners([]byte{1, 2})
+	require.ErrorContains(t, err, "invalid multi-signer public key")
+}
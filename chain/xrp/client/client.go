@@ -16,6 +16,7 @@ import (
 	"github.com/cordialsys/crosschain/chain/xrp/client/types"
 	xrptxinput "github.com/cordialsys/crosschain/chain/xrp/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -27,6 +28,7 @@ type Client struct {
 }
 
 var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
 
 // NewClient returns a new JSON-RPC Client to the XRP node
 func NewClient(cfgI xc.ITask) (*Client, error) {
@@ -34,13 +36,28 @@ func NewClient(cfgI xc.ITask) (*Client, error) {
 
 	return &Client{
 		Url:        cfg.URL,
-		HttpClient: http.DefaultClient,
+		HttpClient: utils.NewHttpClient(cfg),
 		Asset:      cfgI,
 	}, nil
 }
 
+// FetchLatestHeight returns the index of the current ledger of the node
+func (client *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	index, err := client.getLatestValidatedLedgerSequence()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(*index), nil
+}
+
 const MethodPost string = "POST"
 
+// Number of ledgers a transaction remains valid for
+const LedgerOffset = int64(20)
+
+// An account can only be deleted once its sequence is this far behind the current ledger index
+const AccountDeleteMinLedgers = int64(256)
+
 func (client *Client) FetchBaseInput(ctx context.Context, args xcbuilder.TransferArgs) (xrptxinput.TxInput, error) {
 	txInput := xrptxinput.NewTxInput()
 
@@ -58,10 +75,15 @@ func (client *Client) FetchBaseInput(ctx context.Context, args xcbuilder.Transfe
 		return xrptxinput.TxInput{}, err
 	}
 	ledgerSequencePtr := *ledgerSequence
-	ledgerOffset := int64(20) // Ledger offset
-	lastLedgerSequence := ledgerSequencePtr + ledgerOffset
+	lastLedgerSequence := ledgerSequencePtr + LedgerOffset
 	txInput.LastLedgerSequence = lastLedgerSequence
 
+	fee, err := client.getOpenLedgerFee()
+	if err != nil {
+		return xrptxinput.TxInput{}, err
+	}
+	txInput.Fee = fee.ApplyGasPriceMultiplier(client.Asset.GetChain())
+
 	return *txInput, nil
 }
 
@@ -71,6 +93,46 @@ func (client *Client) FetchTransferInput(ctx context.Context, args xcbuilder.Tra
 	if err != nil {
 		return nil, err
 	}
+	if priority, ok := args.GetPriority(); ok && priority != "" {
+		err = txInput.SetGasFeePriority(priority)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &txInput, nil
+}
+
+// FetchAccountDeleteInput returns the input for deleting an account.  Deleting an account
+// costs the owner reserve increment, which is paid as the transaction fee.
+func (client *Client) FetchAccountDeleteInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
+	args, err := xcbuilder.NewTransferArgs(from, to, xc.NewAmountBlockchainFromUint64(0))
+	if err != nil {
+		return nil, err
+	}
+	txInput, err := client.FetchBaseInput(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	currentLedger := txInput.LastLedgerSequence - LedgerOffset
+	if txInput.Sequence+AccountDeleteMinLedgers > currentLedger {
+		return nil, fmt.Errorf("account sequence %d must be at least %d less than the current ledger %d to delete the account", txInput.Sequence, AccountDeleteMinLedgers, currentLedger)
+	}
+
+	request := types.ServerStateRequest{
+		Method: "server_state",
+		Params: []struct{}{{}},
+	}
+	var response types.ServerStateResponse
+	err = client.Send(MethodPost, request, &response)
+	if err != nil {
+		return nil, err
+	}
+	reserveInc := response.Result.State.ValidatedLedger.ReserveInc
+	if reserveInc <= 0 {
+		return nil, fmt.Errorf("could not determine the owner reserve")
+	}
+	txInput.Fee = xc.NewAmountBlockchainFromUint64(uint64(reserveInc))
 
 	return &txInput, nil
 }
@@ -107,7 +169,7 @@ func (client *Client) SubmitTx(ctx context.Context, txInput xc.Tx) error {
 	}
 
 	var submitResponse types.SubmitResponse
-	err = client.Send(MethodPost, submitRequest, &submitResponse)
+	err = client.SendContext(utils.NonIdempotent(ctx), MethodPost, submitRequest, &submitResponse)
 	if err != nil {
 		return err
 	}
@@ -339,13 +401,16 @@ func (client *Client) fetchContractBalance(ctx context.Context, address xc.Addre
 }
 
 func (client *Client) Send(method string, requestBody any, response any) error {
+	return client.SendContext(context.Background(), method, requestBody, response)
+}
 
+func (client *Client) SendContext(ctx context.Context, method string, requestBody any, response any) error {
 	jsonPayload, err := json.Marshal(requestBody)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request payload: %w", err)
 	}
 
-	request, err := http.NewRequest(method, client.Url, bytes.NewBuffer(jsonPayload))
+	request, err := http.NewRequestWithContext(ctx, method, client.Url, bytes.NewBuffer(jsonPayload))
 	if err != nil {
 		return fmt.Errorf("failed to create new HTTP request: %w", err)
 	}
@@ -408,3 +473,24 @@ func (client *Client) getLatestValidatedLedgerSequence() (*int64, error) {
 	ledgerCurrentIndex := ledgerResponse.Result.LedgerCurrentIndex
 	return &ledgerCurrentIndex, nil
 }
+
+// The open ledger fee is the minimum fee for a transaction to be included in the current ledger
+func (client *Client) getOpenLedgerFee() (xc.AmountBlockchain, error) {
+	request := types.FeeRequest{
+		Method: "fee",
+		Params: []struct{}{{}},
+	}
+
+	var feeResponse types.FeeResponse
+	err := client.Send(MethodPost, request, &feeResponse)
+	if err != nil {
+		return xc.AmountBlockchain{}, err
+	}
+
+	fee := xc.NewAmountBlockchainFromStr(feeResponse.Result.Drops.OpenLedgerFee)
+	baseFee := xc.NewAmountBlockchainFromStr(feeResponse.Result.Drops.BaseFee)
+	if fee.Cmp(&baseFee) < 0 {
+		fee = baseFee
+	}
+	return fee, nil
+}
@@ -10,12 +10,14 @@ import (
 	"time"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
 	xrpClient "github.com/cordialsys/crosschain/chain/xrp/client"
 	"github.com/cordialsys/crosschain/chain/xrp/client/types"
 	xrptx "github.com/cordialsys/crosschain/chain/xrp/tx"
 	xrptxinput "github.com/cordialsys/crosschain/chain/xrp/tx_input"
 	xclient "github.com/cordialsys/crosschain/client"
 	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -33,6 +35,7 @@ func TestFetchTxInput(t *testing.T) {
 		asset           xc.ITask
 		accountInfoResp interface{}
 		ledgerResp      interface{}
+		feeResp         interface{}
 		err             string
 		expectedTxInput xrptxinput.TxInput
 	}{
@@ -50,6 +53,14 @@ func TestFetchTxInput(t *testing.T) {
 					LedgerCurrentIndex: 1221001,
 				},
 			},
+			feeResp: types.FeeResponse{
+				Result: types.FeeResult{
+					Drops: types.FeeDrops{
+						BaseFee:       "10",
+						OpenLedgerFee: "12",
+					},
+				},
+			},
 			expectedTxInput: xrptxinput.TxInput{
 				TxInputEnvelope: xc.TxInputEnvelope{
 					Type: "xrp",
@@ -58,6 +69,7 @@ func TestFetchTxInput(t *testing.T) {
 				LastLedgerSequence: 1221021,
 				LegacyMemo:         "",
 				PublicKey:          []uint8(nil),
+				Fee:                xc.NewAmountBlockchainFromUint64(12),
 			},
 		},
 		{
@@ -72,6 +84,14 @@ func TestFetchTxInput(t *testing.T) {
 					LedgerCurrentIndex: 1221001,
 				},
 			},
+			feeResp: types.FeeResponse{
+				Result: types.FeeResult{
+					Drops: types.FeeDrops{
+						BaseFee:       "10",
+						OpenLedgerFee: "8",
+					},
+				},
+			},
 			expectedTxInput: xrptxinput.TxInput{
 				TxInputEnvelope: xc.TxInputEnvelope{
 					Type: "xrp",
@@ -80,6 +100,7 @@ func TestFetchTxInput(t *testing.T) {
 				LastLedgerSequence: 1221021,
 				LegacyMemo:         "",
 				PublicKey:          []uint8(nil),
+				Fee:                xc.NewAmountBlockchainFromUint64(10),
 			},
 		},
 		{
@@ -94,6 +115,14 @@ func TestFetchTxInput(t *testing.T) {
 			ledgerResp: types.LedgerResponse{
 				Result: types.LedgerResult{},
 			},
+			feeResp: types.FeeResponse{
+				Result: types.FeeResult{
+					Drops: types.FeeDrops{
+						BaseFee:       "10",
+						OpenLedgerFee: "12",
+					},
+				},
+			},
 			expectedTxInput: xrptxinput.TxInput{
 				TxInputEnvelope: xc.TxInputEnvelope{
 					Type: "xrp",
@@ -102,6 +131,7 @@ func TestFetchTxInput(t *testing.T) {
 				LastLedgerSequence: 20,
 				LegacyMemo:         "",
 				PublicKey:          []uint8(nil),
+				Fee:                xc.NewAmountBlockchainFromUint64(12),
 			},
 		},
 	}
@@ -118,6 +148,8 @@ func TestFetchTxInput(t *testing.T) {
 			} else if method == "ledger" {
 				// Respond with LedgerResponse
 				json.NewEncoder(w).Encode(vector.ledgerResp)
+			} else if method == "fee" {
+				json.NewEncoder(w).Encode(vector.feeResp)
 			} else {
 				t.Errorf("unexpected method: %s", method)
 			}
@@ -150,6 +182,70 @@ func TestFetchTxInput(t *testing.T) {
 	}
 }
 
+func newInputServer(t *testing.T, sequence int64, ledgerIndex int64, openLedgerFee string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&reqBody)
+
+		switch reqBody["method"].(string) {
+		case "account_info":
+			json.NewEncoder(w).Encode(types.AccountInfoResponse{
+				Result: types.AccountInfoResultDetails{AccountData: types.AccountData{Sequence: sequence}},
+			})
+		case "ledger":
+			json.NewEncoder(w).Encode(types.LedgerResponse{
+				Result: types.LedgerResult{LedgerCurrentIndex: ledgerIndex},
+			})
+		case "fee":
+			json.NewEncoder(w).Encode(types.FeeResponse{
+				Result: types.FeeResult{Drops: types.FeeDrops{BaseFee: "10", OpenLedgerFee: openLedgerFee}},
+			})
+		case "server_state":
+			json.NewEncoder(w).Encode(types.ServerStateResponse{
+				Result: types.ServerStateResult{State: types.ServerState{
+					ValidatedLedger: types.ValidatedLedgerState{BaseFee: 10, ReserveBase: 1000000, ReserveInc: 200000},
+				}},
+			})
+		default:
+			t.Errorf("unexpected method: %s", reqBody["method"])
+		}
+	}))
+}
+
+func TestFetchTransferInputPriority(t *testing.T) {
+	server := newInputServer(t, 861823, 1221001, "5000")
+	defer server.Close()
+	client, _ := xrpClient.NewClient(&xc.ChainConfig{URL: server.URL, Chain: "XRP"})
+
+	from := xc.Address("r92tsEZEjK82wra6xaDvjZocKnR78VqpEM")
+	to := xc.Address("rs2x5gvFupB22myz86BUu7m5F4YuizsFna")
+	args, _ := xcbuilder.NewTransferArgs(from, to, xc.NewAmountBlockchainFromUint64(1), xcbuilder.OptionPriority(xc.Aggressive))
+	input, err := client.FetchTransferInput(context.Background(), args)
+	require.NoError(t, err)
+	multiplier, _ := xc.Aggressive.GetDefault()
+	require.Equal(t, multiplier.Mul(decimal.NewFromInt(5000)).String(), input.(*xrptxinput.TxInput).Fee.String())
+}
+
+func TestFetchAccountDeleteInput(t *testing.T) {
+	from := xc.Address("r92tsEZEjK82wra6xaDvjZocKnR78VqpEM")
+	to := xc.Address("rs2x5gvFupB22myz86BUu7m5F4YuizsFna")
+
+	server := newInputServer(t, 861823, 1221001, "12")
+	defer server.Close()
+	client, _ := xrpClient.NewClient(&xc.ChainConfig{URL: server.URL, Chain: "XRP"})
+	input, err := client.FetchAccountDeleteInput(context.Background(), from, to)
+	require.NoError(t, err)
+	require.Equal(t, "200000", input.(*xrptxinput.TxInput).Fee.String())
+	require.EqualValues(t, 861823, input.(*xrptxinput.TxInput).Sequence)
+
+	// sequence is too recent
+	server2 := newInputServer(t, 1221000, 1221001, "12")
+	defer server2.Close()
+	client, _ = xrpClient.NewClient(&xc.ChainConfig{URL: server2.URL, Chain: "XRP"})
+	_, err = client.FetchAccountDeleteInput(context.Background(), from, to)
+	require.ErrorContains(t, err, "must be at least 256 less than the current ledger")
+}
+
 func TestSubmitTx(t *testing.T) {
 
 	vectors := []struct {
@@ -206,6 +302,14 @@ func TestSubmitTx(t *testing.T) {
 		err := client.SubmitTx(context.Background(), vector.txInput)
 		require.NoError(t, err)
 	}
+
+	// the transaction may have been processed, so server errors aren't retried
+	server, close := testtypes.MockHTTP(t, []string{`{}`}, 503)
+	defer close()
+	client, _ := xrpClient.NewClient(&xc.ChainConfig{URL: server.URL})
+	err := client.SubmitTx(context.Background(), vectors[0].txInput)
+	require.ErrorContains(t, err, "503")
+	require.Equal(t, 1, server.Counter)
 }
 
 func TestFetchTxInfo(t *testing.T) {
@@ -759,3 +863,13 @@ func TestFetchCBalance(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchLatestHeight(t *testing.T) {
+	server, close := testtypes.MockHTTP(t, `{"result":{"ledger_current_index":91372580,"status":"success","validated":false}}`, 200)
+	defer close()
+	client, _ := xrpClient.NewClient(&xc.ChainConfig{Chain: xc.XRP, URL: server.URL})
+
+	height, err := client.FetchLatestHeight(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 91372580, height)
+}
@@ -30,6 +30,59 @@ type AccountInfoParamEntry struct {
 	LedgerIndex LedgerIndex `json:"ledger_index"`
 }
 
+type FeeRequest struct {
+	Method string     `json:"method"`
+	Params []struct{} `json:"params"`
+}
+
+type FeeResponse struct {
+	Result FeeResult `json:"result"`
+}
+
+type FeeResult struct {
+	CurrentLedgerSize  string   `json:"current_ledger_size"`
+	CurrentQueueSize   string   `json:"current_queue_size"`
+	Drops              FeeDrops `json:"drops"`
+	ExpectedLedgerSize string   `json:"expected_ledger_size"`
+	LedgerCurrentIndex int64    `json:"ledger_current_index"`
+	Status             string   `json:"status"`
+}
+
+// All fees are reported in drops
+type FeeDrops struct {
+	BaseFee       string `json:"base_fee"`
+	MedianFee     string `json:"median_fee"`
+	MinimumFee    string `json:"minimum_fee"`
+	OpenLedgerFee string `json:"open_ledger_fee"`
+}
+
+type ServerStateRequest struct {
+	Method string     `json:"method"`
+	Params []struct{} `json:"params"`
+}
+
+type ServerStateResponse struct {
+	Result ServerStateResult `json:"result"`
+}
+
+type ServerStateResult struct {
+	State  ServerState `json:"state"`
+	Status string      `json:"status"`
+}
+
+type ServerState struct {
+	ValidatedLedger ValidatedLedgerState `json:"validated_ledger"`
+}
+
+// Reserves are reported in drops
+type ValidatedLedgerState struct {
+	BaseFee     int64  `json:"base_fee"`
+	ReserveBase int64  `json:"reserve_base"`
+	ReserveInc  int64  `json:"reserve_inc"`
+	Seq         int64  `json:"seq"`
+	Hash        string `json:"hash"`
+}
+
 type AccountLinesRequest struct {
 	Method string                   `json:"method"`
 	Params []AccountLinesParamEntry `json:"params"`
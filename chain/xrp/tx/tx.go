@@ -1,29 +1,41 @@
 package tx
 
 import (
+	"bytes"
 	"crypto/sha512"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	xc "github.com/cordialsys/crosschain"
 	btctx "github.com/cordialsys/crosschain/chain/bitcoin/tx"
+	xrptxinput "github.com/cordialsys/crosschain/chain/xrp/tx_input"
 	"github.com/sirupsen/logrus"
+	addresscodec "github.com/xyield/xrpl-go/address-codec"
 	binarycodec "github.com/xyield/xrpl-go/binary-codec"
 )
 
 const (
 	PAYMENT                 TransactionType = "Payment"
+	TRUST_SET               TransactionType = "TrustSet"
+	ACCOUNT_DELETE          TransactionType = "AccountDelete"
+	SIGNER_LIST_SET         TransactionType = "SignerListSet"
 	TRANSACTION_HASH_PREFIX                 = "54584E00"
 )
 
+// Disables rippling on the trust line, which is recommended for accounts that are not token issuers.
+// https://xrpl.org/docs/references/protocol/transactions/types/trustset#trustset-flags
+const TF_SET_NO_RIPPLE = 0x00020000
+
 type TransactionType string
 
 type XRPTransaction struct {
 	Account            xc.Address       `json:"Account"`
 	Amount             AmountBlockchain `json:"Amount"`
 	SendMax            Amount           `json:"SendMax"`
+	LimitAmount        *Amount          `json:"LimitAmount,omitempty"`
 	Destination        xc.Address       `json:"Destination"`
 	DestinationTag     int64            `json:"DestinationTag"`
 	Fee                string           `json:"Fee"`
@@ -33,6 +45,22 @@ type XRPTransaction struct {
 	SigningPubKey      string           `json:"SigningPubKey"`
 	TransactionType    TransactionType  `json:"TransactionType"`
 	TxnSignature       string           `json:"TxnSignature"`
+	SignerQuorum       uint32           `json:"SignerQuorum,omitempty"`
+	SignerEntries      []SignerEntry    `json:"SignerEntries,omitempty"`
+	Signers            []Signer         `json:"Signers,omitempty"`
+}
+
+// An entry of the signer list set on an account
+type SignerEntry struct {
+	Account      xc.Address `json:"Account"`
+	SignerWeight uint16     `json:"SignerWeight"`
+}
+
+// A signature of a multi-signed transaction
+type Signer struct {
+	Account       xc.Address `json:"Account"`
+	SigningPubKey string     `json:"SigningPubKey"`
+	TxnSignature  string     `json:"TxnSignature"`
 }
 
 type AmountBlockchain struct {
@@ -51,6 +79,8 @@ type Tx struct {
 	XRPTx                *XRPTransaction
 	SignPubKey           []byte
 	TransactionSignature []xc.TxSignature
+	// When set, the transaction is multi-signed by these members of the account's signer list
+	MultiSigners []xrptxinput.MultiSigner
 }
 
 var _ xc.Tx = &Tx{}
@@ -78,12 +108,39 @@ func (tx Tx) Hash() xc.TxHash {
 	return xc.TxHash(hashHex)
 }
 
-// Sighashes returns the tx payload to sign, aka sighash
+// Sighashes returns the tx payload to sign, aka sighash.
+// Multi-signed transactions have one payload per signer, in the order of `SortedMultiSigners`.
 func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
 	if tx.XRPTx == nil {
 		return nil, errors.New("missing XRP transaction")
 	}
 
+	if len(tx.MultiSigners) > 0 {
+		signers, err := tx.SortedMultiSigners()
+		if err != nil {
+			return nil, err
+		}
+		sighashes := make([]xc.TxDataToSign, len(signers))
+		for i, signer := range signers {
+			// the map is modified when encoding, so it's rendered for each signer
+			resultMapXRP, renderErr := RenderToMap(*tx.XRPTx)
+			if renderErr != nil {
+				return nil, fmt.Errorf("error rendering transaction to map: %v", renderErr)
+			}
+			// Each signer signs over the transaction with their own account ID as a suffix
+			encodeForSigningHex, err := binarycodec.EncodeForMultisigning(resultMapXRP, string(signer.Account))
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize transaction for multi-signing %v", err)
+			}
+			sighash, err := sha512Half(encodeForSigningHex)
+			if err != nil {
+				return nil, err
+			}
+			sighashes[i] = sighash
+		}
+		return sighashes, nil
+	}
+
 	resultMapXRP, renderErr := RenderToMap(*tx.XRPTx)
 	if renderErr != nil {
 		return nil, fmt.Errorf("error rendering transaction to map: %v", renderErr)
@@ -94,34 +151,85 @@ func (tx Tx) Sighashes() ([]xc.TxDataToSign, error) {
 		return nil, fmt.Errorf("failed to serialize transaction for signing %v", err)
 	}
 
-	encodeForSigningBytes, err := hex.DecodeString(encodeForSigningHex)
+	sighash, err := sha512Half(encodeForSigningHex)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create byte object from hex serialized transaction %v", err)
+		return nil, err
 	}
+	return []xc.TxDataToSign{sighash}, nil
+}
 
-	// For k256 signing, XRP uses sha512[:32]
-	// https://github.com/XRPLF/xrpl-py/blob/17aad31f77452d30917b9e4544c9c87c274c0e3d/xrpl/core/keypairs/secp256k1.py#L95
+// For k256 signing, XRP uses sha512[:32]
+// https://github.com/XRPLF/xrpl-py/blob/17aad31f77452d30917b9e4544c9c87c274c0e3d/xrpl/core/keypairs/secp256k1.py#L95
+func sha512Half(encodedHex string) ([]byte, error) {
+	encodeForSigningBytes, err := hex.DecodeString(encodedHex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create byte object from hex serialized transaction %v", err)
+	}
 	digestSha512 := sha512.Sum512(encodeForSigningBytes)
-	firstHalf := digestSha512[:32]
+	return digestSha512[:32], nil
+}
 
-	return []xc.TxDataToSign{firstHalf[:]}, nil
+// SortedMultiSigners returns the multi-signers ordered by account ID, which is the order
+// the protocol requires for the Signers array.
+func (tx Tx) SortedMultiSigners() ([]xrptxinput.MultiSigner, error) {
+	type signerWithID struct {
+		signer    xrptxinput.MultiSigner
+		accountID []byte
+	}
+	sorted := make([]signerWithID, len(tx.MultiSigners))
+	for i, signer := range tx.MultiSigners {
+		_, accountID, err := addresscodec.DecodeClassicAddressToAccountID(string(signer.Account))
+		if err != nil {
+			return nil, fmt.Errorf("invalid multi-signer account %s: %v", signer.Account, err)
+		}
+		sorted[i] = signerWithID{signer, accountID}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].accountID, sorted[j].accountID) < 0
+	})
+	signers := make([]xrptxinput.MultiSigner, len(sorted))
+	for i := range sorted {
+		signers[i] = sorted[i].signer
+	}
+	return signers, nil
 }
 
-// AddSignatures adds a signature to Tx
+// AddSignatures adds a signature to Tx.  Multi-signed transactions expect a signature
+// for each multi-signer, in the same order as the sighashes.
 func (tx *Tx) AddSignatures(signatures ...xc.TxSignature) error {
 	if tx.TransactionSignature != nil {
 		return errors.New("transaction already signed")
 	}
 
-	for _, rsvBytes := range signatures {
-		r, s, err := btctx.DecodeEcdsaSignature(rsvBytes)
+	if len(tx.MultiSigners) > 0 {
+		signers, err := tx.SortedMultiSigners()
 		if err != nil {
 			return err
 		}
+		if len(signatures) != len(signers) {
+			return fmt.Errorf("expected %d signatures for multi-signed transaction, got %d", len(signers), len(signatures))
+		}
+		tx.XRPTx.Signers = make([]Signer, len(signers))
+		for i, rsvBytes := range signatures {
+			signatureHex, err := derSignatureHex(rsvBytes)
+			if err != nil {
+				return err
+			}
+			tx.XRPTx.Signers[i] = Signer{
+				Account:       signers[i].Account,
+				SigningPubKey: hex.EncodeToString(signers[i].PublicKey),
+				TxnSignature:  signatureHex,
+			}
+		}
+		tx.TransactionSignature = append(tx.TransactionSignature, signatures...)
+		return nil
+	}
 
-		signature := ecdsa.NewSignature(&r, &s)
-		signatureBytes := signature.Serialize()
-		signatureHex := hex.EncodeToString(signatureBytes)
+	for _, rsvBytes := range signatures {
+		signatureHex, err := derSignatureHex(rsvBytes)
+		if err != nil {
+			return err
+		}
 		tx.XRPTx.TxnSignature = signatureHex
 		tx.TransactionSignature = append(tx.TransactionSignature, rsvBytes)
 	}
@@ -129,6 +237,17 @@ func (tx *Tx) AddSignatures(signatures ...xc.TxSignature) error {
 	return nil
 }
 
+func derSignatureHex(rsvBytes []byte) (string, error) {
+	r, s, err := btctx.DecodeEcdsaSignature(rsvBytes)
+	if err != nil {
+		return "", err
+	}
+
+	signature := ecdsa.NewSignature(&r, &s)
+	signatureBytes := signature.Serialize()
+	return hex.EncodeToString(signatureBytes), nil
+}
+
 // GetSignatures returns back signatures, which may be used for signed-transaction broadcasting
 func (tx *Tx) GetSignatures() []xc.TxSignature {
 	return tx.TransactionSignature
@@ -147,7 +266,7 @@ func (tx Tx) Serialize() ([]byte, error) {
 		return []byte{}, errors.New("missing last ledger sequence")
 	}
 
-	if xrpTx.TxnSignature == "" {
+	if xrpTx.TxnSignature == "" && len(xrpTx.Signers) == 0 {
 		return []byte{}, errors.New("missing transaction signature")
 	}
 
@@ -195,24 +314,51 @@ func HashFromTx(encodeTx string) (string, error) {
 func RenderToMap(xrpTx XRPTransaction) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	result["Account"] = string(xrpTx.Account)
-	result["Destination"] = string(xrpTx.Destination)
-	result["DestinationTag"] = int(xrpTx.DestinationTag)
 	result["Fee"] = xrpTx.Fee
 	result["Flags"] = int(xrpTx.Flags)
 	result["LastLedgerSequence"] = int(xrpTx.LastLedgerSequence)
 	result["Sequence"] = int(xrpTx.Sequence)
 	result["SigningPubKey"] = xrpTx.SigningPubKey
 	result["TransactionType"] = string(xrpTx.TransactionType)
-	result["TxnSignature"] = xrpTx.TxnSignature
+	if len(xrpTx.Signers) > 0 {
+		// multi-signed transactions do not have a single signature
+		RenderSigners(result, xrpTx.Signers)
+	} else {
+		result["TxnSignature"] = xrpTx.TxnSignature
+	}
 
-	if xrpTx.Amount.XRPAmount != "" {
-		amountRenderErr := RenderXrpAmount(result, xrpTx.Amount.XRPAmount)
-		if amountRenderErr != nil {
-			return nil, fmt.Errorf("failed to render XRP amount: %w", amountRenderErr)
+	switch xrpTx.TransactionType {
+	case SIGNER_LIST_SET:
+		result["SignerQuorum"] = int(xrpTx.SignerQuorum)
+		// a quorum of zero removes the signer list
+		if len(xrpTx.SignerEntries) > 0 {
+			RenderSignerEntries(result, xrpTx.SignerEntries)
+		}
+	case TRUST_SET:
+		if xrpTx.LimitAmount == nil {
+			return nil, errors.New("missing trust line limit amount")
+		}
+		RenderLimitAmount(result, xrpTx.LimitAmount)
+	case ACCOUNT_DELETE:
+		result["Destination"] = string(xrpTx.Destination)
+		if xrpTx.DestinationTag != 0 {
+			result["DestinationTag"] = int(xrpTx.DestinationTag)
+		}
+	default:
+		result["Destination"] = string(xrpTx.Destination)
+		result["DestinationTag"] = int(xrpTx.DestinationTag)
+		if xrpTx.Amount.XRPAmount != "" {
+			amountRenderErr := RenderXrpAmount(result, xrpTx.Amount.XRPAmount)
+			if amountRenderErr != nil {
+				return nil, fmt.Errorf("failed to render XRP amount: %w", amountRenderErr)
+			}
+		} else {
+			if xrpTx.Amount.TokenAmount == nil {
+				return nil, errors.New("missing payment amount")
+			}
+			RenderTokenAmount(result, xrpTx.Amount.TokenAmount)
+			RenderSendMax(result, &xrpTx.SendMax)
 		}
-	} else {
-		RenderTokenAmount(result, xrpTx.Amount.TokenAmount)
-		RenderSendMax(result, &xrpTx.SendMax)
 	}
 
 	return result, nil
@@ -239,3 +385,38 @@ func RenderSendMax(fields map[string]interface{}, sendMax *Amount) {
 		"value":    sendMax.Value,
 	}
 }
+
+func RenderLimitAmount(fields map[string]interface{}, limit *Amount) {
+	fields["LimitAmount"] = map[string]interface{}{
+		"currency": limit.Currency,
+		"issuer":   limit.Issuer,
+		"value":    limit.Value,
+	}
+}
+
+func RenderSignerEntries(fields map[string]interface{}, entries []SignerEntry) {
+	signerEntries := make([]any, len(entries))
+	for i, entry := range entries {
+		signerEntries[i] = map[string]any{
+			"SignerEntry": map[string]any{
+				"Account":      string(entry.Account),
+				"SignerWeight": int(entry.SignerWeight),
+			},
+		}
+	}
+	fields["SignerEntries"] = signerEntries
+}
+
+func RenderSigners(fields map[string]interface{}, signers []Signer) {
+	renderedSigners := make([]any, len(signers))
+	for i, signer := range signers {
+		renderedSigners[i] = map[string]any{
+			"Signer": map[string]any{
+				"Account":       string(signer.Account),
+				"SigningPubKey": signer.SigningPubKey,
+				"TxnSignature":  signer.TxnSignature,
+			},
+		}
+	}
+	fields["Signers"] = renderedSigners
+}
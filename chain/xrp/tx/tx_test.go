@@ -5,6 +5,7 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/chain/xrp/tx"
+	"github.com/cordialsys/crosschain/chain/xrp/tx_input"
 	"github.com/test-go/testify/require"
 )
 
@@ -229,3 +230,81 @@ func TestTxAddSignature(t *testing.T) {
 	err = tx3.AddSignatures([]xc.TxSignature{bytes}...)
 	require.Nil(t, err)
 }
+
+func TestTxMultiSign(t *testing.T) {
+	xrpTx := &tx.XRPTransaction{
+		Account:            "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe",
+		Amount:             tx.AmountBlockchain{XRPAmount: "1000"},
+		Destination:        "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		Fee:                "36",
+		LastLedgerSequence: 100,
+		Sequence:           5,
+		TransactionType:    tx.PAYMENT,
+	}
+	signerA := tx_input.MultiSigner{Account: "rsA2LpzuawewSBQXkiju3YQTMzW13pAAdW", PublicKey: []byte{0x02, 1}}
+	signerB := tx_input.MultiSigner{Account: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", PublicKey: []byte{0x03, 2}}
+	multiTx := tx.Tx{
+		XRPTx:        xrpTx,
+		MultiSigners: []tx_input.MultiSigner{signerB, signerA},
+	}
+
+	// signers are ordered by account ID
+	sorted, err := multiTx.SortedMultiSigners()
+	require.NoError(t, err)
+	require.Equal(t, []tx_input.MultiSigner{signerA, signerB}, sorted)
+
+	sighashes, err := multiTx.Sighashes()
+	require.NoError(t, err)
+	require.Len(t, sighashes, 2)
+	require.Len(t, sighashes[0], 32)
+	require.NotEqual(t, sighashes[0], sighashes[1])
+
+	// each signer's payload is independent of the order of the signers
+	reversedTx := tx.Tx{
+		XRPTx:        xrpTx,
+		MultiSigners: []tx_input.MultiSigner{signerA, signerB},
+	}
+	reversedSighashes, err := reversedTx.Sighashes()
+	require.NoError(t, err)
+	require.Equal(t, sighashes, reversedSighashes)
+
+	err = multiTx.AddSignatures(make([]byte, 64))
+	require.EqualError(t, err, "expected 2 signatures for multi-signed transaction, got 1")
+
+	err = multiTx.AddSignatures(make([]byte, 64), make([]byte, 64))
+	require.NoError(t, err)
+	require.Len(t, xrpTx.Signers, 2)
+	require.Equal(t, signerA.Account, xrpTx.Signers[0].Account)
+	require.Equal(t, "0201", xrpTx.Signers[0].SigningPubKey)
+	require.Equal(t, signerB.Account, xrpTx.Signers[1].Account)
+	require.Equal(t, "0302", xrpTx.Signers[1].SigningPubKey)
+
+	rendered, err := tx.RenderToMap(*xrpTx)
+	require.NoError(t, err)
+	require.NotContains(t, rendered, "TxnSignature")
+	require.Len(t, rendered["Signers"], 2)
+
+	serialized, err := multiTx.Serialize()
+	require.NoError(t, err)
+	require.NotEmpty(t, serialized)
+	require.NotEmpty(t, multiTx.Hash())
+}
+
+func TestRenderSignerListSet(t *testing.T) {
+	rendered, err := tx.RenderToMap(tx.XRPTransaction{
+		Account:         "rPT1Sjq2YGrBMTttX4GZHjKu9dyfzbpAYe",
+		TransactionType: tx.SIGNER_LIST_SET,
+		SignerQuorum:    2,
+		SignerEntries: []tx.SignerEntry{
+			{Account: "rsA2LpzuawewSBQXkiju3YQTMzW13pAAdW", SignerWeight: 1},
+			{Account: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", SignerWeight: 1},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, rendered["SignerQuorum"])
+	require.Equal(t, []any{
+		map[string]any{"SignerEntry": map[string]any{"Account": "rsA2LpzuawewSBQXkiju3YQTMzW13pAAdW", "SignerWeight": 1}},
+		map[string]any{"SignerEntry": map[string]any{"Account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "SignerWeight": 1}},
+	}, rendered["SignerEntries"])
+	require.NotContains(t, rendered, "Destination")
+}
@@ -3,8 +3,12 @@ package tx_input
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"fmt"
+
 	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/xrp/address"
 	"github.com/cordialsys/crosschain/factory/drivers/registry"
+	"github.com/shopspring/decimal"
 )
 
 // TxInput for Template
@@ -14,6 +18,15 @@ type TxInput struct {
 	LastLedgerSequence int64  `json:"LastLedgerSequence"`
 	LegacyMemo         string `json:"Memo,omitempty"`
 	PublicKey          []byte
+	// Transaction cost in drops
+	Fee xc.AmountBlockchain `json:"Fee"`
+	// Members of the account's signer list that will sign, if the transaction is multi-signed
+	MultiSigners []MultiSigner `json:"MultiSigners,omitempty"`
+}
+
+type MultiSigner struct {
+	Account   xc.Address `json:"Account"`
+	PublicKey []byte     `json:"PublicKey"`
 }
 
 var _ xc.TxInput = &TxInput{}
@@ -60,6 +73,27 @@ func (input *TxInput) SetPublicKeyFromStr(publicKeyStr string) error {
 	return nil
 }
 
+// SetMultiSigners switches the transaction to be multi-signed by the given public keys,
+// which must belong to members of the sending account's signer list.
+func (input *TxInput) SetMultiSigners(publicKeys ...[]byte) error {
+	input.MultiSigners = nil
+	for _, publicKey := range publicKeys {
+		account, err := address.AddressBuilder{}.GetAddressFromPublicKey(publicKey)
+		if err != nil {
+			return fmt.Errorf("invalid multi-signer public key: %v", err)
+		}
+		input.MultiSigners = append(input.MultiSigners, MultiSigner{
+			Account:   account,
+			PublicKey: publicKey,
+		})
+	}
+	return nil
+}
+
+func (input *TxInput) IsMultiSigned() bool {
+	return len(input.MultiSigners) > 0
+}
+
 func NewTxInput() *TxInput {
 	return &TxInput{
 		TxInputEnvelope: xc.TxInputEnvelope{
@@ -72,13 +106,34 @@ func (input *TxInput) GetDriver() xc.Driver {
 	return xc.DriverXrp
 }
 
+// The minimum transaction cost, in drops
+const DefaultFee = 10
+
+// Returns the fee in drops to pay for the transaction.
+// It will not go above the max price for safety concerns.
+func (input *TxInput) GetLimitedFee(chain *xc.ChainConfig) uint64 {
+	fee := input.Fee.Uint64()
+	if fee == 0 {
+		fee = DefaultFee
+	}
+	max := uint64(chain.ChainMaxGasPrice)
+	if max == 0 {
+		// default to spend max 1 XRP on a transaction
+		max = 1_000_000
+	}
+	if fee > max {
+		fee = max
+	}
+	return fee
+}
+
 func (input *TxInput) SetGasFeePriority(other xc.GasFeePriority) error {
 	multiplier, err := other.GetDefault()
 	if err != nil {
 		return err
 	}
-	// multiply the gas price using the default, or apply a strategy according to the enum
-	_ = multiplier
+	multipliedFee := multiplier.Mul(decimal.NewFromBigInt(input.Fee.Int(), 0)).BigInt()
+	input.Fee = xc.AmountBlockchain(*multipliedFee)
 	return nil
 }
 
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/sirupsen/logrus"
+)
+
+// Values that never change, like finalized transactions or derived accounts.
+const NoExpiry time.Duration = 0
+
+// How long to cache token metadata by default, as things like the total supply can change.
+var DefaultMetadataTTL = time.Hour
+
+// Cache stores serialized values by key.  The in-memory LRU is used by default, but this
+// can be implemented to share a cache between processes, e.g. using Redis.
+type Cache interface {
+	// Returns false if the key is not in the cache or has expired.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// A ttl of NoExpiry keeps the value until it's evicted.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Cacheable is implemented by clients that can cache the static data they look up,
+// like token metadata or derived accounts.
+type Cacheable interface {
+	SetCache(cache Cache)
+}
+
+// Key namespaces a cache key by chain and the kind of data being cached.
+func Key(chain xc.NativeAsset, kind string, parts ...string) string {
+	return strings.Join(append([]string{"xc", string(chain), kind}, parts...), ":")
+}
+
+// GetOrFetch returns the cached value for the key, or fetches and caches it.  Values are
+// stored as JSON.  Errors from the cache are logged and the value is fetched instead, so an
+// unavailable cache never fails a call.  A nil cache always fetches.
+func GetOrFetch[T any](ctx context.Context, cache Cache, key string, ttl time.Duration, fetch func() (T, error)) (T, error) {
+	return getOrFetch(ctx, cache, key, ttl, fetch, nil)
+}
+
+// Same as GetOrFetch, but only caches fetched values that keep returns true for.
+func getOrFetch[T any](ctx context.Context, cache Cache, key string, ttl time.Duration, fetch func() (T, error), keep func(T) bool) (T, error) {
+	if cache == nil {
+		return fetch()
+	}
+	value, ok, err := cache.Get(ctx, key)
+	if err != nil {
+		logrus.WithError(err).WithField("key", key).Warn("could not read from cache")
+	} else if ok {
+		var result T
+		decodeErr := json.Unmarshal(value, &result)
+		if decodeErr == nil {
+			return result, nil
+		}
+		logrus.WithError(decodeErr).WithField("key", key).Warn("could not decode cached value")
+	}
+
+	result, err := fetch()
+	if err != nil {
+		return result, err
+	}
+	if keep == nil || keep(result) {
+		store(ctx, cache, key, ttl, result)
+	}
+	return result, nil
+}
+
+func store(ctx context.Context, cache Cache, key string, ttl time.Duration, value interface{}) {
+	bz, err := json.Marshal(value)
+	if err != nil {
+		logrus.WithError(err).WithField("key", key).Warn("could not encode value to cache")
+		return
+	}
+	if err := cache.Set(ctx, key, bz, ttl); err != nil {
+		logrus.WithError(err).WithField("key", key).Warn("could not write to cache")
+	}
+}
@@ -0,0 +1,102 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUEviction(t *testing.T) {
+	ctx := context.Background()
+	lru := NewLRU(2)
+	require.NoError(t, lru.Set(ctx, "a", []byte("1"), NoExpiry))
+	require.NoError(t, lru.Set(ctx, "b", []byte("2"), NoExpiry))
+
+	// reading "a" makes "b" the least recently used
+	value, ok, err := lru.Get(ctx, "a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+
+	require.NoError(t, lru.Set(ctx, "c", []byte("3"), NoExpiry))
+	require.Equal(t, 2, lru.Len())
+	_, ok, _ = lru.Get(ctx, "b")
+	require.False(t, ok)
+	_, ok, _ = lru.Get(ctx, "a")
+	require.True(t, ok)
+	_, ok, _ = lru.Get(ctx, "c")
+	require.True(t, ok)
+
+	// overwriting does not grow the cache
+	require.NoError(t, lru.Set(ctx, "c", []byte("4"), NoExpiry))
+	require.Equal(t, 2, lru.Len())
+	value, _, _ = lru.Get(ctx, "c")
+	require.Equal(t, []byte("4"), value)
+}
+
+func TestLRUExpiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	lru := NewLRU(0)
+	lru.now = func() time.Time { return now }
+
+	require.NoError(t, lru.Set(ctx, "mutable", []byte("1"), time.Minute))
+	require.NoError(t, lru.Set(ctx, "static", []byte("2"), NoExpiry))
+
+	now = now.Add(59 * time.Second)
+	_, ok, _ := lru.Get(ctx, "mutable")
+	require.True(t, ok)
+
+	now = now.Add(time.Second)
+	_, ok, _ = lru.Get(ctx, "mutable")
+	require.False(t, ok)
+	_, ok, _ = lru.Get(ctx, "static")
+	require.True(t, ok)
+	require.Equal(t, 1, lru.Len())
+}
+
+type brokenCache struct{}
+
+func (brokenCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+func (brokenCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func TestGetOrFetch(t *testing.T) {
+	ctx := context.Background()
+	calls := 0
+	fetch := func() (map[string]int, error) {
+		calls++
+		return map[string]int{"decimals": 6}, nil
+	}
+
+	lru := NewLRU(0)
+	for i := 0; i < 3; i++ {
+		value, err := GetOrFetch(ctx, lru, "key", NoExpiry, fetch)
+		require.NoError(t, err)
+		require.Equal(t, 6, value["decimals"])
+	}
+	require.Equal(t, 1, calls)
+
+	// errors are not cached
+	_, err := GetOrFetch(ctx, lru, "failing", NoExpiry, func() (int, error) {
+		return 0, errors.New("not found")
+	})
+	require.ErrorContains(t, err, "not found")
+	_, ok, _ := lru.Get(ctx, "failing")
+	require.False(t, ok)
+
+	// an unavailable or missing cache falls back to fetching
+	for _, store := range []Cache{brokenCache{}, nil} {
+		calls = 0
+		value, err := GetOrFetch(ctx, store, "key", NoExpiry, fetch)
+		require.NoError(t, err)
+		require.Equal(t, 6, value["decimals"])
+		require.Equal(t, 1, calls)
+	}
+}
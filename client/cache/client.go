@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+type Options struct {
+	// How long to cache balances.  Balances change often, so they are not cached by default.
+	BalanceTTL time.Duration
+}
+
+var DefaultOptions = Options{}
+
+type Option func(opts *Options)
+
+func OptionBalanceTTL(ttl time.Duration) Option {
+	return func(opts *Options) { opts.BalanceTTL = ttl }
+}
+
+// Client caches finalized transactions, and optionally balances, for a client.  Clients that
+// implement Cacheable are also given the cache for the static data they look up.
+type Client struct {
+	client  xclient.FullClient
+	cache   Cache
+	chain   *xc.ChainConfig
+	asset   xc.ITask
+	options Options
+}
+
+var _ xclient.FullClient = &Client{}
+var _ xclient.TokenMetadataClient = &Client{}
+
+// Also forwards the latest height for clients that support it.
+type LatestHeightClient struct {
+	*Client
+}
+
+var _ xclient.LatestHeightClient = &LatestHeightClient{}
+
+// WrapClient adds caching to the client.  Clients that do not support FetchTransferInput are returned as is.
+func WrapClient(cfg xc.ITask, client xclient.Client, cache Cache, options ...Option) xclient.Client {
+	if cacheable, ok := client.(Cacheable); ok {
+		cacheable.SetCache(cache)
+	}
+	fullClient, ok := client.(xclient.FullClient)
+	if !ok {
+		return client
+	}
+	opts := DefaultOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	wrapped := &Client{
+		client:  fullClient,
+		cache:   cache,
+		chain:   cfg.GetChain(),
+		asset:   cfg,
+		options: opts,
+	}
+	if _, ok := client.(xclient.LatestHeightClient); ok {
+		return &LatestHeightClient{wrapped}
+	}
+	return wrapped
+}
+
+// Unwrap returns the client being cached.
+func (c *Client) Unwrap() xclient.FullClient {
+	return c.client
+}
+
+func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	return c.client.FetchTransferInput(ctx, args)
+}
+
+func (c *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
+	return c.client.FetchLegacyTxInput(ctx, from, to)
+}
+
+func (c *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
+	return c.client.SubmitTx(ctx, tx)
+}
+
+// Legacy tx info keeps unexported state that does not survive serialization, so it is not cached.
+func (c *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (xc.LegacyTxInfo, error) {
+	return c.client.FetchLegacyTxInfo(ctx, txHash)
+}
+
+// FetchTxInfo caches transactions once they have ConfirmationsFinal confirmations.  Cached transactions
+// report the confirmations at the time they were cached.
+func (c *Client) FetchTxInfo(ctx context.Context, txHash xc.TxHash) (xclient.TxInfo, error) {
+	key := Key(c.chain.Chain, "txinfo", string(txHash))
+	return getOrFetch(ctx, c.cache, key, NoExpiry, func() (xclient.TxInfo, error) {
+		return c.client.FetchTxInfo(ctx, txHash)
+	}, c.isFinal)
+}
+
+func (c *Client) FetchBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	if c.options.BalanceTTL <= 0 {
+		return c.client.FetchBalance(ctx, address)
+	}
+	key := Key(c.chain.Chain, "balance", c.asset.GetContract(), string(address))
+	return GetOrFetch(ctx, c.cache, key, c.options.BalanceTTL, func() (xc.AmountBlockchain, error) {
+		return c.client.FetchBalance(ctx, address)
+	})
+}
+
+func (c *Client) FetchNativeBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	if c.options.BalanceTTL <= 0 {
+		return c.client.FetchNativeBalance(ctx, address)
+	}
+	key := Key(c.chain.Chain, "native-balance", string(address))
+	return GetOrFetch(ctx, c.cache, key, c.options.BalanceTTL, func() (xc.AmountBlockchain, error) {
+		return c.client.FetchNativeBalance(ctx, address)
+	})
+}
+
+func (c *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	key := Key(c.chain.Chain, "token-metadata", string(contract))
+	return GetOrFetch(ctx, c.cache, key, DefaultMetadataTTL, func() (*xclient.TokenMetadata, error) {
+		return xclient.FetchTokenMetadata(ctx, c.client, contract)
+	})
+}
+
+func (c *LatestHeightClient) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return c.client.(xclient.LatestHeightClient).FetchLatestHeight(ctx)
+}
+
+// Chains configured without ConfirmationsFinal never have their transactions cached.
+func (c *Client) isFinal(info xclient.TxInfo) bool {
+	final := c.chain.ConfirmationsFinal
+	return final > 0 && info.Confirmations >= uint64(final)
+}
@@ -0,0 +1,95 @@
+package cache_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	evmclient "github.com/cordialsys/crosschain/chain/evm/client"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	testutil "github.com/cordialsys/crosschain/testutil/factory"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func txInfo(hash string, confirmations uint64) xclient.TxInfo {
+	info := xclient.NewTxInfo(
+		xclient.NewBlock(100, "0xblock", time.Unix(1700000000, 0).UTC()),
+		xc.ETH, hash, confirmations, nil,
+	)
+	info.AddSimpleTransfer("0xfrom", "0xto", "", xc.NewAmountBlockchainFromUint64(10), nil, "")
+	info.AddFee("0xfrom", "", xc.NewAmountBlockchainFromUint64(1), nil)
+	return *info
+}
+
+func TestCacheFinalTxInfo(t *testing.T) {
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, ConfirmationsFinal: 6}
+	mockClient := &testutil.MockedClient{}
+	mockClient.On("FetchTxInfo", mock.Anything, xc.TxHash("0xfinal")).Return(txInfo("0xfinal", 6), nil).Once()
+	mockClient.On("FetchTxInfo", mock.Anything, xc.TxHash("0xpending")).Return(txInfo("0xpending", 5), nil).Twice()
+	client := cache.WrapClient(chain, mockClient, cache.NewLRU(0))
+
+	for i := 0; i < 2; i++ {
+		info, err := client.FetchTxInfo(context.Background(), "0xfinal")
+		require.NoError(t, err)
+		expected, _ := json.Marshal(txInfo("0xfinal", 6))
+		actual, _ := json.Marshal(info)
+		require.JSONEq(t, string(expected), string(actual))
+
+		info, err = client.FetchTxInfo(context.Background(), "0xpending")
+		require.NoError(t, err)
+		require.EqualValues(t, 5, info.Confirmations)
+	}
+	mockClient.AssertExpectations(t)
+
+	// nothing is final without a configured number of confirmations
+	chain = &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}
+	mockClient = &testutil.MockedClient{}
+	mockClient.On("FetchTxInfo", mock.Anything, xc.TxHash("0xfinal")).Return(txInfo("0xfinal", 100), nil).Twice()
+	client = cache.WrapClient(chain, mockClient, cache.NewLRU(0))
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchTxInfo(context.Background(), "0xfinal")
+		require.NoError(t, err)
+	}
+	mockClient.AssertExpectations(t)
+}
+
+func TestCacheBalances(t *testing.T) {
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, ConfirmationsFinal: 6}
+	mockClient := &testutil.MockedClient{}
+	mockClient.On("FetchNativeBalance", mock.Anything, xc.Address("0xfrom")).Return(xc.NewAmountBlockchainFromUint64(10), nil)
+
+	// not cached by default
+	client := cache.WrapClient(chain, mockClient, cache.NewLRU(0))
+	for i := 0; i < 2; i++ {
+		_, err := client.FetchNativeBalance(context.Background(), "0xfrom")
+		require.NoError(t, err)
+	}
+	mockClient.AssertNumberOfCalls(t, "FetchNativeBalance", 2)
+
+	client = cache.WrapClient(chain, mockClient, cache.NewLRU(0), cache.OptionBalanceTTL(time.Minute))
+	for i := 0; i < 2; i++ {
+		balance, err := client.FetchNativeBalance(context.Background(), "0xfrom")
+		require.NoError(t, err)
+		require.Equal(t, "10", balance.String())
+	}
+	mockClient.AssertNumberOfCalls(t, "FetchNativeBalance", 3)
+}
+
+func TestCachePreservesInterfaces(t *testing.T) {
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, URL: "http://localhost"}
+	evmClient, err := evmclient.NewClient(chain)
+	require.NoError(t, err)
+	client := cache.WrapClient(chain, evmClient, cache.NewLRU(0))
+	_, ok := client.(xclient.LatestHeightClient)
+	require.True(t, ok)
+
+	client = cache.WrapClient(chain, &testutil.MockedClient{}, cache.NewLRU(0))
+	_, ok = client.(xclient.LatestHeightClient)
+	require.False(t, ok)
+	_, ok = client.(*cache.Client)
+	require.True(t, ok)
+}
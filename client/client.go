@@ -37,6 +37,16 @@ type FullClient interface {
 	ClientV2
 }
 
+// Optional interface for clients that can report the latest block height of their node, used for health checks.
+type LatestHeightClient interface {
+	FetchLatestHeight(ctx context.Context) (uint64, error)
+}
+
+// Optional interface for clients that can look up the metadata of a token contract, e.g. its decimals.
+type TokenMetadataClient interface {
+	FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*TokenMetadata, error)
+}
+
 type StakingClient interface {
 	// Fetch staked balances accross different possible states
 	FetchStakeBalance(ctx context.Context, args StakedBalanceArgs) ([]*StakedBalance, error)
@@ -0,0 +1,408 @@
+package failover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
+	"github.com/cordialsys/crosschain/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Classifies an error returned by a client, e.g. factory.CheckError for the driver.
+type CheckErrorFunc func(err error) xclient.ClientError
+
+// Endpoint is a client connected to a single node.  Its health is updated by concurrent calls and health checks.
+type Endpoint struct {
+	Name   string
+	Client xclient.FullClient
+
+	lock    sync.RWMutex
+	healthy bool
+	height  uint64
+	lastErr error
+}
+
+func NewEndpoint(name string, client xclient.FullClient) *Endpoint {
+	return &Endpoint{
+		Name:    name,
+		Client:  client,
+		healthy: true,
+	}
+}
+
+func (e *Endpoint) Healthy() bool {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.healthy
+}
+
+func (e *Endpoint) Height() uint64 {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.height
+}
+
+func (e *Endpoint) LastError() error {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	return e.lastErr
+}
+
+func (e *Endpoint) setHealth(healthy bool, height uint64, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.healthy = healthy
+	e.height = height
+	e.lastErr = err
+}
+
+func (e *Endpoint) markFailed(err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.healthy = false
+	e.lastErr = err
+}
+
+type Options struct {
+	// Nodes further behind than this number of blocks from the highest node are unhealthy.
+	MaxHeightLag uint64
+	// How often to re-check the health of the nodes.
+	HealthCheckInterval time.Duration
+	// Timeout for each health check request.
+	HealthCheckTimeout time.Duration
+	// Maximum number of endpoints to try for a single call.
+	MaxAttempts int
+}
+
+var DefaultOptions = Options{
+	MaxHeightLag:        10,
+	HealthCheckInterval: 30 * time.Second,
+	HealthCheckTimeout:  5 * time.Second,
+	MaxAttempts:         3,
+}
+
+type Option func(opts *Options)
+
+func OptionMaxHeightLag(lag uint64) Option {
+	return func(opts *Options) { opts.MaxHeightLag = lag }
+}
+func OptionHealthCheckInterval(interval time.Duration) Option {
+	return func(opts *Options) { opts.HealthCheckInterval = interval }
+}
+func OptionHealthCheckTimeout(timeout time.Duration) Option {
+	return func(opts *Options) { opts.HealthCheckTimeout = timeout }
+}
+func OptionMaxAttempts(attempts int) Option {
+	return func(opts *Options) { opts.MaxAttempts = attempts }
+}
+
+// Client wraps clients for multiple nodes of the same chain.  Read calls are balanced between the healthy nodes
+// and retried on the next node if there is a network error.  Transactions are submitted to all healthy nodes.
+type Client struct {
+	endpoints  []*Endpoint
+	checkError CheckErrorFunc
+	options    Options
+
+	lock            sync.Mutex
+	next            int
+	lastHealthCheck time.Time
+}
+
+var _ xclient.FullClient = &Client{}
+var _ xclient.LatestHeightClient = &Client{}
+var _ cache.Cacheable = &Client{}
+var _ xclient.TokenMetadataClient = &Client{}
+
+func NewClient(endpoints []*Endpoint, checkError CheckErrorFunc, options ...Option) (*Client, error) {
+	if len(endpoints) == 0 {
+		return nil, errors.New("failover client requires at least one endpoint")
+	}
+	opts := DefaultOptions
+	for _, opt := range options {
+		opt(&opts)
+	}
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = len(endpoints)
+	}
+	return &Client{
+		endpoints:  endpoints,
+		checkError: checkError,
+		options:    opts,
+	}, nil
+}
+
+func (c *Client) Endpoints() []*Endpoint {
+	return c.endpoints
+}
+
+// SetCache shares the cache with each node's client that supports it.
+func (c *Client) SetCache(store cache.Cache) {
+	for _, endpoint := range c.endpoints {
+		if cacheable, ok := endpoint.Client.(cache.Cacheable); ok {
+			cacheable.SetCache(store)
+		}
+	}
+}
+
+// CheckHealth fetches the latest height from each node that supports it.  Nodes that fail or are lagging
+// behind the highest node are marked unhealthy.
+func (c *Client) CheckHealth(ctx context.Context) {
+	type result struct {
+		height uint64
+		err    error
+		ok     bool
+	}
+	results := make([]result, len(c.endpoints))
+	wg := sync.WaitGroup{}
+	for i, endpoint := range c.endpoints {
+		heightClient, ok := endpoint.Client.(xclient.LatestHeightClient)
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, heightClient xclient.LatestHeightClient) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, c.options.HealthCheckTimeout)
+			defer cancel()
+			height, err := heightClient.FetchLatestHeight(ctx)
+			results[i] = result{height, err, true}
+		}(i, heightClient)
+	}
+	wg.Wait()
+
+	maxHeight := uint64(0)
+	for _, res := range results {
+		if res.ok && res.err == nil && res.height > maxHeight {
+			maxHeight = res.height
+		}
+	}
+
+	for i, endpoint := range c.endpoints {
+		res := results[i]
+		if !res.ok {
+			// can't check the height, so only recent errors make the node unhealthy
+			continue
+		}
+		healthy := res.err == nil && res.height+c.options.MaxHeightLag >= maxHeight
+		endpoint.setHealth(healthy, res.height, res.err)
+		if !healthy {
+			logrus.WithFields(logrus.Fields{
+				"endpoint": endpoint.Name,
+				"height":   res.height,
+				"max":      maxHeight,
+				"error":    res.err,
+			}).Warn("endpoint is unhealthy")
+		}
+	}
+	c.lock.Lock()
+	c.lastHealthCheck = time.Now()
+	c.lock.Unlock()
+}
+
+func (c *Client) checkHealthIfNeeded(ctx context.Context) {
+	c.lock.Lock()
+	stale := time.Since(c.lastHealthCheck) > c.options.HealthCheckInterval
+	c.lock.Unlock()
+	if stale {
+		c.CheckHealth(ctx)
+	}
+}
+
+// Returns the endpoints to try in order, starting from the next healthy endpoint (round robin).
+// Unhealthy endpoints are only used as a last resort.
+func (c *Client) candidates() []*Endpoint {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	healthy := []*Endpoint{}
+	unhealthy := []*Endpoint{}
+	for _, endpoint := range c.endpoints {
+		if endpoint.Healthy() {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+	ordered := []*Endpoint{}
+	if len(healthy) > 0 {
+		start := c.next % len(healthy)
+		c.next++
+		ordered = append(ordered, healthy[start:]...)
+		ordered = append(ordered, healthy[:start]...)
+	}
+	return append(ordered, unhealthy...)
+}
+
+// IsNetworkError returns true if the error should be retried on another node.  The status is the
+// last http response status of the request, if known.
+func (c *Client) IsNetworkError(err error, status int) bool {
+	if c.checkError != nil && c.checkError(err) == xclient.NetworkError {
+		return true
+	}
+	return isTransportError(err, status)
+}
+
+// Drivers don't always classify connection failures or overloaded nodes, so we also check for them here.
+func isTransportError(err error, status int) bool {
+	var netErr net.Error
+	var urlErr *url.Error
+	if errors.As(err, &netErr) || errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// Call an idempotent method, retrying on the next node if there is a network error.
+func call[T any](ctx context.Context, c *Client, method string, f func(ctx context.Context, client xclient.FullClient) (T, error)) (T, error) {
+	c.checkHealthIfNeeded(ctx)
+	var result T
+	var err error
+	for i, endpoint := range c.candidates() {
+		if i >= c.options.MaxAttempts {
+			break
+		}
+		attemptCtx, status := utils.WithResponseStatus(ctx)
+		result, err = f(attemptCtx, endpoint.Client)
+		if err == nil || !c.IsNetworkError(err, status.Code()) {
+			return result, err
+		}
+		logrus.WithFields(logrus.Fields{
+			"endpoint": endpoint.Name,
+			"method":   method,
+			"status":   status.Code(),
+			"error":    err,
+		}).Warn("network error, trying next endpoint")
+		endpoint.markFailed(err)
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return result, err
+}
+
+func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	return call(ctx, c, "FetchTransferInput", func(ctx context.Context, client xclient.FullClient) (xc.TxInput, error) {
+		return client.FetchTransferInput(ctx, args)
+	})
+}
+
+func (c *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
+	return call(ctx, c, "FetchLegacyTxInput", func(ctx context.Context, client xclient.FullClient) (xc.TxInput, error) {
+		return client.FetchLegacyTxInput(ctx, from, to)
+	})
+}
+
+func (c *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (xc.LegacyTxInfo, error) {
+	return call(ctx, c, "FetchLegacyTxInfo", func(ctx context.Context, client xclient.FullClient) (xc.LegacyTxInfo, error) {
+		return client.FetchLegacyTxInfo(ctx, txHash)
+	})
+}
+
+func (c *Client) FetchTxInfo(ctx context.Context, txHash xc.TxHash) (xclient.TxInfo, error) {
+	return call(ctx, c, "FetchTxInfo", func(ctx context.Context, client xclient.FullClient) (xclient.TxInfo, error) {
+		return client.FetchTxInfo(ctx, txHash)
+	})
+}
+
+func (c *Client) FetchBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	return call(ctx, c, "FetchBalance", func(ctx context.Context, client xclient.FullClient) (xc.AmountBlockchain, error) {
+		return client.FetchBalance(ctx, address)
+	})
+}
+
+func (c *Client) FetchNativeBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	return call(ctx, c, "FetchNativeBalance", func(ctx context.Context, client xclient.FullClient) (xc.AmountBlockchain, error) {
+		return client.FetchNativeBalance(ctx, address)
+	})
+}
+
+func (c *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	return call(ctx, c, "FetchTokenMetadata", func(ctx context.Context, client xclient.FullClient) (*xclient.TokenMetadata, error) {
+		return xclient.FetchTokenMetadata(ctx, client, contract)
+	})
+}
+
+// SubmitTx broadcasts the transaction to all healthy nodes.  It succeeds if any node accepts the transaction.
+func (c *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
+	c.checkHealthIfNeeded(ctx)
+	targets := []*Endpoint{}
+	for _, endpoint := range c.candidates() {
+		if endpoint.Healthy() {
+			targets = append(targets, endpoint)
+		}
+	}
+	if len(targets) == 0 {
+		// try everything rather than giving up
+		targets = c.endpoints
+	}
+
+	errs := make([]error, len(targets))
+	statuses := make([]*utils.ResponseStatus, len(targets))
+	wg := sync.WaitGroup{}
+	for i, endpoint := range targets {
+		wg.Add(1)
+		go func(i int, endpoint *Endpoint) {
+			defer wg.Done()
+			ctx, status := utils.WithResponseStatus(ctx)
+			statuses[i] = status
+			errs[i] = endpoint.Client.SubmitTx(ctx, tx)
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	var firstErr error
+	firstErrIsNetwork := false
+	for i, err := range errs {
+		if err == nil {
+			return nil
+		}
+		if c.checkError != nil && c.checkError(err) == xclient.TransactionExists {
+			// another node got there first
+			return nil
+		}
+		if c.IsNetworkError(err, statuses[i].Code()) {
+			targets[i].markFailed(err)
+			if firstErr == nil {
+				firstErr = err
+				firstErrIsNetwork = true
+			}
+		} else if firstErr == nil || firstErrIsNetwork {
+			// prefer reporting an error about the transaction itself
+			firstErr = err
+			firstErrIsNetwork = false
+		}
+	}
+	return fmt.Errorf("could not submit transaction to any of %d endpoints: %w", len(targets), firstErr)
+}
+
+// FetchLatestHeight returns the highest block height reported by the nodes.
+func (c *Client) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	c.CheckHealth(ctx)
+	maxHeight := uint64(0)
+	var err error = errors.New("no endpoints report their latest height")
+	for _, endpoint := range c.endpoints {
+		if _, ok := endpoint.Client.(xclient.LatestHeightClient); !ok {
+			continue
+		}
+		if lastErr := endpoint.LastError(); lastErr != nil {
+			err = lastErr
+			continue
+		}
+		if height := endpoint.Height(); height > maxHeight {
+			maxHeight = height
+		}
+	}
+	if maxHeight == 0 {
+		return 0, err
+	}
+	return maxHeight, nil
+}
@@ -0,0 +1,255 @@
+package failover_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/evm"
+	evmclient "github.com/cordialsys/crosschain/chain/evm/client"
+	"github.com/cordialsys/crosschain/client/failover"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	"github.com/stretchr/testify/require"
+)
+
+func newEndpoint(t *testing.T, url string) *failover.Endpoint {
+	// don't retry on the same node, so we can see the failover
+	transport := xc.TransportConfig{MaxRetries: -1}
+	client, err := evmclient.NewClient(&xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, URL: url, Transport: transport})
+	require.NoError(t, err)
+	return failover.NewEndpoint(url, client)
+}
+
+func TestFailoverOnNetworkError(t *testing.T) {
+	down, _ := testtypes.MockJSONRPC(t, `"0x64"`)
+	down.Close()
+	up, close := testtypes.MockJSONRPC(t, `"0x64"`)
+	defer close()
+
+	client, err := failover.NewClient(
+		[]*failover.Endpoint{newEndpoint(t, down.URL), newEndpoint(t, up.URL)},
+		evm.CheckError,
+	)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		balance, err := client.FetchNativeBalance(context.Background(), "0x0eC9f48533bb2A03F53F341EF5cc1B057892B10B")
+		require.NoError(t, err)
+		require.Equal(t, "100", balance.String())
+	}
+	require.False(t, client.Endpoints()[0].Healthy())
+	require.True(t, client.Endpoints()[1].Healthy())
+}
+
+func TestFailoverRetriesServerErrors(t *testing.T) {
+	// the height check passes, but then the node is overloaded
+	overloaded, close1 := testtypes.MockHTTP(t, []string{`{"jsonrpc":"2.0","result":"0x64","id":1}`, `{}`}, 200)
+	overloaded.StatusCodes = []int{200, 503}
+	defer close1()
+	up, close2 := testtypes.MockJSONRPC(t, `"0x64"`)
+	defer close2()
+
+	client, err := failover.NewClient(
+		[]*failover.Endpoint{newEndpoint(t, overloaded.URL), newEndpoint(t, up.URL)},
+		evm.CheckError,
+	)
+	require.NoError(t, err)
+
+	balance, err := client.FetchNativeBalance(context.Background(), "0x0eC9f48533bb2A03F53F341EF5cc1B057892B10B")
+	require.NoError(t, err)
+	require.Equal(t, "100", balance.String())
+	require.Equal(t, 2, overloaded.Counter)
+	require.False(t, client.Endpoints()[0].Healthy())
+}
+
+func TestFailoverDoesNotRetryOtherErrors(t *testing.T) {
+	first, close1 := testtypes.MockJSONRPC(t, []string{
+		`"0x64"`,
+		`{"jsonrpc":"2.0","error":{"code":-32000,"message":"invalid argument"},"id":1}`,
+	})
+	defer close1()
+	second, close2 := testtypes.MockJSONRPC(t, `"0x64"`)
+	defer close2()
+
+	client, err := failover.NewClient(
+		[]*failover.Endpoint{newEndpoint(t, first.URL), newEndpoint(t, second.URL)},
+		evm.CheckError,
+	)
+	require.NoError(t, err)
+
+	_, err = client.FetchNativeBalance(context.Background(), "0x0eC9f48533bb2A03F53F341EF5cc1B057892B10B")
+	require.ErrorContains(t, err, "invalid argument")
+	// only the health check reached the second node
+	require.Equal(t, 1, second.Counter)
+	require.True(t, client.Endpoints()[0].Healthy())
+}
+
+func TestFailoverDoesNotRetryErrorMessages(t *testing.T) {
+	// errors from the node are not mistaken for transport errors by their message
+	first, close1 := testtypes.MockJSONRPC(t, []string{
+		`"0x64"`,
+		`{"jsonrpc":"2.0","error":{"code":-32000,"message":"internal server error: bad gateway"},"id":1}`,
+	})
+	defer close1()
+	second, close2 := testtypes.MockJSONRPC(t, `"0x64"`)
+	defer close2()
+
+	client, err := failover.NewClient(
+		[]*failover.Endpoint{newEndpoint(t, first.URL), newEndpoint(t, second.URL)},
+		evm.CheckError,
+	)
+	require.NoError(t, err)
+
+	_, err = client.FetchNativeBalance(context.Background(), "0x0eC9f48533bb2A03F53F341EF5cc1B057892B10B")
+	require.ErrorContains(t, err, "internal server error")
+	require.Equal(t, 1, second.Counter)
+	require.True(t, client.Endpoints()[0].Healthy())
+}
+
+func TestFailoverConcurrentCalls(t *testing.T) {
+	// the mock servers aren't safe for concurrent requests
+	newServer := func(status int) (*httptest.Server, *atomic.Int64) {
+		counter := &atomic.Int64{}
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			counter.Add(1)
+			rw.WriteHeader(status)
+			_, _ = rw.Write([]byte(`{"jsonrpc":"2.0","result":"0x64","id":1}`))
+		}))
+		return server, counter
+	}
+	up, upCounter := newServer(200)
+	defer up.Close()
+	overloaded, _ := newServer(503)
+	defer overloaded.Close()
+
+	client, err := failover.NewClient(
+		[]*failover.Endpoint{newEndpoint(t, overloaded.URL), newEndpoint(t, up.URL)},
+		evm.CheckError,
+		failover.OptionHealthCheckInterval(0),
+	)
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			balance, err := client.FetchNativeBalance(context.Background(), "0x0eC9f48533bb2A03F53F341EF5cc1B057892B10B")
+			require.NoError(t, err)
+			require.Equal(t, "100", balance.String())
+		}()
+		go func() {
+			defer wg.Done()
+			for _, endpoint := range client.Endpoints() {
+				_ = endpoint.Healthy()
+				_ = endpoint.Height()
+			}
+		}()
+	}
+	wg.Wait()
+	require.False(t, client.Endpoints()[0].Healthy())
+	require.True(t, client.Endpoints()[1].Healthy())
+	require.EqualValues(t, 100, client.Endpoints()[1].Height())
+	require.Greater(t, upCounter.Load(), int64(10))
+}
+
+func TestFailoverSkipsLaggingEndpoints(t *testing.T) {
+	// same response for the height and balance, so we can tell which node answered
+	ahead, close1 := testtypes.MockJSONRPC(t, `"0x64"`)
+	defer close1()
+	lagging, close2 := testtypes.MockJSONRPC(t, `"0x10"`)
+	defer close2()
+	behindByLessThanMax, close3 := testtypes.MockJSONRPC(t, `"0x60"`)
+	defer close3()
+
+	client, err := failover.NewClient(
+		[]*failover.Endpoint{
+			newEndpoint(t, lagging.URL),
+			newEndpoint(t, ahead.URL),
+			newEndpoint(t, behindByLessThanMax.URL),
+		},
+		evm.CheckError,
+		failover.OptionMaxHeightLag(10),
+	)
+	require.NoError(t, err)
+
+	height, err := client.FetchLatestHeight(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 100, height)
+	require.False(t, client.Endpoints()[0].Healthy())
+	require.True(t, client.Endpoints()[1].Healthy())
+	require.True(t, client.Endpoints()[2].Healthy())
+	require.EqualValues(t, 16, client.Endpoints()[0].Height())
+
+	for i := 0; i < 4; i++ {
+		balance, err := client.FetchNativeBalance(context.Background(), "0x0eC9f48533bb2A03F53F341EF5cc1B057892B10B")
+		require.NoError(t, err)
+		require.NotEqual(t, "16", balance.String())
+	}
+	// only the health check
+	require.Equal(t, 1, lagging.Counter)
+}
+
+func TestSubmitTxToAllEndpoints(t *testing.T) {
+	tx := &testtypes.MockXcTx{SerializedSignedTx: []byte{1, 2, 3}}
+	vectors := []struct {
+		name      string
+		responses []string
+		err       string
+	}{
+		{
+			name:      "all accept",
+			responses: []string{`"0x01"`, `"0x01"`},
+		},
+		{
+			name: "one accepts",
+			responses: []string{
+				`{"jsonrpc":"2.0","error":{"code":-32000,"message":"nonce too low"},"id":1}`,
+				`"0x01"`,
+			},
+		},
+		{
+			name: "already known",
+			responses: []string{
+				`{"jsonrpc":"2.0","error":{"code":-32000,"message":"already known"},"id":1}`,
+				`{"jsonrpc":"2.0","error":{"code":-32000,"message":"already known"},"id":1}`,
+			},
+		},
+		{
+			name: "all reject",
+			responses: []string{
+				`{"jsonrpc":"2.0","error":{"code":-32000,"message":"nonce too low"},"id":1}`,
+				`{"jsonrpc":"2.0","error":{"code":-32000,"message":"nonce too low"},"id":1}`,
+			},
+			err: "nonce too low",
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			endpoints := []*failover.Endpoint{}
+			servers := []*testtypes.MockJSONRPCServer{}
+			for _, resp := range v.responses {
+				server, close := testtypes.MockJSONRPC(t, []string{`"0x64"`, resp})
+				defer close()
+				servers = append(servers, server)
+				endpoints = append(endpoints, newEndpoint(t, server.URL))
+			}
+			client, err := failover.NewClient(endpoints, evm.CheckError)
+			require.NoError(t, err)
+
+			err = client.SubmitTx(context.Background(), tx)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+			} else {
+				require.NoError(t, err)
+			}
+			for _, server := range servers {
+				require.Equal(t, 2, server.Counter)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/cordialsys/crosschain/chain/evm/address"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -69,7 +70,7 @@ func (cli *Client) Send(method string, path string, requestBody any, response an
 		request.Header.Add("x-api-key", cli.ApiKey)
 	}
 	logrus.WithField("url", url).Debug(method)
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := utils.NewDefaultHttpClient(cli.Chain).Do(request)
 	if err != nil {
 		return fmt.Errorf("failed to GET: %v", err)
 	}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/cordialsys/crosschain/client/services"
+	"github.com/cordialsys/crosschain/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -98,7 +99,7 @@ func (cli *Client) Login() (string, error) {
 	request.Header.Add("X-Amz-Target", "AWSCognitoIdentityProviderService.InitiateAuth")
 
 	logrus.WithField("url", url).Debug("POST")
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := utils.NewDefaultHttpClient(cli.Chain).Do(request)
 	if err != nil {
 		return "", fmt.Errorf("failed to POST: %v", err)
 	}
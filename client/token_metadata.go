@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+type TokenMetadata struct {
+	Contract xc.ContractAddress `json:"contract"`
+	Name     string             `json:"name"`
+	Symbol   string             `json:"symbol"`
+	Decimals int32              `json:"decimals"`
+}
+
+// FetchTokenMetadata looks up the metadata of the token contract, if the client supports it.
+func FetchTokenMetadata(ctx context.Context, client Client, contract xc.ContractAddress) (*TokenMetadata, error) {
+	metadataClient, ok := client.(TokenMetadataClient)
+	if !ok {
+		return nil, fmt.Errorf("token metadata is not supported by %T", client)
+	}
+	return metadataClient.FetchTokenMetadata(ctx, contract)
+}
+
+// AssetConfig creates a token config for the chain from the metadata.
+func (metadata *TokenMetadata) AssetConfig(chain *xc.ChainConfig) *xc.TokenAssetConfig {
+	return &xc.TokenAssetConfig{
+		Asset:       metadata.Symbol,
+		Chain:       chain.Chain,
+		Decimals:    metadata.Decimals,
+		Contract:    string(metadata.Contract),
+		ChainConfig: chain,
+	}
+}
@@ -119,6 +119,19 @@ type Unstake struct {
 	Address   string              `json:"address"`
 }
 
+type VoteOption struct {
+	// e.g. "yes", "no", "abstain", "no_with_veto"
+	Option string `json:"option"`
+	Weight string `json:"weight"`
+}
+
+// Governance vote
+type Vote struct {
+	Proposal string        `json:"proposal"`
+	Address  string        `json:"address"`
+	Options  []*VoteOption `json:"options"`
+}
+
 type StakeEvent interface {
 	GetValidator() string
 }
@@ -154,6 +167,9 @@ type TxInfo struct {
 	Stakes   []*Stake   `json:"stakes,omitempty"`
 	Unstakes []*Unstake `json:"unstakes,omitempty"`
 
+	// Governance votes
+	Votes []*Vote `json:"votes,omitempty"`
+
 	// required: set the confirmations at time of querying the info
 	Confirmations uint64 `json:"confirmations"`
 	// optional: set the error of the transaction if there was an error
@@ -190,6 +206,7 @@ func NewTxInfo(block *Block, chain xc.NativeAsset, hash string, confirmations ui
 	fees := []*Balance{}
 	var stakes []*Stake = nil
 	var unstakes []*Unstake = nil
+	var votes []*Vote = nil
 	name := NewTransactionName(chain, hash)
 	return &TxInfo{
 		name,
@@ -200,6 +217,7 @@ func NewTxInfo(block *Block, chain xc.NativeAsset, hash string, confirmations ui
 		fees,
 		stakes,
 		unstakes,
+		votes,
 		confirmations,
 		err,
 	}
@@ -5,13 +5,19 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	bitcoinparams "github.com/cordialsys/crosschain/chain/bitcoin/params"
 	"github.com/cordialsys/crosschain/chain/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -110,6 +116,35 @@ func CmdTxInfo() *cobra.Command {
 	return cmd
 }
 
+func CmdTokenMetadata() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "token <contract>",
+		Aliases: []string{"token-metadata"},
+		Short:   "Lookup the name, symbol and decimals of a token.",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			xcFactory := setup.UnwrapXc(cmd.Context())
+			chain := setup.UnwrapChain(cmd.Context())
+			contract := args[0]
+
+			cli, err := xcFactory.NewClient(assetConfig(chain, "", 0))
+			if err != nil {
+				return err
+			}
+
+			metadata, err := xclient.FetchTokenMetadata(context.Background(), cli, xc.ContractAddress(contract))
+			if err != nil {
+				return fmt.Errorf("could not fetch token metadata: %v", err)
+			}
+
+			bz, _ := json.MarshalIndent(metadata, "", "  ")
+			fmt.Println(string(bz))
+			return nil
+		},
+	}
+	return cmd
+}
+
 func CmdTxTransfer() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "transfer <to> <amount>",
@@ -120,6 +155,17 @@ func CmdTxTransfer() *cobra.Command {
 			xcFactory := setup.UnwrapXc(cmd.Context())
 			chain := setup.UnwrapChain(cmd.Context())
 			to := args[0]
+			validation, err := xcFactory.ValidateAddress(chain, xc.Address(to))
+			if err != nil {
+				return fmt.Errorf("could not validate address: %v", err)
+			}
+			if !validation.Valid {
+				return fmt.Errorf("invalid address '%s': %s", to, validation.Reason)
+			}
+			toAddress, options, err := transferDestination(validation)
+			if err != nil {
+				return err
+			}
 			amountHuman, err := xc.NewAmountHumanReadableFromStr(args[1])
 			if err != nil {
 				return err
@@ -140,11 +186,22 @@ func CmdTxTransfer() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if decimalsStr == "" && contract != "" {
-				return fmt.Errorf("must set --decimals if using --contract")
-			}
 			decimals := chain.GetDecimals()
-			if contract != "" {
+			if contract != "" && decimalsStr == "" {
+				cli, err := xcFactory.NewClient(chain)
+				if err != nil {
+					return fmt.Errorf("could not load client: %v", err)
+				}
+				metadata, err := xclient.FetchTokenMetadata(context.Background(), cli, xc.ContractAddress(contract))
+				if err != nil {
+					return fmt.Errorf("could not lookup decimals, set --decimals instead: %v", err)
+				}
+				logrus.WithFields(logrus.Fields{
+					"symbol":   metadata.Symbol,
+					"decimals": metadata.Decimals,
+				}).Info("token")
+				decimals = metadata.Decimals
+			} else if contract != "" {
 				parsed, err := strconv.ParseUint(decimalsStr, 10, 32)
 				if err != nil {
 					return fmt.Errorf("invalid decimals: %v", err)
@@ -154,11 +211,11 @@ func CmdTxTransfer() *cobra.Command {
 
 			amountBlockchain := amountHuman.ToBlockchain(decimals)
 
-			privateKeyInput := os.Getenv("PRIVATE_KEY")
-			if privateKeyInput == "" {
-				return fmt.Errorf("must set env PRIVATE_KEY")
+			privateKeyInput, err := setup.PrivateKeyFromEnv()
+			if err != nil {
+				return err
 			}
-			signer, err := xcFactory.NewSigner(chain, privateKeyInput)
+			signer, err := xcFactory.NewSigner(chain, privateKeyInput, setup.UnwrapHdOptions(cmd.Context())...)
 			if err != nil {
 				return fmt.Errorf("could not import private key: %v", err)
 			}
@@ -183,7 +240,7 @@ func CmdTxTransfer() *cobra.Command {
 				return fmt.Errorf("could not load client: %v", err)
 			}
 
-			input, err := cli.FetchLegacyTxInput(context.Background(), from, xc.Address(to))
+			input, err := cli.FetchLegacyTxInput(context.Background(), from, toAddress)
 			if err != nil {
 				return fmt.Errorf("could not fetch transfer input: %v", err)
 			}
@@ -211,7 +268,11 @@ func CmdTxTransfer() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("could not load tx-builder: %v", err)
 			}
-			tx, err := builder.NewTransfer(from, xc.Address(to), amountBlockchain, input)
+			transferArgs, err := xcbuilder.NewTransferArgs(from, toAddress, amountBlockchain, options...)
+			if err != nil {
+				return fmt.Errorf("invalid transfer: %v", err)
+			}
+			tx, err := builder.Transfer(transferArgs, input)
 			if err != nil {
 				return fmt.Errorf("could not build transfer: %v", err)
 			}
@@ -262,26 +323,82 @@ func CmdTxTransfer() *cobra.Command {
 		},
 	}
 	cmd.Flags().String("contract", "", "contract address of asset to send, if applicable")
-	cmd.Flags().String("decimals", "", "decimals of the token, when using --contract.")
+	cmd.Flags().String("decimals", "", "decimals of the token, when using --contract.  Looked up from the chain if not set.")
 	cmd.Flags().String("memo", "", "set a memo for the transfer.")
 	cmd.Flags().Duration("timeout", 1*time.Minute, "Amount of time to wait for transaction to confirm on chain.")
 	return cmd
 }
 
+// transferDestination returns the normalized address to send to, and the builder options
+// embedded in the address, like the destination tag of an XRP X-address.
+func transferDestination(validation *xc.AddressValidation) (xc.Address, []xcbuilder.BuilderOption, error) {
+	options := []xcbuilder.BuilderOption{}
+	if validation.Tag != nil {
+		if *validation.Tag > math.MaxUint32 {
+			return "", nil, fmt.Errorf("destination tag %d is too large", *validation.Tag)
+		}
+		options = append(options, xcbuilder.OptionDestinationTag(uint32(*validation.Tag)))
+	}
+	return validation.Normalized, options, nil
+}
+
 func CmdAddress() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "address",
-		Short: "Derive an address from the PRIVATE_KEY environment variable.",
+		Short: "Derive an address from the PRIVATE_KEY environment variable, which may be a key or mnemonic.",
 		Args:  cobra.ExactArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			xcFactory := setup.UnwrapXc(cmd.Context())
 			chain := setup.UnwrapChain(cmd.Context())
 
-			privateKeyInput := os.Getenv("PRIVATE_KEY")
-			if privateKeyInput == "" {
-				return fmt.Errorf("must set env PRIVATE_KEY")
+			index, err := cmd.Flags().GetUint32("index")
+			if err != nil {
+				return err
 			}
-			signer, err := xcFactory.NewSigner(chain, privateKeyInput)
+			xpub, err := cmd.Flags().GetString("xpub")
+			if err != nil {
+				return err
+			}
+			if xpub != "" {
+				count, err := cmd.Flags().GetUint32("count")
+				if err != nil {
+					return err
+				}
+				change, err := cmd.Flags().GetBool("change")
+				if err != nil {
+					return err
+				}
+				return printXpubAddresses(chain, xpub, change, index, count)
+			}
+
+			privateKeyInput, err := setup.PrivateKeyFromEnv()
+			if err != nil {
+				return err
+			}
+			account, err := cmd.Flags().GetUint32("hd-account")
+			if err != nil {
+				return err
+			}
+			path, err := cmd.Flags().GetString("path")
+			if err != nil {
+				return err
+			}
+			options := append(setup.UnwrapHdOptions(cmd.Context()),
+				hd.OptionAccount(account),
+				hd.OptionIndex(index),
+				hd.OptionPassphrase(os.Getenv("PASSPHRASE")),
+				hd.OptionPath(path),
+			)
+			if chain.Driver == xc.DriverTon && path == "" && !hd.NewOptions(options...).Legacy && hd.IsTonMnemonic(privateKeyInput, os.Getenv("PASSPHRASE")) {
+				logrus.Info("deriving from TON wallet mnemonic")
+			} else if strings.Contains(strings.TrimSpace(privateKeyInput), " ") {
+				derivationPath, err := hd.NewOptions(options...).DerivationPath(chain)
+				if err != nil {
+					return err
+				}
+				logrus.WithField("path", derivationPath.String()).Info("deriving from mnemonic")
+			}
+			signer, err := xcFactory.NewSigner(chain, privateKeyInput, options...)
 			if err != nil {
 				return fmt.Errorf("could not import private key: %v", err)
 			}
@@ -295,6 +412,21 @@ func CmdAddress() *cobra.Command {
 				return fmt.Errorf("could not create address builder: %v", err)
 			}
 
+			all, err := cmd.Flags().GetBool("all")
+			if err != nil {
+				return err
+			}
+			if all {
+				possibles, err := addressBuilder.GetAllPossibleAddressesFromPublicKey(publicKey)
+				if err != nil {
+					return fmt.Errorf("could not derive addresses: %v", err)
+				}
+				for _, possible := range possibles {
+					fmt.Printf("%s %s\n", possible.Address, possible.Type)
+				}
+				return nil
+			}
+
 			from, err := addressBuilder.GetAddressFromPublicKey(publicKey)
 			if err != nil {
 				return fmt.Errorf("could not derive address: %v", err)
@@ -303,9 +435,39 @@ func CmdAddress() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().Bool("all", false, "list all possible addresses for the key (e.g. each TON wallet version)")
+	cmd.Flags().Uint32("index", 0, "address index to derive, when using a mnemonic or --xpub")
+	cmd.Flags().Uint32("hd-account", 0, "HD account to derive, when using a mnemonic")
+	cmd.Flags().String("path", "", "derivation path to use instead of the default for the chain, e.g. m/44'/60'/0'/0/0.  Set env PASSPHRASE to use a BIP-39 passphrase.")
+	cmd.Flags().String("xpub", "", "derive watch-only bitcoin addresses from an account xpub, ypub or zpub")
+	cmd.Flags().Uint32("count", 1, "number of addresses to derive, starting at --index, when using --xpub")
+	cmd.Flags().Bool("change", false, "derive change addresses, when using --xpub")
 	return cmd
 }
 
+func printXpubAddresses(chain *xc.ChainConfig, xpub string, change bool, index uint32, count uint32) error {
+	extendedKey, err := hd.ParseExtendedPublicKey(xpub)
+	if err != nil {
+		return err
+	}
+	params, err := bitcoinparams.GetParams(chain)
+	if err != nil {
+		return fmt.Errorf("--xpub is only supported for bitcoin chains: %v", err)
+	}
+	changeIndex := uint32(0)
+	if change {
+		changeIndex = 1
+	}
+	addresses, err := extendedKey.Addresses(params, changeIndex, index, count)
+	if err != nil {
+		return fmt.Errorf("could not derive addresses: %v", err)
+	}
+	for i, address := range addresses {
+		fmt.Printf("%d %s %s\n", index+uint32(i), address, extendedKey.Type)
+	}
+	return nil
+}
+
 func CmdChains() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "chains",
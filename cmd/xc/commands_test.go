@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/xrp/address"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferDestination(t *testing.T) {
+	vectors := []struct {
+		name    string
+		address string
+		to      string
+		tag     uint32
+		hasTag  bool
+	}{
+		{"classic", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 0, false},
+		{"x_address", "X7AcgcsBL6XDcUb289X4mJ8djcdyKaB5hJDWMArnXr61cqZ", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 0, false},
+		{"x_address_tag", "X7AcgcsBL6XDcUb289X4mJ8djcdyKaGZMhc9YTE92ehJ2Fu", "r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", 1, true},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			builder, _ := address.NewAddressBuilder(&xc.ChainConfig{Net: "mainnet"})
+			validation := builder.ValidateAddress(xc.Address(v.address))
+			require.True(t, validation.Valid, validation.Reason)
+
+			to, options, err := transferDestination(validation)
+			require.NoError(t, err)
+			require.Equal(t, xc.Address(v.to), to)
+
+			args, err := xcbuilder.NewTransferArgs("from", to, xc.NewAmountBlockchainFromUint64(1), options...)
+			require.NoError(t, err)
+			tag, ok := args.GetDestinationTag()
+			require.Equal(t, v.hasTag, ok)
+			require.Equal(t, v.tag, tag)
+		})
+	}
+}
+
+func TestTransferDestinationTagTooLarge(t *testing.T) {
+	tag := uint64(1 << 32)
+	validation := xc.ValidAddress("r9cZA1mLK5R5Am25ArfXFmqgNwjZgnfk59", address.AddressTypeXAddress)
+	validation.Tag = &tag
+	_, _, err := transferDestination(validation)
+	require.ErrorContains(t, err, "destination tag 4294967296 is too large")
+}
@@ -0,0 +1,141 @@
+package gov
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	cosmosbuilder "github.com/cordialsys/crosschain/chain/cosmos/builder"
+	cosmosclient "github.com/cordialsys/crosschain/chain/cosmos/client"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/cmd/xc/staking"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func CmdGov() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "gov",
+		Short:        "Governance commands",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+
+	cmd.AddCommand(CmdProposals())
+	cmd.AddCommand(CmdVote())
+	return cmd
+}
+
+func newClient(chain *xc.ChainConfig) (*cosmosclient.Client, error) {
+	if chain.Driver != xc.DriverCosmos {
+		return nil, fmt.Errorf("governance is not supported for %s chain", chain.Chain)
+	}
+	return cosmosclient.NewClient(chain)
+}
+
+func CmdProposals() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "proposals",
+		Short: "List the proposals that are currently being voted on, with their tally.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain := setup.UnwrapChain(cmd.Context())
+			client, err := newClient(chain)
+			if err != nil {
+				return err
+			}
+			proposals, err := client.FetchActiveProposals(cmd.Context())
+			if err != nil {
+				return err
+			}
+			staking.JsonPrint(proposals)
+			return nil
+		},
+	}
+	return cmd
+}
+
+func CmdVote() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote <proposal-id> <option>",
+		Short: "Vote on a proposal.  The option may be yes, no, abstain, no_with_veto, or weighted (e.g. yes=0.6,no=0.4).",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			xcFactory := setup.UnwrapXc(cmd.Context())
+			chain := setup.UnwrapChain(cmd.Context())
+			offline, err := cmd.Flags().GetBool("offline")
+			if err != nil {
+				return err
+			}
+			proposalId, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid proposal id: %v", err)
+			}
+
+			client, err := newClient(chain)
+			if err != nil {
+				return err
+			}
+			txBuilder, err := cosmosbuilder.NewTxBuilder(chain)
+			if err != nil {
+				return err
+			}
+			from, signer, err := staking.LoadPrivateKey(xcFactory, chain, setup.UnwrapHdOptions(cmd.Context())...)
+			if err != nil {
+				return err
+			}
+
+			// chains on older cosmos-sdk versions only accept gov v1beta1 votes
+			version, err := client.FetchGovVersion(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			var msg types.Msg
+			if strings.Contains(args[1], "=") || strings.Contains(args[1], ",") {
+				options, err := cosmosbuilder.ParseWeightedVoteOptions(args[1])
+				if err != nil {
+					return err
+				}
+				msg, err = cosmosbuilder.NewMsgVoteWeighted(from, proposalId, options, version)
+				if err != nil {
+					return err
+				}
+			} else {
+				option, err := cosmosbuilder.ParseVoteOption(args[1])
+				if err != nil {
+					return err
+				}
+				msg, err = cosmosbuilder.NewMsgVote(from, proposalId, option, version)
+				if err != nil {
+					return err
+				}
+			}
+
+			input, err := client.FetchMsgsInput(cmd.Context(), from, signer.MustPublicKey(), msg)
+			if err != nil {
+				return err
+			}
+			tx, err := txBuilder.NewMsgsTx(input, msg)
+			if err != nil {
+				return err
+			}
+			logrus.WithField("tx", tx).Debug("built tx")
+
+			hash, err := staking.SignAndMaybeBroadcast(xcFactory, chain, signer, tx, !offline)
+			if err != nil || offline {
+				return err
+			}
+			txInfo, err := staking.WaitForTx(xcFactory, chain, hash, 1)
+			if err != nil {
+				return err
+			}
+			staking.JsonPrint(txInfo)
+			return nil
+		},
+	}
+	cmd.Flags().Bool("offline", false, "do not broadcast the signed transaction")
+	return cmd
+}
@@ -0,0 +1,213 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
+	"github.com/cordialsys/crosschain/config"
+	"github.com/cordialsys/crosschain/config/keystore"
+	"github.com/cordialsys/crosschain/factory"
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+func CmdKeys() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "keys",
+		Short:        "Manage encrypted keystores, which can be used by setting PRIVATE_KEY=keystore:<path>.",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+	}
+	cmd.PersistentFlags().String("dir", keystore.DefaultDir(), "Directory of the keystores.")
+	cmd.PersistentFlags().String("passphrase", "", "Secret reference for the keystore passphrase, e.g. env:KEYSTORE_PASSPHRASE.  Prompts if not set.")
+
+	cmd.AddCommand(CmdNew())
+	cmd.AddCommand(CmdImport())
+	cmd.AddCommand(CmdList())
+	return cmd
+}
+
+func CmdNew() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "new <name>",
+		Short: "Generate a new private key for the chain and store it in an encrypted keystore.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain := setup.UnwrapChain(cmd.Context())
+			useMnemonic, err := cmd.Flags().GetBool("mnemonic")
+			if err != nil {
+				return err
+			}
+			var secret string
+			if useMnemonic {
+				secret, err = newMnemonic()
+			} else {
+				secret, err = newPrivateKey(chain.Driver.SignatureAlgorithm())
+			}
+			if err != nil {
+				return err
+			}
+			return store(cmd, args[0], secret)
+		},
+	}
+	cmd.Flags().Bool("mnemonic", false, "Generate a 24 word mnemonic instead of a private key.")
+	return cmd
+}
+
+func CmdImport() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import <name>",
+		Short: "Store the private key or mnemonic from the PRIVATE_KEY environment variable in an encrypted keystore.  Prompts if not set.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var secret string
+			var err error
+			if os.Getenv("PRIVATE_KEY") != "" {
+				secret, err = setup.PrivateKeyFromEnv()
+			} else {
+				secret, err = config.PromptPassphrase("Private key or mnemonic: ")
+			}
+			if err != nil {
+				return err
+			}
+			return store(cmd, args[0], secret)
+		},
+	}
+	return cmd
+}
+
+func CmdList() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the keystores.",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, err := cmd.Flags().GetString("dir")
+			if err != nil {
+				return err
+			}
+			files, err := keystore.List(dir)
+			if err != nil {
+				return err
+			}
+			for _, file := range files {
+				fmt.Printf("%s %s %s %s\n", file.Crosschain.Name, file.Crosschain.Chain, file.Crosschain.Address, file.Path)
+			}
+			return nil
+		},
+	}
+	return cmd
+}
+
+func newPrivateKey(alg xc.SignatureType) (string, error) {
+	switch alg {
+	case xc.Ed255:
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(privateKey.Seed()), nil
+	case xc.K256Keccak, xc.K256Sha256:
+		privateKey, err := crypto.GenerateKey()
+		if err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(crypto.FromECDSA(privateKey)), nil
+	default:
+		return "", fmt.Errorf("unsupported signing alg: %v", alg)
+	}
+}
+
+func newMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+func deriveAddress(xcFactory *factory.Factory, chain *xc.ChainConfig, secret string) (xc.Address, error) {
+	signer, err := xcFactory.NewSigner(chain, secret)
+	if err != nil {
+		return "", fmt.Errorf("could not import private key: %v", err)
+	}
+	publicKey, err := signer.PublicKey()
+	if err != nil {
+		return "", fmt.Errorf("could not create public key: %v", err)
+	}
+	addressBuilder, err := xcFactory.NewAddressBuilder(chain)
+	if err != nil {
+		return "", fmt.Errorf("could not create address builder: %v", err)
+	}
+	return addressBuilder.GetAddressFromPublicKey(publicKey)
+}
+
+func newPassphrase(cmd *cobra.Command) (string, error) {
+	passphraseRef, err := cmd.Flags().GetString("passphrase")
+	if err != nil {
+		return "", err
+	}
+	if passphraseRef != "" {
+		return config.GetSecret(passphraseRef)
+	}
+	passphrase, err := config.PromptPassphrase("New passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	confirm, err := config.PromptPassphrase("Confirm passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase != confirm {
+		return "", fmt.Errorf("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+// store encrypts the secret and saves it, recording the address for the chain
+func store(cmd *cobra.Command, name string, secret string) error {
+	xcFactory := setup.UnwrapXc(cmd.Context())
+	chain := setup.UnwrapChain(cmd.Context())
+	dir, err := cmd.Flags().GetString("dir")
+	if err != nil {
+		return err
+	}
+	secret = strings.TrimSpace(secret)
+	address, err := deriveAddress(xcFactory, chain, secret)
+	if err != nil {
+		return err
+	}
+	passphrase, err := newPassphrase(cmd)
+	if err != nil {
+		return err
+	}
+	if passphrase == "" {
+		logrus.Warn("keystore is not protected by a passphrase")
+	}
+	ks, err := keystore.Encrypt(secret, passphrase, keystore.Metadata{
+		Name:    name,
+		Chain:   string(chain.Chain),
+		Address: string(address),
+	})
+	if err != nil {
+		return err
+	}
+	path, err := ks.Save(dir)
+	if err != nil {
+		return err
+	}
+	logrus.WithFields(logrus.Fields{
+		"address": address,
+		"path":    path,
+	}).Info("stored keystore")
+	fmt.Printf("export PRIVATE_KEY=keystore:%s\n", path)
+	return nil
+}
@@ -5,8 +5,11 @@ import (
 
 	xc "github.com/cordialsys/crosschain"
 	"github.com/cordialsys/crosschain/client/services"
+	"github.com/cordialsys/crosschain/cmd/xc/gov"
+	"github.com/cordialsys/crosschain/cmd/xc/keys"
 	"github.com/cordialsys/crosschain/cmd/xc/setup"
 	"github.com/cordialsys/crosschain/cmd/xc/staking"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -58,6 +61,7 @@ func CmdXc() *cobra.Command {
 			ctx := setup.CreateContext(xcFactory, chainConfig)
 			ctx = setup.WrapStakingArgs(ctx, stakingArgs)
 			ctx = setup.WrapStakingConfig(ctx, stakingCfg)
+			ctx = setup.WrapHdOptions(ctx, []hd.Option{hd.OptionLegacy(args.LegacyDerivation)})
 
 			logrus.WithFields(logrus.Fields{
 				"rpc":     chainConfig.GetAllClients()[0].URL,
@@ -74,10 +78,13 @@ func CmdXc() *cobra.Command {
 	cmd.AddCommand(CmdRpcBalance())
 	cmd.AddCommand(CmdTxInput())
 	cmd.AddCommand(CmdTxInfo())
+	cmd.AddCommand(CmdTokenMetadata())
 	cmd.AddCommand(CmdTxTransfer())
 	cmd.AddCommand(CmdAddress())
 	cmd.AddCommand(CmdChains())
 	cmd.AddCommand(staking.CmdStaking())
+	cmd.AddCommand(gov.CmdGov())
+	cmd.AddCommand(keys.CmdKeys())
 
 	return cmd
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/client/services"
 	"github.com/cordialsys/crosschain/factory"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -22,6 +23,7 @@ const ContextXc RpcContextKey = "xc"
 const ContextStakingArgs RpcContextKey = "staking-args"
 const ContextStakingConfig RpcContextKey = "staking-config"
 const ContextChain RpcContextKey = "chain"
+const ContextHdOptions RpcContextKey = "hd-options"
 
 func WrapXc(ctx context.Context, xcFactory *factory.Factory) context.Context {
 	ctx = context.WithValue(ctx, ContextXc, xcFactory)
@@ -40,6 +42,10 @@ func WrapChain(ctx context.Context, chain *xc.ChainConfig) context.Context {
 	ctx = context.WithValue(ctx, ContextChain, chain)
 	return ctx
 }
+func WrapHdOptions(ctx context.Context, options []hd.Option) context.Context {
+	ctx = context.WithValue(ctx, ContextHdOptions, options)
+	return ctx
+}
 func UnwrapXc(ctx context.Context) *factory.Factory {
 	return ctx.Value(ContextXc).(*factory.Factory)
 }
@@ -55,6 +61,12 @@ func UnwrapChain(ctx context.Context) *xc.ChainConfig {
 	return ctx.Value(ContextChain).(*xc.ChainConfig)
 }
 
+// UnwrapHdOptions returns the options for deriving keys from a mnemonic, which are not set by every command
+func UnwrapHdOptions(ctx context.Context) []hd.Option {
+	options, _ := ctx.Value(ContextHdOptions).([]hd.Option)
+	return options
+}
+
 func ConfigureLogger(args *RpcArgs) {
 	if args.VerbosityCount == 0 {
 		logrus.SetLevel(logrus.WarnLevel)
@@ -141,6 +153,7 @@ type RpcArgs struct {
 	ApiKey         string
 	// ConfigPath     string
 	UseLocalImplementation bool
+	LegacyDerivation       bool
 
 	Overrides map[string]*ChainOverride
 }
@@ -154,6 +167,7 @@ func AddRpcArgs(cmd *cobra.Command) {
 	cmd.PersistentFlags().CountP("verbose", "v", "Set verbosity.")
 	cmd.PersistentFlags().Bool("not-mainnet", false, "Do not use mainnets, instead use a test or dev network.")
 	cmd.PersistentFlags().Bool("local", false, "Use local client implementation(s) instead of using remote connector.cordialapis.com.")
+	cmd.PersistentFlags().Bool("legacy-derivation", false, "Derive keys from a mnemonic as previous versions did, using m/44'/<chain_coin_hd_path>'/0'/0/0 for every chain.")
 }
 
 func RpcArgsFromCmd(cmd *cobra.Command) (*RpcArgs, error) {
@@ -170,6 +184,7 @@ func RpcArgsFromCmd(cmd *cobra.Command) (*RpcArgs, error) {
 		return nil, err
 	}
 	notmainnet, _ := cmd.Flags().GetBool("not-mainnet")
+	legacyDerivation, _ := cmd.Flags().GetBool("legacy-derivation")
 	rpcProvider, _ := cmd.Flags().GetString("rpc-provider")
 	apikey, _ := cmd.Flags().GetString("api-key")
 	if apikey == "" {
@@ -192,6 +207,7 @@ func RpcArgsFromCmd(cmd *cobra.Command) (*RpcArgs, error) {
 		Provider:               rpcProvider,
 		ApiKey:                 apikey,
 		UseLocalImplementation: local,
+		LegacyDerivation:       legacyDerivation,
 		// ConfigPath:     config,
 		Overrides: map[string]*ChainOverride{},
 	}, nil
@@ -203,6 +219,7 @@ type StakingArgs struct {
 	Amount     xc.AmountHumanReadable
 	Validator  string
 	Provider   xc.StakingProvider
+	Subnet     *uint16
 }
 
 func (args *StakingArgs) ToBuilderOptions() []builder.BuilderOption {
@@ -213,6 +230,9 @@ func (args *StakingArgs) ToBuilderOptions() []builder.BuilderOption {
 	if args.AccountId != "" {
 		options = append(options, builder.OptionStakeAccount(args.AccountId))
 	}
+	if args.Subnet != nil {
+		options = append(options, builder.OptionSubnet(*args.Subnet))
+	}
 	options = append(options, builder.OptionTimestamp(time.Now().Unix()))
 	return options
 }
@@ -240,6 +260,7 @@ func AddStakingArgs(cmd *cobra.Command) {
 	cmd.PersistentFlags().String("validator", "", "Validator address, if applicable.")
 	cmd.PersistentFlags().String("account", "", "Account address or ID, if applicable.")
 	cmd.PersistentFlags().String("amount", "", "Decimal amount to stake or unstake.")
+	cmd.PersistentFlags().Uint16("subnet", 0, "Subnet (netuid) to stake in, if applicable.")
 
 	options := []string{}
 	for _, v := range xc.SupportedStakingProviders {
@@ -284,6 +305,15 @@ func StakingArgsFromCmd(cmd *cobra.Command) (*StakingArgs, error) {
 		return nil, err
 	}
 
+	var subnet *uint16
+	if cmd.Flags().Changed("subnet") {
+		netuid, err := cmd.Flags().GetUint16("subnet")
+		if err != nil {
+			return nil, err
+		}
+		subnet = &netuid
+	}
+
 	dec, _ := xc.NewAmountHumanReadableFromStr("0")
 	if amount != "" {
 		dec, err = xc.NewAmountHumanReadableFromStr(amount)
@@ -298,5 +328,6 @@ func StakingArgsFromCmd(cmd *cobra.Command) (*StakingArgs, error) {
 		Amount:     dec,
 		Validator:  validator,
 		Provider:   provider,
+		Subnet:     subnet,
 	}, nil
 }
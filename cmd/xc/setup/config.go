@@ -1,9 +1,12 @@
 package setup
 
 import (
+	"fmt"
+	"os"
 	"strings"
 
 	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/config"
 	"github.com/cordialsys/crosschain/factory"
 	"github.com/sirupsen/logrus"
 )
@@ -63,3 +66,16 @@ func OverwriteCrosschainSettings(overrides map[string]*ChainOverride, xcFactory
 		}
 	}
 }
+
+// PrivateKeyFromEnv reads the PRIVATE_KEY env, which is a private key, mnemonic, or a secret reference
+// such as "keystore:~/.cordial/keystore/dev.json".
+func PrivateKeyFromEnv() (string, error) {
+	privateKeyInput := os.Getenv("PRIVATE_KEY")
+	if privateKeyInput == "" {
+		return "", fmt.Errorf("must set env PRIVATE_KEY")
+	}
+	if strings.Contains(privateKeyInput, ":") && config.HasTypePrefix(privateKeyInput) {
+		return config.GetSecret(privateKeyInput)
+	}
+	return privateKeyInput, nil
+}
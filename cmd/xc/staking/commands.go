@@ -28,7 +28,7 @@ func CmdStakedBalances() *cobra.Command {
 				from = args[0]
 			} else {
 				// try loading from private-key env
-				fromWallet, _, err := LoadPrivateKey(xcFactory, chain)
+				fromWallet, _, err := LoadPrivateKey(xcFactory, chain, setup.UnwrapHdOptions(cmd.Context())...)
 				if err != nil {
 					return fmt.Errorf("must provider an address or private key env (%v)", err)
 				}
@@ -50,7 +50,7 @@ func CmdStakedBalances() *cobra.Command {
 				return err
 			}
 
-			jsonprint(balances)
+			JsonPrint(balances)
 
 			return nil
 		},
@@ -79,7 +79,7 @@ func CmdStake() *cobra.Command {
 			}
 			amount := amountHuman.ToBlockchain(chain.Decimals)
 
-			from, signer, err := LoadPrivateKey(xcFactory, chain)
+			from, signer, err := LoadPrivateKey(xcFactory, chain, setup.UnwrapHdOptions(cmd.Context())...)
 			if err != nil {
 				return err
 			}
@@ -138,7 +138,7 @@ func CmdUnstake() *cobra.Command {
 			}
 			amount := amountHuman.ToBlockchain(chain.Decimals)
 
-			from, signer, err := LoadPrivateKey(xcFactory, chain)
+			from, signer, err := LoadPrivateKey(xcFactory, chain, setup.UnwrapHdOptions(cmd.Context())...)
 			if err != nil {
 				return err
 			}
@@ -178,7 +178,7 @@ func CmdUnstake() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			jsonprint(txInfo)
+			JsonPrint(txInfo)
 			if manualClient, ok := stakingClient.(client.ManualUnstakingClient); ok {
 				logrus.Debug("chain does not support unstaking; using 3rd-party manual unstaking client")
 				for _, unstake := range txInfo.Unstakes {
@@ -221,7 +221,7 @@ func CmdWithdraw() *cobra.Command {
 				return fmt.Errorf("must pass --amount to stake")
 			}
 			amount := amountHuman.ToBlockchain(chain.Decimals)
-			from, signer, err := LoadPrivateKey(xcFactory, chain)
+			from, signer, err := LoadPrivateKey(xcFactory, chain, setup.UnwrapHdOptions(cmd.Context())...)
 			if err != nil {
 				return err
 			}
@@ -296,7 +296,7 @@ func CmdFetchStakeInput() *cobra.Command {
 				return err
 			}
 
-			jsonprint(input)
+			JsonPrint(input)
 
 			return nil
 		},
@@ -337,7 +337,7 @@ func CmdFetchUnstakeInput() *cobra.Command {
 				return err
 			}
 
-			jsonprint(input)
+			JsonPrint(input)
 
 			return nil
 		},
@@ -378,7 +378,7 @@ func CmdFetchWithdrawInput() *cobra.Command {
 				return err
 			}
 
-			jsonprint(input)
+			JsonPrint(input)
 
 			return nil
 		},
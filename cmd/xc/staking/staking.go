@@ -1,17 +1,9 @@
 package staking
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/spf13/cobra"
 )
 
-func jsonprint(a any) {
-	bz, _ := json.MarshalIndent(a, "", "  ")
-	fmt.Println(string(bz))
-}
-
 func CmdStaking() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "staking",
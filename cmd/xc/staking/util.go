@@ -3,23 +3,31 @@ package staking
 import (
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
-	"os"
 	"time"
 
 	xc "github.com/cordialsys/crosschain"
 	xcclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/cmd/xc/setup"
 	"github.com/cordialsys/crosschain/factory"
 	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 	"github.com/sirupsen/logrus"
 )
 
-func LoadPrivateKey(xcFactory *factory.Factory, chain *xc.ChainConfig) (xc.Address, *signer.Signer, error) {
-	privateKeyInput := os.Getenv("PRIVATE_KEY")
-	if privateKeyInput == "" {
-		return "", nil, fmt.Errorf("must set env PRIVATE_KEY")
+// JsonPrint prints a value as indented json
+func JsonPrint(a any) {
+	bz, _ := json.MarshalIndent(a, "", "  ")
+	fmt.Println(string(bz))
+}
+
+func LoadPrivateKey(xcFactory *factory.Factory, chain *xc.ChainConfig, options ...hd.Option) (xc.Address, *signer.Signer, error) {
+	privateKeyInput, err := setup.PrivateKeyFromEnv()
+	if err != nil {
+		return "", nil, err
 	}
-	signer, err := xcFactory.NewSigner(chain, privateKeyInput)
+	signer, err := xcFactory.NewSigner(chain, privateKeyInput, options...)
 	if err != nil {
 		return "", nil, fmt.Errorf("could not import private key: %v", err)
 	}
@@ -12,8 +12,10 @@ import (
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
 	"github.com/cordialsys/crosschain/config/constants"
+	"github.com/cordialsys/crosschain/config/keystore"
 	vault "github.com/hashicorp/vault/api"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 	"google.golang.org/api/iterator"
 	"gopkg.in/yaml.v3"
 )
@@ -120,6 +122,30 @@ type VaultLoader interface {
 	LoadSecretData(path string) (*vault.Secret, error)
 }
 
+func expandHome(path string) string {
+	if len(path) > 1 && path[0] == '~' {
+		path = strings.Replace(path, "~", os.Getenv("HOME"), 1)
+	}
+	return path
+}
+
+func promptPassphrase(prompt string) (string, error) {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", errors.New("cannot prompt for passphrase as stdin is not a terminal")
+	}
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(passphrase), nil
+}
+
+// PromptPassphrase reads a passphrase from the terminal, for keystore secrets that do not reference one
+var PromptPassphrase = promptPassphrase
+
 // GetSecret returns a secret, e.g. from env variable. Extend as needed.
 func GetSecret(uri string) (string, error) {
 	value := uri
@@ -136,10 +162,7 @@ func GetSecret(uri string) (string, error) {
 		path := args[0]
 		return strings.TrimSpace(os.Getenv(path)), nil
 	case File:
-		path := args[0]
-		if len(path) > 1 && path[0] == '~' {
-			path = strings.Replace(path, "~", os.Getenv("HOME"), 1)
-		}
+		path := expandHome(args[0])
 		_, err := os.Stat(path)
 		if err != nil {
 			return "", err
@@ -231,6 +254,27 @@ func GetSecret(uri string) (string, error) {
 			}
 		}
 		return "", fmt.Errorf("could not find a gsm secret by name %s", name)
+	case Keystore:
+		// keystore:path[,passphrase secret], e.g. keystore:~/.cordial/keystore/dev.json,env:KEYSTORE_PASSPHRASE
+		path := expandHome(args[0])
+		ks, err := keystore.Load(path)
+		if err != nil {
+			return "", err
+		}
+		var passphrase string
+		if len(args) > 1 {
+			passphrase, err = GetSecret(strings.Join(args[1:], ","))
+		} else {
+			passphrase, err = PromptPassphrase(fmt.Sprintf("Passphrase for %s: ", path))
+		}
+		if err != nil {
+			return "", err
+		}
+		result, err := ks.Decrypt(passphrase)
+		if err != nil {
+			return "", fmt.Errorf("could not decrypt %s: %v", path, err)
+		}
+		return strings.TrimSpace(result), nil
 	case Raw:
 		return strings.Join(splits[1:], ":"), nil
 	}
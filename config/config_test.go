@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/cordialsys/crosschain/config/constants"
+	"github.com/cordialsys/crosschain/config/keystore"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 	vault "github.com/hashicorp/vault/api"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -225,6 +227,46 @@ func (s *CrosschainTestSuite) TestGetSecretFileTrimmed() {
 	require.Equal("MY SECRET", sec)
 }
 
+func (s *CrosschainTestSuite) TestGetSecretKeystore() {
+	require := s.Require()
+	keystore.ScryptN = ethkeystore.LightScryptN
+	keystore.ScryptP = ethkeystore.LightScryptP
+	defer func() {
+		keystore.ScryptN = ethkeystore.StandardScryptN
+		keystore.ScryptP = ethkeystore.StandardScryptP
+	}()
+
+	ks, err := keystore.Encrypt("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "hunter2", keystore.Metadata{Name: "dev"})
+	require.NoError(err)
+	path, err := ks.Save(s.T().TempDir())
+	require.NoError(err)
+
+	os.Setenv("XCTEST_PASSPHRASE", "hunter2")
+	defer os.Unsetenv("XCTEST_PASSPHRASE")
+	secret, err := GetSecret("keystore:" + path + ",env:XCTEST_PASSPHRASE")
+	require.NoError(err)
+	require.Equal("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", secret)
+
+	_, err = GetSecret("keystore:" + path + ",raw:hunter3")
+	require.ErrorContains(err, "could not decrypt")
+
+	// prompts if no passphrase is referenced
+	prompted := ""
+	PromptPassphrase = func(prompt string) (string, error) {
+		prompted = prompt
+		return "hunter2", nil
+	}
+	defer func() { PromptPassphrase = promptPassphrase }()
+	secret, err = GetSecret("keystore:" + path)
+	require.NoError(err)
+	require.Equal("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", secret)
+	require.Equal(fmt.Sprintf("Passphrase for %s: ", path), prompted)
+
+	_, err = GetSecret("keystore:/does/not/exist.json,raw:hunter2")
+	require.Error(err)
+	require.True(HasTypePrefix("keystore:" + path))
+}
+
 type TestHobby struct {
 	Type    string   `yaml:"type,omitempty"`
 	Actions []string `yaml:"actions"`
@@ -0,0 +1,178 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cordialsys/crosschain/config/constants"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/google/uuid"
+)
+
+// Scrypt parameters used when encrypting, the same defaults as geth
+var ScryptN = ethkeystore.StandardScryptN
+var ScryptP = ethkeystore.StandardScryptP
+
+// How the decrypted secret is encoded
+type Encoding string
+
+// Raw key bytes, as used by ethereum keystores.  The secret is returned in hex.
+var EncodingHex Encoding = "hex"
+
+// Any other secret, such as a mnemonic or a base58 key.
+var EncodingText Encoding = "text"
+
+// Metadata is stored in plaintext alongside the encrypted secret.
+type Metadata struct {
+	Name     string   `json:"name,omitempty"`
+	Chain    string   `json:"chain,omitempty"`
+	Address  string   `json:"address,omitempty"`
+	Encoding Encoding `json:"encoding,omitempty"`
+}
+
+// Keystore is an Ethereum V3 keystore (scrypt + AES-128-CTR), extended with metadata so it can hold keys for
+// any chain as well as mnemonics.  Keystores exported by geth or other ethereum wallets can be read as is.
+type Keystore struct {
+	Version int    `json:"version"`
+	Id      string `json:"id"`
+	// Ethereum address of the key, set by ethereum wallets
+	Address    string                 `json:"address,omitempty"`
+	Crypto     ethkeystore.CryptoJSON `json:"crypto"`
+	Crosschain Metadata               `json:"crosschain"`
+}
+
+// DefaultDir is where keystores are stored unless otherwise specified
+func DefaultDir() string {
+	return filepath.Join(constants.DefaultHome, "keystore")
+}
+
+// Encrypt a private key or mnemonic using the passphrase.  Keys in hex are stored as raw bytes, so they
+// remain compatible with ethereum wallets.
+func Encrypt(secret string, passphrase string, metadata Metadata) (*Keystore, error) {
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return nil, errors.New("secret is empty")
+	}
+	data := []byte(secret)
+	metadata.Encoding = EncodingText
+	if bz, err := hex.DecodeString(strings.TrimPrefix(secret, "0x")); err == nil {
+		data = bz
+		metadata.Encoding = EncodingHex
+	}
+	cryptoJson, err := ethkeystore.EncryptDataV3(data, []byte(passphrase), ScryptN, ScryptP)
+	if err != nil {
+		return nil, err
+	}
+	return &Keystore{
+		Version:    3,
+		Id:         uuid.NewString(),
+		Crypto:     cryptoJson,
+		Crosschain: metadata,
+	}, nil
+}
+
+// Decrypt the secret using the passphrase
+func (ks *Keystore) Decrypt(passphrase string) (string, error) {
+	if ks.Version != 3 {
+		return "", fmt.Errorf("unsupported keystore version %d", ks.Version)
+	}
+	data, err := ethkeystore.DecryptDataV3(ks.Crypto, passphrase)
+	if err != nil {
+		return "", err
+	}
+	if ks.Crosschain.Encoding == EncodingText {
+		return string(data), nil
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// Load a keystore file
+func Load(path string) (*Keystore, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{}
+	if err := json.Unmarshal(bz, ks); err != nil {
+		return nil, fmt.Errorf("invalid keystore %s: %v", path, err)
+	}
+	return ks, nil
+}
+
+// Path returns the path of a named keystore in the directory
+func Path(dir string, name string) string {
+	return filepath.Join(dir, name+".json")
+}
+
+// Save writes the keystore to the directory, using its name.  An existing keystore is never overwritten.
+func (ks *Keystore) Save(dir string) (string, error) {
+	if ks.Crosschain.Name == "" {
+		return "", errors.New("keystore must have a name")
+	}
+	if strings.ContainsAny(ks.Crosschain.Name, `/\`) {
+		return "", fmt.Errorf("invalid keystore name '%s'", ks.Crosschain.Name)
+	}
+	bz, err := json.MarshalIndent(ks, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	path := Path(dir, ks.Crosschain.Name)
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return "", fmt.Errorf("keystore '%s' already exists", ks.Crosschain.Name)
+		}
+		return "", err
+	}
+	defer file.Close()
+	if _, err := file.Write(bz); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// File is a keystore found in a directory
+type File struct {
+	Path string
+	*Keystore
+}
+
+// List the keystores in a directory, sorted by name.  A missing directory has no keystores.
+func List(dir string) ([]*File, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*File{}, nil
+		}
+		return nil, err
+	}
+	files := []*File{}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		ks, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if ks.Crosschain.Name == "" {
+			// e.g. imported from geth
+			ks.Crosschain.Name = strings.TrimSuffix(entry.Name(), ".json")
+		}
+		files = append(files, &File{path, ks})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Crosschain.Name < files[j].Crosschain.Name
+	})
+	return files, nil
+}
@@ -0,0 +1,120 @@
+package keystore_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cordialsys/crosschain/config/keystore"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	keystore.ScryptN = ethkeystore.LightScryptN
+	keystore.ScryptP = ethkeystore.LightScryptP
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	vectors := []struct {
+		name     string
+		secret   string
+		expected string
+		encoding keystore.Encoding
+	}{
+		{"hex", "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", keystore.EncodingHex},
+		{"hex_0x", "0x1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", keystore.EncodingHex},
+		{"base58", "4Z7cXSyeFR8wNGMVXUE1TwtKn5D5Vu7FzEv69dokLv7KrQk7h6pu4LF8ZRR9yQBhc7uSM6RTTZtU1fmaxiNrxXrs", "4Z7cXSyeFR8wNGMVXUE1TwtKn5D5Vu7FzEv69dokLv7KrQk7h6pu4LF8ZRR9yQBhc7uSM6RTTZtU1fmaxiNrxXrs", keystore.EncodingText},
+		{"mnemonic", " abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about\n", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", keystore.EncodingText},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			ks, err := keystore.Encrypt(v.secret, "hunter2", keystore.Metadata{Name: v.name})
+			require.NoError(t, err)
+			require.Equal(t, 3, ks.Version)
+			require.Equal(t, v.encoding, ks.Crosschain.Encoding)
+			require.NotContains(t, ks.Crypto.CipherText, v.expected)
+
+			secret, err := ks.Decrypt("hunter2")
+			require.NoError(t, err)
+			require.Equal(t, v.expected, secret)
+
+			_, err = ks.Decrypt("hunter3")
+			require.ErrorContains(t, err, "could not decrypt")
+		})
+	}
+	_, err := keystore.Encrypt("  ", "hunter2", keystore.Metadata{})
+	require.ErrorContains(t, err, "secret is empty")
+}
+
+func TestDecryptEthereumKeystore(t *testing.T) {
+	// test vector from the Web3 Secret Storage definition
+	keyJson := `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {"dklen": 32, "n": 262144, "r": 1, "p": 8, "salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`
+	dir := t.TempDir()
+	path := filepath.Join(dir, "geth.json")
+	require.NoError(t, os.WriteFile(path, []byte(keyJson), 0600))
+
+	ks, err := keystore.Load(path)
+	require.NoError(t, err)
+	secret, err := ks.Decrypt("testpassword")
+	require.NoError(t, err)
+	require.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", secret)
+}
+
+func TestSaveList(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keystore")
+
+	files, err := keystore.List(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	for _, name := range []string{"dev", "alice"} {
+		ks, err := keystore.Encrypt("abcd", "hunter2", keystore.Metadata{Name: name, Chain: "ETH", Address: "0x1234"})
+		require.NoError(t, err)
+		path, err := ks.Save(dir)
+		require.NoError(t, err)
+		require.Equal(t, keystore.Path(dir, name), path)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	ks, err := keystore.Encrypt("abcd", "hunter2", keystore.Metadata{Name: "dev"})
+	require.NoError(t, err)
+	_, err = ks.Save(dir)
+	require.ErrorContains(t, err, "keystore 'dev' already exists")
+	ks.Crosschain.Name = "../dev"
+	_, err = ks.Save(dir)
+	require.ErrorContains(t, err, "invalid keystore name")
+
+	// ignored
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hello"), 0600))
+
+	files, err = keystore.List(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	require.Equal(t, "alice", files[0].Crosschain.Name)
+	require.Equal(t, "dev", files[1].Crosschain.Name)
+	require.Equal(t, "ETH", files[1].Crosschain.Chain)
+	require.Equal(t, "0x1234", files[1].Crosschain.Address)
+
+	bz, err := os.ReadFile(files[1].Path)
+	require.NoError(t, err)
+	var parsed map[string]interface{}
+	require.NoError(t, json.Unmarshal(bz, &parsed))
+	require.EqualValues(t, 3, parsed["version"])
+	require.Contains(t, parsed, "crypto")
+}
@@ -37,6 +37,7 @@ import (
 	xclient "github.com/cordialsys/crosschain/client"
 	"github.com/cordialsys/crosschain/client/services"
 	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 )
 
 func NewClient(cfg ITask, driver Driver) (xclient.FullClient, error) {
@@ -99,6 +100,14 @@ func NewStakingClient(servicesConfig *services.ServicesConfig, cfg ITask, provid
 		return cosmosclient.NewClient(cfg)
 	case DriverSolana:
 		return solanaclient.NewClient(cfg)
+	case DriverTon:
+		return ton.NewClient(cfg)
+	case DriverSubstrate:
+		return substrate.NewClient(cfg)
+	case DriverSui:
+		return sui.NewClient(cfg)
+	case DriverTron:
+		return tron.NewClient(cfg)
 	}
 	return nil, fmt.Errorf("no staking client defined for %s on %s", provider, driver)
 }
@@ -133,9 +142,9 @@ func NewTxBuilder(cfg ITask) (xcbuilder.FullTransferBuilder, error) {
 	return nil, errors.New("no tx-builder defined for: " + string(cfg.ID()))
 }
 
-func NewSigner(cfg ITask, secret string) (*signer.Signer, error) {
+func NewSigner(cfg ITask, secret string, options ...hd.Option) (*signer.Signer, error) {
 	chain := cfg.GetChain()
-	return signer.New(chain.Driver, secret, chain)
+	return signer.New(chain.Driver, secret, chain, options...)
 }
 
 func NewAddressBuilder(cfg ITask) (AddressBuilder, error) {
@@ -11,9 +11,14 @@ import (
 	cosmostxinput "github.com/cordialsys/crosschain/chain/cosmos/tx_input"
 	remoteclient "github.com/cordialsys/crosschain/chain/crosschain"
 	solanatxinput "github.com/cordialsys/crosschain/chain/solana/tx_input"
+	"github.com/cordialsys/crosschain/client/cache"
+	"github.com/cordialsys/crosschain/client/failover"
 	"github.com/cordialsys/crosschain/config/constants"
 	"github.com/cordialsys/crosschain/factory"
 	"github.com/cordialsys/crosschain/factory/drivers"
+	"github.com/cordialsys/crosschain/instrumentation"
+	testtypes "github.com/cordialsys/crosschain/testutil/types"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/suite"
 )
@@ -96,6 +101,81 @@ func (s *CrosschainTestSuite) TestNewClient() {
 	require.ErrorContains(err, "no clients possible")
 }
 
+func (s *CrosschainTestSuite) TestNewClientWithMultipleEndpoints() {
+	require := s.Require()
+
+	chain := &xc.ChainConfig{
+		Chain:  xc.ETH,
+		Driver: xc.DriverEVM,
+		Clients: []*xc.ClientConfig{
+			{Driver: xc.DriverEVM, URL: "http://node1"},
+			{Driver: xc.DriverEVM, URL: "http://node2"},
+		},
+	}
+	client, err := s.Factory.NewClient(chain)
+	require.NoError(err)
+	failoverClient, ok := client.(*failover.Client)
+	require.True(ok)
+	require.Len(failoverClient.Endpoints(), 2)
+	require.Equal("http://node1", failoverClient.Endpoints()[0].Name)
+	require.Equal("http://node2", failoverClient.Endpoints()[1].Name)
+
+	// the chain config is not modified
+	require.Equal("", chain.URL)
+
+	// a single endpoint uses the driver directly
+	chain.Clients = chain.Clients[:1]
+	client, err = s.Factory.NewClient(chain)
+	require.NoError(err)
+	_, ok = client.(*failover.Client)
+	require.False(ok)
+}
+
+func (s *CrosschainTestSuite) TestNewClientWithInstrumentation() {
+	require := s.Require()
+	inst, err := instrumentation.New(prometheus.NewRegistry(), nil)
+	require.NoError(err)
+	s.Factory.Instrumentation = inst
+	defer func() { s.Factory.Instrumentation = nil }()
+
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, URL: "http://localhost"}
+	client, err := s.Factory.NewClient(chain)
+	require.NoError(err)
+	_, ok := client.(*instrumentation.LatestHeightClient)
+	require.True(ok)
+
+	txBuilder, err := s.Factory.NewTxBuilder(chain)
+	require.NoError(err)
+	_, ok = txBuilder.(*instrumentation.StakingTxBuilder)
+	require.True(ok)
+
+	// staking builders are still available
+	_, err = s.Factory.NewStakingTxBuilder(chain)
+	require.NoError(err)
+}
+
+func (s *CrosschainTestSuite) TestNewClientWithCache() {
+	require := s.Require()
+	s.Factory.Cache = cache.NewLRU(0)
+	defer func() { s.Factory.Cache = nil }()
+
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, URL: "http://localhost"}
+	client, err := s.Factory.NewClient(chain)
+	require.NoError(err)
+	_, ok := client.(*cache.LatestHeightClient)
+	require.True(ok)
+
+	// instrumentation records calls that are served from the cache
+	inst, err := instrumentation.New(prometheus.NewRegistry(), nil)
+	require.NoError(err)
+	s.Factory.Instrumentation = inst
+	defer func() { s.Factory.Instrumentation = nil }()
+	client, err = s.Factory.NewClient(chain)
+	require.NoError(err)
+	_, ok = client.(*instrumentation.LatestHeightClient).Unwrap().(*cache.LatestHeightClient)
+	require.True(ok)
+}
+
 func (s *CrosschainTestSuite) TestNewTxBuilder() {
 	require := s.Require()
 	for _, asset := range s.TestAssetConfigs {
@@ -153,6 +233,26 @@ func (s *CrosschainTestSuite) TestGetAllPossibleAddressesFromPublicKey() {
 	}
 }
 
+func (s *CrosschainTestSuite) TestValidateAddress() {
+	require := s.Require()
+	for _, asset := range s.TestAssetConfigs {
+		result, err := s.Factory.ValidateAddress(asset, "")
+		require.NoError(err)
+		require.False(result.Valid, "empty address is valid on "+asset.GetChain().Chain)
+		require.NotEmpty(result.Reason)
+	}
+
+	eth, _ := s.Factory.GetAssetConfig("", "ETH")
+	result, err := s.Factory.ValidateAddress(eth, "0x5891906fef64a5ae924c7fc5ed48c0f64a55fce1")
+	require.NoError(err)
+	require.True(result.Valid)
+	require.Equal(xc.Address("0x5891906fEf64A5ae924C7Fc5ed48c0F64a55fCe1"), result.Normalized)
+
+	asset, _ := s.Factory.PutAssetConfig(&xc.ChainConfig{Chain: "TEST"})
+	_, err = s.Factory.ValidateAddress(asset, "")
+	require.ErrorContains(err, "no address builder defined for")
+}
+
 // MustObject functions
 
 func (s *CrosschainTestSuite) TestMustAmountBlockchain() {
@@ -422,6 +522,45 @@ func (s *CrosschainTestSuite) TestGetAssetConfigByContract() {
 	require.Equal("", asset.Asset)
 }
 
+func (s *CrosschainTestSuite) TestGetAssetConfigByContractDiscovery() {
+	require := s.Require()
+	server, close := testtypes.MockJSONRPC(s.T(), []string{
+		`"0x0000000000000000000000000000000000000000000000000000000000000006"`,
+		`"0x000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000045553444300000000000000000000000000000000000000000000000000000000"`,
+		`"0x0000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000000855534420436f696e000000000000000000000000000000000000000000000000"`,
+	})
+	defer close()
+
+	ethI, _ := s.Factory.AllAssets.Load(xc.AssetID("ETH"))
+	defer s.Factory.AllAssets.Store(xc.AssetID("ETH"), ethI)
+	eth := *ethI.(*xc.ChainConfig)
+	eth.URL = server.URL
+	eth.Clients = nil
+	s.Factory.AllAssets.Store(xc.AssetID("ETH"), &eth)
+
+	contract := "0x00000000000000000000000000000000000000aa"
+	_, err := s.Factory.GetAssetConfigByContract(contract, "ETH")
+	require.EqualError(err, "unknown contract: '"+contract+"'")
+
+	s.Factory.DiscoverTokens = true
+	defer func() { s.Factory.DiscoverTokens = false }()
+	// looked up once, and then reused
+	for i := 0; i < 2; i++ {
+		assetI, err := s.Factory.GetAssetConfigByContract(contract, "ETH")
+		require.NoError(err)
+		asset := assetI.(*xc.TokenAssetConfig)
+		require.Equal("USDC", asset.Asset)
+		require.EqualValues(6, asset.Decimals)
+		require.Equal(contract, asset.Contract)
+		require.Equal(xc.ETH, asset.GetChain().Chain)
+	}
+	require.Equal(3, server.Counter)
+
+	// a discovered token does not replace a configured one
+	_, err = s.Factory.GetAssetConfig("USDC", "ETH")
+	require.NoError(err)
+}
+
 func (s *CrosschainTestSuite) TestPutAssetConfig() {
 	require := s.Require()
 	assetName := "TEST"
@@ -0,0 +1,65 @@
+package factory
+
+import (
+	"fmt"
+
+	. "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/failover"
+	"github.com/cordialsys/crosschain/config"
+	"github.com/cordialsys/crosschain/factory/drivers"
+)
+
+// Returns the configured clients that use the given driver and have their own URL.
+func endpointsForDriver(clients []*ClientConfig, driver Driver) []*ClientConfig {
+	endpoints := []*ClientConfig{}
+	for _, client := range clients {
+		if client.Driver == driver && client.URL != "" {
+			endpoints = append(endpoints, client)
+		}
+	}
+	return endpoints
+}
+
+// Copy the asset, pointing it at a different node.
+func withEndpoint(cfg ITask, endpoint *ClientConfig) (ITask, error) {
+	chain := *cfg.GetChain()
+	chain.URL = endpoint.URL
+	if endpoint.Auth != "" {
+		secret, err := config.GetSecret(endpoint.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("could not access secret for %s: %v", endpoint.URL, err)
+		}
+		chain.Auth = endpoint.Auth
+		chain.AuthSecret = secret
+	}
+	switch asset := cfg.(type) {
+	case *ChainConfig:
+		return &chain, nil
+	case *TokenAssetConfig:
+		token := *asset
+		token.ChainConfig = &chain
+		return &token, nil
+	default:
+		return nil, fmt.Errorf("cannot create client for multiple endpoints for %T", cfg)
+	}
+}
+
+// Creates a client that fails over between multiple nodes for the same driver.
+func newFailoverClient(cfg ITask, driver Driver, endpoints []*ClientConfig) (xclient.Client, error) {
+	failoverEndpoints := []*failover.Endpoint{}
+	for _, endpoint := range endpoints {
+		endpointCfg, err := withEndpoint(cfg, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		client, err := drivers.NewClient(endpointCfg, driver)
+		if err != nil {
+			return nil, err
+		}
+		failoverEndpoints = append(failoverEndpoints, failover.NewEndpoint(endpoint.URL, client))
+	}
+	return failover.NewClient(failoverEndpoints, func(err error) xclient.ClientError {
+		return drivers.CheckError(driver, err)
+	})
+}
@@ -10,10 +10,13 @@ import (
 	"github.com/cordialsys/crosschain/builder"
 	remoteclient "github.com/cordialsys/crosschain/chain/crosschain"
 	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/client/cache"
 	"github.com/cordialsys/crosschain/client/services"
 	"github.com/cordialsys/crosschain/factory/config"
 	"github.com/cordialsys/crosschain/factory/drivers"
 	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/cordialsys/crosschain/instrumentation"
 	"github.com/cordialsys/crosschain/normalize"
 )
 
@@ -21,7 +24,7 @@ import (
 type FactoryContext interface {
 	NewClient(asset ITask) (xclient.Client, error)
 	NewTxBuilder(asset ITask) (builder.FullTransferBuilder, error)
-	NewSigner(asset ITask, secret string) (*signer.Signer, error)
+	NewSigner(asset ITask, secret string, options ...hd.Option) (*signer.Signer, error)
 	NewAddressBuilder(asset ITask) (AddressBuilder, error)
 
 	MarshalTxInput(input TxInput) ([]byte, error)
@@ -29,6 +32,7 @@ type FactoryContext interface {
 
 	GetAddressFromPublicKey(asset ITask, publicKey []byte) (Address, error)
 	GetAllPossibleAddressesFromPublicKey(asset ITask, publicKey []byte) ([]PossibleAddress, error)
+	ValidateAddress(asset ITask, address Address) (*AddressValidation, error)
 
 	MustAmountBlockchain(asset ITask, humanAmountStr string) AmountBlockchain
 	MustAddress(asset ITask, addressStr string) Address
@@ -70,6 +74,10 @@ type Factory struct {
 	callbackGetAssetConfigByContract func(contract string, nativeAsset NativeAsset) (ITask, error)
 	NoXcClients                      bool
 	Config                           *config.Config
+	Instrumentation                  *instrumentation.Instrumentation
+	Cache                            cache.Cache
+	DiscoverTokens                   bool
+	discoveredTokens                 sync.Map
 }
 
 var _ FactoryContext = &Factory{}
@@ -146,12 +154,23 @@ func (f *Factory) cfgFromAssetByContract(contract string, nativeAsset NativeAsse
 	})
 	if res != nil {
 		return f.cfgFromAsset(res.ID())
-	} else {
-		if f.callbackGetAssetConfigByContract != nil {
-			return f.callbackGetAssetConfigByContract(contract, nativeAsset)
+	}
+	err := fmt.Errorf("unknown contract: '%s'", contract)
+	if f.callbackGetAssetConfigByContract != nil {
+		var cfg ITask
+		cfg, err = f.callbackGetAssetConfigByContract(contract, nativeAsset)
+		if err == nil || !f.DiscoverTokens {
+			return cfg, err
 		}
 	}
-	return &TokenAssetConfig{}, fmt.Errorf("unknown contract: '%s'", contract)
+	if f.DiscoverTokens {
+		cfg, err := f.discoverToken(contract, nativeAsset)
+		if err != nil {
+			return &TokenAssetConfig{}, err
+		}
+		return cfg, nil
+	}
+	return &TokenAssetConfig{}, err
 }
 
 func (f *Factory) enrichTask(task *TaskConfig, srcAssetID AssetID, dstAssetID AssetID) (*TaskConfig, error) {
@@ -343,6 +362,20 @@ func (f *Factory) cfgEnrichDestinations(activity ITask, txInfo LegacyTxInfo) (Le
 
 // NewClient creates a new Client
 func (f *Factory) NewClient(cfg ITask) (xclient.Client, error) {
+	client, err := f.newClient(cfg)
+	if err != nil {
+		return client, err
+	}
+	if f.Cache != nil {
+		client = cache.WrapClient(cfg, client, f.Cache)
+	}
+	if f.Instrumentation != nil {
+		client = f.Instrumentation.WrapClient(cfg, client, f.checkErrorFunc(cfg))
+	}
+	return client, nil
+}
+
+func (f *Factory) newClient(cfg ITask) (xclient.Client, error) {
 	nativeAsset := cfg.GetChain()
 	clients := nativeAsset.GetAllClients()
 	if f.NoXcClients {
@@ -354,6 +387,10 @@ func (f *Factory) NewClient(cfg ITask) (xclient.Client, error) {
 		case DriverCrosschain:
 			return remoteclient.NewClient(cfg, client.Auth)
 		default:
+			endpoints := endpointsForDriver(clients, client.Driver)
+			if len(endpoints) > 1 {
+				return newFailoverClient(cfg, Driver(client.Driver), endpoints)
+			}
 			return drivers.NewClient(cfg, Driver(client.Driver))
 		}
 	}
@@ -361,6 +398,14 @@ func (f *Factory) NewClient(cfg ITask) (xclient.Client, error) {
 }
 
 func (f *Factory) NewStakingClient(stakingCfg *services.ServicesConfig, cfg ITask, provider StakingProvider) (xclient.StakingClient, error) {
+	client, err := f.newStakingClient(stakingCfg, cfg, provider)
+	if err != nil || f.Instrumentation == nil {
+		return client, err
+	}
+	return f.Instrumentation.WrapStakingClient(cfg, client, f.checkErrorFunc(cfg)), nil
+}
+
+func (f *Factory) newStakingClient(stakingCfg *services.ServicesConfig, cfg ITask, provider StakingProvider) (xclient.StakingClient, error) {
 	if !f.NoXcClients {
 		clients := cfg.GetChain().GetAllClients()
 		for _, client := range clients {
@@ -375,7 +420,18 @@ func (f *Factory) NewStakingClient(stakingCfg *services.ServicesConfig, cfg ITas
 
 // NewTxBuilder creates a new TxBuilder
 func (f *Factory) NewTxBuilder(cfg ITask) (builder.FullTransferBuilder, error) {
-	return drivers.NewTxBuilder(cfg)
+	txBuilder, err := drivers.NewTxBuilder(cfg)
+	if err != nil || f.Instrumentation == nil {
+		return txBuilder, err
+	}
+	return f.Instrumentation.WrapTxBuilder(cfg, txBuilder, f.checkErrorFunc(cfg)), nil
+}
+
+func (f *Factory) checkErrorFunc(cfg ITask) instrumentation.CheckErrorFunc {
+	driver := cfg.GetChain().Driver
+	return func(err error) xclient.ClientError {
+		return drivers.CheckError(driver, err)
+	}
 }
 
 func (f *Factory) NewStakingTxBuilder(cfg ITask) (builder.Staking, error) {
@@ -390,9 +446,9 @@ func (f *Factory) NewStakingTxBuilder(cfg ITask) (builder.Staking, error) {
 	return stakingBuilder, nil
 }
 
-// NewSigner creates a new Signer
-func (f *Factory) NewSigner(cfg ITask, secret string) (*signer.Signer, error) {
-	return drivers.NewSigner(cfg, secret)
+// NewSigner creates a new Signer.  The options set how keys are derived from mnemonics.
+func (f *Factory) NewSigner(cfg ITask, secret string, options ...hd.Option) (*signer.Signer, error) {
+	return drivers.NewSigner(cfg, secret, options...)
 }
 
 // NewAddressBuilder creates a new AddressBuilder
@@ -514,6 +570,15 @@ func (f *Factory) GetConfig() config.Config {
 	return *f.Config
 }
 
+// ValidateAddress checks that an address is valid for the chain, and returns its normalized form
+func (f *Factory) ValidateAddress(cfg ITask, address Address) (*AddressValidation, error) {
+	builder, err := drivers.NewAddressBuilder(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return builder.ValidateAddress(address), nil
+}
+
 // MustAddress coverts a string to Address, panic if error
 func (f *Factory) MustAddress(cfg ITask, addressStr string) Address {
 	return Address(addressStr)
@@ -6,9 +6,11 @@ import (
 	"sync"
 
 	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/client/cache"
 	"github.com/cordialsys/crosschain/config"
 	factoryconfig "github.com/cordialsys/crosschain/factory/config"
 	"github.com/cordialsys/crosschain/factory/defaults"
+	"github.com/cordialsys/crosschain/instrumentation"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,6 +19,12 @@ type FactoryOptions struct {
 	UseDisabledChains bool
 	// do not use xc clients, only use native clients
 	NoXcClients bool
+	// record metrics and traces for clients and builders created by the factory
+	Instrumentation *instrumentation.Instrumentation
+	// cache finalized transactions and static chain data for clients created by the factory
+	Cache cache.Cache
+	// look up the metadata of token contracts that aren't configured
+	DiscoverTokens bool
 }
 
 func NewFactory(options *FactoryOptions) *Factory {
@@ -84,11 +92,14 @@ func NewDefaultFactoryWithConfig(cfg *factoryconfig.Config, options *FactoryOpti
 	}
 
 	factory := &Factory{
-		AllAssets:    &sync.Map{},
-		AllTasks:     cfg.GetTasks(),
-		AllPipelines: cfg.GetPipelines(),
-		NoXcClients:  options.NoXcClients,
-		Config:       cfg,
+		AllAssets:       &sync.Map{},
+		AllTasks:        cfg.GetTasks(),
+		AllPipelines:    cfg.GetPipelines(),
+		NoXcClients:     options.NoXcClients,
+		Config:          cfg,
+		Instrumentation: options.Instrumentation,
+		Cache:           options.Cache,
+		DiscoverTokens:  options.DiscoverTokens,
 	}
 	for _, asset := range assetsList {
 		disabled := asset.GetChain().Disabled
@@ -0,0 +1,107 @@
+package hardware
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Most payloads need to be split over several APDUs
+const MaxChunkSize = 255
+
+// Status words returned by devices
+const (
+	StatusOk              uint16 = 0x9000
+	StatusRejected        uint16 = 0x6985
+	StatusInvalidData     uint16 = 0x6a80
+	StatusInsNotSupported uint16 = 0x6d00
+	StatusClaNotSupported uint16 = 0x6e00
+)
+
+var statusMessages = map[uint16]string{
+	StatusRejected:        "rejected on the device",
+	StatusInvalidData:     "invalid data",
+	StatusInsNotSupported: "instruction not supported, is the right app open?",
+	StatusClaNotSupported: "class not supported, is the right app open?",
+}
+
+// StatusError is returned when a device responds with a status other than StatusOk
+type StatusError struct {
+	Status uint16
+}
+
+func (err *StatusError) Error() string {
+	if message, ok := statusMessages[err.Status]; ok {
+		return fmt.Sprintf("device returned status 0x%04x: %s", err.Status, message)
+	}
+	return fmt.Sprintf("device returned status 0x%04x", err.Status)
+}
+
+// Transport exchanges APDUs with a device, e.g. over USB HID, bluetooth, or the Emulator.
+// The response includes the 2 byte status word at the end.
+type Transport interface {
+	Exchange(apdu []byte) ([]byte, error)
+}
+
+// APDU is a command sent to a device
+type APDU struct {
+	CLA  byte
+	INS  byte
+	P1   byte
+	P2   byte
+	Data []byte
+}
+
+// Encode the APDU as CLA INS P1 P2 Lc Data
+func (apdu APDU) Encode() ([]byte, error) {
+	if len(apdu.Data) > MaxChunkSize {
+		return nil, fmt.Errorf("apdu data is %d bytes, more than the max of %d", len(apdu.Data), MaxChunkSize)
+	}
+	encoded := []byte{apdu.CLA, apdu.INS, apdu.P1, apdu.P2, byte(len(apdu.Data))}
+	return append(encoded, apdu.Data...), nil
+}
+
+// DecodeAPDU decodes an APDU, as a device would
+func DecodeAPDU(bz []byte) (APDU, error) {
+	if len(bz) < 5 {
+		return APDU{}, errors.New("apdu is too short")
+	}
+	if int(bz[4]) != len(bz)-5 {
+		return APDU{}, fmt.Errorf("apdu has length %d, but %d bytes of data", bz[4], len(bz)-5)
+	}
+	return APDU{bz[0], bz[1], bz[2], bz[3], bz[5:]}, nil
+}
+
+// Exchange sends each APDU in turn, returning the response data of the last one
+func Exchange(transport Transport, apdus []APDU) ([]byte, error) {
+	var data []byte
+	for _, apdu := range apdus {
+		encoded, err := apdu.Encode()
+		if err != nil {
+			return nil, err
+		}
+		response, err := transport.Exchange(encoded)
+		if err != nil {
+			return nil, err
+		}
+		if len(response) < 2 {
+			return nil, errors.New("device response is missing the status")
+		}
+		status := binary.BigEndian.Uint16(response[len(response)-2:])
+		if status != StatusOk {
+			return nil, &StatusError{status}
+		}
+		data = response[:len(response)-2]
+	}
+	return data, nil
+}
+
+// chunks splits the data into pieces of at most size
+func chunks(data []byte, size int) [][]byte {
+	result := [][]byte{}
+	for len(data) > size {
+		result = append(result, data[:size])
+		data = data[size:]
+	}
+	return append(result, data)
+}
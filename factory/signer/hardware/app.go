@@ -0,0 +1,61 @@
+package hardware
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+)
+
+// App encodes the APDUs of a device app for a chain
+type App interface {
+	// GetPublicKey returns the APDUs to fetch the public key at the path, without confirming on the device
+	GetPublicKey(path hd.Path) ([]APDU, error)
+	ParsePublicKey(response []byte) ([]byte, error)
+	// Sign returns the APDUs to display and sign the payload with the key at the path
+	Sign(path hd.Path, payload xc.TxDataToDisplay) ([]APDU, error)
+	// ParseSignature returns r || s for secp256k1, or the ed25519 signature
+	ParseSignature(response []byte) ([]byte, error)
+}
+
+// NewApp returns the app used to sign for the chain
+func NewApp(chain *xc.ChainConfig) (App, error) {
+	switch chain.Driver {
+	case xc.DriverEVM, xc.DriverEVMLegacy:
+		return &EthereumApp{}, nil
+	case xc.DriverSolana:
+		return &SolanaApp{}, nil
+	case xc.DriverCosmos:
+		return &CosmosApp{Prefix: chain.ChainPrefix}, nil
+	case xc.DriverBitcoin:
+		return &BitcoinApp{}, nil
+	default:
+		return nil, fmt.Errorf("no hardware app for driver %s", chain.Driver)
+	}
+}
+
+// encodePath encodes the path as its length followed by each index in big endian
+func encodePath(path hd.Path) ([]byte, error) {
+	if len(path) > 10 {
+		return nil, fmt.Errorf("derivation path %s is too long", path)
+	}
+	encoded := []byte{byte(len(path))}
+	for _, index := range path {
+		encoded = binary.BigEndian.AppendUint32(encoded, index)
+	}
+	return encoded, nil
+}
+
+// decodePath decodes a path from encodePath, returning the remaining data
+func decodePath(data []byte) (hd.Path, []byte, error) {
+	if len(data) < 1 || len(data) < 1+4*int(data[0]) {
+		return nil, nil, errors.New("invalid derivation path")
+	}
+	path := hd.Path{}
+	for i := 0; i < int(data[0]); i++ {
+		path = append(path, binary.BigEndian.Uint32(data[1+4*i:]))
+	}
+	return path, data[1+4*int(data[0]):], nil
+}
@@ -0,0 +1,87 @@
+package hardware
+
+import (
+	"errors"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+)
+
+const (
+	bitcoinCLA                  byte = 0xe1
+	bitcoinInsGetExtendedPubkey byte = 0x00
+)
+
+// The bitcoin app signs PSBTs (v2), which needs the full previous transactions of legacy inputs
+var ErrBitcoinSigning = errors.New("signing bitcoin txs on a device is not supported, as the bitcoin app requires PSBT v2")
+
+// BitcoinApp reads the public key from the extended public key at the path, using the bitcoin app's
+// GET_EXTENDED_PUBKEY command.  Signing is not supported, see ErrBitcoinSigning.
+type BitcoinApp struct{}
+
+var _ App = &BitcoinApp{}
+
+func (app *BitcoinApp) GetPublicKey(path hd.Path) ([]APDU, error) {
+	encodedPath, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	// the first byte is whether to display the key
+	data := append([]byte{0x00}, encodedPath...)
+	return []APDU{{bitcoinCLA, bitcoinInsGetExtendedPubkey, 0x00, 0x00, data}}, nil
+}
+
+// ParsePublicKey parses an extended public key, e.g. "xpub..."
+func (app *BitcoinApp) ParsePublicKey(response []byte) ([]byte, error) {
+	key, err := hdkeychain.NewKeyFromString(string(response))
+	if err != nil {
+		return nil, errors.New("invalid public key response")
+	}
+	publicKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return publicKey.SerializeCompressed(), nil
+}
+
+func (app *BitcoinApp) Sign(path hd.Path, payload xc.TxDataToDisplay) ([]APDU, error) {
+	return nil, ErrBitcoinSigning
+}
+
+func (app *BitcoinApp) ParseSignature(response []byte) ([]byte, error) {
+	return nil, ErrBitcoinSigning
+}
+
+func (e *Emulator) emulateBitcoin(apdu APDU) ([]byte, uint16) {
+	if apdu.CLA != bitcoinCLA {
+		return nil, StatusClaNotSupported
+	}
+	switch apdu.INS {
+	case bitcoinInsGetExtendedPubkey:
+		if len(apdu.Data) < 1 {
+			return nil, StatusInvalidData
+		}
+		path, _, err := decodePath(apdu.Data[1:])
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		key, err := hdkeychain.NewMaster(e.seed, e.params)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		for _, index := range path {
+			key, err = key.Derive(index)
+			if err != nil {
+				return nil, StatusInvalidData
+			}
+		}
+		xpub, err := key.Neuter()
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		return []byte(xpub.String()), StatusOk
+	default:
+		return nil, StatusInsNotSupported
+	}
+}
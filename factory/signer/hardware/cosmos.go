@@ -0,0 +1,110 @@
+package hardware
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	cosmosCLA              byte = 0x55
+	cosmosInsGetAddress    byte = 0x04
+	cosmosPathLen               = 5
+	cosmosCompressedKeyLen      = 33
+)
+
+// The cosmos app only parses amino-JSON sign docs, so it can't sign the protobuf (SIGN_MODE_DIRECT) sign docs
+// that cosmos txs are built with.
+var ErrCosmosSigning = errors.New("signing cosmos txs on a device is not supported, as the cosmos app only signs amino-JSON sign docs but cosmos txs use SIGN_MODE_DIRECT")
+
+// CosmosApp reads public keys from the cosmos app, which always uses a path of 5 indexes, encoded in little
+// endian.  Signing is not supported, see ErrCosmosSigning.
+type CosmosApp struct {
+	// bech32 prefix of the chain's addresses
+	Prefix string
+}
+
+var _ App = &CosmosApp{}
+
+func encodeCosmosPath(path hd.Path) ([]byte, error) {
+	if len(path) != cosmosPathLen {
+		return nil, fmt.Errorf("cosmos app requires a path with %d indexes, not %s", cosmosPathLen, path)
+	}
+	encoded := []byte{}
+	for _, index := range path {
+		encoded = binary.LittleEndian.AppendUint32(encoded, index)
+	}
+	return encoded, nil
+}
+
+func decodeCosmosPath(data []byte) (hd.Path, []byte, error) {
+	if len(data) < 4*cosmosPathLen {
+		return nil, nil, errors.New("invalid derivation path")
+	}
+	path := hd.Path{}
+	for i := 0; i < cosmosPathLen; i++ {
+		path = append(path, binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return path, data[4*cosmosPathLen:], nil
+}
+
+func (app *CosmosApp) GetPublicKey(path hd.Path) ([]APDU, error) {
+	encodedPath, err := encodeCosmosPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data := append([]byte{byte(len(app.Prefix))}, app.Prefix...)
+	data = append(data, encodedPath...)
+	return []APDU{{cosmosCLA, cosmosInsGetAddress, 0x00, 0x00, data}}, nil
+}
+
+// ParsePublicKey parses a response of the compressed pubkey || bech32 address
+func (app *CosmosApp) ParsePublicKey(response []byte) ([]byte, error) {
+	if len(response) < cosmosCompressedKeyLen {
+		return nil, errors.New("invalid public key response")
+	}
+	return response[:cosmosCompressedKeyLen], nil
+}
+
+func (app *CosmosApp) Sign(path hd.Path, payload xc.TxDataToDisplay) ([]APDU, error) {
+	return nil, ErrCosmosSigning
+}
+
+func (app *CosmosApp) ParseSignature(response []byte) ([]byte, error) {
+	return nil, ErrCosmosSigning
+}
+
+func (e *Emulator) emulateCosmos(apdu APDU) ([]byte, uint16) {
+	if apdu.CLA != cosmosCLA {
+		return nil, StatusClaNotSupported
+	}
+	switch apdu.INS {
+	case cosmosInsGetAddress:
+		if len(apdu.Data) < 1 || len(apdu.Data) < 1+int(apdu.Data[0]) {
+			return nil, StatusInvalidData
+		}
+		prefix := string(apdu.Data[1 : 1+apdu.Data[0]])
+		path, _, err := decodeCosmosPath(apdu.Data[1+apdu.Data[0]:])
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		key, err := e.secp256k1(path)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		publicKey := crypto.CompressPubkey(&key.PublicKey)
+		address, err := bech32.ConvertAndEncode(prefix, btcutil.Hash160(publicKey))
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		return append(publicKey, address...), StatusOk
+	default:
+		return nil, StatusInsNotSupported
+	}
+}
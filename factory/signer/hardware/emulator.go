@@ -0,0 +1,101 @@
+package hardware
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"encoding/binary"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	xc "github.com/cordialsys/crosschain"
+	bitcoinparams "github.com/cordialsys/crosschain/chain/bitcoin/params"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Emulator is a Transport that emulates a device with the app for a chain open, so signing flows can be
+// tested without a device.  Keys are derived from a mnemonic, like a device.
+type Emulator struct {
+	driver xc.Driver
+	seed   []byte
+	params *chaincfg.Params
+	// Approve is called with each payload before it's signed, to emulate confirming it on the device.
+	// Everything is approved if not set.
+	Approve func(payload []byte) bool
+	// state of a payload sent over several APDUs
+	path    hd.Path
+	pending []byte
+}
+
+var _ Transport = &Emulator{}
+
+// NewEmulator creates an emulator for the chain, with a BIP-39 mnemonic and optional passphrase
+func NewEmulator(chain *xc.ChainConfig, mnemonic string, passphrase string) (*Emulator, error) {
+	if _, err := NewApp(chain); err != nil {
+		return nil, err
+	}
+	seed, err := hd.NewSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	params := &chaincfg.MainNetParams
+	if chain.Driver == xc.DriverBitcoin {
+		params, err = bitcoinparams.GetParams(chain)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Emulator{driver: chain.Driver, seed: seed, params: params}, nil
+}
+
+// Exchange handles an APDU, returning the response data followed by the status word
+func (e *Emulator) Exchange(encoded []byte) ([]byte, error) {
+	var data []byte
+	var status uint16
+	apdu, err := DecodeAPDU(encoded)
+	if err != nil {
+		status = StatusInvalidData
+	} else {
+		switch e.driver {
+		case xc.DriverEVM, xc.DriverEVMLegacy:
+			data, status = e.emulateEthereum(apdu)
+		case xc.DriverSolana:
+			data, status = e.emulateSolana(apdu)
+		case xc.DriverCosmos:
+			data, status = e.emulateCosmos(apdu)
+		case xc.DriverBitcoin:
+			data, status = e.emulateBitcoin(apdu)
+		default:
+			status = StatusClaNotSupported
+		}
+	}
+	if status != StatusOk {
+		// like a device, an error aborts a payload being sent
+		e.reset()
+	}
+	return binary.BigEndian.AppendUint16(data, status), nil
+}
+
+func (e *Emulator) reset() {
+	e.path = nil
+	e.pending = nil
+}
+
+func (e *Emulator) approve(payload []byte) bool {
+	return e.Approve == nil || e.Approve(payload)
+}
+
+func (e *Emulator) secp256k1(path hd.Path) (*ecdsa.PrivateKey, error) {
+	key, err := hd.DeriveSecp256k1(e.seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.ToECDSA(key)
+}
+
+func (e *Emulator) ed25519(path hd.Path) (ed25519.PrivateKey, error) {
+	key, err := hd.DeriveEd25519(e.seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return ed25519.NewKeyFromSeed(key), nil
+}
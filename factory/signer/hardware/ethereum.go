@@ -0,0 +1,148 @@
+package hardware
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+const (
+	ethereumCLA            byte = 0xe0
+	ethereumInsGetAddress  byte = 0x02
+	ethereumInsSign        byte = 0x04
+	ethereumP1FirstChunk   byte = 0x00
+	ethereumP1NextChunk    byte = 0x80
+	ethereumPublicKeyLen        = 65
+	ethereumSignatureLen        = 65
+	ethereumAddressHexLen       = 40
+	ethereumTypedTxMaxType byte = 0x7f
+)
+
+// EthereumApp signs the unsigned RLP of a tx, which the app parses to display
+type EthereumApp struct{}
+
+var _ App = &EthereumApp{}
+
+func (app *EthereumApp) GetPublicKey(path hd.Path) ([]APDU, error) {
+	encodedPath, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return []APDU{{ethereumCLA, ethereumInsGetAddress, 0x00, 0x00, encodedPath}}, nil
+}
+
+// ParsePublicKey parses a response of pubkey length || pubkey || address length || address
+func (app *EthereumApp) ParsePublicKey(response []byte) ([]byte, error) {
+	if len(response) < 1+ethereumPublicKeyLen || response[0] != ethereumPublicKeyLen {
+		return nil, errors.New("invalid public key response")
+	}
+	return response[1 : 1+ethereumPublicKeyLen], nil
+}
+
+func (app *EthereumApp) Sign(path hd.Path, payload xc.TxDataToDisplay) ([]APDU, error) {
+	encodedPath, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	apdus := []APDU{}
+	for i, chunk := range chunks(append(encodedPath, payload...), MaxChunkSize) {
+		p1 := ethereumP1NextChunk
+		if i == 0 {
+			p1 = ethereumP1FirstChunk
+		}
+		apdus = append(apdus, APDU{ethereumCLA, ethereumInsSign, p1, 0x00, chunk})
+	}
+	return apdus, nil
+}
+
+// ParseSignature parses a response of v || r || s.  The signer recovers v itself, as the app encodes it
+// differently for legacy txs.
+func (app *EthereumApp) ParseSignature(response []byte) ([]byte, error) {
+	if len(response) != ethereumSignatureLen {
+		return nil, errors.New("invalid signature response")
+	}
+	return response[1:], nil
+}
+
+// ethereumPayloadComplete checks if the whole tx has been received, as the app only knows from the RLP
+func ethereumPayloadComplete(payload []byte) (bool, error) {
+	if len(payload) > 0 && payload[0] <= ethereumTypedTxMaxType {
+		payload = payload[1:]
+	}
+	_, rest, err := rlp.SplitList(payload)
+	if err == rlp.ErrValueTooLarge || err == io.ErrUnexpectedEOF || len(payload) == 0 {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(rest) > 0 {
+		return false, errors.New("trailing data after tx")
+	}
+	return true, nil
+}
+
+func (e *Emulator) emulateEthereum(apdu APDU) ([]byte, uint16) {
+	if apdu.CLA != ethereumCLA {
+		return nil, StatusClaNotSupported
+	}
+	switch apdu.INS {
+	case ethereumInsGetAddress:
+		path, _, err := decodePath(apdu.Data)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		key, err := e.secp256k1(path)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		publicKey := crypto.FromECDSAPub(&key.PublicKey)
+		address := strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex()[2:])
+		response := append([]byte{ethereumPublicKeyLen}, publicKey...)
+		response = append(response, ethereumAddressHexLen)
+		return append(response, address...), StatusOk
+	case ethereumInsSign:
+		if apdu.P1 == ethereumP1FirstChunk {
+			path, rest, err := decodePath(apdu.Data)
+			if err != nil {
+				return nil, StatusInvalidData
+			}
+			e.path = path
+			e.pending = append([]byte{}, rest...)
+		} else if apdu.P1 == ethereumP1NextChunk && e.path != nil {
+			e.pending = append(e.pending, apdu.Data...)
+		} else {
+			return nil, StatusInvalidData
+		}
+		complete, err := ethereumPayloadComplete(e.pending)
+		if err != nil {
+			e.reset()
+			return nil, StatusInvalidData
+		}
+		if !complete {
+			return nil, StatusOk
+		}
+		path, payload := e.path, e.pending
+		e.reset()
+		if !e.approve(payload) {
+			return nil, StatusRejected
+		}
+		key, err := e.secp256k1(path)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		signature, err := crypto.Sign(crypto.Keccak256(payload), key)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		// v || r || s
+		return append([]byte{signature[64]}, signature[:64]...), StatusOk
+	default:
+		return nil, StatusInsNotSupported
+	}
+}
@@ -0,0 +1,262 @@
+package hardware_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/chain/bitcoin"
+	bitcointx "github.com/cordialsys/crosschain/chain/bitcoin/tx"
+	"github.com/cordialsys/crosschain/chain/bitcoin/tx_input"
+	cosmostx "github.com/cordialsys/crosschain/chain/cosmos/tx"
+	evmtx "github.com/cordialsys/crosschain/chain/evm/tx"
+	solanatx "github.com/cordialsys/crosschain/chain/solana/tx"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hardware"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/programs/system"
+	"github.com/stretchr/testify/require"
+)
+
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+var (
+	eth  = &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}
+	sol  = &xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}
+	atom = &xc.ChainConfig{Chain: xc.ATOM, Driver: xc.DriverCosmos, ChainPrefix: "cosmos"}
+	btc  = &xc.ChainConfig{Chain: xc.BTC, Driver: xc.DriverBitcoin, Net: "testnet"}
+)
+
+func newSigners(t *testing.T, chain *xc.ChainConfig, options ...hd.Option) (*hardware.Emulator, *hardware.Signer, *signer.Signer) {
+	emulator, err := hardware.NewEmulator(chain, mnemonic, "")
+	require.NoError(t, err)
+	hardwareSigner, err := hardware.New(emulator, chain, options...)
+	require.NoError(t, err)
+	softwareSigner, err := signer.New(chain.Driver, mnemonic, chain, options...)
+	require.NoError(t, err)
+	return emulator, hardwareSigner, softwareSigner
+}
+
+// a payload that needs several APDUs
+func longData() []byte {
+	data := make([]byte, 600)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	return data
+}
+
+func newEvmTxs() []xc.Tx {
+	to := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	chainId := big.NewInt(1)
+	return []xc.Tx{
+		&evmtx.Tx{
+			EthTx:  types.NewTx(&types.DynamicFeeTx{ChainID: chainId, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(10)}),
+			Signer: types.LatestSignerForChainID(chainId),
+		},
+		&evmtx.Tx{
+			EthTx:  types.NewTx(&types.DynamicFeeTx{ChainID: chainId, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, Data: longData()}),
+			Signer: types.LatestSignerForChainID(chainId),
+		},
+		&evmtx.Tx{
+			EthTx:  types.NewTransaction(1, to, big.NewInt(10), 21000, big.NewInt(100), nil),
+			Signer: types.LatestSignerForChainID(chainId),
+		},
+	}
+}
+
+func newSolanaTx(t *testing.T, from []byte) xc.Tx {
+	payer := solana.PublicKeyFromBytes(from)
+	instructions := []solana.Instruction{}
+	// enough instructions to need several APDUs
+	for i := 0; i < 10; i++ {
+		instructions = append(instructions, system.NewTransferInstruction(uint64(i+1), payer, solana.SystemProgramID).Build())
+	}
+	solTx, err := solana.NewTransaction(instructions, solana.Hash{1, 2, 3}, solana.TransactionPayer(payer))
+	require.NoError(t, err)
+	return &solanatx.Tx{SolTx: solTx}
+}
+
+func newCosmosTx(signDoc []byte) xc.Tx {
+	return &cosmostx.Tx{SignDoc: signDoc, TxDataToSign: cosmostx.GetSighash(atom, signDoc)}
+}
+
+// an evm tx with the sighash of another tx
+type mismatchedEvmTx struct{ *evmtx.Tx }
+
+func (tx *mismatchedEvmTx) Sighashes() ([]xc.TxDataToSign, error) {
+	return newEvmTxs()[2].Sighashes()
+}
+
+func newBitcoinTx(t *testing.T) xc.Tx {
+	builder, err := bitcoin.NewTxBuilder(btc)
+	require.NoError(t, err)
+	segwitScript, _ := hex.DecodeString("0014b9361a6fb2f194301a127ab2604d5a652580ede0")
+	legacyScript, _ := hex.DecodeString("76a914ba27f99e007c7f605a8305e318c1abde3cd220ac88ac")
+	input := &tx_input.TxInput{
+		UnspentOutputs: []tx_input.Output{
+			{Value: xc.NewAmountBlockchainFromUint64(10000), PubKeyScript: segwitScript},
+			{Value: xc.NewAmountBlockchainFromUint64(10000), PubKeyScript: legacyScript},
+		},
+		GasPricePerByte: xc.NewAmountBlockchainFromUint64(1),
+	}
+	tf, err := builder.NewNativeTransfer("tb1qhymp5maj7x2rqxsj02exqn26v5jcqm0q3x3pz4", "mxVFsFW5N4mu1HPkxPttorvocvzeZ7KZyk", xc.NewAmountBlockchainFromUint64(15000), input)
+	require.NoError(t, err)
+	return tf.(*bitcointx.Tx)
+}
+
+func TestPublicKey(t *testing.T) {
+	for _, chain := range []*xc.ChainConfig{eth, sol, atom, btc} {
+		t.Run(string(chain.Chain), func(t *testing.T) {
+			_, hardwareSigner, softwareSigner := newSigners(t, chain)
+			publicKey, err := hardwareSigner.PublicKey()
+			require.NoError(t, err)
+			require.EqualValues(t, softwareSigner.MustPublicKey(), publicKey)
+
+			_, hardwareSigner, softwareSigner = newSigners(t, chain, hd.OptionIndex(1))
+			publicKey, err = hardwareSigner.PublicKey()
+			require.NoError(t, err)
+			require.EqualValues(t, softwareSigner.MustPublicKey(), publicKey)
+		})
+	}
+}
+
+func TestSignTx(t *testing.T) {
+	_, solanaSigner, _ := newSigners(t, sol)
+	solanaPublicKey, err := solanaSigner.PublicKey()
+	require.NoError(t, err)
+
+	vectors := []struct {
+		name  string
+		chain *xc.ChainConfig
+		tx    xc.Tx
+	}{
+		{"eth", eth, newEvmTxs()[0]},
+		{"eth_long", eth, newEvmTxs()[1]},
+		{"eth_legacy", eth, newEvmTxs()[2]},
+		{"sol", sol, newSolanaTx(t, solanaPublicKey)},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			emulator, hardwareSigner, softwareSigner := newSigners(t, v.chain)
+			displayed := [][]byte{}
+			emulator.Approve = func(payload []byte) bool {
+				displayed = append(displayed, payload)
+				return true
+			}
+
+			signatures, err := hardwareSigner.SignTx(v.tx)
+			require.NoError(t, err)
+
+			// same signatures as signing the sighashes in software
+			sighashes, err := v.tx.Sighashes()
+			require.NoError(t, err)
+			require.Equal(t, softwareSigner.MustSignAll(sighashes), signatures)
+			require.NoError(t, v.tx.AddSignatures(signatures...))
+
+			// the device was sent what should be displayed
+			payloads, err := v.tx.(xc.TxWithDisplayPayloads).DisplayPayloads()
+			require.NoError(t, err)
+			require.Len(t, displayed, len(payloads))
+			for i := range payloads {
+				require.EqualValues(t, payloads[i], displayed[i])
+			}
+		})
+	}
+}
+
+func TestSignTxRejected(t *testing.T) {
+	emulator, hardwareSigner, _ := newSigners(t, eth)
+	emulator.Approve = func(payload []byte) bool { return false }
+	_, err := hardwareSigner.SignTx(newEvmTxs()[0])
+	var statusErr *hardware.StatusError
+	require.True(t, errors.As(err, &statusErr))
+	require.Equal(t, hardware.StatusRejected, statusErr.Status)
+	require.ErrorContains(t, err, "rejected on the device")
+
+	// the emulator can still be used
+	emulator.Approve = nil
+	_, err = hardwareSigner.SignTx(newEvmTxs()[0])
+	require.NoError(t, err)
+}
+
+func TestSignTxErrors(t *testing.T) {
+	// the device signs something other than the sighash
+	_, hardwareSigner, _ := newSigners(t, eth)
+	mismatched := &mismatchedEvmTx{newEvmTxs()[0].(*evmtx.Tx)}
+	_, err := hardwareSigner.SignTx(mismatched)
+	require.ErrorContains(t, err, "signature from device does not match the tx")
+
+	// the cosmos app can't parse SIGN_MODE_DIRECT sign docs
+	_, hardwareSigner, _ = newSigners(t, atom)
+	_, err = hardwareSigner.SignTx(newCosmosTx([]byte("sign doc")))
+	require.ErrorIs(t, err, hardware.ErrCosmosSigning)
+
+	// txs must have display payloads
+	_, err = hardwareSigner.SignTx(&txWithoutPayloads{})
+	require.ErrorContains(t, err, "cannot be signed on a hardware wallet")
+
+	// the wrong app is open
+	emulator, err := hardware.NewEmulator(sol, mnemonic, "")
+	require.NoError(t, err)
+	hardwareSigner, err = hardware.New(emulator, eth)
+	require.NoError(t, err)
+	_, err = hardwareSigner.PublicKey()
+	require.ErrorContains(t, err, "is the right app open?")
+
+	// cosmos requires a path with 5 indexes
+	emulator, err = hardware.NewEmulator(atom, mnemonic, "")
+	require.NoError(t, err)
+	hardwareSigner, err = hardware.New(emulator, atom, hd.OptionPath("m/44'/118'/0'"))
+	require.NoError(t, err)
+	_, err = hardwareSigner.PublicKey()
+	require.ErrorContains(t, err, "requires a path with 5 indexes")
+
+	_, err = hardware.New(emulator, &xc.ChainConfig{Chain: xc.TRX, Driver: xc.DriverTron})
+	require.ErrorContains(t, err, "no hardware app for driver tron")
+
+	// the bitcoin app requires PSBTs, so only the public key is supported
+	_, hardwareSigner, _ = newSigners(t, btc)
+	_, err = hardwareSigner.PublicKey()
+	require.NoError(t, err)
+	_, err = hardwareSigner.SignTx(newBitcoinTx(t))
+	require.ErrorIs(t, err, hardware.ErrBitcoinSigning)
+}
+
+// a tx without display payloads
+type txWithoutPayloads struct{ xc.Tx }
+
+func (tx *txWithoutPayloads) Sighashes() ([]xc.TxDataToSign, error) {
+	return []xc.TxDataToSign{make([]byte, 32)}, nil
+}
+
+func TestAPDU(t *testing.T) {
+	apdu := hardware.APDU{CLA: 0xe0, INS: 0x04, P1: 0x80, P2: 0x00, Data: []byte{1, 2, 3}}
+	encoded, err := apdu.Encode()
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xe0, 0x04, 0x80, 0x00, 0x03, 1, 2, 3}, encoded)
+	decoded, err := hardware.DecodeAPDU(encoded)
+	require.NoError(t, err)
+	require.Equal(t, apdu, decoded)
+
+	_, err = hardware.DecodeAPDU(encoded[:6])
+	require.ErrorContains(t, err, "apdu has length 3, but 1 bytes of data")
+	_, err = (hardware.APDU{Data: make([]byte, 256)}).Encode()
+	require.ErrorContains(t, err, "more than the max")
+
+	// payloads are split into chunks
+	path, _ := hd.ParsePath("m/44'/60'/0'/0/0")
+	apdus, err := (&hardware.EthereumApp{}).Sign(path, longData())
+	require.NoError(t, err)
+	require.Len(t, apdus, 3)
+	require.EqualValues(t, 0x00, apdus[0].P1)
+	require.EqualValues(t, 0x80, apdus[1].P1)
+	require.EqualValues(t, 0x80, apdus[2].P1)
+	require.Len(t, apdus[0].Data, hardware.MaxChunkSize)
+	require.Len(t, apdus[2].Data, 1+4*5+600-2*hardware.MaxChunkSize)
+}
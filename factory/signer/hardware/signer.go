@@ -0,0 +1,145 @@
+package hardware
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Signer signs with a hardware wallet, which displays each tx before signing it.  Unlike signer.Signer, the
+// device is sent the display payloads of a tx rather than its sighashes, and the signatures are checked
+// against the sighashes before being returned.
+type Signer struct {
+	transport Transport
+	driver    xc.Driver
+	app       App
+	path      hd.Path
+	publicKey []byte
+}
+
+// New creates a signer for the chain, using the key at the default path for the chain unless overridden by
+// the options.
+func New(transport Transport, chain *xc.ChainConfig, options ...hd.Option) (*Signer, error) {
+	app, err := NewApp(chain)
+	if err != nil {
+		return nil, err
+	}
+	path, err := hd.NewOptions(options...).DerivationPath(chain)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		transport: transport,
+		driver:    chain.Driver,
+		app:       app,
+		path:      path,
+	}, nil
+}
+
+// Path returns the derivation path of the key on the device
+func (s *Signer) Path() hd.Path {
+	return s.path
+}
+
+// PublicKey returns the public key from the device, in the format used by the chain
+func (s *Signer) PublicKey() ([]byte, error) {
+	if s.publicKey != nil {
+		return s.publicKey, nil
+	}
+	apdus, err := s.app.GetPublicKey(s.path)
+	if err != nil {
+		return nil, err
+	}
+	response, err := Exchange(s.transport, apdus)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := s.app.ParsePublicKey(response)
+	if err != nil {
+		return nil, err
+	}
+	if s.driver.SignatureAlgorithm() != xc.Ed255 {
+		ecdsaKey, err := crypto.DecompressPubkey(publicKey)
+		if err != nil {
+			ecdsaKey, err = crypto.UnmarshalPubkey(publicKey)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key from device: %v", err)
+		}
+		if s.driver.PublicKeyFormat() == xc.Compressed {
+			publicKey = crypto.CompressPubkey(ecdsaKey)
+		} else {
+			publicKey = crypto.FromECDSAPub(ecdsaKey)
+		}
+	}
+	s.publicKey = publicKey
+	return publicKey, nil
+}
+
+// SignTx displays and signs each payload of the tx on the device.  The signatures can be added to the tx with
+// AddSignatures.
+func (s *Signer) SignTx(tx xc.Tx) ([]xc.TxSignature, error) {
+	txWithPayloads, ok := tx.(xc.TxWithDisplayPayloads)
+	if !ok {
+		return nil, fmt.Errorf("%T cannot be signed on a hardware wallet", tx)
+	}
+	payloads, err := txWithPayloads.DisplayPayloads()
+	if err != nil {
+		return nil, err
+	}
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return nil, err
+	}
+	if len(payloads) != len(sighashes) {
+		return nil, fmt.Errorf("tx has %d payloads to display but %d sighashes", len(payloads), len(sighashes))
+	}
+	publicKey, err := s.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([]xc.TxSignature, len(payloads))
+	for i, payload := range payloads {
+		apdus, err := s.app.Sign(s.path, payload)
+		if err != nil {
+			return nil, err
+		}
+		response, err := Exchange(s.transport, apdus)
+		if err != nil {
+			return nil, err
+		}
+		signature, err := s.app.ParseSignature(response)
+		if err != nil {
+			return nil, err
+		}
+		signatures[i], err = verify(s.driver, publicKey, sighashes[i], signature)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return signatures, nil
+}
+
+// verify checks the signature is for the sighash, so the device can't sign something other than what's
+// expected.  Secp256k1 signatures are returned as r || s || v with a low s, the same as signer.Signer.
+func verify(driver xc.Driver, publicKey []byte, sighash xc.TxDataToSign, signature []byte) (xc.TxSignature, error) {
+	if driver.SignatureAlgorithm() == xc.Ed255 {
+		if !ed25519.Verify(publicKey, sighash, signature) {
+			return nil, errors.New("signature from device does not match the tx")
+		}
+		return signature, nil
+	}
+	if len(signature) != 64 {
+		return nil, errors.New("invalid signature from device")
+	}
+	rsv, err := signer.RecoverSignature(sighash, signature, publicKey)
+	if err != nil {
+		return nil, errors.New("signature from device does not match the tx")
+	}
+	return rsv, nil
+}
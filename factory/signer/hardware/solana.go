@@ -0,0 +1,120 @@
+package hardware
+
+import (
+	"crypto/ed25519"
+	"errors"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+)
+
+const (
+	solanaCLA            byte = 0xe0
+	solanaInsGetPubkey   byte = 0x05
+	solanaInsSignMessage byte = 0x06
+	solanaP1NonConfirm   byte = 0x00
+	solanaP1Confirm      byte = 0x01
+	// set on every chunk after the first
+	solanaP2Extend byte = 0x01
+	// set on every chunk but the last
+	solanaP2More byte = 0x02
+)
+
+// SolanaApp signs the serialized message, which the app parses to display
+type SolanaApp struct{}
+
+var _ App = &SolanaApp{}
+
+func (app *SolanaApp) GetPublicKey(path hd.Path) ([]APDU, error) {
+	encodedPath, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return []APDU{{solanaCLA, solanaInsGetPubkey, solanaP1NonConfirm, 0x00, encodedPath}}, nil
+}
+
+func (app *SolanaApp) ParsePublicKey(response []byte) ([]byte, error) {
+	if len(response) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid public key response")
+	}
+	return response, nil
+}
+
+func (app *SolanaApp) Sign(path hd.Path, payload xc.TxDataToDisplay) ([]APDU, error) {
+	encodedPath, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+	// the first chunk is prefixed with the number of signers (only 1 is supported) and their path
+	data := append([]byte{1}, encodedPath...)
+	data = append(data, payload...)
+	parts := chunks(data, MaxChunkSize)
+	apdus := []APDU{}
+	for i, chunk := range parts {
+		var p2 byte
+		if i > 0 {
+			p2 |= solanaP2Extend
+		}
+		if i < len(parts)-1 {
+			p2 |= solanaP2More
+		}
+		apdus = append(apdus, APDU{solanaCLA, solanaInsSignMessage, solanaP1Confirm, p2, chunk})
+	}
+	return apdus, nil
+}
+
+func (app *SolanaApp) ParseSignature(response []byte) ([]byte, error) {
+	if len(response) != ed25519.SignatureSize {
+		return nil, errors.New("invalid signature response")
+	}
+	return response, nil
+}
+
+func (e *Emulator) emulateSolana(apdu APDU) ([]byte, uint16) {
+	if apdu.CLA != solanaCLA {
+		return nil, StatusClaNotSupported
+	}
+	switch apdu.INS {
+	case solanaInsGetPubkey:
+		path, _, err := decodePath(apdu.Data)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		key, err := e.ed25519(path)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		return key.Public().(ed25519.PublicKey), StatusOk
+	case solanaInsSignMessage:
+		if apdu.P2&solanaP2Extend == 0 {
+			if len(apdu.Data) < 1 || apdu.Data[0] != 1 {
+				return nil, StatusInvalidData
+			}
+			path, rest, err := decodePath(apdu.Data[1:])
+			if err != nil {
+				return nil, StatusInvalidData
+			}
+			e.path = path
+			e.pending = append([]byte{}, rest...)
+		} else if e.path != nil {
+			e.pending = append(e.pending, apdu.Data...)
+		} else {
+			return nil, StatusInvalidData
+		}
+		if apdu.P2&solanaP2More != 0 {
+			return nil, StatusOk
+		}
+		path, message := e.path, e.pending
+		e.reset()
+		if !e.approve(message) {
+			return nil, StatusRejected
+		}
+		key, err := e.ed25519(path)
+		if err != nil {
+			return nil, StatusInvalidData
+		}
+		return ed25519.Sign(key, message), StatusOk
+	default:
+		return nil, StatusInsNotSupported
+	}
+}
@@ -0,0 +1,154 @@
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cosmos/go-bip39"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// Indexes at or above this offset are hardened
+const HardenedOffset uint32 = 0x80000000
+
+// Path is a BIP-32 derivation path, with hardened indexes offset by HardenedOffset
+type Path []uint32
+
+// ParsePath parses a path like "m/44'/60'/0'/0/0".  Hardened indexes may be marked with ' or h.
+func ParsePath(path string) (Path, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path '%s': must start with m", path)
+	}
+	result := Path{}
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		part = strings.TrimRight(part, "'h")
+		index, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid derivation path '%s': %v", path, err)
+		}
+		if hardened {
+			index += uint64(HardenedOffset)
+		}
+		result = append(result, uint32(index))
+	}
+	return result, nil
+}
+
+func (path Path) String() string {
+	parts := []string{"m"}
+	for _, index := range path {
+		if index >= HardenedOffset {
+			parts = append(parts, fmt.Sprintf("%d'", index-HardenedOffset))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d", index))
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// NewSeed returns the BIP-39 seed of a mnemonic, using an optional passphrase.
+func NewSeed(mnemonic string, passphrase string) ([]byte, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid mnemonic: %v", err)
+	}
+	return seed, nil
+}
+
+// DerivePrivateKey derives the private key at the path, using BIP-32 for secp256k1 and SLIP-10 for
+// ed25519.  Ed25519 keys are returned as their 32 byte seed.
+func DerivePrivateKey(alg xc.SignatureType, seed []byte, path Path) ([]byte, error) {
+	switch alg {
+	case xc.K256Keccak, xc.K256Sha256:
+		return DeriveSecp256k1(seed, path)
+	case xc.Ed255:
+		return DeriveEd25519(seed, path)
+	default:
+		return nil, fmt.Errorf("unsupported signing alg for derivation: %v", alg)
+	}
+}
+
+// DeriveSecp256k1 derives a secp256k1 private key following BIP-32.
+func DeriveSecp256k1(seed []byte, path Path) ([]byte, error) {
+	// the network only sets the version bytes of the serialized key, which is not used
+	key, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range path {
+		key, err = key.Derive(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	privateKey, err := key.ECPrivKey()
+	if err != nil {
+		return nil, err
+	}
+	return privateKey.Serialize(), nil
+}
+
+// DeriveEd25519 derives an ed25519 private key seed following SLIP-10, which only supports hardened indexes.
+func DeriveEd25519(seed []byte, path Path) ([]byte, error) {
+	key, chainCode := slip10(seed, []byte("ed25519 seed"))
+	for _, index := range path {
+		if index < HardenedOffset {
+			return nil, errors.New("ed25519 derivation only supports hardened indexes")
+		}
+		data := make([]byte, 0, 1+32+4)
+		data = append(data, 0x00)
+		data = append(data, key...)
+		data = binary.BigEndian.AppendUint32(data, index)
+		key, chainCode = slip10(data, chainCode)
+	}
+	return key, nil
+}
+
+func slip10(data []byte, hmacKey []byte) (key []byte, chainCode []byte) {
+	mac := hmac.New(sha512.New, hmacKey)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
+
+const tonIterations = 100000
+
+func tonEntropy(words []string, passphrase string) []byte {
+	mac := hmac.New(sha512.New, []byte(strings.Join(words, " ")))
+	mac.Write([]byte(passphrase))
+	return mac.Sum(nil)
+}
+
+// IsTonMnemonic reports whether the mnemonic was generated by a TON wallet (e.g. Tonkeeper).  These use the
+// BIP-39 word list, but a different checksum, and the key is derived from the mnemonic without a path.
+func IsTonMnemonic(mnemonic string, passphrase string) bool {
+	words := strings.Fields(mnemonic)
+	if len(words) != 24 {
+		return false
+	}
+	entropy := tonEntropy(words, passphrase)
+	if passphrase != "" {
+		return pbkdf2.Key(entropy, []byte("TON fast seed version"), 1, 1, sha512.New)[0] == 1
+	}
+	return pbkdf2.Key(entropy, []byte("TON seed version"), tonIterations/256, 1, sha512.New)[0] == 0
+}
+
+// DeriveTon derives the ed25519 private key seed of a TON mnemonic, as TON wallets do.
+func DeriveTon(mnemonic string, passphrase string) ([]byte, error) {
+	if !IsTonMnemonic(mnemonic, passphrase) {
+		return nil, errors.New("invalid TON mnemonic")
+	}
+	entropy := tonEntropy(strings.Fields(mnemonic), passphrase)
+	return pbkdf2.Key(entropy, []byte("TON default seed"), tonIterations, 32, sha512.New), nil
+}
@@ -0,0 +1,245 @@
+package hd_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	xc "github.com/cordialsys/crosschain"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
+	"github.com/stretchr/testify/require"
+)
+
+const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func TestParsePath(t *testing.T) {
+	path, err := hd.ParsePath("m/44'/60'/0'/0/1")
+	require.NoError(t, err)
+	require.Equal(t, hd.Path{44 + hd.HardenedOffset, 60 + hd.HardenedOffset, hd.HardenedOffset, 0, 1}, path)
+	require.Equal(t, "m/44'/60'/0'/0/1", path.String())
+
+	path, err = hd.ParsePath("m/44h/501h/0h/0h")
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/501'/0'/0'", path.String())
+
+	path, err = hd.ParsePath("m")
+	require.NoError(t, err)
+	require.Empty(t, path)
+
+	_, err = hd.ParsePath("44'/60'/0'/0/1")
+	require.ErrorContains(t, err, "must start with m")
+	_, err = hd.ParsePath("m/44'/abc")
+	require.ErrorContains(t, err, "invalid derivation path")
+	_, err = hd.ParsePath("m/2147483648")
+	require.ErrorContains(t, err, "invalid derivation path")
+}
+
+func TestNewSeed(t *testing.T) {
+	seed, err := hd.NewSeed(mnemonic, "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04", hex.EncodeToString(seed))
+
+	// extra whitespace is ignored
+	seed2, err := hd.NewSeed("  abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon   about\n", "TREZOR")
+	require.NoError(t, err)
+	require.Equal(t, seed, seed2)
+
+	_, err = hd.NewSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "")
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
+
+func TestDerivePrivateKey(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	vectors := []struct {
+		name string
+		alg  xc.SignatureType
+		path string
+		key  string
+	}{
+		// BIP-32 test vector 1
+		{"bip32_master", xc.K256Sha256, "m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"bip32", xc.K256Sha256, "m/0'/1/2'/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		// SLIP-10 ed25519 test vector 1
+		{"slip10_master", xc.Ed255, "m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"slip10_child", xc.Ed255, "m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"slip10", xc.Ed255, "m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			path, err := hd.ParsePath(v.path)
+			require.NoError(t, err)
+			key, err := hd.DerivePrivateKey(v.alg, seed, path)
+			require.NoError(t, err)
+			require.Equal(t, v.key, hex.EncodeToString(key))
+		})
+	}
+
+	path, _ := hd.ParsePath("m/0'/1")
+	_, err := hd.DerivePrivateKey(xc.Ed255, seed, path)
+	require.ErrorContains(t, err, "only supports hardened indexes")
+}
+
+func TestDefaultPath(t *testing.T) {
+	vectors := []struct {
+		chain xc.ChainConfig
+		path  string
+	}{
+		{xc.ChainConfig{Chain: xc.BTC, Driver: xc.DriverBitcoin}, "m/44'/0'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.LTC, Driver: xc.DriverBitcoin}, "m/44'/2'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.BCH, Driver: xc.DriverBitcoinCash}, "m/44'/145'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}, "m/44'/60'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.ATOM, Driver: xc.DriverCosmos}, "m/44'/118'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.LUNA, Driver: xc.DriverCosmos, ChainCoinHDPath: 330}, "m/44'/330'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}, "m/44'/501'/0'/1'"},
+		{xc.ChainConfig{Chain: xc.APTOS, Driver: xc.DriverAptos}, "m/44'/637'/0'/0'/1'"},
+		{xc.ChainConfig{Chain: xc.SUI, Driver: xc.DriverSui}, "m/44'/784'/0'/0'/1'"},
+		{xc.ChainConfig{Chain: xc.TON, Driver: xc.DriverTon}, "m/44'/607'/0'/0'/1'/0'"},
+		{xc.ChainConfig{Chain: xc.TRX, Driver: xc.DriverTron}, "m/44'/195'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.XRP, Driver: xc.DriverXrp}, "m/44'/144'/0'/0/1"},
+	}
+	for _, v := range vectors {
+		require.Equal(t, v.path, hd.DefaultPath(&v.chain, 0, 1).String(), v.chain.Chain)
+	}
+}
+
+func TestLegacyPath(t *testing.T) {
+	vectors := []struct {
+		chain xc.ChainConfig
+		path  string
+	}{
+		{xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, ChainCoinHDPath: 60}, "m/44'/60'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}, "m/44'/0'/0'/0/1"},
+		{xc.ChainConfig{Chain: xc.TON, Driver: xc.DriverTon}, "m/44'/0'/0'/0/1"},
+	}
+	for _, v := range vectors {
+		require.Equal(t, v.path, hd.LegacyPath(&v.chain, 0, 1).String(), v.chain.Chain)
+		path, err := hd.NewOptions(hd.OptionLegacy(true), hd.OptionIndex(1)).DerivationPath(&v.chain)
+		require.NoError(t, err)
+		require.Equal(t, v.path, path.String(), v.chain.Chain)
+	}
+
+	// ed25519 chains use the secp256k1 key as their seed
+	sol := &xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}
+	key, err := hd.DeriveFromMnemonic(sol, mnemonic, hd.OptionLegacy(true))
+	require.NoError(t, err)
+	seed, _ := hd.NewSeed(mnemonic, "")
+	expected, _ := hd.DeriveSecp256k1(seed, hd.LegacyPath(sol, 0, 0))
+	require.Equal(t, expected, key)
+}
+
+func TestDeriveFromMnemonic(t *testing.T) {
+	eth := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}
+	key, err := hd.DeriveFromMnemonic(eth, mnemonic)
+	require.NoError(t, err)
+	require.Equal(t, "1ab42cc412b618bdea3a599e3c9bae199ebf030895b039e9db1e30dafb12b727", hex.EncodeToString(key))
+
+	// same as setting the path
+	key2, err := hd.DeriveFromMnemonic(eth, mnemonic, hd.OptionPath("m/44'/60'/0'/0/0"))
+	require.NoError(t, err)
+	require.Equal(t, key, key2)
+
+	indexKey, err := hd.DeriveFromMnemonic(eth, mnemonic, hd.OptionIndex(1))
+	require.NoError(t, err)
+	require.NotEqual(t, key, indexKey)
+	accountKey, err := hd.DeriveFromMnemonic(eth, mnemonic, hd.OptionAccount(1))
+	require.NoError(t, err)
+	require.NotEqual(t, key, accountKey)
+	require.NotEqual(t, indexKey, accountKey)
+	passphraseKey, err := hd.DeriveFromMnemonic(eth, mnemonic, hd.OptionPassphrase("TREZOR"))
+	require.NoError(t, err)
+	require.NotEqual(t, key, passphraseKey)
+
+	_, err = hd.DeriveFromMnemonic(eth, mnemonic, hd.OptionPath("44'/60'"))
+	require.ErrorContains(t, err, "must start with m")
+}
+
+func TestExtendedPublicKey(t *testing.T) {
+	// BIP-84 test vectors, for the mnemonic at m/84'/0'/0'
+	zpub, err := hd.ParseExtendedPublicKey("zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs")
+	require.NoError(t, err)
+	require.Equal(t, xc.AddressTypeP2WPKH, zpub.Type)
+	require.False(t, zpub.Testnet)
+
+	publicKey, err := zpub.PublicKey(0, 0)
+	require.NoError(t, err)
+	require.Equal(t, "0330d54fd0dd420a6e5f8d3624f5f3482cae350f79d5f0753bf5beef9c2d91af3c", hex.EncodeToString(publicKey))
+
+	addresses, err := zpub.Addresses(&chaincfg.MainNetParams, 0, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, []xc.Address{
+		"bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu",
+		"bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g",
+	}, addresses)
+	change, err := zpub.Address(&chaincfg.MainNetParams, 1, 0)
+	require.NoError(t, err)
+	require.Equal(t, xc.Address("bc1q8c6fshw2dlwun7ekn9qwf37cu2rn755upcp6el"), change)
+
+	_, err = zpub.Address(&chaincfg.TestNet3Params, 0, 0)
+	require.ErrorContains(t, err, "extended public key is not for testnet3")
+	_, err = zpub.PublicKey(0, hd.HardenedOffset)
+	require.ErrorContains(t, err, "cannot derive hardened index")
+
+	_, err = hd.ParseExtendedPublicKey("xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi")
+	require.ErrorContains(t, err, "not a private key")
+	_, err = hd.ParseExtendedPublicKey("zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYt")
+	require.ErrorContains(t, err, "invalid extended public key")
+}
+
+func TestExtendedPublicKeyScan(t *testing.T) {
+	zpub, err := hd.ParseExtendedPublicKey("zpub6rFR7y4Q2AijBEqTUquhVz398htDFrtymD9xYYfG1m4wAcvPhXNfE3EfH1r1ADqtfSdVCToUG868RvUUkgDKf31mGDtKsAYz2oz2AGutZYs")
+	require.NoError(t, err)
+	all, err := zpub.Addresses(&chaincfg.MainNetParams, 0, 0, 10)
+	require.NoError(t, err)
+
+	// indexes 0 and 3 have been used
+	used := map[xc.Address]bool{all[0]: true, all[3]: true}
+	checked := 0
+	addresses, err := zpub.Scan(&chaincfg.MainNetParams, 0, 3, func(address xc.Address) (bool, error) {
+		checked++
+		return used[address], nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, all[:4], addresses)
+	// stops after 3 unused addresses following index 3
+	require.Equal(t, 7, checked)
+
+	addresses, err = zpub.Scan(&chaincfg.MainNetParams, 0, 3, func(address xc.Address) (bool, error) {
+		return false, nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+}
+
+func TestDeriveTonMnemonic(t *testing.T) {
+	// generated by a TON wallet, with the key of the wallet
+	tonMnemonic := "beef control canyon ethics vessel myth organ author chunk goddess bacon mercy hawk void pulse seek bitter thought input wrong knock link hawk direct"
+	ton := &xc.ChainConfig{Chain: xc.TON, Driver: xc.DriverTon}
+	require.True(t, hd.IsTonMnemonic(tonMnemonic, ""))
+	key, err := hd.DeriveFromMnemonic(ton, tonMnemonic)
+	require.NoError(t, err)
+	require.Equal(t, "c7437900e117b58ad1b12aaa1ade7d4f0c2d8c2c3a692c35e5a7c3de6c291460", hex.EncodeToString(key))
+
+	passphraseMnemonic := "resemble warrior supreme assume pact neither tourist camera rare green diet believe enroll south napkin glad chat waste inside off guilt fan beach will"
+	require.False(t, hd.IsTonMnemonic(passphraseMnemonic, ""))
+	key, err = hd.DeriveFromMnemonic(ton, passphraseMnemonic, hd.OptionPassphrase("secret"))
+	require.NoError(t, err)
+	require.Equal(t, "86dead99057e3733dfc6c022cf73b75df607df8bf99b5b0699843a551d121113", hex.EncodeToString(key))
+
+	// setting the path derives with BIP-39 instead, which has a different checksum
+	_, err = hd.DeriveFromMnemonic(ton, tonMnemonic, hd.OptionPath("m/44'/607'/0'/0'/0'/0'"))
+	require.ErrorContains(t, err, "invalid mnemonic")
+	_, err = hd.DeriveFromMnemonic(ton, tonMnemonic, hd.OptionIndex(1))
+	require.ErrorContains(t, err, "single key")
+
+	// BIP-39 mnemonics use the default path
+	require.False(t, hd.IsTonMnemonic(mnemonic, ""))
+	key, err = hd.DeriveFromMnemonic(ton, mnemonic)
+	require.NoError(t, err)
+	expected, err := hd.DeriveFromMnemonic(ton, mnemonic, hd.OptionPath("m/44'/607'/0'/0'/0'/0'"))
+	require.NoError(t, err)
+	require.Equal(t, expected, key)
+
+	// other chains always use BIP-39
+	_, err = hd.DeriveFromMnemonic(&xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}, tonMnemonic)
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
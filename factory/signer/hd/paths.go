@@ -0,0 +1,151 @@
+package hd
+
+import (
+	"errors"
+
+	xc "github.com/cordialsys/crosschain"
+)
+
+type Options struct {
+	// Account and address index, placed in the default path for the chain
+	Account uint32
+	Index   uint32
+	// Optional BIP-39 passphrase
+	Passphrase string
+	// Overrides the default path for the chain, e.g. "m/44'/60'/0'/0/0"
+	Path string
+	// Derive keys as previous versions did, see LegacyPath
+	Legacy bool
+}
+
+type Option func(opts *Options)
+
+func OptionAccount(account uint32) Option {
+	return func(opts *Options) { opts.Account = account }
+}
+func OptionIndex(index uint32) Option {
+	return func(opts *Options) { opts.Index = index }
+}
+func OptionPassphrase(passphrase string) Option {
+	return func(opts *Options) { opts.Passphrase = passphrase }
+}
+func OptionPath(path string) Option {
+	return func(opts *Options) { opts.Path = path }
+}
+func OptionLegacy(legacy bool) Option {
+	return func(opts *Options) { opts.Legacy = legacy }
+}
+
+func NewOptions(options ...Option) Options {
+	opts := Options{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+// SLIP-44 coin types used when the chain does not set chain_coin_hd_path
+var defaultCoinTypes = map[xc.Driver]uint32{
+	xc.DriverBitcoin:       0,
+	xc.DriverBitcoinLegacy: 0,
+	xc.DriverBitcoinCash:   145,
+	xc.DriverEVM:           60,
+	xc.DriverEVMLegacy:     60,
+	xc.DriverCosmos:        118,
+	xc.DriverCosmosEvmos:   60,
+	xc.DriverSolana:        501,
+	xc.DriverAptos:         637,
+	xc.DriverSui:           784,
+	xc.DriverTon:           607,
+	xc.DriverTron:          195,
+	xc.DriverXrp:           144,
+	xc.DriverSubstrate:     354,
+}
+
+// Bitcoin forks share a driver, so their coin types are by chain
+var bitcoinCoinTypes = map[xc.NativeAsset]uint32{
+	xc.BCH:  145,
+	xc.DOGE: 3,
+	xc.LTC:  2,
+}
+
+// CoinType returns the SLIP-44 coin type for the chain
+func CoinType(chain *xc.ChainConfig) uint32 {
+	if chain.ChainCoinHDPath != 0 {
+		return chain.ChainCoinHDPath
+	}
+	if coinType, ok := bitcoinCoinTypes[chain.Chain]; ok {
+		return coinType
+	}
+	return defaultCoinTypes[chain.Driver]
+}
+
+func hardened(index uint32) uint32 {
+	return index + HardenedOffset
+}
+
+// DefaultPath returns the path that wallets for the chain commonly use:
+//   - secp256k1 chains use BIP-44, m/44'/coin'/account'/0/index
+//   - bitcoin also uses BIP-44 to keep deriving the same keys as before.  Native segwit wallets use BIP-84
+//     (m/84'/0'/account'/0/index), which must be set as the path.
+//   - solana uses m/44'/501'/account'/index'
+//   - aptos, sui and substrate use m/44'/coin'/account'/0'/index'
+//   - ton uses m/44'/607'/account'/0'/index'/0', as the ledger app does.  Mnemonics generated by TON wallets
+//     don't use a path, see DeriveTon.
+//
+// Ed25519 chains are derived with SLIP-10, so every index in their paths is hardened.
+func DefaultPath(chain *xc.ChainConfig, account uint32, index uint32) Path {
+	coin := hardened(CoinType(chain))
+	switch chain.Driver {
+	case xc.DriverSolana:
+		return Path{hardened(44), coin, hardened(account), hardened(index)}
+	case xc.DriverAptos, xc.DriverSui, xc.DriverSubstrate:
+		return Path{hardened(44), coin, hardened(account), hardened(0), hardened(index)}
+	case xc.DriverTon:
+		return Path{hardened(44), coin, hardened(account), hardened(0), hardened(index), hardened(0)}
+	default:
+		return Path{hardened(44), coin, hardened(account), 0, index}
+	}
+}
+
+// LegacyPath returns the path that previous versions derived for every chain, m/44'/coin'/account'/0/index,
+// using chain_coin_hd_path as the coin type even when it's not set.  Legacy keys are always derived with
+// BIP-32 for secp256k1, and ed25519 chains use the derived key as their seed.
+func LegacyPath(chain *xc.ChainConfig, account uint32, index uint32) Path {
+	return Path{hardened(44), hardened(chain.ChainCoinHDPath), hardened(account), 0, index}
+}
+
+// DerivationPath returns the path to derive for the chain, which is the default path unless overridden
+func (opts Options) DerivationPath(chain *xc.ChainConfig) (Path, error) {
+	if opts.Path != "" {
+		return ParsePath(opts.Path)
+	}
+	if opts.Legacy {
+		return LegacyPath(chain, opts.Account, opts.Index), nil
+	}
+	return DefaultPath(chain, opts.Account, opts.Index), nil
+}
+
+// DeriveFromMnemonic derives the private key for the chain from a BIP-39 mnemonic.  On TON, mnemonics from
+// TON wallets are derived as they do instead, unless the path is set or using the legacy derivation.
+func DeriveFromMnemonic(chain *xc.ChainConfig, mnemonic string, options ...Option) ([]byte, error) {
+	opts := NewOptions(options...)
+	if chain.Driver == xc.DriverTon && opts.Path == "" && !opts.Legacy && IsTonMnemonic(mnemonic, opts.Passphrase) {
+		if opts.Account != 0 || opts.Index != 0 {
+			return nil, errors.New("TON wallet mnemonics have a single key, set the path to derive with BIP-39")
+		}
+		return DeriveTon(mnemonic, opts.Passphrase)
+	}
+	path, err := opts.DerivationPath(chain)
+	if err != nil {
+		return nil, err
+	}
+	seed, err := NewSeed(mnemonic, opts.Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Legacy {
+		return DeriveSecp256k1(seed, path)
+	}
+	return DerivePrivateKey(chain.Driver.SignatureAlgorithm(), seed, path)
+}
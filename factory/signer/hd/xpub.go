@@ -0,0 +1,143 @@
+package hd
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	xc "github.com/cordialsys/crosschain"
+)
+
+// The version bytes of an extended public key (SLIP-132) indicate the type of address it's used for
+type extendedKeyVersion struct {
+	addressType xc.AddressType
+	testnet     bool
+}
+
+// Nested segwit (P2WPKH wrapped in P2SH), used by ypub keys
+const AddressTypeP2SHP2WPKH xc.AddressType = "P2SH-P2WPKH"
+
+var extendedKeyVersions = map[string]extendedKeyVersion{
+	"0488b21e": {xc.AddressTypeP2PKH, false},   // xpub
+	"049d7cb2": {AddressTypeP2SHP2WPKH, false}, // ypub
+	"04b24746": {xc.AddressTypeP2WPKH, false},  // zpub
+	"043587cf": {xc.AddressTypeP2PKH, true},    // tpub
+	"044a5262": {AddressTypeP2SHP2WPKH, true},  // upub
+	"045f1cf6": {xc.AddressTypeP2WPKH, true},   // vpub
+}
+
+// ExtendedPublicKey derives watch-only bitcoin addresses from an account level xpub, ypub or zpub.
+type ExtendedPublicKey struct {
+	key     *hdkeychain.ExtendedKey
+	Type    xc.AddressType
+	Testnet bool
+}
+
+// ParseExtendedPublicKey parses an extended public key, e.g. as exported by a wallet for m/84'/0'/0'.
+func ParseExtendedPublicKey(xpub string) (*ExtendedPublicKey, error) {
+	key, err := hdkeychain.NewKeyFromString(xpub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extended public key: %v", err)
+	}
+	if key.IsPrivate() {
+		return nil, fmt.Errorf("expected an extended public key, not a private key")
+	}
+	version, ok := extendedKeyVersions[hex.EncodeToString(key.Version())]
+	if !ok {
+		return nil, fmt.Errorf("unsupported extended public key version %x", key.Version())
+	}
+	return &ExtendedPublicKey{key, version.addressType, version.testnet}, nil
+}
+
+// PublicKey returns the compressed public key at change/index below the extended key.
+func (xpub *ExtendedPublicKey) PublicKey(change uint32, index uint32) ([]byte, error) {
+	key := xpub.key
+	for _, i := range []uint32{change, index} {
+		if i >= HardenedOffset {
+			return nil, fmt.Errorf("cannot derive hardened index from a public key")
+		}
+		var err error
+		key, err = key.Derive(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	publicKey, err := key.ECPubKey()
+	if err != nil {
+		return nil, err
+	}
+	return publicKey.SerializeCompressed(), nil
+}
+
+// Address returns the address at change/index.  Change is 0 for receiving addresses and 1 for change addresses.
+func (xpub *ExtendedPublicKey) Address(params *chaincfg.Params, change uint32, index uint32) (xc.Address, error) {
+	isTestnet := params.Name != chaincfg.MainNetParams.Name
+	if isTestnet != xpub.Testnet {
+		return "", fmt.Errorf("extended public key is not for %s", params.Name)
+	}
+	publicKey, err := xpub.PublicKey(change, index)
+	if err != nil {
+		return "", err
+	}
+	hash := btcutil.Hash160(publicKey)
+	var address btcutil.Address
+	switch xpub.Type {
+	case xc.AddressTypeP2WPKH:
+		address, err = btcutil.NewAddressWitnessPubKeyHash(hash, params)
+	case AddressTypeP2SHP2WPKH:
+		var script []byte
+		script, err = txscript.NewScriptBuilder().AddOp(txscript.OP_0).AddData(hash).Script()
+		if err == nil {
+			address, err = btcutil.NewAddressScriptHash(script, params)
+		}
+	default:
+		address, err = btcutil.NewAddressPubKeyHash(hash, params)
+	}
+	if err != nil {
+		return "", err
+	}
+	return xc.Address(address.EncodeAddress()), nil
+}
+
+// Addresses returns count addresses at change/start onwards
+func (xpub *ExtendedPublicKey) Addresses(params *chaincfg.Params, change uint32, start uint32, count uint32) ([]xc.Address, error) {
+	addresses := []xc.Address{}
+	for index := start; index < start+count; index++ {
+		address, err := xpub.Address(params, change, index)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// Scan returns the addresses up to the last used one, stopping after gap consecutive unused addresses (BIP-44 uses
+// a gap limit of 20).
+func (xpub *ExtendedPublicKey) Scan(params *chaincfg.Params, change uint32, gap uint32, used func(address xc.Address) (bool, error)) ([]xc.Address, error) {
+	addresses := []xc.Address{}
+	lastUsed := -1
+	for index := uint32(0); index < uint32(lastUsed+1)+gap; index++ {
+		address, err := xpub.Address(params, change, index)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, address)
+		isUsed, err := used(address)
+		if err != nil {
+			return nil, err
+		}
+		if isUsed {
+			lastUsed = int(index)
+		}
+	}
+	return addresses[:lastUsed+1], nil
+}
+
+// String returns the extended public key as it was parsed, e.g. "zpub..."
+func (xpub *ExtendedPublicKey) String() string {
+	return xpub.key.String()
+}
@@ -0,0 +1,199 @@
+package signer
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var secp256k1N = crypto.S256().Params().N
+var secp256k1HalfN = new(big.Int).Rsh(secp256k1N, 1)
+
+type derSignature struct {
+	R *big.Int
+	S *big.Int
+}
+
+// ParseDER converts a DER encoded secp256k1 signature to r || s
+func ParseDER(der []byte) ([]byte, error) {
+	var sig derSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DER signature: %v", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid DER signature: trailing data")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, errors.New("invalid DER signature: r or s is out of range")
+	}
+	rs := make([]byte, 64)
+	sig.R.FillBytes(rs[:32])
+	sig.S.FillBytes(rs[32:])
+	return rs, nil
+}
+
+// EncodeDER encodes r || s as a DER signature
+func EncodeDER(rs []byte) ([]byte, error) {
+	if len(rs) < 64 {
+		return nil, errors.New("signature must have at least 64 bytes")
+	}
+	return asn1.Marshal(derSignature{
+		R: new(big.Int).SetBytes(rs[:32]),
+		S: new(big.Int).SetBytes(rs[32:64]),
+	})
+}
+
+// parseSecp256k1 decodes a DER, r || s or r || s || v signature to r || s with a low s, and v if it's set.
+func parseSecp256k1(signature []byte) (rs []byte, v *byte, err error) {
+	if len(signature) > 0 && signature[0] == 0x30 {
+		rs, err = ParseDER(signature)
+		if err != nil {
+			// may have the sighash type appended, like in a bitcoin script
+			rs, err = ParseDER(signature[:len(signature)-1])
+		}
+	}
+	if rs == nil {
+		switch len(signature) {
+		case 64:
+			rs = append([]byte{}, signature...)
+		case 65:
+			rs = append([]byte{}, signature[:64]...)
+			recoveryId := signature[64]
+			if recoveryId >= 27 {
+				recoveryId -= 27
+			}
+			if recoveryId > 1 {
+				return nil, nil, fmt.Errorf("invalid signature recovery id %d", signature[64])
+			}
+			v = &recoveryId
+		default:
+			if err == nil {
+				err = fmt.Errorf("invalid signature length %d", len(signature))
+			}
+			return nil, nil, fmt.Errorf("unsupported signature encoding: %v", err)
+		}
+	}
+	s := new(big.Int).SetBytes(rs[32:])
+	if s.Cmp(secp256k1HalfN) > 0 {
+		// negating s flips the parity of the recovered point
+		new(big.Int).Sub(secp256k1N, s).FillBytes(rs[32:])
+		if v != nil {
+			flipped := *v ^ 1
+			v = &flipped
+		}
+	}
+	return rs, v, nil
+}
+
+// RecoverSignature returns r || s || v for the sighash and public key, where the signature may be in any
+// encoding supported by NormalizeSignature.  The s of the signature is made low.
+func RecoverSignature(sighash xc.TxDataToSign, signature []byte, publicKey []byte) (xc.TxSignature, error) {
+	rs, v, err := parseSecp256k1(signature)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, err := crypto.DecompressPubkey(publicKey)
+	if err != nil {
+		ecdsaKey, err = crypto.UnmarshalPubkey(publicKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	expected := crypto.FromECDSAPub(ecdsaKey)
+	recoveryIds := []byte{0, 1}
+	if v != nil {
+		recoveryIds = []byte{*v}
+	}
+	for _, recoveryId := range recoveryIds {
+		rsv := append(append([]byte{}, rs...), recoveryId)
+		recovered, err := crypto.Ecrecover(sighash, rsv)
+		if err == nil && bytes.Equal(recovered, expected) {
+			return rsv, nil
+		}
+	}
+	return nil, errors.New("signature does not match the public key")
+}
+
+// NormalizeSignature converts a signature to the encoding expected by AddSignatures for the driver, so signers
+// other than Signer don't need to know it.  Secp256k1 signatures may be DER encoded (optionally with a sighash
+// type suffix), r || s, or r || s || v where v is 0, 1, 27 or 28.
+//
+// If the public key is set, the signature is verified against it.  It's required to recover v for drivers
+// expecting r || s || v, when the signature doesn't include it.
+func NormalizeSignature(driver xc.Driver, sighash xc.TxDataToSign, signature []byte, publicKey []byte) (xc.TxSignature, error) {
+	metadata := driver.SighashMetadata()
+	switch metadata.SignatureEncoding {
+	case xc.SignatureEncodingEd25519:
+		if len(signature) != ed25519.SignatureSize {
+			return nil, fmt.Errorf("invalid ed25519 signature length %d", len(signature))
+		}
+		if len(publicKey) > 0 && !ed25519.Verify(publicKey, sighash, signature) {
+			return nil, errors.New("signature does not match the public key")
+		}
+		return signature, nil
+	case xc.SignatureEncodingRs, xc.SignatureEncodingRsv:
+		var rsv xc.TxSignature
+		if len(publicKey) > 0 {
+			var err error
+			rsv, err = RecoverSignature(sighash, signature, publicKey)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			rs, v, err := parseSecp256k1(signature)
+			if err != nil {
+				return nil, err
+			}
+			if metadata.SignatureEncoding == xc.SignatureEncodingRs {
+				return rs, nil
+			}
+			if v == nil {
+				return nil, fmt.Errorf("a public key is needed to recover the signature for driver %s", driver)
+			}
+			rsv = append(rs, *v)
+		}
+		if metadata.SignatureEncoding == xc.SignatureEncodingRs {
+			return rsv[:64], nil
+		}
+		return rsv, nil
+	default:
+		return nil, fmt.Errorf("unsupported signature encoding for driver: %v", driver)
+	}
+}
+
+// AddSignatures normalizes signatures in any encoding supported by NormalizeSignature and adds them to the tx,
+// in the same order as its sighashes.  Either no public keys, one for all of the signatures, or one per
+// signature may be passed.
+func AddSignatures(driver xc.Driver, tx xc.Tx, signatures []xc.TxSignature, publicKeys ...[]byte) error {
+	sighashes, err := tx.Sighashes()
+	if err != nil {
+		return err
+	}
+	if len(signatures) != len(sighashes) {
+		return fmt.Errorf("expected %d signatures, got %d signatures", len(sighashes), len(signatures))
+	}
+	if len(publicKeys) > 1 && len(publicKeys) != len(signatures) {
+		return fmt.Errorf("expected 1 or %d public keys, got %d public keys", len(signatures), len(publicKeys))
+	}
+	normalized := make([]xc.TxSignature, len(signatures))
+	for i, signature := range signatures {
+		var publicKey []byte
+		if len(publicKeys) == 1 {
+			publicKey = publicKeys[0]
+		} else if len(publicKeys) > 1 {
+			publicKey = publicKeys[i]
+		}
+		normalized[i], err = NormalizeSignature(driver, sighashes[i], signature, publicKey)
+		if err != nil {
+			return fmt.Errorf("invalid signature %d: %v", i, err)
+		}
+	}
+	return tx.AddSignatures(normalized...)
+}
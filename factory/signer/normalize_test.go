@@ -0,0 +1,128 @@
+package signer_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	evmtx "github.com/cordialsys/crosschain/chain/evm/tx"
+	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const secp256k1Key = "289c2857d4598e37fb9647507e47a309d6133539bf21a8b9cb6df88fd5232032"
+
+// the same signature with a high s, which flips v
+func highS(rsv []byte) []byte {
+	high := append([]byte{}, rsv...)
+	s := new(big.Int).SetBytes(rsv[32:64])
+	new(big.Int).Sub(crypto.S256().Params().N, s).FillBytes(high[32:64])
+	high[64] ^= 1
+	return high
+}
+
+func TestNormalizeSignature(t *testing.T) {
+	s, err := signer.New(xc.DriverEVM, secp256k1Key, nil)
+	require.NoError(t, err)
+	publicKey := s.MustPublicKey()
+	sighash := crypto.Keccak256([]byte("foo"))
+	rsv, err := s.Sign(sighash)
+	require.NoError(t, err)
+	der, err := signer.EncodeDER(rsv)
+	require.NoError(t, err)
+	rsv27 := append(append([]byte{}, rsv[:64]...), rsv[64]+27)
+
+	vectors := []struct {
+		name      string
+		driver    xc.Driver
+		signature []byte
+		publicKey []byte
+		expected  []byte
+		err       string
+	}{
+		{"rsv", xc.DriverEVM, rsv, publicKey, rsv, ""},
+		{"rsv_without_public_key", xc.DriverEVM, rsv, nil, rsv, ""},
+		{"rsv_27", xc.DriverEVM, rsv27, nil, rsv, ""},
+		{"rsv_high_s", xc.DriverEVM, highS(rsv), nil, rsv, ""},
+		{"rs", xc.DriverEVM, rsv[:64], publicKey, rsv, ""},
+		{"der", xc.DriverEVM, der, publicKey, rsv, ""},
+		{"der_with_sighash_type", xc.DriverEVM, append(der, 0x01), publicKey, rsv, ""},
+		{"rs_to_rs", xc.DriverCosmos, rsv[:64], nil, rsv[:64], ""},
+		{"rsv_to_rs", xc.DriverBitcoin, rsv, publicKey, rsv[:64], ""},
+		{"der_to_rs", xc.DriverXrp, der, nil, rsv[:64], ""},
+		{"rs_without_public_key", xc.DriverEVM, rsv[:64], nil, nil, "a public key is needed"},
+		{"invalid_public_key", xc.DriverEVM, rsv, publicKey[1:], nil, "invalid public key"},
+		{"invalid_length", xc.DriverEVM, rsv[:63], nil, nil, "unsupported signature encoding"},
+		{"invalid_v", xc.DriverTron, append(append([]byte{}, rsv[:64]...), 5), nil, nil, "invalid signature recovery id 5"},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			normalized, err := signer.NormalizeSignature(v.driver, sighash, v.signature, v.publicKey)
+			if v.err != "" {
+				require.ErrorContains(t, err, v.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(v.expected), hex.EncodeToString(normalized))
+		})
+	}
+
+	// a signature of something else
+	_, err = signer.NormalizeSignature(xc.DriverEVM, crypto.Keccak256([]byte("bar")), rsv, publicKey)
+	require.ErrorContains(t, err, "signature does not match the public key")
+}
+
+func TestNormalizeSignatureEd25519(t *testing.T) {
+	s, err := signer.New(xc.DriverSolana, "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60", nil)
+	require.NoError(t, err)
+	message := []byte("message")
+	signature, err := s.Sign(message)
+	require.NoError(t, err)
+
+	normalized, err := signer.NormalizeSignature(xc.DriverSolana, message, signature, s.MustPublicKey())
+	require.NoError(t, err)
+	require.EqualValues(t, signature, normalized)
+
+	_, err = signer.NormalizeSignature(xc.DriverSolana, []byte("another message"), signature, s.MustPublicKey())
+	require.ErrorContains(t, err, "signature does not match the public key")
+	_, err = signer.NormalizeSignature(xc.DriverSolana, message, append(signature, 0), nil)
+	require.ErrorContains(t, err, "invalid ed25519 signature length 65")
+}
+
+func TestAddSignatures(t *testing.T) {
+	s, err := signer.New(xc.DriverEVM, secp256k1Key, nil)
+	require.NoError(t, err)
+	to := common.HexToAddress("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")
+	chainId := big.NewInt(1)
+	tx := &evmtx.Tx{
+		EthTx:  types.NewTx(&types.DynamicFeeTx{ChainID: chainId, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(100), Gas: 21000, To: &to, Value: big.NewInt(10)}),
+		Signer: types.LatestSignerForChainID(chainId),
+	}
+	sighashes, err := xc.SighashesWithMetadata(xc.DriverEVM, tx)
+	require.NoError(t, err)
+	require.Len(t, sighashes, 1)
+	require.True(t, sighashes[0].PreHashed)
+	require.Equal(t, xc.Keccak256, sighashes[0].Hash)
+
+	// e.g. an MPC signer that returns DER signatures
+	rsv, err := s.Sign(sighashes[0].Payload)
+	require.NoError(t, err)
+	der, err := signer.EncodeDER(rsv)
+	require.NoError(t, err)
+
+	err = signer.AddSignatures(xc.DriverEVM, tx, []xc.TxSignature{der, der})
+	require.ErrorContains(t, err, "expected 1 signatures, got 2 signatures")
+	err = signer.AddSignatures(xc.DriverEVM, tx, []xc.TxSignature{der})
+	require.ErrorContains(t, err, "invalid signature 0: a public key is needed")
+
+	require.NoError(t, signer.AddSignatures(xc.DriverEVM, tx, []xc.TxSignature{der}, s.MustPublicKey()))
+	sender, err := types.Sender(tx.Signer, tx.EthTx)
+	require.NoError(t, err)
+	ecdsaKey, err := crypto.UnmarshalPubkey(s.MustPublicKey())
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(*ecdsaKey), sender)
+}
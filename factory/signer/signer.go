@@ -9,10 +9,7 @@ import (
 
 	"github.com/btcsuite/btcutil/base58"
 	xc "github.com/cordialsys/crosschain"
-	cosmostypes "github.com/cordialsys/crosschain/chain/cosmos/types"
-	cosmoscrypto "github.com/cosmos/cosmos-sdk/crypto"
-	"github.com/cosmos/cosmos-sdk/crypto/hd"
-	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -28,57 +25,38 @@ type PrivateKey []byte
 // PublicKey is a public key
 type PublicKey []byte
 
-func fromMnemonic(privateKeyOrMnemonic string, hdPathNum uint32) (PrivateKey, error) {
-	if strings.Contains(privateKeyOrMnemonic, " ") {
-		if len(privateKeyOrMnemonic) < 16 {
-			return nil, errors.New("invalid mnemonic")
-		}
-		codec := cosmostypes.MakeCosmosConfig().Marshaler
-		kb := keyring.NewInMemory(codec)
-		// common path, will not be correct for all chains
-		hdPath := hd.CreateHDPath(hdPathNum, 0, 0).String()
-		_, err := kb.NewAccount("test", privateKeyOrMnemonic, keyring.DefaultBIP39Passphrase, hdPath, hd.Secp256k1)
-		if err != nil {
-			return PrivateKey{}, err
-		}
-		armored, err := kb.ExportPrivKeyArmor("test", keyring.DefaultBIP39Passphrase)
-		if err != nil {
-			return PrivateKey{}, err
-		}
-		privkey, _, err := cosmoscrypto.UnarmorDecryptPrivKey(armored, keyring.DefaultBIP39Passphrase)
-		if err != nil {
-			return PrivateKey{}, err
-		}
-		return PrivateKey(privkey.Bytes()), nil
-	} else {
-		return nil, errors.New("invalid mnemonic")
-	}
+func isMnemonic(secret string) bool {
+	return strings.Contains(strings.TrimSpace(secret), " ")
 }
 
-func fromString(secret string, hdNumMaybe uint32) ([]byte, error) {
-	// try mnemonic first
-	bz, err := fromMnemonic(secret, hdNumMaybe)
+func fromString(secret string) []byte {
+	// Try hex first
+	bz, err := hex.DecodeString(secret)
 	if err != nil {
-		// Try hex next
-		bz, err := hex.DecodeString(secret)
-		if err != nil {
-			// try base58
-			base58bz := base58.Decode(secret)
-			return base58bz, nil
-		}
-		return bz, nil
+		// try base58
+		return base58.Decode(secret)
 	}
-	return bz, nil
+	return bz
 }
 
-func New(driver xc.Driver, secret string, cfgMaybe *xc.ChainConfig) (*Signer, error) {
-	hdNum := uint32(118)
-	if cfgMaybe != nil {
-		hdNum = cfgMaybe.ChainCoinHDPath
-	}
-	secretBz, err := fromString(secret, hdNum)
-	if err != nil {
-		return nil, fmt.Errorf("expected private key to be a hex or base58 string")
+// New creates a signer from a private key in hex or base58, or a BIP-39 mnemonic.  Keys are derived from
+// mnemonics using the default path for the chain, unless overridden by the options.
+func New(driver xc.Driver, secret string, cfgMaybe *xc.ChainConfig, options ...hd.Option) (*Signer, error) {
+	var secretBz []byte
+	if isMnemonic(secret) {
+		chain := &xc.ChainConfig{}
+		if cfgMaybe != nil {
+			copied := *cfgMaybe
+			chain = &copied
+		}
+		chain.Driver = driver
+		var err error
+		secretBz, err = hd.DeriveFromMnemonic(chain, secret, options...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		secretBz = fromString(secret)
 	}
 	alg := driver.SignatureAlgorithm()
 	switch alg {
@@ -5,7 +5,11 @@ import (
 	"testing"
 
 	xc "github.com/cordialsys/crosschain"
+	bitcoinaddress "github.com/cordialsys/crosschain/chain/bitcoin/address"
+	evmaddress "github.com/cordialsys/crosschain/chain/evm/address"
+	solanaaddress "github.com/cordialsys/crosschain/chain/solana/address"
 	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 	"github.com/stretchr/testify/require"
 )
 
@@ -24,6 +28,51 @@ func TestNewSigner(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNewSignerFromMnemonic(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	vectors := []struct {
+		chain   *xc.ChainConfig
+		options []hd.Option
+		address xc.Address
+	}{
+		{&xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}, nil, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		{&xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}, []hd.Option{hd.OptionIndex(1)}, "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"},
+		{&xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}, nil, "HAgk14JpMQLgt6rVgv7cBQFJWFto5Dqxi472uT3DKpqk"},
+		// the same addresses as previous versions
+		{&xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana}, []hd.Option{hd.OptionLegacy(true)}, "5434SA6J931RaTX1JQxMyURx2vyYuS1YEhK1MvUQAUWi"},
+		{&xc.ChainConfig{Chain: xc.SOL, Driver: xc.DriverSolana, ChainCoinHDPath: 501}, []hd.Option{hd.OptionLegacy(true)}, "4EngF3p73rFnEgjcAG5DVQ91QGFze4vsvjVUkAwLjv14"},
+		{&xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, ChainCoinHDPath: 60}, []hd.Option{hd.OptionLegacy(true)}, "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"},
+		// BIP-44 by default
+		{&xc.ChainConfig{Chain: xc.BTC, Driver: xc.DriverBitcoin, Net: "mainnet"}, nil, "bc1qmxrw6qdh5g3ztfcwm0et5l8mvws4eva24kmp8m"},
+		// BIP-84 test vectors
+		{&xc.ChainConfig{Chain: xc.BTC, Driver: xc.DriverBitcoin, Net: "mainnet"}, []hd.Option{hd.OptionPath("m/84'/0'/0'/0/0")}, "bc1qcr8te4kr609gcawutmrza0j4xv80jy8z306fyu"},
+		{&xc.ChainConfig{Chain: xc.BTC, Driver: xc.DriverBitcoin, Net: "mainnet"}, []hd.Option{hd.OptionPath("m/84'/0'/0'/0/1")}, "bc1qnjg0jd8228aq7egyzacy8cys3knf9xvrerkf9g"},
+	}
+	for _, v := range vectors {
+		s, err := signer.New(v.chain.Driver, mnemonic, v.chain, v.options...)
+		require.NoError(t, err)
+		publicKey, err := s.PublicKey()
+		require.NoError(t, err)
+
+		var builder xc.AddressBuilder
+		switch v.chain.Driver {
+		case xc.DriverEVM:
+			builder, err = evmaddress.NewAddressBuilder(v.chain)
+		case xc.DriverSolana:
+			builder, err = solanaaddress.NewAddressBuilder(v.chain)
+		default:
+			builder, err = bitcoinaddress.NewAddressBuilder(v.chain)
+		}
+		require.NoError(t, err)
+		address, err := builder.GetAddressFromPublicKey(publicKey)
+		require.NoError(t, err)
+		require.Equal(t, v.address, address)
+	}
+
+	_, err := signer.New(xc.DriverEVM, "not a valid mnemonic", nil)
+	require.ErrorContains(t, err, "invalid mnemonic")
+}
+
 func TestSign(t *testing.T) {
 
 	vectors := []struct {
@@ -0,0 +1,36 @@
+package factory
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+// Look up the metadata of a token contract that isn't configured, and create a config for it.  Discovered
+// tokens are kept separately from the configured assets, so they can't replace a configured asset that
+// uses the same symbol.
+func (f *Factory) discoverToken(contract string, nativeAsset NativeAsset) (*TokenAssetConfig, error) {
+	key := string(nativeAsset) + ":" + contract
+	if cfg, ok := f.discoveredTokens.Load(key); ok {
+		return cfg.(*TokenAssetConfig), nil
+	}
+	chainI, ok := f.AllAssets.Load(AssetID(nativeAsset))
+	if !ok {
+		return nil, fmt.Errorf("unsupported native asset: %s", nativeAsset)
+	}
+	// make copy so edits do not persist to local store
+	chain := *chainI.(*ChainConfig)
+	client, err := f.NewClient(&chain)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := xclient.FetchTokenMetadata(context.Background(), client, ContractAddress(contract))
+	if err != nil {
+		return nil, fmt.Errorf("could not discover contract '%s': %v", contract, err)
+	}
+	cfg := metadata.AssetConfig(&chain)
+	f.discoveredTokens.Store(key, cfg)
+	return cfg, nil
+}
@@ -0,0 +1,71 @@
+package instrumentation
+
+import (
+	"context"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+)
+
+// TxBuilder instruments calls to a transaction builder.
+type TxBuilder struct {
+	builder xcbuilder.FullTransferBuilder
+	inst    *Instrumentation
+	target  target
+}
+
+var _ xcbuilder.FullTransferBuilder = &TxBuilder{}
+
+// Also forwards staking for builders that support it.
+type StakingTxBuilder struct {
+	*TxBuilder
+}
+
+var _ xcbuilder.FullBuilder = &StakingTxBuilder{}
+
+func (inst *Instrumentation) WrapTxBuilder(cfg xc.ITask, builder xcbuilder.FullTransferBuilder, checkError CheckErrorFunc) xcbuilder.FullTransferBuilder {
+	wrapped := &TxBuilder{
+		builder: builder,
+		inst:    inst,
+		target:  newTarget(cfg, checkError),
+	}
+	if _, ok := builder.(xcbuilder.Staking); ok {
+		return &StakingTxBuilder{wrapped}
+	}
+	return wrapped
+}
+
+// Unwrap returns the builder being instrumented.
+func (b *TxBuilder) Unwrap() xcbuilder.FullTransferBuilder {
+	return b.builder
+}
+
+func (b *TxBuilder) Transfer(args xcbuilder.TransferArgs, input xc.TxInput) (xc.Tx, error) {
+	return observe(context.Background(), b.inst, b.target, "Transfer", func(ctx context.Context) (xc.Tx, error) {
+		return b.builder.Transfer(args, input)
+	})
+}
+
+func (b *TxBuilder) NewTransfer(from xc.Address, to xc.Address, amount xc.AmountBlockchain, input xc.TxInput) (xc.Tx, error) {
+	return observe(context.Background(), b.inst, b.target, "NewTransfer", func(ctx context.Context) (xc.Tx, error) {
+		return b.builder.NewTransfer(from, to, amount, input)
+	})
+}
+
+func (b *StakingTxBuilder) Stake(args xcbuilder.StakeArgs, input xc.StakeTxInput) (xc.Tx, error) {
+	return observe(context.Background(), b.inst, b.target, "Stake", func(ctx context.Context) (xc.Tx, error) {
+		return b.builder.(xcbuilder.Staking).Stake(args, input)
+	})
+}
+
+func (b *StakingTxBuilder) Unstake(args xcbuilder.StakeArgs, input xc.UnstakeTxInput) (xc.Tx, error) {
+	return observe(context.Background(), b.inst, b.target, "Unstake", func(ctx context.Context) (xc.Tx, error) {
+		return b.builder.(xcbuilder.Staking).Unstake(args, input)
+	})
+}
+
+func (b *StakingTxBuilder) Withdraw(args xcbuilder.StakeArgs, input xc.WithdrawTxInput) (xc.Tx, error) {
+	return observe(context.Background(), b.inst, b.target, "Withdraw", func(ctx context.Context) (xc.Tx, error) {
+		return b.builder.(xcbuilder.Staking).Withdraw(args, input)
+	})
+}
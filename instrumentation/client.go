@@ -0,0 +1,165 @@
+package instrumentation
+
+import (
+	"context"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	xclient "github.com/cordialsys/crosschain/client"
+)
+
+// Client instruments calls to a client.
+type Client struct {
+	client xclient.FullClient
+	inst   *Instrumentation
+	target target
+}
+
+var _ xclient.FullClient = &Client{}
+var _ xclient.TokenMetadataClient = &Client{}
+
+// Also forwards the latest height for clients that support it.
+type LatestHeightClient struct {
+	*Client
+}
+
+var _ xclient.LatestHeightClient = &LatestHeightClient{}
+
+// WrapClient instruments the client.  Clients that do not support FetchTransferInput are returned as is.
+func (inst *Instrumentation) WrapClient(cfg xc.ITask, client xclient.Client, checkError CheckErrorFunc) xclient.Client {
+	fullClient, ok := client.(xclient.FullClient)
+	if !ok {
+		return client
+	}
+	wrapped := &Client{
+		client: fullClient,
+		inst:   inst,
+		target: newTarget(cfg, checkError),
+	}
+	if _, ok := client.(xclient.LatestHeightClient); ok {
+		return &LatestHeightClient{wrapped}
+	}
+	return wrapped
+}
+
+// Unwrap returns the client being instrumented.
+func (c *Client) Unwrap() xclient.FullClient {
+	return c.client
+}
+
+func (c *Client) FetchTransferInput(ctx context.Context, args xcbuilder.TransferArgs) (xc.TxInput, error) {
+	return observe(ctx, c.inst, c.target, "FetchTransferInput", func(ctx context.Context) (xc.TxInput, error) {
+		return c.client.FetchTransferInput(ctx, args)
+	})
+}
+
+func (c *Client) FetchLegacyTxInput(ctx context.Context, from xc.Address, to xc.Address) (xc.TxInput, error) {
+	return observe(ctx, c.inst, c.target, "FetchLegacyTxInput", func(ctx context.Context) (xc.TxInput, error) {
+		return c.client.FetchLegacyTxInput(ctx, from, to)
+	})
+}
+
+func (c *Client) SubmitTx(ctx context.Context, tx xc.Tx) error {
+	return observeErr(ctx, c.inst, c.target, "SubmitTx", func(ctx context.Context) error {
+		return c.client.SubmitTx(ctx, tx)
+	})
+}
+
+func (c *Client) FetchLegacyTxInfo(ctx context.Context, txHash xc.TxHash) (xc.LegacyTxInfo, error) {
+	return observe(ctx, c.inst, c.target, "FetchLegacyTxInfo", func(ctx context.Context) (xc.LegacyTxInfo, error) {
+		return c.client.FetchLegacyTxInfo(ctx, txHash)
+	})
+}
+
+func (c *Client) FetchTxInfo(ctx context.Context, txHash xc.TxHash) (xclient.TxInfo, error) {
+	return observe(ctx, c.inst, c.target, "FetchTxInfo", func(ctx context.Context) (xclient.TxInfo, error) {
+		return c.client.FetchTxInfo(ctx, txHash)
+	})
+}
+
+func (c *Client) FetchBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	return observe(ctx, c.inst, c.target, "FetchBalance", func(ctx context.Context) (xc.AmountBlockchain, error) {
+		return c.client.FetchBalance(ctx, address)
+	})
+}
+
+func (c *Client) FetchNativeBalance(ctx context.Context, address xc.Address) (xc.AmountBlockchain, error) {
+	return observe(ctx, c.inst, c.target, "FetchNativeBalance", func(ctx context.Context) (xc.AmountBlockchain, error) {
+		return c.client.FetchNativeBalance(ctx, address)
+	})
+}
+
+func (c *Client) FetchTokenMetadata(ctx context.Context, contract xc.ContractAddress) (*xclient.TokenMetadata, error) {
+	return observe(ctx, c.inst, c.target, "FetchTokenMetadata", func(ctx context.Context) (*xclient.TokenMetadata, error) {
+		return xclient.FetchTokenMetadata(ctx, c.client, contract)
+	})
+}
+
+func (c *LatestHeightClient) FetchLatestHeight(ctx context.Context) (uint64, error) {
+	return observe(ctx, c.inst, c.target, "FetchLatestHeight", func(ctx context.Context) (uint64, error) {
+		return c.client.(xclient.LatestHeightClient).FetchLatestHeight(ctx)
+	})
+}
+
+// StakingClient instruments calls to a staking client.
+type StakingClient struct {
+	client xclient.StakingClient
+	inst   *Instrumentation
+	target target
+}
+
+var _ xclient.StakingClient = &StakingClient{}
+
+// Also forwards manual unstaking for clients that support it.
+type ManualUnstakingClient struct {
+	*StakingClient
+}
+
+var _ xclient.ManualUnstakingClient = &ManualUnstakingClient{}
+
+func (inst *Instrumentation) WrapStakingClient(cfg xc.ITask, client xclient.StakingClient, checkError CheckErrorFunc) xclient.StakingClient {
+	wrapped := &StakingClient{
+		client: client,
+		inst:   inst,
+		target: newTarget(cfg, checkError),
+	}
+	if _, ok := client.(xclient.ManualUnstakingClient); ok {
+		return &ManualUnstakingClient{wrapped}
+	}
+	return wrapped
+}
+
+// Unwrap returns the staking client being instrumented.
+func (c *StakingClient) Unwrap() xclient.StakingClient {
+	return c.client
+}
+
+func (c *StakingClient) FetchStakeBalance(ctx context.Context, args xclient.StakedBalanceArgs) ([]*xclient.StakedBalance, error) {
+	return observe(ctx, c.inst, c.target, "FetchStakeBalance", func(ctx context.Context) ([]*xclient.StakedBalance, error) {
+		return c.client.FetchStakeBalance(ctx, args)
+	})
+}
+
+func (c *StakingClient) FetchStakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.StakeTxInput, error) {
+	return observe(ctx, c.inst, c.target, "FetchStakingInput", func(ctx context.Context) (xc.StakeTxInput, error) {
+		return c.client.FetchStakingInput(ctx, args)
+	})
+}
+
+func (c *StakingClient) FetchUnstakingInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.UnstakeTxInput, error) {
+	return observe(ctx, c.inst, c.target, "FetchUnstakingInput", func(ctx context.Context) (xc.UnstakeTxInput, error) {
+		return c.client.FetchUnstakingInput(ctx, args)
+	})
+}
+
+func (c *StakingClient) FetchWithdrawInput(ctx context.Context, args xcbuilder.StakeArgs) (xc.WithdrawTxInput, error) {
+	return observe(ctx, c.inst, c.target, "FetchWithdrawInput", func(ctx context.Context) (xc.WithdrawTxInput, error) {
+		return c.client.FetchWithdrawInput(ctx, args)
+	})
+}
+
+func (c *ManualUnstakingClient) CompleteManualUnstaking(ctx context.Context, unstake *xclient.Unstake) error {
+	return observeErr(ctx, c.inst, c.target, "CompleteManualUnstaking", func(ctx context.Context) error {
+		return c.client.(xclient.ManualUnstakingClient).CompleteManualUnstaking(ctx, unstake)
+	})
+}
@@ -0,0 +1,129 @@
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const TracerName = "github.com/cordialsys/crosschain"
+
+// Classifies an error returned by a client or builder, e.g. factory.CheckError for the driver.
+type CheckErrorFunc func(err error) xclient.ClientError
+
+// Instrumentation records metrics and traces for calls to clients and builders.
+type Instrumentation struct {
+	tracer   trace.Tracer
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// New creates instrumentation that registers its metrics with the registerer and creates spans using the
+// tracer provider.  The global prometheus registerer and otel tracer provider are used if these are nil.
+func New(registerer prometheus.Registerer, tracerProvider trace.TracerProvider) (*Instrumentation, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "crosschain",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of crosschain client and builder calls.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"chain", "driver", "method"})
+	errorCount := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "crosschain",
+		Name:      "call_errors_total",
+		Help:      "Number of failed crosschain client and builder calls, by error class.",
+	}, []string{"chain", "driver", "method", "error"})
+
+	var err error
+	if duration, err = register(registerer, duration); err != nil {
+		return nil, err
+	}
+	if errorCount, err = register(registerer, errorCount); err != nil {
+		return nil, err
+	}
+	return &Instrumentation{
+		tracer:   tracerProvider.Tracer(TracerName),
+		duration: duration,
+		errors:   errorCount,
+	}, nil
+}
+
+// Reuse the existing metrics if they've already been registered, e.g. by another factory.
+func register[T prometheus.Collector](registerer prometheus.Registerer, collector T) (T, error) {
+	err := registerer.Register(collector)
+	if err != nil {
+		already := prometheus.AlreadyRegisteredError{}
+		if errors.As(err, &already) {
+			if existing, ok := already.ExistingCollector.(T); ok {
+				return existing, nil
+			}
+		}
+		return collector, err
+	}
+	return collector, nil
+}
+
+// The chain and driver that calls are made for
+type target struct {
+	chain      string
+	driver     string
+	checkError CheckErrorFunc
+}
+
+func newTarget(cfg xc.ITask, checkError CheckErrorFunc) target {
+	chain := cfg.GetChain()
+	return target{
+		chain:      string(chain.Chain),
+		driver:     string(chain.Driver),
+		checkError: checkError,
+	}
+}
+
+func (t target) classify(err error) xclient.ClientError {
+	if t.checkError == nil {
+		return xclient.UnknownError
+	}
+	return t.checkError(err)
+}
+
+func observe[T any](ctx context.Context, inst *Instrumentation, target target, method string, f func(ctx context.Context) (T, error)) (T, error) {
+	ctx, span := inst.tracer.Start(ctx, method, trace.WithAttributes(
+		attribute.String("xc.chain", target.chain),
+		attribute.String("xc.driver", target.driver),
+	))
+	defer span.End()
+
+	start := time.Now()
+	result, err := f(ctx)
+	inst.duration.WithLabelValues(target.chain, target.driver, method).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		class := target.classify(err)
+		inst.errors.WithLabelValues(target.chain, target.driver, method, string(class)).Inc()
+		span.SetAttributes(attribute.String("xc.error", string(class)))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+// For calls that only return an error
+func observeErr(ctx context.Context, inst *Instrumentation, target target, method string, f func(ctx context.Context) error) error {
+	_, err := observe(ctx, inst, target, method, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, f(ctx)
+	})
+	return err
+}
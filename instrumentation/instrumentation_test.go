@@ -0,0 +1,182 @@
+package instrumentation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	xc "github.com/cordialsys/crosschain"
+	xcbuilder "github.com/cordialsys/crosschain/builder"
+	"github.com/cordialsys/crosschain/chain/evm"
+	evmclient "github.com/cordialsys/crosschain/chain/evm/client"
+	xclient "github.com/cordialsys/crosschain/client"
+	"github.com/cordialsys/crosschain/factory/drivers"
+	"github.com/cordialsys/crosschain/instrumentation"
+	testutil "github.com/cordialsys/crosschain/testutil/factory"
+	"github.com/prometheus/client_golang/prometheus"
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newInstrumentation(t *testing.T) (*instrumentation.Instrumentation, *prometheus.Registry, *tracetest.InMemoryExporter) {
+	registry := prometheus.NewRegistry()
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	inst, err := instrumentation.New(registry, tracerProvider)
+	require.NoError(t, err)
+	return inst, registry, exporter
+}
+
+func spanAttribute(span tracetest.SpanStub, key string) string {
+	for _, attr := range span.Attributes {
+		if attr.Key == attribute.Key(key) {
+			return attr.Value.AsString()
+		}
+	}
+	return ""
+}
+
+func TestInstrumentClient(t *testing.T) {
+	inst, registry, exporter := newInstrumentation(t)
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}
+
+	mockClient := &testutil.MockedClient{}
+	mockClient.On("FetchNativeBalance", mock.Anything, xc.Address("from")).Return(xc.NewAmountBlockchainFromUint64(10), nil)
+	mockClient.On("SubmitTx", mock.Anything, mock.Anything).Return(errors.New("insufficient funds for gas * price + value"))
+	client := inst.WrapClient(chain, mockClient, evm.CheckError)
+
+	balance, err := client.FetchNativeBalance(context.Background(), "from")
+	require.NoError(t, err)
+	require.Equal(t, "10", balance.String())
+	balance, err = client.FetchNativeBalance(context.Background(), "from")
+	require.NoError(t, err)
+	err = client.SubmitTx(context.Background(), nil)
+	require.ErrorContains(t, err, "insufficient funds")
+
+	// latency is recorded for every call
+	require.Equal(t, 2, promtestutil.CollectAndCount(registry, "crosschain_call_duration_seconds"))
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	counts := map[string]uint64{}
+	for _, family := range families {
+		if family.GetName() != "crosschain_call_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.Metric {
+			labels := map[string]string{}
+			for _, label := range metric.Label {
+				labels[label.GetName()] = label.GetValue()
+			}
+			require.Equal(t, "ETH", labels["chain"])
+			require.Equal(t, "evm", labels["driver"])
+			counts[labels["method"]] = metric.Histogram.GetSampleCount()
+		}
+	}
+	require.Equal(t, map[string]uint64{"FetchNativeBalance": 2, "SubmitTx": 1}, counts)
+
+	// errors are counted by class
+	require.Equal(t, 1, promtestutil.CollectAndCount(registry, "crosschain_call_errors_total"))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 3)
+	require.Equal(t, "FetchNativeBalance", spans[0].Name)
+	require.Equal(t, "ETH", spanAttribute(spans[0], "xc.chain"))
+	require.Equal(t, "evm", spanAttribute(spans[0], "xc.driver"))
+	require.Equal(t, codes.Unset, spans[0].Status.Code)
+
+	require.Equal(t, "SubmitTx", spans[2].Name)
+	require.Equal(t, codes.Error, spans[2].Status.Code)
+	require.Equal(t, string(xclient.NoBalanceForGas), spanAttribute(spans[2], "xc.error"))
+	require.Len(t, spans[2].Events, 1)
+}
+
+func TestInstrumentErrorClasses(t *testing.T) {
+	inst, _, _ := newInstrumentation(t)
+	chain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM}
+
+	mockClient := &testutil.MockedClient{}
+	mockClient.On("SubmitTx", mock.Anything, mock.Anything).Return(errors.New("insufficient funds for gas * price + value")).Once()
+	mockClient.On("SubmitTx", mock.Anything, mock.Anything).Return(errors.New("already known")).Once()
+	mockClient.On("SubmitTx", mock.Anything, mock.Anything).Return(errors.New("already known")).Once()
+	client := inst.WrapClient(chain, mockClient, evm.CheckError)
+	for i := 0; i < 3; i++ {
+		_ = client.SubmitTx(context.Background(), nil)
+	}
+
+	// metrics are shared by instrumentation using the same registry
+	registry := prometheus.NewRegistry()
+	inst1, err := instrumentation.New(registry, nil)
+	require.NoError(t, err)
+	inst2, err := instrumentation.New(registry, nil)
+	require.NoError(t, err)
+	mockClient.On("SubmitTx", mock.Anything, mock.Anything).Return(errors.New("already known"))
+	_ = inst1.WrapClient(chain, mockClient, evm.CheckError).SubmitTx(context.Background(), nil)
+	_ = inst2.WrapClient(chain, mockClient, evm.CheckError).SubmitTx(context.Background(), nil)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == "crosschain_call_errors_total" {
+			require.Len(t, family.Metric, 1)
+			require.EqualValues(t, 2, family.Metric[0].Counter.GetValue())
+			for _, label := range family.Metric[0].Label {
+				if label.GetName() == "error" {
+					require.Equal(t, string(xclient.TransactionExists), label.GetValue())
+				}
+			}
+		}
+	}
+}
+
+func TestInstrumentPreservesInterfaces(t *testing.T) {
+	inst, _, _ := newInstrumentation(t)
+
+	evmChain := &xc.ChainConfig{Chain: xc.ETH, Driver: xc.DriverEVM, URL: "http://localhost"}
+	evmClient, err := evmclient.NewClient(evmChain)
+	require.NoError(t, err)
+	client := inst.WrapClient(evmChain, evmClient, evm.CheckError)
+	_, ok := client.(xclient.FullClient)
+	require.True(t, ok)
+	_, ok = client.(xclient.LatestHeightClient)
+	require.True(t, ok)
+
+	client = inst.WrapClient(evmChain, &testutil.MockedClient{}, evm.CheckError)
+	_, ok = client.(xclient.LatestHeightClient)
+	require.False(t, ok)
+
+	// evm supports staking, aptos does not
+	evmBuilder, err := drivers.NewTxBuilder(evmChain)
+	require.NoError(t, err)
+	_, ok = inst.WrapTxBuilder(evmChain, evmBuilder, nil).(xcbuilder.Staking)
+	require.True(t, ok)
+
+	aptosChain := &xc.ChainConfig{Chain: xc.APTOS, Driver: xc.DriverAptos}
+	aptosBuilder, err := drivers.NewTxBuilder(aptosChain)
+	require.NoError(t, err)
+	_, ok = inst.WrapTxBuilder(aptosChain, aptosBuilder, nil).(xcbuilder.Staking)
+	require.False(t, ok)
+}
+
+func TestInstrumentTxBuilder(t *testing.T) {
+	inst, registry, exporter := newInstrumentation(t)
+	chain := &xc.ChainConfig{Chain: xc.APTOS, Driver: xc.DriverAptos}
+	aptosBuilder, err := drivers.NewTxBuilder(chain)
+	require.NoError(t, err)
+	txBuilder := inst.WrapTxBuilder(chain, aptosBuilder, nil)
+
+	// missing input
+	_, err = txBuilder.NewTransfer("0x1", "0x2", xc.NewAmountBlockchainFromUint64(1), nil)
+	require.Error(t, err)
+
+	require.Equal(t, 1, promtestutil.CollectAndCount(registry, "crosschain_call_duration_seconds"))
+	require.Equal(t, 1, promtestutil.CollectAndCount(registry, "crosschain_call_errors_total"))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	require.Equal(t, "NewTransfer", spans[0].Name)
+	require.Equal(t, string(xclient.UnknownError), spanAttribute(spans[0], "xc.error"))
+}
@@ -0,0 +1,96 @@
+package crosschain
+
+// HashFunction is the hash of a signed message
+type HashFunction string
+
+const (
+	Sha256 HashFunction = "sha256"
+	// sha256(sha256(message)), used by bitcoin
+	DoubleSha256 HashFunction = "double-sha256"
+	Keccak256    HashFunction = "keccak256"
+	// used internally by ed25519, so the signer hashes the message itself
+	Sha512 HashFunction = "sha512"
+	// first 32 bytes of sha512, used by xrp
+	Sha512Half HashFunction = "sha512-half"
+)
+
+// SignatureEncoding is how a signature is serialized
+type SignatureEncoding string
+
+const (
+	// ASN.1 DER encoded secp256k1 signature
+	SignatureEncodingDER SignatureEncoding = "der"
+	// 64 byte r || s secp256k1 signature
+	SignatureEncodingRs SignatureEncoding = "r||s"
+	// 65 byte r || s || v secp256k1 signature, where v is the recovery id of 0 or 1
+	SignatureEncodingRsv SignatureEncoding = "r||s||v"
+	// 64 byte ed25519 signature
+	SignatureEncodingEd25519 SignatureEncoding = "ed25519"
+)
+
+// SighashMetadata describes how to sign the sighashes of a driver, for signers that don't use signer.Signer
+// (e.g. an MPC or threshold signer).
+type SighashMetadata struct {
+	// If true, the sighash is a digest that should be signed as is.  Otherwise it's a message that the
+	// signature algorithm hashes itself (ed25519).
+	PreHashed bool `json:"pre_hashed"`
+	// The hash that produced the digest, or that the signer applies to the message
+	Hash      HashFunction  `json:"hash"`
+	Algorithm SignatureType `json:"algorithm"`
+	// The encoding of signatures expected by Tx.AddSignatures.  Secp256k1 signatures should have a low s.
+	SignatureEncoding SignatureEncoding `json:"signature_encoding"`
+	PublicKeyFormat   PublicKeyFormat   `json:"public_key_format"`
+}
+
+// Sighash is a sighash of a tx, with how it should be signed
+type Sighash struct {
+	Payload TxDataToSign `json:"payload"`
+	SighashMetadata
+}
+
+func (driver Driver) SighashMetadata() SighashMetadata {
+	metadata := SighashMetadata{
+		Algorithm:       driver.SignatureAlgorithm(),
+		PublicKeyFormat: driver.PublicKeyFormat(),
+	}
+	switch driver {
+	case DriverBitcoin, DriverBitcoinCash, DriverBitcoinLegacy:
+		metadata.PreHashed = true
+		metadata.Hash = DoubleSha256
+		metadata.SignatureEncoding = SignatureEncodingRs
+	case DriverCosmos:
+		metadata.PreHashed = true
+		metadata.Hash = Sha256
+		metadata.SignatureEncoding = SignatureEncodingRs
+	case DriverXrp:
+		metadata.PreHashed = true
+		metadata.Hash = Sha512Half
+		metadata.SignatureEncoding = SignatureEncodingRs
+	case DriverEVM, DriverEVMLegacy, DriverCosmosEvmos:
+		metadata.PreHashed = true
+		metadata.Hash = Keccak256
+		metadata.SignatureEncoding = SignatureEncodingRsv
+	case DriverTron:
+		metadata.PreHashed = true
+		metadata.Hash = Sha256
+		metadata.SignatureEncoding = SignatureEncodingRsv
+	case DriverAptos, DriverSolana, DriverSui, DriverTon, DriverSubstrate:
+		metadata.Hash = Sha512
+		metadata.SignatureEncoding = SignatureEncodingEd25519
+	}
+	return metadata
+}
+
+// SighashesWithMetadata returns the sighashes of a tx, with how each should be signed
+func SighashesWithMetadata(driver Driver, tx Tx) ([]*Sighash, error) {
+	payloads, err := tx.Sighashes()
+	if err != nil {
+		return nil, err
+	}
+	metadata := driver.SighashMetadata()
+	sighashes := make([]*Sighash, len(payloads))
+	for i, payload := range payloads {
+		sighashes[i] = &Sighash{payload, metadata}
+	}
+	return sighashes, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cordialsys/crosschain/factory"
 	factoryconfig "github.com/cordialsys/crosschain/factory/config"
 	"github.com/cordialsys/crosschain/factory/signer"
+	"github.com/cordialsys/crosschain/factory/signer/hd"
 )
 
 // TestFactory for unit tests
@@ -40,11 +41,11 @@ func (f *TestFactory) NewTxBuilder(asset xc.ITask) (builder.FullTransferBuilder,
 }
 
 // NewSigner creates a new Signer
-func (f *TestFactory) NewSigner(asset xc.ITask, secret string) (*signer.Signer, error) {
+func (f *TestFactory) NewSigner(asset xc.ITask, secret string, options ...hd.Option) (*signer.Signer, error) {
 	if f.NewSignerFunc != nil {
 		return f.NewSignerFunc(asset)
 	}
-	return f.DefaultFactory.NewSigner(asset.GetChain(), secret)
+	return f.DefaultFactory.NewSigner(asset.GetChain(), secret, options...)
 }
 
 // NewAddressBuilder creates a new AddressBuilder
@@ -82,6 +83,11 @@ func (f *TestFactory) GetAllPossibleAddressesFromPublicKey(asset xc.ITask, publi
 	return f.DefaultFactory.GetAllPossibleAddressesFromPublicKey(asset, publicKey)
 }
 
+// ValidateAddress checks that an address is valid for the chain
+func (f *TestFactory) ValidateAddress(asset xc.ITask, address xc.Address) (*xc.AddressValidation, error) {
+	return f.DefaultFactory.ValidateAddress(asset, address)
+}
+
 // ConvertAmountToHuman converts an AmountBlockchain into AmountHumanReadable, dividing by the appropriate number of decimals
 func (f *TestFactory) ConvertAmountToHuman(asset xc.ITask, blockchainAmount xc.AmountBlockchain) (xc.AmountHumanReadable, error) {
 	return f.DefaultFactory.ConvertAmountToHuman(asset, blockchainAmount)
@@ -115,6 +115,10 @@ func (data TxDataToSign) String() string {
 	return base64.RawURLEncoding.EncodeToString(data)
 }
 
+// TxDataToDisplay is the payload a hardware wallet parses to display a tx and derive its sighash from,
+// e.g. the unsigned RLP of an ethereum tx rather than its hash.
+type TxDataToDisplay []byte
+
 // TxSignature is a tx signature
 type TxSignature []byte
 
@@ -136,3 +140,10 @@ type Tx interface {
 	GetSignatures() []TxSignature
 	Serialize() ([]byte, error)
 }
+
+// TxWithDisplayPayloads is implemented by transactions that can be clear signed on a hardware wallet.
+// There is one payload for each sighash, in the same order.
+type TxWithDisplayPayloads interface {
+	Tx
+	DisplayPayloads() ([]TxDataToDisplay, error)
+}
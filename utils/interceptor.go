@@ -6,6 +6,8 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+
+	xc "github.com/cordialsys/crosschain"
 )
 
 // Interceptor
@@ -25,6 +27,13 @@ func NewHttpInterceptor(bodyReplacer Replacer) *HttpInterceptor {
 	return interceptor
 }
 
+// NewChainHttpInterceptor intercepts responses sent over the chain's shared transport.
+func NewChainHttpInterceptor(chain *xc.ChainConfig, bodyReplacer Replacer) *HttpInterceptor {
+	interceptor := NewHttpInterceptor(bodyReplacer)
+	interceptor.core = NewTransport(chain)
+	return interceptor
+}
+
 func (i *HttpInterceptor) Enable() {
 	i.enabled = true
 }
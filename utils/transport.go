@@ -0,0 +1,367 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const DefaultMaxRetries = 3
+const DefaultTimeout = 60 * time.Second
+const DefaultInitialBackoff = 500 * time.Millisecond
+const DefaultMaxBackoff = 10 * time.Second
+
+// RequestMetrics is reported for every attempt of an outbound request
+type RequestMetrics struct {
+	Chain string
+	// http method
+	Method string
+	// only the host is reported, as some providers put api keys in the path
+	Host       string
+	StatusCode int
+	Latency    time.Duration
+	// 0 for the first attempt, incremented for each retry
+	Attempt int
+	Err     error
+}
+
+type MetricsHook func(metrics *RequestMetrics)
+
+var metricsLock sync.RWMutex
+var metricsHooks []MetricsHook
+
+// AddMetricsHook registers a hook that is called after every outbound request made by any driver.
+func AddMetricsHook(hook MetricsHook) {
+	metricsLock.Lock()
+	defer metricsLock.Unlock()
+	metricsHooks = append(metricsHooks, hook)
+}
+
+// Rate limits are shared by all clients for the same chain
+var limitersLock sync.Mutex
+var limiters = map[string]*rate.Limiter{}
+
+func chainLimiter(chain string, cfg xc.TransportConfig) *rate.Limiter {
+	if cfg.RateLimit <= 0 {
+		return nil
+	}
+	burst := cfg.RateLimitBurst
+	if burst <= 0 {
+		burst = 1
+	}
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+	limiter, ok := limiters[chain]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(cfg.RateLimit), burst)
+		limiters[chain] = limiter
+	} else {
+		// use the latest configuration
+		limiter.SetLimit(rate.Limit(cfg.RateLimit))
+		limiter.SetBurst(burst)
+	}
+	return limiter
+}
+
+// Transport applies the chain's rate limit, timeout and retry policy to outbound requests.
+type Transport struct {
+	core    http.RoundTripper
+	chain   string
+	limiter *rate.Limiter
+
+	maxRetries     int
+	timeout        time.Duration
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+var _ http.RoundTripper = &Transport{}
+
+func NewTransport(chain *xc.ChainConfig) *Transport {
+	return newTransport(string(chain.Chain), chain.Transport)
+}
+
+func newTransport(chain string, cfg xc.TransportConfig) *Transport {
+	maxRetries := cfg.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+	timeout := DefaultTimeout
+	if cfg.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(cfg.Timeout)
+		if err != nil {
+			logrus.WithError(err).WithField("chain", chain).Warn("invalid transport timeout, using default")
+			timeout = DefaultTimeout
+		}
+	}
+	return &Transport{
+		core:           http.DefaultTransport,
+		chain:          chain,
+		limiter:        chainLimiter(chain, cfg),
+		maxRetries:     maxRetries,
+		timeout:        timeout,
+		initialBackoff: DefaultInitialBackoff,
+		maxBackoff:     DefaultMaxBackoff,
+	}
+}
+
+// NewHttpClient returns a http client that uses the chain's transport policy.
+func NewHttpClient(chain *xc.ChainConfig) *http.Client {
+	return &http.Client{
+		Transport: NewTransport(chain),
+	}
+}
+
+// NewDefaultHttpClient returns a http client using the default retry and timeout policy, for
+// 3rd party services that are not configured per chain.
+func NewDefaultHttpClient(chain string) *http.Client {
+	return &http.Client{
+		Transport: newTransport(chain, xc.TransportConfig{}),
+	}
+}
+
+// NewPublicHttpClient returns a http client using the default policy that only connects to public IP
+// addresses over https, for fetching uri's that are chosen by 3rd parties (e.g. token metadata).
+func NewPublicHttpClient(chain string) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 30 * time.Second,
+		Control: rejectNonPublicAddress,
+	}
+	core := http.DefaultTransport.(*http.Transport).Clone()
+	// a proxy would be dialed instead of the destination
+	core.Proxy = nil
+	core.DialContext = dialer.DialContext
+	return &http.Client{
+		Transport: newTransport(chain, xc.TransportConfig{}).WithCore(core),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme: %s", req.URL.Scheme)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		},
+	}
+}
+
+// 100.64.0.0/10, shared address space used by carrier-grade NAT
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// Checked after DNS resolution, so a public hostname can't resolve to an internal address.
+func rejectNonPublicAddress(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("invalid address: %s", address)
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsMulticast() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("connecting to non-public address %s is not allowed", ip)
+	}
+	return nil
+}
+
+func (t *Transport) WithCore(core http.RoundTripper) *Transport {
+	t.core = core
+	return t
+}
+
+func (t *Transport) WithBackoff(initial time.Duration, max time.Duration) *Transport {
+	t.initialBackoff = initial
+	t.maxBackoff = max
+	return t
+}
+
+type nonIdempotentKey struct{}
+
+// NonIdempotent marks requests made with the context as unsafe to repeat, e.g. broadcasting a transaction,
+// so they aren't retried on server errors.
+func NonIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, nonIdempotentKey{}, true)
+}
+
+func isIdempotent(req *http.Request) bool {
+	nonIdempotent, _ := req.Context().Value(nonIdempotentKey{}).(bool)
+	return !nonIdempotent
+}
+
+// Rate limited requests weren't processed, so they're always retried.  Other requests could have been
+// processed before the server failed, so they're only retried on server errors if they're idempotent.
+// The http method can't tell us this, as most RPC calls are POSTs that only read state.
+func shouldRetry(idempotent bool, status int) bool {
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return idempotent && status >= 500
+}
+
+// The request wasn't sent if the connection couldn't be made, so it's safe to retry for any method.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// Respect the server's Retry-After header (in seconds) if it's present.  The response is nil if the
+// connection failed.
+func (t *Transport) retryDelay(res *http.Response, backoff time.Duration) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			backoff = time.Duration(seconds) * time.Second
+		}
+	}
+	if backoff > t.maxBackoff {
+		backoff = t.maxBackoff
+	}
+	return backoff
+}
+
+func (t *Transport) report(req *http.Request, res *http.Response, err error, attempt int, latency time.Duration) {
+	metricsLock.RLock()
+	defer metricsLock.RUnlock()
+	if len(metricsHooks) == 0 {
+		return
+	}
+	metrics := &RequestMetrics{
+		Chain:   t.chain,
+		Method:  req.Method,
+		Host:    req.URL.Host,
+		Latency: latency,
+		Attempt: attempt,
+		Err:     err,
+	}
+	if res != nil {
+		metrics.StatusCode = res.StatusCode
+	}
+	for _, hook := range metricsHooks {
+		hook(metrics)
+	}
+}
+
+type responseStatusKey struct{}
+
+// ResponseStatus records the status code of the last response to a request made using its context, so callers
+// can tell why a request failed without depending on how each driver reports http errors.
+type ResponseStatus struct {
+	lock sync.Mutex
+	code int
+}
+
+// WithResponseStatus returns a context that records the status code of responses to requests made with it.
+func WithResponseStatus(ctx context.Context) (context.Context, *ResponseStatus) {
+	status := &ResponseStatus{}
+	return context.WithValue(ctx, responseStatusKey{}, status), status
+}
+
+// Code returns the status code of the last response, or 0 if there wasn't one.
+func (status *ResponseStatus) Code() int {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+	return status.code
+}
+
+func (status *ResponseStatus) set(code int) {
+	status.lock.Lock()
+	defer status.lock.Unlock()
+	status.code = code
+}
+
+// Cancels the timeout of the request once the response has been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnClose) Close() error {
+	defer body.cancel()
+	return body.ReadCloser.Close()
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// the body is buffered so it can be sent again
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	backoff := t.initialBackoff
+	for attempt := 0; ; attempt++ {
+		if t.limiter != nil {
+			if err := t.limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+		attemptReq := req.Clone(ctx)
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.GetBody = func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(body)), nil
+			}
+		}
+
+		start := time.Now()
+		res, err := t.core.RoundTrip(attemptReq)
+		t.report(req, res, err, attempt, time.Since(start))
+		if status, ok := req.Context().Value(responseStatusKey{}).(*ResponseStatus); ok && res != nil {
+			status.set(res.StatusCode)
+		}
+		log := logrus.WithFields(logrus.Fields{
+			"chain":   t.chain,
+			"host":    req.URL.Host,
+			"attempt": attempt,
+		})
+		if err != nil {
+			cancel()
+			if !isDialError(err) || attempt >= t.maxRetries {
+				return nil, err
+			}
+			log = log.WithError(err)
+		} else {
+			if !shouldRetry(isIdempotent(req), res.StatusCode) || attempt >= t.maxRetries {
+				res.Body = &cancelOnClose{res.Body, cancel}
+				return res, nil
+			}
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+			cancel()
+			log = log.WithField("status", res.StatusCode)
+		}
+
+		delay := t.retryDelay(res, backoff)
+		log.WithField("delay", delay).Debug("retrying request")
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+		backoff *= 2
+		if backoff > t.maxBackoff {
+			backoff = t.maxBackoff
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	xc "github.com/cordialsys/crosschain"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, statuses []int) (*httptest.Server, *[]string) {
+	bodies := []string{}
+	lock := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		status := statuses[len(bodies)]
+		bodies = append(bodies, string(body))
+		rw.WriteHeader(status)
+		_, _ = rw.Write([]byte(http.StatusText(status)))
+	}))
+	return server, &bodies
+}
+
+func TestTransportRetries(t *testing.T) {
+	vectors := []struct {
+		name          string
+		cfg           xc.TransportConfig
+		method        string
+		nonIdempotent bool
+		statuses      []int
+		attempts      int
+		lastStatus    int
+	}{
+		{
+			name:       "success",
+			statuses:   []int{200},
+			attempts:   1,
+			lastStatus: 200,
+		},
+		{
+			name:       "rate limited",
+			statuses:   []int{429, 429, 200},
+			attempts:   3,
+			lastStatus: 200,
+		},
+		{
+			name:       "server error",
+			method:     http.MethodGet,
+			statuses:   []int{502, 200},
+			attempts:   2,
+			lastStatus: 200,
+		},
+		{
+			// most rpc calls are posts
+			name:       "server errors are retried for post",
+			statuses:   []int{502, 200},
+			attempts:   2,
+			lastStatus: 200,
+		},
+		{
+			name:          "server errors are not retried for non-idempotent requests",
+			nonIdempotent: true,
+			statuses:      []int{502},
+			attempts:      1,
+			lastStatus:    502,
+		},
+		{
+			name:          "rate limited non-idempotent requests are retried",
+			nonIdempotent: true,
+			statuses:      []int{429, 200},
+			attempts:      2,
+			lastStatus:    200,
+		},
+		{
+			name:       "client errors are not retried",
+			statuses:   []int{400},
+			attempts:   1,
+			lastStatus: 400,
+		},
+		{
+			name:       "retries exhausted",
+			cfg:        xc.TransportConfig{MaxRetries: 2},
+			method:     http.MethodGet,
+			statuses:   []int{503, 503, 503},
+			attempts:   3,
+			lastStatus: 503,
+		},
+		{
+			name:       "retries disabled",
+			cfg:        xc.TransportConfig{MaxRetries: -1},
+			method:     http.MethodGet,
+			statuses:   []int{503},
+			attempts:   1,
+			lastStatus: 503,
+		},
+	}
+	for _, v := range vectors {
+		t.Run(v.name, func(t *testing.T) {
+			server, bodies := newTestServer(t, v.statuses)
+			defer server.Close()
+
+			chain := &xc.ChainConfig{Chain: xc.ETH, Transport: v.cfg}
+			client := &http.Client{
+				Transport: NewTransport(chain).WithBackoff(time.Millisecond, 5*time.Millisecond),
+			}
+			if v.method == "" {
+				v.method = http.MethodPost
+			}
+			ctx := context.Background()
+			if v.nonIdempotent {
+				ctx = NonIdempotent(ctx)
+			}
+			req, err := http.NewRequestWithContext(ctx, v.method, server.URL, strings.NewReader("request body"))
+			require.NoError(t, err)
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			require.Equal(t, v.lastStatus, res.StatusCode)
+			require.Equal(t, http.StatusText(v.lastStatus), string(body))
+			require.Len(t, *bodies, v.attempts)
+			// the body is resent on each attempt
+			for _, body := range *bodies {
+				require.Equal(t, "request body", body)
+			}
+		})
+	}
+}
+
+type roundTripperFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestTransportRetriesDialErrors(t *testing.T) {
+	server, bodies := newTestServer(t, []int{200})
+	defer server.Close()
+
+	dials := 0
+	core := roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		dials++
+		if dials == 1 {
+			return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+		}
+		return http.DefaultTransport.RoundTrip(req)
+	})
+	client := &http.Client{
+		Transport: NewTransport(&xc.ChainConfig{Chain: xc.ETH}).WithCore(core).WithBackoff(time.Millisecond, 5*time.Millisecond),
+	}
+	// the request was never sent, so it's safe to retry a post
+	res, err := client.Post(server.URL, "text/plain", strings.NewReader("request body"))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, 2, dials)
+	require.Equal(t, []string{"request body"}, *bodies)
+
+	// other errors may happen after the request is sent
+	core = roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		dials++
+		return nil, &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset")}
+	})
+	client.Transport.(*Transport).WithCore(core)
+	_, err = client.Post(server.URL, "text/plain", strings.NewReader("request body"))
+	require.ErrorContains(t, err, "connection reset")
+	require.Equal(t, 3, dials)
+}
+
+func TestTransportTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		select {
+		case <-req.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	chain := &xc.ChainConfig{Chain: xc.ETH, Transport: xc.TransportConfig{Timeout: "10ms"}}
+	_, err := NewHttpClient(chain).Get(server.URL)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestTransportRateLimit(t *testing.T) {
+	server, bodies := newTestServer(t, []int{200, 200, 200, 200})
+	defer server.Close()
+
+	chain := &xc.ChainConfig{Chain: "RATELIMITED", Transport: xc.TransportConfig{RateLimit: 20, RateLimitBurst: 1}}
+	// clients for the same chain share the limit
+	clients := []*http.Client{NewHttpClient(chain), NewHttpClient(chain)}
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		res, err := clients[i%2].Get(server.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+	require.Len(t, *bodies, 4)
+	// 3 requests must wait 50ms each
+	require.GreaterOrEqual(t, time.Since(start), 140*time.Millisecond)
+}
+
+func TestTransportMetrics(t *testing.T) {
+	server, _ := newTestServer(t, []int{500, 200})
+	defer server.Close()
+
+	metrics := []RequestMetrics{}
+	AddMetricsHook(func(m *RequestMetrics) {
+		if m.Chain == "METRICS" {
+			metrics = append(metrics, *m)
+		}
+	})
+
+	chain := &xc.ChainConfig{Chain: "METRICS"}
+	client := &http.Client{
+		Transport: NewTransport(chain).WithBackoff(time.Millisecond, time.Millisecond),
+	}
+	res, err := client.Get(server.URL + "/secret-api-key")
+	require.NoError(t, err)
+	res.Body.Close()
+
+	require.Len(t, metrics, 2)
+	require.Equal(t, 500, metrics[0].StatusCode)
+	require.Equal(t, 0, metrics[0].Attempt)
+	require.Equal(t, 200, metrics[1].StatusCode)
+	require.Equal(t, 1, metrics[1].Attempt)
+	for _, m := range metrics {
+		require.Equal(t, "GET", m.Method)
+		require.Equal(t, strings.TrimPrefix(server.URL, "http://"), m.Host)
+		require.NoError(t, m.Err)
+	}
+}
+
+func TestPublicHttpClient(t *testing.T) {
+	server, bodies := newTestServer(t, []int{200})
+	defer server.Close()
+
+	_, err := NewPublicHttpClient("TEST").Get(server.URL)
+	require.ErrorContains(t, err, "connecting to non-public address 127.0.0.1 is not allowed")
+	require.Len(t, *bodies, 0)
+
+	for _, address := range []string{"10.0.0.1:443", "192.168.1.1:443", "169.254.169.254:80", "100.64.0.1:443", "[::1]:443", "[fd00::1]:443", "0.0.0.0:443"} {
+		require.Error(t, rejectNonPublicAddress("tcp", address, nil), address)
+	}
+	for _, address := range []string{"1.1.1.1:443", "[2606:4700::1111]:443"} {
+		require.NoError(t, rejectNonPublicAddress("tcp", address, nil), address)
+	}
+}
+
+func TestTransportResponseStatus(t *testing.T) {
+	server, _ := newTestServer(t, []int{429, 503})
+	defer server.Close()
+
+	chain := &xc.ChainConfig{Chain: "STATUS", Transport: xc.TransportConfig{MaxRetries: 1}}
+	client := &http.Client{
+		Transport: NewTransport(chain).WithBackoff(time.Millisecond, time.Millisecond),
+	}
+	ctx, status := WithResponseStatus(context.Background())
+	require.Equal(t, 0, status.Code())
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader("{}"))
+	require.NoError(t, err)
+	res, err := client.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	// the status of the last attempt
+	require.Equal(t, 503, status.Code())
+}